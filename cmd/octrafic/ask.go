@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	agent "github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"github.com/spf13/cobra"
+)
+
+var askProjectName string
+
+var askCmd = &cobra.Command{
+	Use:   "ask <question>",
+	Short: "Ask a one-shot question about a saved project's API",
+	Long:  `Answer a single question using the project's specification and exit. Read-only: no tests are executed and no requests are sent to the API.`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, err := storage.FindProjectByName(askProjectName)
+		if err != nil {
+			return err
+		}
+
+		endpoints, err := storage.LoadEndpoints(project.ID, project.IsTemporary)
+		if err != nil {
+			spec, parseErr := parser.ParseSpecification(project.SpecPath)
+			if parseErr != nil {
+				return fmt.Errorf("failed to load endpoints: %w", parseErr)
+			}
+			endpoints = spec.Endpoints
+		}
+
+		details, err := json.Marshal(endpoints)
+		if err != nil {
+			return fmt.Errorf("failed to marshal endpoints: %w", err)
+		}
+
+		a, err := agent.NewAgent(project.BaseURL, project.ModelOverride())
+		if err != nil {
+			return withExitCode(exitLLM, fmt.Errorf("failed to initialize agent: %w", err))
+		}
+		defer func() { _ = a.Close() }()
+
+		response, err := a.Ask(strings.Join(args, " "), string(details))
+		if err != nil {
+			return withExitCode(exitLLM, fmt.Errorf("failed to get answer: %w", err))
+		}
+
+		fmt.Println(strings.TrimSpace(response.Message))
+		return nil
+	},
+}
+
+func init() {
+	askCmd.Flags().StringVarP(&askProjectName, "name", "n", "", "Name of the saved project to ask about")
+	_ = askCmd.MarkFlagRequired("name")
+	rootCmd.AddCommand(askCmd)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	agent "github.com/Octrafic/octrafic-cli/internal/agents"
+	internalConfig "github.com/Octrafic/octrafic-cli/internal/config"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+	"github.com/Octrafic/octrafic-cli/internal/core/reporter"
+	"github.com/Octrafic/octrafic-cli/internal/llm"
+	"github.com/spf13/cobra"
+)
+
+// capabilitiesSchemaVersion is bumped when fields are removed or change meaning; new fields don't bump it
+const capabilitiesSchemaVersion = 1
+
+var capabilitiesJSON bool
+
+// Capabilities describes what this build supports, for tools that wrap octrafic
+type Capabilities struct {
+	SchemaVersion  int                  `json:"schema_version"`
+	Version        string               `json:"version"`
+	Profile        string               `json:"profile"`
+	Commands       []string             `json:"commands"`
+	SpecFormats    []string             `json:"spec_formats"`
+	SpecExtensions []string             `json:"spec_extensions"`
+	AuthTypes      []string             `json:"auth_types"`
+	ReportFormats  []string             `json:"report_formats"`
+	Providers      []string             `json:"providers"`
+	Provider       CapabilitiesProvider `json:"provider"`
+}
+
+// CapabilitiesProvider is the LLM provider the current configuration resolves to
+type CapabilitiesProvider struct {
+	Name       string `json:"name"`
+	Model      string `json:"model,omitempty"`
+	BaseURL    string `json:"base_url,omitempty"`
+	Configured bool   `json:"configured"` // Set up via onboarding rather than environment defaults
+}
+
+var capabilitiesCmd = &cobra.Command{
+	Use:   "capabilities",
+	Short: "Show the version and features supported by this build",
+	Long:  `Report the version, subcommands, spec formats, auth types, report formats and configured LLM provider. Use --json for a stable machine-readable contract.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		caps := currentCapabilities()
+
+		if capabilitiesJSON {
+			data, err := json.MarshalIndent(caps, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal capabilities: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("Version:         %s\n", caps.Version)
+		fmt.Printf("Profile:         %s\n", caps.Profile)
+		fmt.Printf("Commands:        %s\n", strings.Join(caps.Commands, ", "))
+		fmt.Printf("Spec formats:    %s (%s)\n", strings.Join(caps.SpecFormats, ", "), strings.Join(caps.SpecExtensions, " "))
+		fmt.Printf("Auth types:      %s\n", strings.Join(caps.AuthTypes, ", "))
+		fmt.Printf("Report formats:  %s\n", strings.Join(caps.ReportFormats, ", "))
+		fmt.Printf("Providers:       %s\n", strings.Join(caps.Providers, ", "))
+		provider := caps.Provider.Name
+		if caps.Provider.Model != "" {
+			provider += " / " + caps.Provider.Model
+		}
+		if !caps.Provider.Configured {
+			provider += " (not configured, run octrafic --onboarding)"
+		}
+		fmt.Printf("Active provider: %s\n", provider)
+		return nil
+	},
+}
+
+// currentCapabilities collects the capabilities of this build and the active configuration
+func currentCapabilities() Capabilities {
+	var commands []string
+	for _, c := range rootCmd.Commands() {
+		if c.IsAvailableCommand() {
+			commands = append(commands, c.Name())
+		}
+	}
+
+	profile := internalConfig.ActiveProfile()
+	if profile == "" {
+		profile = internalConfig.DefaultProfile
+	}
+
+	providerConfig, configured := agent.ResolveProviderConfig()
+	return Capabilities{
+		SchemaVersion:  capabilitiesSchemaVersion,
+		Version:        version,
+		Profile:        profile,
+		Commands:       commands,
+		SpecFormats:    parser.SupportedFormats,
+		SpecExtensions: parser.SupportedExtensions,
+		AuthTypes:      auth.SupportedTypes,
+		ReportFormats:  reporter.Formats,
+		Providers:      llm.SupportedProviders,
+		Provider: CapabilitiesProvider{
+			Name:       providerConfig.Provider,
+			Model:      providerConfig.Model,
+			BaseURL:    providerConfig.BaseURL,
+			Configured: configured,
+		},
+	}
+}
+
+func init() {
+	capabilitiesCmd.Flags().BoolVar(&capabilitiesJSON, "json", false, "Print capabilities as JSON")
+	rootCmd.AddCommand(capabilitiesCmd)
+}
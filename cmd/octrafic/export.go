@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportProjectName string
+	exportOutput      string
+	exportStripAuth   bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a saved project to a single file",
+	Long: `Write a named project with its parsed endpoints to one JSON file, to share it or move it to another machine.
+Saved credentials are included (encrypted if OCTRAFIC_SECRET is set) unless --strip-auth is given.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, err := storage.FindProjectByName(exportProjectName)
+		if err != nil {
+			return err
+		}
+
+		path := exportOutput
+		if path == "" {
+			path = exportFileName(project.Name)
+		}
+		if err := storage.ExportProject(project, path, exportStripAuth); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Exported project '%s' to %s\n", project.Name, path)
+		if project.HasAuth() && !exportStripAuth {
+			fmt.Println("  The file contains the project's saved credentials; use --strip-auth to leave them out")
+		}
+		return nil
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a project exported with 'octrafic export'",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, err := storage.ImportProject(args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Imported project '%s' (%s)\n", project.Name, project.BaseURL)
+		if project.AuthConfig.Locked() {
+			fmt.Printf("  Its credentials are encrypted; set %s to the passphrase they were exported with\n", storage.SecretEnvVar)
+		}
+		fmt.Printf("  Open it with: octrafic -n %q\n", project.Name)
+		return nil
+	},
+}
+
+// exportFileName derives a file name from a project name, e.g. "My API" -> my-api.octrafic.json
+func exportFileName(name string) string {
+	slug := strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if slug == "" {
+		slug = "project"
+	}
+	return slug + ".octrafic.json"
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportProjectName, "name", "n", "", "Name of the saved project to export")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "File to write (default <project-name>.octrafic.json)")
+	exportCmd.Flags().BoolVar(&exportStripAuth, "strip-auth", false, "Leave saved credentials out of the export")
+	_ = exportCmd.MarkFlagRequired("name")
+	rootCmd.AddCommand(exportCmd, importCmd)
+}
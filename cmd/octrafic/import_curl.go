@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	agent "github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importCurlSave  string
+	importCurlNoRun bool
+)
+
+var importCurlCmd = &cobra.Command{
+	Use:   "import-curl [curl command]",
+	Short: "Run a curl command as a test or save it to a test plan",
+	Long: `Parse a curl command (-X, -H, -d/--data, --json, -u, ...) into a test, run it and print the result.
+Pass the command as a single quoted argument, or pipe it on stdin. Use --save to append it to a test plan file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		command := strings.Join(args, " ")
+		if command == "" {
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return fmt.Errorf("failed to read curl command: %w", err)
+			}
+			command = string(data)
+		}
+
+		req, err := tester.ParseCurl(command)
+		if err != nil {
+			return fmt.Errorf("failed to parse curl command: %w", err)
+		}
+		baseURL, endpoint, err := req.SplitURL()
+		if err != nil {
+			return err
+		}
+
+		if importCurlSave != "" {
+			plan, err := agent.LoadTestPlan(importCurlSave)
+			if err != nil {
+				return err
+			}
+			plan.Add(agent.TestCase{
+				Description: fmt.Sprintf("Imported from curl: %s %s", req.Method, endpoint),
+				Method:      req.Method,
+				Endpoint:    endpoint,
+				Headers:     req.Headers,
+				Body:        req.Body,
+			})
+			if err := plan.Save(importCurlSave); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Saved %s %s to %s\n", req.Method, endpoint, importCurlSave)
+		}
+
+		if importCurlNoRun {
+			return nil
+		}
+
+		executor := tester.NewExecutor(baseURL, nil)
+		executor.SetTimeout(resolveRequestTimeout())
+		result, err := executor.ExecuteTest(req.Method, endpoint, req.Headers, req.Body)
+		if err != nil {
+			return withExitCode(exitNetwork, err)
+		}
+		fmt.Printf("%s %s → %d (%dms, %s)\n", req.Method, req.URL, result.StatusCode, result.Duration.Milliseconds(), tester.FormatBytes(result.ResponseBytes))
+		fmt.Println(result.ResponseBody)
+		return nil
+	},
+}
+
+func init() {
+	importCurlCmd.Flags().StringVar(&importCurlSave, "save", "", "Append the request to a test plan JSON file")
+	importCurlCmd.Flags().BoolVar(&importCurlNoRun, "no-run", false, "Don't execute the request (use with --save)")
+	rootCmd.AddCommand(importCurlCmd)
+}
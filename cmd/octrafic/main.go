@@ -2,6 +2,7 @@ package main
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"github.com/Octrafic/octrafic-cli/internal/cli"
 	internalConfig "github.com/Octrafic/octrafic-cli/internal/config"
@@ -9,11 +10,17 @@ import (
 	"github.com/Octrafic/octrafic-cli/internal/core/auth"
 	"github.com/Octrafic/octrafic-cli/internal/core/converter"
 	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
 	"github.com/Octrafic/octrafic-cli/internal/infra/logger"
 	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
 	"github.com/Octrafic/octrafic-cli/internal/updater"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/joho/godotenv"
@@ -27,8 +34,67 @@ const (
 	authValueEnvVar = "OCTRAFIC_AUTH_VALUE"
 	authUserEnvVar  = "OCTRAFIC_AUTH_USER"
 	authPassEnvVar  = "OCTRAFIC_AUTH_PASS"
+
+	authHeaderEnvVar   = "OCTRAFIC_AUTH_HEADER"
+	authTemplateEnvVar = "OCTRAFIC_AUTH_TEMPLATE"
+	authLocationEnvVar = "OCTRAFIC_AUTH_LOCATION"
+
+	authTokenCommandEnvVar = "OCTRAFIC_AUTH_TOKEN_COMMAND"
+	authTokenURLEnvVar     = "OCTRAFIC_AUTH_TOKEN_URL"
+	authClientIDEnvVar     = "OCTRAFIC_AUTH_CLIENT_ID"
+	authClientSecretEnvVar = "OCTRAFIC_AUTH_CLIENT_SECRET"
+	authScopesEnvVar       = "OCTRAFIC_AUTH_SCOPES"
+
+	authAccessKeyEnvVar = "OCTRAFIC_AUTH_ACCESS_KEY"
+	authSecretKeyEnvVar = "OCTRAFIC_AUTH_SECRET_KEY"
+	authRegionEnvVar    = "OCTRAFIC_AUTH_REGION"
+	authServiceEnvVar   = "OCTRAFIC_AUTH_SERVICE"
+
+	requestTimeoutEnvVar = "OCTRAFIC_REQUEST_TIMEOUT"
+)
+
+// defaultAWSService is the SigV4 service name of API Gateway
+const defaultAWSService = "execute-api"
+
+// Exit codes, so scripts and CI can tell genuine test failures from infrastructure problems.
+// Interactive mode shows test results in the session, so it only exits with 0, 2 or 4.
+const (
+	exitTestsFailed = 1 // At least one test failed
+	exitConfig      = 2 // Invalid flags, configuration, spec, test plan or authentication, or the session couldn't start
+	exitNetwork     = 3 // The API couldn't be reached
+	exitLLM         = 4 // The LLM provider failed or isn't configured
 )
 
+// exitError is an error that ends the program with a specific exit code
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// withExitCode tags err so the program exits with code when a command returns it
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+// exitCode returns the exit code for an error returned by a command. LLM failures while parsing
+// a spec exit with exitLLM; other errors without a code, such as unknown flags, are configuration problems.
+func exitCode(err error) int {
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	if errors.Is(err, storage.ErrLLM) {
+		return exitLLM
+	}
+	return exitConfig
+}
+
 var (
 	version = "dev"
 )
@@ -38,16 +104,57 @@ var (
 	specFile    string
 	projectName string
 
-	authType  string
-	authToken string
-	authKey   string
-	authValue string
-	authUser  string
-	authPass  string
+	authType         string
+	authToken        string
+	authTokenCommand string
+	authKey          string
+	authValues       []string
+	authUser         string
+	authPass         string
+
+	authHeader   string
+	authTemplate string
+	authLocation string
+
+	authTokenURL     string
+	authClientID     string
+	authClientSecret string
+	authScopes       string
+
+	authAccessKey string
+	authSecretKey string
+	authRegion    string
+	authService   string
 
 	clearAuth bool
+	saveAuth  bool
+
+	openReports      bool
+	validateSchemas  bool
+	basePathFromSpec bool
+
+	replayDir      string
+	replayFallback int
+	recordDir      string
+	binaryDir      string
+
+	requestTimeout time.Duration
+	retries        int
+	retryOn        string
+	proxyURL       string
+	insecureTLS    bool
+	caCertFile     string
+	keepCookies    bool
+	concurrency    int
+
+	resumeSession bool
+	autoExecute   bool
+	noCache       bool
+	offline       bool
 
 	debugFilePath string
+	logFormat     string
+	logLevel      string
 
 	forceOnboarding bool
 )
@@ -72,7 +179,7 @@ var rootCmd = &cobra.Command{
 		hasName := projectName != ""
 
 		if !hasURL && !hasSpec && !hasName {
-			showProjectList()
+			showProjectList(cmd)
 			return
 		}
 
@@ -80,26 +187,33 @@ var rootCmd = &cobra.Command{
 			loadProjectByName(projectName)
 			return
 		}
-		if !hasURL {
+		if !hasURL && hasSpec {
+			apiURL = urlFromSpec(specFile)
+		}
+		if apiURL == "" {
 			logger.Error("API URL is required")
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
 
 		if !hasSpec {
 			logger.Error("Specification file is required")
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
 
 		authProvider := buildAuthFromFlags()
+		if profileAuth := loadProfileAuth(); profileAuth != nil && !cmd.Flags().Changed("auth") {
+			authProvider = profileAuth.AuthProvider()
+			fmt.Printf("✓ Using profile authentication (%s)\n", profileAuth.Type)
+		}
 
 		if err := authProvider.Validate(); err != nil {
 			logger.Error("Invalid authentication configuration", logger.Err(err))
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
 
 		if err := storage.ValidateSpecPath(specFile); err != nil {
 			logger.Error("Spec path validation failed", logger.Err(err))
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
 
 		isTemporary := !hasName
@@ -154,25 +268,25 @@ var rootCmd = &cobra.Command{
 				conflict, err := storage.CheckNameConflict(projectName, "")
 				if err != nil {
 					logger.Error("Error checking name conflicts", logger.Err(err))
-					os.Exit(1)
+					os.Exit(exitConfig)
 				}
 				if conflict != nil {
 					logger.Error("Project already exists", logger.String("name", projectName))
-					os.Exit(1)
+					os.Exit(exitConfig)
 				}
 				projectID = generateUUID()
 			}
 		} else {
 			projectID = generateUUID()
 		}
-		project, _, err := storage.CreateOrUpdateProject(projectID, projectName, apiURL, specFile, "", isTemporary)
+		project, err := createProject(projectID, projectName, apiURL, specFile, isTemporary)
 		if err != nil {
 			logger.Error("Error processing specification", logger.Err(err))
-			os.Exit(1)
+			os.Exit(exitCode(err))
 		}
 
-		// Auto-save auth with named projects
-		if hasName && authType != "none" && authType != "" {
+		// Save auth with named projects only when the user opted in
+		if hasName && authType != "none" && authType != "" && shouldSaveAuth(cmd, project) {
 			project.AuthConfig = createAuthConfig()
 			if err := storage.SaveProject(project); err != nil {
 				fmt.Printf("Warning: failed to save authentication: %v\n", err)
@@ -184,16 +298,18 @@ var rootCmd = &cobra.Command{
 		specContent, err := parser.ParseSpecification(specFile)
 		if err != nil {
 			logger.Error("Error parsing specification", logger.Err(err))
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
+		suggestSpecUpgrade(specContent, specFile)
+		suggestSpecAuth(specContent, authProvider)
 
-		analysis, err := analyzer.AnalyzeAPI(apiURL, specContent)
+		analysis, err := analyzeSpec(apiURL, specContent)
 		if err != nil {
 			logger.Error("Error analyzing API", logger.Err(err))
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
 
-		cli.StartWithProject(apiURL, analysis, project, authProvider, version)
+		startInteractive(resolveBaseURL(project, specContent), analysis, project, authProvider, version, startOptions())
 	},
 }
 
@@ -205,84 +321,196 @@ func buildAuthFromEnvironments() auth.AuthProvider {
 		authToken := os.Getenv(authTokenEnvVar)
 		if authToken == "" {
 			logger.Error("OCTRAFIC_AUTH_TOKEN is required when using OCTRAFIC_AUTH_TYPE bearer")
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
-		return auth.NewBearerAuth(authToken)
+		return newBearerAuth(authToken, os.Getenv(authTokenCommandEnvVar))
 	case "apikey":
 		authKey := os.Getenv(authKeyEnvVar)
 		authValue := os.Getenv(authValueEnvVar)
 
 		if authKey == "" || authValue == "" {
 			logger.Error("OCTRAFIC_AUTH_KEY and OCTRAFIC_AUTH_VALUE are required when using OCTRAFIC_AUTH_TYPE apikey")
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
-		return auth.NewAPIKeyAuth(authKey, authValue, "header")
+		return auth.NewAPIKeyAuthRotating(authKey, splitKeyValues(authValue), os.Getenv(authLocationEnvVar))
 	case "basic":
 		authUser := os.Getenv(authUserEnvVar)
 		authPass := os.Getenv(authPassEnvVar)
 
 		if authUser == "" || authPass == "" {
 			logger.Error("OCTRAFIC_AUTH_USER and OCTRAFIC_AUTH_PASS are required when using OCTRAFIC_AUTH_TYPE basic")
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
 		return auth.NewBasicAuth(authUser, authPass)
+	case "digest":
+		authUser := os.Getenv(authUserEnvVar)
+		authPass := os.Getenv(authPassEnvVar)
+
+		if authUser == "" || authPass == "" {
+			logger.Error("OCTRAFIC_AUTH_USER and OCTRAFIC_AUTH_PASS are required when using OCTRAFIC_AUTH_TYPE digest")
+			os.Exit(exitConfig)
+		}
+		return auth.NewDigestAuth(authUser, authPass)
+	case "custom":
+		authHeader := os.Getenv(authHeaderEnvVar)
+		authValue := os.Getenv(authValueEnvVar)
+
+		if authHeader == "" || authValue == "" {
+			logger.Error("OCTRAFIC_AUTH_HEADER and OCTRAFIC_AUTH_VALUE are required when using OCTRAFIC_AUTH_TYPE custom")
+			os.Exit(exitConfig)
+		}
+		return auth.NewCustomHeaderAuth(authHeader, os.Getenv(authTemplateEnvVar), authValue)
+	case "oauth2":
+		tokenURL := os.Getenv(authTokenURLEnvVar)
+		clientID := os.Getenv(authClientIDEnvVar)
+		clientSecret := os.Getenv(authClientSecretEnvVar)
+
+		if tokenURL == "" || clientID == "" || clientSecret == "" {
+			logger.Error("OCTRAFIC_AUTH_TOKEN_URL, OCTRAFIC_AUTH_CLIENT_ID and OCTRAFIC_AUTH_CLIENT_SECRET are required when using OCTRAFIC_AUTH_TYPE oauth2")
+			os.Exit(exitConfig)
+		}
+		return auth.NewOAuth2ClientCredentials(tokenURL, clientID, clientSecret, splitScopes(os.Getenv(authScopesEnvVar)))
+	case "awssigv4":
+		// The standard AWS variables are used when the Octrafic-specific ones aren't set
+		accessKey := envOr(authAccessKeyEnvVar, "AWS_ACCESS_KEY_ID")
+		secretKey := envOr(authSecretKeyEnvVar, "AWS_SECRET_ACCESS_KEY")
+		region := envOr(authRegionEnvVar, "AWS_REGION")
+		service := os.Getenv(authServiceEnvVar)
+
+		if accessKey == "" || secretKey == "" || region == "" {
+			logger.Error("OCTRAFIC_AUTH_ACCESS_KEY, OCTRAFIC_AUTH_SECRET_KEY and OCTRAFIC_AUTH_REGION (or the AWS_* equivalents) are required when using OCTRAFIC_AUTH_TYPE awssigv4")
+			os.Exit(exitConfig)
+		}
+		if service == "" {
+			service = defaultAWSService
+		}
+		return auth.NewAWSSigV4(accessKey, secretKey, region, service)
 	case "none":
 		return &auth.NoAuth{}
 	default:
 		logger.Error("Invalid OCTRAFIC_AUTH_TYPE", logger.String("type", authType))
-		os.Exit(1)
+		os.Exit(exitConfig)
 		return nil
 	}
 }
 
+// newBearerAuth creates a bearer provider that renews its token with tokenCommand, when given,
+// after the API rejects it
+func newBearerAuth(token, tokenCommand string) *auth.BearerAuth {
+	bearer := auth.NewBearerAuth(token)
+	if tokenCommand != "" {
+		bearer.RefreshFunc = auth.CommandTokenSource(tokenCommand)
+	}
+	return bearer
+}
+
 func buildAuthFromFlags() auth.AuthProvider {
 	switch authType {
 	case "bearer":
 		if authToken == "" {
 			logger.Error("--token is required when using --auth bearer")
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
-		return auth.NewBearerAuth(authToken)
+		return newBearerAuth(authToken, authTokenCommand)
 
 	case "apikey":
-		if authKey == "" || authValue == "" {
+		if authKey == "" || len(authValues) == 0 {
 			logger.Error("--key and --value are required when using --auth apikey")
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
-		return auth.NewAPIKeyAuth(authKey, authValue, "header")
+		return auth.NewAPIKeyAuthRotating(authKey, authValues, authLocation)
 
 	case "basic":
 		if authUser == "" || authPass == "" {
 			logger.Error("--user and --pass are required when using --auth basic")
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
 		return auth.NewBasicAuth(authUser, authPass)
 
+	case "digest":
+		if authUser == "" || authPass == "" {
+			logger.Error("--user and --pass are required when using --auth digest")
+			os.Exit(exitConfig)
+		}
+		return auth.NewDigestAuth(authUser, authPass)
+
+	case "custom":
+		if authHeader == "" || singleAuthValue() == "" {
+			logger.Error("--header and --value are required when using --auth custom")
+			os.Exit(exitConfig)
+		}
+		return auth.NewCustomHeaderAuth(authHeader, authTemplate, singleAuthValue())
+
+	case "oauth2":
+		if authTokenURL == "" || authClientID == "" || authClientSecret == "" {
+			logger.Error("--token-url, --client-id and --client-secret are required when using --auth oauth2")
+			os.Exit(exitConfig)
+		}
+		return auth.NewOAuth2ClientCredentials(authTokenURL, authClientID, authClientSecret, splitScopes(authScopes))
+
+	case "awssigv4":
+		if authAccessKey == "" || authSecretKey == "" || authRegion == "" {
+			logger.Error("--access-key, --secret-key and --region are required when using --auth awssigv4")
+			os.Exit(exitConfig)
+		}
+		return auth.NewAWSSigV4(authAccessKey, authSecretKey, authRegion, authService)
+
 	case "none":
 		return &auth.NoAuth{}
 
 	default:
 		logger.Error("Invalid auth type", logger.String("type", authType))
-		os.Exit(1)
+		os.Exit(exitConfig)
 		return nil
 	}
 }
 
-func buildAuthFromProject(project *storage.Project) auth.AuthProvider {
-	if project.AuthConfig == nil {
-		return &auth.NoAuth{}
+// loadProfileAuth returns the active profile's default authentication, or nil when it has none
+func loadProfileAuth() *storage.AuthConfig {
+	authConfig, err := storage.LoadProfileAuth()
+	if err != nil {
+		logger.Warn("Failed to load profile authentication", logger.Err(err))
+		return nil
+	}
+	if authConfig == nil || authConfig.Type == "" || authConfig.Type == "none" || lockedAuth(authConfig) {
+		return nil
 	}
+	return authConfig
+}
 
-	switch project.AuthConfig.Type {
-	case "bearer":
-		return auth.NewBearerAuth(project.AuthConfig.Token)
-	case "apikey":
-		return auth.NewAPIKeyAuth(project.AuthConfig.KeyName, project.AuthConfig.KeyValue, "header")
-	case "basic":
-		return auth.NewBasicAuth(project.AuthConfig.Username, project.AuthConfig.Password)
-	default:
-		return &auth.NoAuth{}
+// lockedAuth reports saved credentials that couldn't be decrypted, warning the user about them
+func lockedAuth(authConfig *storage.AuthConfig) bool {
+	if !authConfig.Locked() {
+		return false
 	}
+	fmt.Printf("⚠ Saved credentials are encrypted; set %s to the passphrase they were saved with\n", storage.SecretEnvVar)
+	return true
+}
+
+// shouldSaveAuth decides whether credentials may be persisted with a project.
+// The --save-auth flag wins, then the project's earlier answer and the save_auth config default;
+// otherwise the user is asked once and the answer is remembered for this project only.
+func shouldSaveAuth(cmd *cobra.Command, project *storage.Project) bool {
+	if cmd.Flags().Changed("save-auth") {
+		return saveAuth
+	}
+	if project.SaveAuth != nil {
+		return *project.SaveAuth
+	}
+	if cfg, err := internalConfig.Load(); err == nil && cfg.SaveAuth != nil {
+		return *cfg.SaveAuth
+	}
+
+	fmt.Printf("Save credentials with this project? They'll be stored on disk (y/N): ")
+	var response string
+	_, _ = fmt.Scanln(&response)
+	answer := response == "y" || response == "Y"
+
+	project.SaveAuth = &answer
+	if err := storage.SaveProject(project); err != nil {
+		logger.Warn("Failed to save auth preference", logger.Err(err))
+	}
+	return answer
 }
 
 func createAuthConfig() *storage.AuthConfig {
@@ -297,35 +525,301 @@ func createAuthConfig() *storage.AuthConfig {
 	switch authType {
 	case "bearer":
 		config.Token = authToken
+		config.TokenCommand = authTokenCommand
 	case "apikey":
 		config.KeyName = authKey
-		config.KeyValue = authValue
-		config.Location = "header"
-	case "basic":
+		if len(authValues) > 1 {
+			config.KeyValues = authValues
+		} else {
+			config.KeyValue = singleAuthValue()
+		}
+		config.Location = authLocation
+	case "basic", "digest":
 		config.Username = authUser
 		config.Password = authPass
+	case "custom":
+		config.HeaderName = authHeader
+		config.HeaderTemplate = authTemplate
+		config.KeyValue = singleAuthValue()
+	case "oauth2":
+		config.TokenURL = authTokenURL
+		config.ClientID = authClientID
+		config.ClientSecret = authClientSecret
+		config.Scopes = splitScopes(authScopes)
+	case "awssigv4":
+		config.AccessKey = authAccessKey
+		config.SecretKey = authSecretKey
+		config.Region = authRegion
+		config.Service = authService
 	}
 
 	return config
 }
 
+// startOptions builds the interactive session options from flags and config defaults
+func startOptions() cli.StartOptions {
+	opts := cli.StartOptions{OpenReports: openReports, BinaryDir: binaryDir, Timeout: resolveRequestTimeout(), Retry: retryPolicy(), Validate: validateSchemas, Concurrency: concurrency, Resume: resumeSession, Proxy: resolveProxy(), TLS: tlsOptions(), Cookies: keepCookies, AutoExecute: autoExecute}
+	if !opts.OpenReports || !opts.Validate {
+		if cfg, err := internalConfig.Load(); err == nil {
+			opts.OpenReports = opts.OpenReports || cfg.OpenReports
+			opts.Validate = opts.Validate || cfg.ValidateSchemas
+		}
+	}
+
+	if replayDir != "" {
+		replayer, err := tester.NewReplayer(replayDir)
+		if err != nil {
+			logger.Error("Invalid replay directory", logger.Err(err))
+			os.Exit(exitConfig)
+		}
+		replayer.SetFallback(replayFallback, `{"error":"no recording for this request"}`)
+		opts.Replayer = replayer
+	}
+
+	if recordDir != "" {
+		recorder, err := tester.NewRecorder(recordDir)
+		if err != nil {
+			logger.Error("Invalid record directory", logger.Err(err))
+			os.Exit(exitConfig)
+		}
+		opts.Recorder = recorder
+	}
+	return opts
+}
+
+// retryPolicy builds the request retry policy from --retries and --retry-on
+func retryPolicy() tester.RetryPolicy {
+	policy, err := tester.ParseRetryOn(retries, retryOn)
+	if err != nil {
+		logger.Error("Invalid retry settings", logger.Err(err))
+		os.Exit(exitConfig)
+	}
+	return policy
+}
+
+// resolveProxy returns the --proxy address for test requests, exiting if it isn't a valid proxy URL
+func resolveProxy() string {
+	if proxyURL == "" {
+		return ""
+	}
+	if _, err := tester.ParseProxyURL(proxyURL); err != nil {
+		logger.Error("Invalid proxy", logger.Err(err))
+		os.Exit(exitConfig)
+	}
+	return proxyURL
+}
+
+// tlsOptions builds the certificate verification options from --insecure and --cacert,
+// warning on stderr when verification is off
+func tlsOptions() tester.TLSOptions {
+	if insecureTLS {
+		fmt.Fprintln(os.Stderr, "⚠ --insecure: TLS certificates are not verified; only use this against APIs you trust")
+	}
+	return tester.TLSOptions{Insecure: insecureTLS, CAFile: caCertFile}
+}
+
+// resolveRequestTimeout returns the per-request timeout from --timeout, then OCTRAFIC_REQUEST_TIMEOUT
+// (a duration like 90s, or plain seconds), then the executor default
+func resolveRequestTimeout() time.Duration {
+	if requestTimeout > 0 {
+		return requestTimeout
+	}
+	value := strings.TrimSpace(os.Getenv(requestTimeoutEnvVar))
+	if value == "" {
+		return tester.DefaultTimeout
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		logger.Error("Invalid "+requestTimeoutEnvVar+" (use a duration like 45s)", logger.String("value", value))
+		os.Exit(exitConfig)
+	}
+	return timeout
+}
+
+// urlFromSpec offers the first server declared in the spec as the base URL when --url is missing,
+// returning "" when there is none or the user declines
+func urlFromSpec(specPath string) string {
+	servers, err := parser.DetectServers(specPath)
+	if err != nil || len(servers) == 0 {
+		return ""
+	}
+
+	fmt.Printf("No --url given; the spec declares the server %s\n", servers[0])
+	fmt.Printf("Use it as the base URL? (Y/n): ")
+	var response string
+	_, _ = fmt.Scanln(&response)
+	if response != "" && response != "y" && response != "Y" {
+		return ""
+	}
+	return servers[0]
+}
+
+// suggestSpecUpgrade shows a hint to upgrade a Swagger 2.0 spec to OpenAPI 3.x, once per spec file
+func suggestSpecUpgrade(spec *parser.Specification, specPath string) {
+	if !spec.IsSwagger2() {
+		return
+	}
+	key, err := filepath.Abs(specPath)
+	if err != nil {
+		key = specPath
+	}
+	cfg, err := internalConfig.Load()
+	if err != nil || slices.Contains(cfg.UpgradeHinted, key) {
+		return
+	}
+
+	fmt.Printf("💡 This spec is Swagger %s. OpenAPI 3.x parses more accurately; upgrade it with:\n", spec.Version)
+	fmt.Printf("   octrafic upgrade -s %q\n", specPath)
+
+	cfg.UpgradeHinted = append(cfg.UpgradeHinted, key)
+	if err := cfg.Save(); err != nil {
+		logger.Warn("Failed to save upgrade hint state", logger.Err(err))
+	}
+}
+
+// suggestSpecAuth prints the flags for the spec's main security scheme when no auth is configured
+func suggestSpecAuth(spec *parser.Specification, authProvider auth.AuthProvider) {
+	scheme := spec.PrimaryAuthScheme()
+	if scheme == nil {
+		return
+	}
+	if _, ok := authProvider.(*auth.NoAuth); !ok {
+		return
+	}
+
+	fmt.Printf("💡 The spec declares %s auth (%s). To authenticate, run with:\n", scheme.Type, scheme.Name)
+	fmt.Printf("   %s\n", specAuthFlags(scheme))
+}
+
+// specAuthFlags returns the auth flags for a spec security scheme, with placeholders for secrets
+func specAuthFlags(scheme *parser.AuthScheme) string {
+	switch scheme.Type {
+	case "bearer":
+		return "--auth bearer --token <token>"
+	case "basic", "digest":
+		return fmt.Sprintf("--auth %s --user <user> --pass <pass>", scheme.Type)
+	case "apikey":
+		flags := fmt.Sprintf("--auth apikey --key %s --value <key>", scheme.KeyName)
+		if scheme.Location == "query" {
+			flags += " --key-location query"
+		}
+		return flags
+	case "oauth2":
+		tokenURL := scheme.TokenURL
+		if tokenURL == "" {
+			tokenURL = "<token-url>"
+		}
+		flags := fmt.Sprintf("--auth oauth2 --token-url %s --client-id <id> --client-secret <secret>", tokenURL)
+		if len(scheme.Scopes) > 0 {
+			flags += fmt.Sprintf(" --scopes %q", strings.Join(scheme.Scopes, " "))
+		}
+		return flags
+	}
+	return "--auth " + scheme.Type
+}
+
+// createProject creates or updates a project from its spec. When the spec has no endpoints it
+// explains why and, for locally parsed formats, offers to extract them with the LLM instead.
+// LLM failures are tagged with exitLLM.
+func createProject(projectID, name, url, specPath string, isTemporary bool) (*storage.Project, error) {
+	if offline && specPath != "" && !storage.HasNativeParser(specPath) {
+		return nil, fmt.Errorf("%s needs the LLM to extract its endpoints; run without --offline", filepath.Base(specPath))
+	}
+	project, _, err := storage.CreateOrUpdateProject(projectID, name, url, specPath, "", isTemporary)
+	if !errors.Is(err, parser.ErrNoEndpoints) {
+		return project, err
+	}
+
+	fmt.Printf("\n⚠ No endpoints were found in %s\n", specPath)
+	fmt.Println("  The file may not be an API specification, its paths may be empty, or it may need a different parse strategy.")
+	if offline || !storage.HasNativeParser(specPath) {
+		return nil, err
+	}
+
+	fmt.Printf("\nRetry by extracting endpoints with the LLM? (y/N): ")
+	var response string
+	_, _ = fmt.Scanln(&response)
+	if response != "y" && response != "Y" {
+		return nil, err
+	}
+
+	endpoints, err := storage.ParseSpecWithLLM(specPath, projectID, url, isTemporary)
+	if err != nil {
+		return nil, withExitCode(exitLLM, err)
+	}
+	fmt.Printf("✓ Found %d endpoints with LLM parsing\n", len(endpoints))
+
+	project, _, err = storage.CreateOrUpdateProject(projectID, name, url, specPath, "", isTemporary)
+	return project, err
+}
+
+// singleAuthValue returns the last --value given, for auth types that take one value
+func singleAuthValue() string {
+	if len(authValues) == 0 {
+		return ""
+	}
+	return authValues[len(authValues)-1]
+}
+
+// splitKeyValues splits the API key values of OCTRAFIC_AUTH_VALUE, one per line. Keys may
+// contain commas, so only newlines separate them.
+func splitKeyValues(value string) []string {
+	var values []string
+	for v := range strings.SplitSeq(value, "\n") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// splitScopes splits an OAuth2 scope list separated by spaces or commas
+func splitScopes(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+}
+
+// envOr returns the first non-empty environment variable among names
+func envOr(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
 func generateUUID() string {
 	b := make([]byte, 16)
 	_, err := rand.Read(b)
 	if err != nil {
 		logger.Error("Failed to generate UUID", logger.Err(err))
-		os.Exit(1)
+		os.Exit(exitConfig)
 	}
 	b[6] = (b[6] & 0x0f) | 0x40
 	b[8] = (b[8] & 0x3f) | 0x80
 	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
 }
 
-func showProjectList() {
+func showProjectList(cmd *cobra.Command) {
 	projects, err := storage.ListNamedProjects()
 	if err != nil {
 		logger.Error("Error loading projects", logger.Err(err))
-		os.Exit(1)
+		os.Exit(exitConfig)
+	}
+
+	// First real launch: skip the empty list and go straight to project creation,
+	// making sure onboarding has been completed first
+	if len(projects) == 0 {
+		if isFirstLaunch, err := internalConfig.IsFirstLaunch(); err == nil && isFirstLaunch {
+			if !runOnboarding() {
+				os.Exit(0)
+			}
+		}
+		promptNewProject(cmd)
+		return
 	}
 
 	listModel := cli.NewProjectListModel(projects)
@@ -334,17 +828,17 @@ func showProjectList() {
 	finalModel, err := p.Run()
 	if err != nil {
 		logger.Error("Error running project list", logger.Err(err))
-		os.Exit(1)
+		os.Exit(exitConfig)
 	}
 
 	result, ok := finalModel.(cli.ProjectListModel)
 	if !ok {
 		logger.Error("Unexpected model type")
-		os.Exit(1)
+		os.Exit(exitConfig)
 	}
 
 	if result.ShouldCreateNew() {
-		promptNewProject()
+		promptNewProject(cmd)
 		return
 	}
 
@@ -356,20 +850,20 @@ func showProjectList() {
 	loadAndStartProject(selectedProject)
 }
 
-func promptNewProject() {
+func promptNewProject(cmd *cobra.Command) {
 	creatorModel := cli.NewProjectCreatorModel()
 	p := tea.NewProgram(creatorModel)
 
 	finalModel, err := p.Run()
 	if err != nil {
 		logger.Error("Error running project creator", logger.Err(err))
-		os.Exit(1)
+		os.Exit(exitConfig)
 	}
 
 	result, ok := finalModel.(cli.ProjectCreatorModel)
 	if !ok {
 		logger.Error("Unexpected model type")
-		os.Exit(1)
+		os.Exit(exitConfig)
 	}
 
 	if result.IsCancelled() {
@@ -383,12 +877,16 @@ func promptNewProject() {
 
 	url, specPath, name := result.GetProjectData()
 	if result.NeedsConversion() {
+		if offline {
+			logger.Error("Converting this spec to OpenAPI needs the LLM; run without --offline")
+			os.Exit(exitConfig)
+		}
 		fmt.Printf("\nConverting %s to OpenAPI format...\n", result.GetDetectedFormat())
 
-		convertedPath, err := converter.ConvertToOpenAPI(specPath, result.GetDetectedFormat())
+		convertedPath, err := converter.ConvertToOpenAPI(specPath, result.GetDetectedFormat(), !noCache)
 		if err != nil {
 			logger.Error("Conversion failed", logger.Err(err))
-			os.Exit(1)
+			os.Exit(exitLLM)
 		}
 
 		fmt.Printf("Converted specification saved to: %s\n", convertedPath)
@@ -396,44 +894,25 @@ func promptNewProject() {
 	}
 
 	projectID := generateUUID()
-	project, _, err := storage.CreateOrUpdateProject(projectID, name, url, specPath, "", false)
+	project, err := createProject(projectID, name, url, specPath, false)
 	if err != nil {
 		logger.Error("Error creating project", logger.Err(err))
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 
-	// Handle auth configuration from wizard
+	// Auth from the wizard is used for this session and saved only when the user opted in
 	var authProvider auth.AuthProvider = &auth.NoAuth{}
 	authType, authData := result.GetAuthConfig()
 	if authType != "none" && authData != nil {
-		// Create auth provider from wizard data
-		switch authType {
-		case "bearer":
-			authProvider = auth.NewBearerAuth(authData["token"])
-		case "apikey":
-			location := authData["location"]
-			if location == "" {
-				location = "header"
+		authConfig := cli.AuthConfigFromForm(authType, authData)
+		authProvider = authConfig.AuthProvider()
+		if shouldSaveAuth(cmd, project) {
+			project.AuthConfig = authConfig
+			if err := storage.SaveProject(project); err != nil {
+				fmt.Printf("Warning: failed to save authentication: %v\n", err)
+			} else {
+				fmt.Println("✓ Authentication saved with project")
 			}
-			authProvider = auth.NewAPIKeyAuth(authData["key"], authData["value"], location)
-		case "basic":
-			authProvider = auth.NewBasicAuth(authData["username"], authData["password"])
-		}
-
-		// Save auth config with project
-		project.AuthConfig = &storage.AuthConfig{
-			Type:     authType,
-			Token:    authData["token"],
-			KeyName:  authData["key"],
-			KeyValue: authData["value"],
-			Location: authData["location"],
-			Username: authData["username"],
-			Password: authData["password"],
-		}
-		if err := storage.SaveProject(project); err != nil {
-			fmt.Printf("Warning: failed to save authentication: %v\n", err)
-		} else {
-			fmt.Println("✓ Authentication configured and saved with project")
 		}
 	}
 
@@ -447,23 +926,24 @@ func promptNewProject() {
 	specContent, err := parser.ParseSpecification(specPath)
 	if err != nil {
 		logger.Error("Error parsing specification", logger.Err(err))
-		os.Exit(1)
+		os.Exit(exitConfig)
 	}
+	suggestSpecUpgrade(specContent, specPath)
 
-	analysis, err := analyzer.AnalyzeAPI(url, specContent)
+	analysis, err := analyzeSpec(url, specContent)
 	if err != nil {
 		logger.Error("Error analyzing API", logger.Err(err))
-		os.Exit(1)
+		os.Exit(exitConfig)
 	}
 
-	cli.StartWithProject(url, analysis, project, authProvider, version)
+	startInteractive(resolveBaseURL(project, specContent), analysis, project, authProvider, version, startOptions())
 }
 
 func loadProjectByName(name string) {
 	project, err := storage.FindProjectByName(name)
 	if err != nil {
 		logger.Error("Error loading project", logger.String("name", name), logger.Err(err))
-		os.Exit(1)
+		os.Exit(exitConfig)
 	}
 
 	if clearAuth {
@@ -478,32 +958,48 @@ func loadProjectByName(name string) {
 	loadAndStartProject(project)
 }
 
+// resolveAuth picks the credentials for a project: auth flags, then OCTRAFIC_AUTH_* variables,
+// then the project's saved auth, then the active profile's default. project may be nil.
+func resolveAuth(project *storage.Project) auth.AuthProvider {
+	if authType != "" && authType != "none" {
+		return buildAuthFromFlags()
+	} else if authEnv, exists := os.LookupEnv(authTypeEnvVar); exists && authEnv != "" {
+		return buildAuthFromEnvironments()
+	} else if project != nil && project.HasAuth() && !lockedAuth(project.AuthConfig) {
+		fmt.Printf("✓ Using saved authentication (%s)\n", project.AuthConfig.Type)
+		return project.AuthConfig.AuthProvider()
+	} else if profileAuth := loadProfileAuth(); profileAuth != nil {
+		fmt.Printf("✓ Using profile authentication (%s)\n", profileAuth.Type)
+		return profileAuth.AuthProvider()
+	}
+	return &auth.NoAuth{}
+}
+
 func loadAndStartProject(project *storage.Project) {
 	project.LastAccessedAt = time.Now()
 	if err := storage.SaveProject(project); err != nil {
 		fmt.Printf("Warning: failed to update last accessed time: %v\n", err)
 	}
 
-	var authProvider auth.AuthProvider
-	if authType != "" && authType != "none" {
-		authProvider = buildAuthFromFlags()
-	} else if authEnv, exists := os.LookupEnv(authTypeEnvVar); exists && authEnv != "" {
-		authProvider = buildAuthFromEnvironments()
-	} else if project.HasAuth() {
-		authProvider = buildAuthFromProject(project)
-		fmt.Printf("✓ Using saved authentication (%s)\n", project.AuthConfig.Type)
-	} else {
-		authProvider = &auth.NoAuth{}
-	}
+	authProvider := resolveAuth(project)
 
 	var analysis *analyzer.Analysis
+	var endpoints []parser.Endpoint
+	var specContent *parser.Specification
 
 	if storage.HasEndpoints(project.ID, project.IsTemporary) {
 		fmt.Printf("✓ Using cached endpoints\n")
-		analysis = &analyzer.Analysis{
-			BaseURL:      project.BaseURL,
-			Timestamp:    time.Now(),
-			EndpointInfo: make(map[string]analyzer.EndpointAnalysis),
+		if cached, err := storage.LoadEndpoints(project.ID, project.IsTemporary); err == nil {
+			endpoints = cached
+		}
+		if offline {
+			analysis = analyzer.AnalyzeOffline(project.BaseURL, &parser.Specification{Endpoints: endpoints})
+		} else {
+			analysis = &analyzer.Analysis{
+				BaseURL:      project.BaseURL,
+				Timestamp:    time.Now(),
+				EndpointInfo: make(map[string]analyzer.EndpointAnalysis),
+			}
 		}
 	} else {
 		if err := storage.ValidateSpecPath(project.SpecPath); err != nil {
@@ -513,7 +1009,7 @@ func loadAndStartProject(project *storage.Project) {
 			_, _ = fmt.Scanln(&newPath)
 			if err := storage.ValidateSpecPath(newPath); err != nil {
 				logger.Error("Spec path validation failed", logger.Err(err))
-				os.Exit(1)
+				os.Exit(exitConfig)
 			}
 			project.SpecPath = newPath
 			if err := storage.SaveProject(project); err != nil {
@@ -521,48 +1017,166 @@ func loadAndStartProject(project *storage.Project) {
 			}
 		}
 
-		specContent, err := parser.ParseSpecification(project.SpecPath)
+		var err error
+		specContent, err = parser.ParseSpecification(project.SpecPath)
 		if err != nil {
 			logger.Error("Error parsing specification", logger.Err(err))
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
+		suggestSpecUpgrade(specContent, project.SpecPath)
 
-		analysis, err = analyzer.AnalyzeAPI(project.BaseURL, specContent)
+		analysis, err = analyzeSpec(project.BaseURL, specContent)
 		if err != nil {
 			logger.Error("Error analyzing API", logger.Err(err))
-			os.Exit(1)
+			os.Exit(exitConfig)
 		}
+		endpoints = specContent.Endpoints
 	}
 
+	fmt.Printf("📊 %s\n", parser.ComputeStats(endpoints).Summary())
+
+	baseURL := resolveBaseURL(project, specContent)
+
 	fmt.Printf("🚀 Loading project: %s\n", project.Name)
 
-	cli.StartWithProject(project.BaseURL, analysis, project, authProvider, version)
+	startInteractive(baseURL, analysis, project, authProvider, version, startOptions())
+}
+
+// analyzeSpec builds the analysis a session starts with; with --offline it is derived from the parsed
+// endpoints alone
+func analyzeSpec(baseURL string, spec *parser.Specification) (*analyzer.Analysis, error) {
+	if offline {
+		return analyzer.AnalyzeOffline(baseURL, spec), nil
+	}
+	return analyzer.AnalyzeAPI(baseURL, spec)
+}
+
+// startInteractive runs the TUI session and exits with exitConfig if it can't run
+func startInteractive(baseURL string, analysis *analyzer.Analysis, project *storage.Project, authProvider auth.AuthProvider, version string, opts cli.StartOptions) {
+	if err := cli.StartWithProject(baseURL, analysis, project, authProvider, version, opts); err != nil {
+		logger.Error("Error running interactive mode", logger.Err(err))
+		os.Exit(exitConfig)
+	}
+}
+
+// resolveBaseURL returns the URL requests are sent to. With --base-path-from-spec (or
+// base_path_from_spec in the config) the spec's server base path is appended to the project's
+// base URL, and conflicts between the two are reported.
+func resolveBaseURL(project *storage.Project, spec *parser.Specification) string {
+	enabled := basePathFromSpec
+	if !enabled {
+		if cfg, err := internalConfig.Load(); err == nil {
+			enabled = cfg.BasePathFromSpec
+		}
+	}
+	if !enabled {
+		return project.BaseURL
+	}
+
+	if spec == nil {
+		parsed, err := parser.ParseSpecification(project.SpecPath)
+		if err != nil {
+			logger.Warn("Couldn't read the spec's servers, using the base URL as given", logger.Err(err))
+			return project.BaseURL
+		}
+		spec = parsed
+	}
+
+	baseURL, warnings := parser.ApplyBasePath(project.BaseURL, spec)
+	for _, warning := range warnings {
+		fmt.Printf("⚠️  Warning: %s\n", warning)
+	}
+	if baseURL != project.BaseURL {
+		fmt.Printf("✓ Using base path from spec: %s\n", baseURL)
+	}
+	return baseURL
 }
 
 func init() {
-	rootCmd.Flags().StringVarP(&apiURL, "url", "u", "", "Base URL of the API to test")
+	rootCmd.Flags().StringVarP(&apiURL, "url", "u", "", "Base URL of the API to test (optional when the spec declares servers)")
 	rootCmd.Flags().StringVarP(&specFile, "spec", "s", "", "Path to API specification file")
 	rootCmd.Flags().StringVarP(&projectName, "name", "n", "", "Project name for saving/loading")
 
-	rootCmd.Flags().StringVar(&authType, "auth", "none", "Authentication type (none|bearer|apikey|basic)")
-	rootCmd.Flags().StringVar(&authToken, "token", "", "Bearer token")
-	rootCmd.Flags().StringVar(&authKey, "key", "", "API key name (e.g., X-API-Key)")
-	rootCmd.Flags().StringVar(&authValue, "value", "", "API key value")
-	rootCmd.Flags().StringVar(&authUser, "user", "", "Username for basic auth")
-	rootCmd.Flags().StringVar(&authPass, "pass", "", "Password for basic auth")
+	addAuthFlags(rootCmd)
 
 	rootCmd.Flags().BoolVar(&clearAuth, "clear-auth", false, "Remove saved authentication from project")
+	rootCmd.Flags().BoolVar(&openReports, "open", false, "Open generated reports in the default viewer")
+	rootCmd.Flags().BoolVar(&basePathFromSpec, "base-path-from-spec", false, "Prefix requests with the base path of the spec's servers/basePath (e.g. /api/v1)")
+	rootCmd.Flags().BoolVar(&validateSchemas, "validate-schema", false, "Check JSON responses against the response schemas in the spec")
+	rootCmd.Flags().StringVar(&replayDir, "replay", "", "Serve saved responses from a fixture directory instead of calling the API")
+	rootCmd.Flags().IntVar(&replayFallback, "replay-fallback", 404, "Status code returned when no recording exists (0 to fail the request)")
+	rootCmd.Flags().StringVar(&recordDir, "record", "", "Save every request/response as replay fixtures in a directory")
+	rootCmd.MarkFlagsMutuallyExclusive("replay", "record")
+	addRequestFlags(rootCmd)
+	rootCmd.Flags().IntVar(&concurrency, "concurrency", 0, "Send up to this many tests of a group at once (1 sends them one at a time; default: the project's /parallel setting, else 1)")
+	rootCmd.Flags().StringVar(&binaryDir, "save-binary", "", "Save binary responses (images, downloads) to a directory; the model only sees a summary")
+	rootCmd.Flags().BoolVar(&saveAuth, "save-auth", false, "Save authentication with a named project (stored on disk)")
+	rootCmd.Flags().BoolVar(&resumeSession, "resume", false, "Continue the project's last saved conversation")
+	rootCmd.Flags().BoolVar(&autoExecute, "auto", false, "Run test plans and requests without asking first; destructive requests still ask (toggle with /auto)")
+	rootCmd.Flags().BoolVar(&autoExecute, "yolo", false, "Alias for --auto")
+	_ = rootCmd.Flags().MarkHidden("yolo")
+	rootCmd.Flags().BoolVar(&offline, "offline", false, "Build the endpoint analysis from the parsed spec only, without calling the LLM while loading")
+	rootCmd.Flags().BoolVar(&noCache, "no-cache", false, "Convert non-OpenAPI specs with the LLM again even if the file is unchanged")
 
 	rootCmd.Flags().StringVar(&debugFilePath, "debug-file", "", "Path to debug log file (enables file logging)")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "Log format: console or json (default json in a log file, console on stderr); enables logging")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "Lowest level logged: debug, info, warn or error (default debug with --debug-file, otherwise info); enables logging")
 
 	rootCmd.Flags().BoolVar(&forceOnboarding, "onboarding", false, "Force run onboarding wizard (even if already completed)")
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
-		initLogger()
+		initLogger(cmd == rootCmd)
 	}
 }
 
+// addAuthFlags registers the flags that configure authentication for the tested API
+func addAuthFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&authType, "auth", "none", "Authentication type (none|bearer|apikey|basic|custom|oauth2|awssigv4|digest)")
+	cmd.Flags().StringVar(&authToken, "token", "", "Bearer token")
+	cmd.Flags().StringVar(&authTokenCommand, "token-command", "", "Shell command printing a new bearer token, run when the API rejects the current one with 401")
+	cmd.Flags().StringVar(&authKey, "key", "", "API key name (e.g., X-API-Key)")
+	cmd.Flags().StringArrayVar(&authValues, "value", nil, "API key or custom header value (repeat --value to rotate several API keys across requests)")
+	cmd.Flags().StringVar(&authUser, "user", "", "Username for basic or digest auth")
+	cmd.Flags().StringVar(&authPass, "pass", "", "Password for basic or digest auth")
+	cmd.Flags().StringVar(&authHeader, "header", "", "Header name for custom auth (e.g., Authorization)")
+	cmd.Flags().StringVar(&authTemplate, "template", "{value}", "Header value template for custom auth (e.g., \"Token {value}\")")
+	cmd.Flags().StringVar(&authLocation, "key-location", "header", "Where to send the API key (header|query)")
+	cmd.Flags().StringVar(&authTokenURL, "token-url", "", "OAuth2 token endpoint for the client-credentials grant")
+	cmd.Flags().StringVar(&authClientID, "client-id", "", "OAuth2 client ID")
+	cmd.Flags().StringVar(&authClientSecret, "client-secret", "", "OAuth2 client secret")
+	cmd.Flags().StringVar(&authScopes, "scopes", "", "OAuth2 scopes to request (space- or comma-separated)")
+	cmd.Flags().StringVar(&authAccessKey, "access-key", "", "AWS access key ID for SigV4 signing")
+	cmd.Flags().StringVar(&authSecretKey, "secret-key", "", "AWS secret access key for SigV4 signing")
+	cmd.Flags().StringVar(&authRegion, "region", "", "AWS region for SigV4 signing (e.g., us-east-1)")
+	cmd.Flags().StringVar(&authService, "service", defaultAWSService, "AWS service name for SigV4 signing")
+}
+
+// addRequestFlags registers the flags that control how each request is sent
+func addRequestFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&requestTimeout, "timeout", 0, "Timeout for each API request, e.g. 45s or 2m (default 30s, or OCTRAFIC_REQUEST_TIMEOUT)")
+	cmd.Flags().IntVar(&retries, "retries", 0, "Resend failed requests up to this many times, with exponential backoff")
+	cmd.Flags().StringVar(&retryOn, "retry-on", tester.DefaultRetryOn, "Failures to retry: connection, timeout, status codes (503) or classes (5xx), comma-separated")
+	cmd.Flags().BoolVar(&keepCookies, "cookies", false, "Keep cookies set by responses and send them with later requests, e.g. a login session (off by default so tests are isolated)")
+	cmd.Flags().BoolVar(&insecureTLS, "insecure", false, "Don't verify the API's TLS certificate (for self-signed test servers)")
+	cmd.Flags().StringVar(&caCertFile, "cacert", "", "PEM file of extra CA certificates to trust for the API")
+	cmd.Flags().StringVar(&proxyURL, "proxy", "", "Send API test requests through this proxy, e.g. http://proxy:3128 (default HTTP_PROXY/HTTPS_PROXY; LLM calls always use the environment)")
+}
+
 func main() {
 	_ = godotenv.Load()
+	applyProfileFlag(os.Args[1:])
+
+	cmd, args, findErr := rootCmd.Find(os.Args[1:])
+	if findErr == nil {
+		requireActiveProfile(cmd)
+	}
+
+	if findErr == nil && !needsOnboarding(cmd, args) {
+		if err := rootCmd.Execute(); err != nil {
+			os.Exit(exitCode(err))
+		}
+		return
+	}
+
 	if isFirstLaunch, err := internalConfig.IsFirstLaunch(); err == nil && isFirstLaunch {
 		completed := runOnboarding()
 		if !completed {
@@ -576,11 +1190,26 @@ func main() {
 
 	if err := rootCmd.Execute(); err != nil {
 		logger.Error("Command execution failed", logger.Err(err))
-		os.Exit(1)
-	}
-	if debugFilePath != "" {
 		logger.Close()
+		os.Exit(exitCode(err))
+	}
+	logger.Close()
+}
+
+// needsOnboarding reports whether cmd, run with args, talks to the LLM and so needs a provider
+// set up on first launch. Subcommands that work offline, such as "parse", skip onboarding.
+func needsOnboarding(cmd *cobra.Command, args []string) bool {
+	switch cmd {
+	case rootCmd, askCmd, upgradeCmd:
+		return true
+	case runCmd:
+		// A prompt generates the tests with the LLM, a --plan doesn't
+		if err := cmd.ParseFlags(args); err != nil {
+			return false
+		}
+		return len(cmd.Flags().Args()) > 0
 	}
+	return false
 }
 
 func checkForUpdate(currentVersion string) {
@@ -610,7 +1239,7 @@ func runOnboarding() bool {
 	finalModel, err := p.Run()
 	if err != nil {
 		logger.Error("Error running onboarding", logger.Err(err))
-		os.Exit(1)
+		os.Exit(exitConfig)
 	}
 
 	result, ok := finalModel.(cli.OnboardingModel)
@@ -626,12 +1255,50 @@ func runOnboarding() bool {
 	return false
 }
 
-func initLogger() {
-	if debugFilePath != "" {
-		if err := logger.Init(true, debugFilePath); err != nil {
-			logger.Error("Failed to initialize logger", logger.Err(err))
-			os.Exit(1)
+// initLogger turns logging on when --debug-file, --log-format or --log-level is given
+func initLogger(interactive bool) {
+	cfg, enabled, err := loggerConfig(interactive)
+	if err == nil && enabled {
+		err = logger.Init(cfg)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(exitConfig)
+	}
+	if !enabled {
+		return
+	}
+	if interactive && debugFilePath == "" {
+		fmt.Fprintf(os.Stderr, "Logging to %s\n", cfg.Path)
+	}
+	logger.Info("Octrafic starting", logger.String("log_file", cfg.Path), logger.String("level", cfg.Level))
+}
+
+// loggerConfig returns the logging the flags ask for, and false when they leave it off. Logs go to
+// the debug file if there is one, otherwise to stderr for the non-interactive commands. Stderr would
+// draw over the TUI, so interactive mode logs to a dated file in ~/.octrafic/logs instead.
+func loggerConfig(interactive bool) (logger.Config, bool, error) {
+	if debugFilePath == "" && logFormat == "" && logLevel == "" {
+		return logger.Config{}, false, nil
+	}
+
+	cfg := logger.Config{Path: debugFilePath, Format: logFormat, Level: logLevel}
+	if cfg.Level == "" {
+		cfg.Level = "info"
+		if debugFilePath != "" {
+			cfg.Level = "debug"
+		}
+	}
+	if cfg.Path == "" && interactive {
+		dir, err := storage.GetConfigDir()
+		if err != nil {
+			return cfg, false, err
+		}
+		dir = filepath.Join(dir, "logs")
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return cfg, false, fmt.Errorf("failed to create log directory: %w", err)
 		}
-		logger.Info("Octrafic starting", logger.String("log_file", debugFilePath), logger.Bool("debug", true))
+		cfg.Path = filepath.Join(dir, "octrafic-"+time.Now().Format("2006-01-02")+".log")
 	}
+	return cfg, true, nil
 }
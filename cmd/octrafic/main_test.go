@@ -0,0 +1,158 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	internalConfig "github.com/Octrafic/octrafic-cli/internal/config"
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"github.com/spf13/cobra"
+)
+
+func TestShouldSaveAuthPerProject(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cmd := &cobra.Command{}
+	cmd.Flags().BoolVar(&saveAuth, "save-auth", false, "")
+	yes, no := true, false
+
+	if !shouldSaveAuth(cmd, &storage.Project{SaveAuth: &yes}) {
+		t.Error("a project that opted in should save auth")
+	}
+	if shouldSaveAuth(cmd, &storage.Project{SaveAuth: &no}) {
+		t.Error("a project that opted out should not save auth")
+	}
+
+	// Without an earlier answer the user is asked, and the answer stays with this project
+	stdin := os.Stdin
+	t.Cleanup(func() { os.Stdin = stdin })
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.WriteString("y\n")
+	_ = w.Close()
+	os.Stdin = r
+
+	project := &storage.Project{ID: "save-auth-test", Name: "save-auth-test"}
+	if !shouldSaveAuth(cmd, project) {
+		t.Fatal("answering y should save auth")
+	}
+	saved, err := storage.LoadProject(project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.SaveAuth == nil || !*saved.SaveAuth {
+		t.Errorf("saved project SaveAuth = %v, want true", saved.SaveAuth)
+	}
+	if cfg, err := internalConfig.Load(); err == nil && cfg.SaveAuth != nil {
+		t.Errorf("the answer leaked into the global config: save_auth = %v", *cfg.SaveAuth)
+	}
+
+	// The flag wins over the project's answer
+	if err := cmd.Flags().Set("save-auth", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if !shouldSaveAuth(cmd, &storage.Project{SaveAuth: &no}) {
+		t.Error("--save-auth should win over the project's answer")
+	}
+}
+
+func TestRepeatedValueRotatesKeys(t *testing.T) {
+	cmd := &cobra.Command{}
+	addAuthFlags(cmd)
+	t.Cleanup(func() { authType, authKey, authValues = "none", "", nil })
+	if err := cmd.ParseFlags([]string{"--auth", "apikey", "--key", "X-API-Key", "--value", "a,b", "--value", "c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	config := createAuthConfig()
+	if want := []string{"a,b", "c"}; !slices.Equal(config.KeyValues, want) {
+		t.Errorf("KeyValues = %q, want %q (commas kept inside a key)", config.KeyValues, want)
+	}
+	if got := splitKeyValues("a,b\nc\n"); !slices.Equal(got, []string{"a,b", "c"}) {
+		t.Errorf("splitKeyValues = %q, want one key per line", got)
+	}
+}
+
+func TestNeedsOnboarding(t *testing.T) {
+	t.Cleanup(func() { runPlanFile = "" })
+
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{nil, true},
+		{[]string{"ask", "what endpoints are there?"}, true},
+		{[]string{"upgrade", "--spec", "swagger.json"}, true},
+		{[]string{"run", "-u", "http://localhost", "test the users endpoints"}, true},
+		{[]string{"run", "-u", "http://localhost", "--plan", "tests.json"}, false},
+		{[]string{"parse", "--spec", "openapi.yaml"}, false},
+		{[]string{"capabilities", "--json"}, false},
+		{[]string{"profile", "list"}, false},
+	}
+	for _, tt := range tests {
+		cmd, args, err := rootCmd.Find(tt.args)
+		if err != nil {
+			t.Fatalf("Find(%v) error = %v", tt.args, err)
+		}
+		if got := needsOnboarding(cmd, args); got != tt.want {
+			t.Errorf("needsOnboarding(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestSpecUpgradeOncePerSpec(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	stdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = devNull
+	t.Cleanup(func() { os.Stdout = stdout; _ = devNull.Close() })
+
+	spec := &parser.Specification{Format: "openapi", Version: "2.0"}
+	suggestSpecUpgrade(spec, "users.json")
+	suggestSpecUpgrade(spec, "users.json")
+	suggestSpecUpgrade(spec, "orders.json")
+
+	cfg, err := internalConfig.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, _ := filepath.Abs("users.json")
+	orders, _ := filepath.Abs("orders.json")
+	if !slices.Equal(cfg.UpgradeHinted, []string{users, orders}) {
+		t.Errorf("UpgradeHinted = %v, want one entry per spec", cfg.UpgradeHinted)
+	}
+}
+
+func TestLoggerConfigKeepsStderrFreeUnderTUI(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Cleanup(func() { debugFilePath, logFormat, logLevel = "", "", "" })
+
+	if _, enabled, err := loggerConfig(true); enabled || err != nil {
+		t.Errorf("loggerConfig() without logging flags = enabled %v, error %v; want off", enabled, err)
+	}
+
+	logFormat = "json"
+	cfg, enabled, err := loggerConfig(false)
+	if err != nil || !enabled || cfg.Path != "" {
+		t.Errorf("non-interactive loggerConfig() = %+v, %v, %v; want stderr", cfg, enabled, err)
+	}
+
+	// The TUI owns the terminal, so its logs go to a file
+	cfg, enabled, err = loggerConfig(true)
+	if err != nil || !enabled || filepath.Dir(cfg.Path) != filepath.Join(home, ".octrafic", "logs") {
+		t.Errorf("interactive loggerConfig() = %+v, %v, %v; want a file in ~/.octrafic/logs", cfg, enabled, err)
+	}
+
+	debugFilePath = filepath.Join(t.TempDir(), "debug.log")
+	if cfg, _, _ := loggerConfig(true); cfg.Path != debugFilePath {
+		t.Errorf("interactive loggerConfig() with --debug-file = %q, want %q", cfg.Path, debugFilePath)
+	}
+}
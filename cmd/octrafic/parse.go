@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	parseSpecFile string
+	parseJSON     bool
+)
+
+var parseCmd = &cobra.Command{
+	Use:   "parse",
+	Short: "Preview the endpoints extracted from a specification",
+	Long:  `Parse a specification and print the extracted endpoints without creating a project or calling the LLM.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := storage.ValidateSpecPath(parseSpecFile); err != nil {
+			return err
+		}
+
+		spec, err := parser.ParseSpecification(parseSpecFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse specification: %w", err)
+		}
+
+		if parseJSON {
+			data, err := json.MarshalIndent(spec.Endpoints, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal endpoints: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(spec.Endpoints) == 0 {
+			fmt.Printf("No endpoints found in %s (detected format: %s).\n", parseSpecFile, spec.Format)
+			fmt.Println("The file may not be an API specification or its paths may be empty. Starting a project with it offers to extract endpoints with the LLM instead.")
+			return nil
+		}
+
+		printEndpointTable(spec.Endpoints)
+		fmt.Printf("\n%s\n", parser.ComputeStats(spec.Endpoints).Summary())
+		return nil
+	},
+}
+
+// printEndpointTable prints endpoints as an aligned table
+func printEndpointTable(endpoints []parser.Endpoint) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "METHOD\tPATH\tAUTH\tPARAMS\tDESCRIPTION")
+	for _, ep := range endpoints {
+		authLabel := "-"
+		if ep.RequiresAuth {
+			authLabel = ep.AuthType
+			if authLabel == "" {
+				authLabel = "yes"
+			}
+		}
+		path := ep.Path
+		if ep.Deprecated {
+			path += " [deprecated]"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", ep.Method, path, authLabel, len(ep.Parameters), ep.Description)
+	}
+	_ = w.Flush()
+}
+
+func init() {
+	parseCmd.Flags().StringVarP(&parseSpecFile, "spec", "s", "", "Path to the API specification file")
+	parseCmd.Flags().BoolVar(&parseJSON, "json", false, "Print endpoints as JSON")
+	_ = parseCmd.MarkFlagRequired("spec")
+	rootCmd.AddCommand(parseCmd)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	internalConfig "github.com/Octrafic/octrafic-cli/internal/config"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileFlag           string
+	profileSkipOnboarding bool
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named config profiles (provider, model and default auth)",
+	Long: `Profiles keep separate LLM provider settings and default authentication under ~/.octrafic/profiles/<name>/.
+Select one for a single run with --profile <name>, or make it the default with 'octrafic profile use <name>'.`,
+}
+
+var profileCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a profile and configure its provider",
+	Long:  `Create a profile, run the provider setup for it and optionally store default authentication (--auth, --token, ...).`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if err := internalConfig.CreateProfile(name); err != nil {
+			return err
+		}
+		internalConfig.SetProfile(name)
+
+		if authType != "" && authType != "none" {
+			if err := buildAuthFromFlags().Validate(); err != nil {
+				return fmt.Errorf("invalid authentication configuration: %w", err)
+			}
+			if err := storage.SaveProfileAuth(name, createAuthConfig()); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Default authentication (%s) saved to profile\n", authType)
+		}
+
+		if !profileSkipOnboarding && !runOnboarding() {
+			fmt.Printf("Provider setup skipped; run 'octrafic --profile %s --onboarding' to configure it\n", name)
+		}
+
+		fmt.Printf("✓ Profile '%s' created\n", name)
+		fmt.Printf("  Use it once:       octrafic --profile %s\n", name)
+		fmt.Printf("  Make it default:   octrafic profile use %s\n", name)
+		return nil
+	},
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names, err := internalConfig.ListProfiles()
+		if err != nil {
+			return err
+		}
+
+		active := internalConfig.ActiveProfile()
+		for _, name := range append([]string{internalConfig.DefaultProfile}, names...) {
+			marker := "  "
+			if name == active || (active == "" && name == internalConfig.DefaultProfile) {
+				marker = "* "
+			}
+			fmt.Printf("%s%s%s\n", marker, name, profileSummary(name))
+		}
+		return nil
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Make a profile the default for future runs ('default' for the top-level config)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := internalConfig.UseProfile(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Now using profile '%s'\n", args[0])
+		return nil
+	},
+}
+
+// profileSummary describes a profile's provider and model for listings
+func profileSummary(name string) string {
+	previous := internalConfig.ActiveProfile()
+	internalConfig.SetProfile(name)
+	defer internalConfig.SetProfile(previous)
+
+	cfg, err := internalConfig.Load()
+	if err != nil || !cfg.Onboarded {
+		return "  (not configured)"
+	}
+	parts := []string{cfg.Provider}
+	if cfg.Model != "" {
+		parts = append(parts, cfg.Model)
+	}
+	if authConfig, err := storage.LoadProfileAuth(); err == nil && authConfig != nil {
+		parts = append(parts, "auth: "+authConfig.Type)
+	}
+	return "  (" + strings.Join(parts, ", ") + ")"
+}
+
+// applyProfileFlag selects the --profile given on the command line. It runs before cobra parses
+// flags because onboarding and config loading happen first.
+func applyProfileFlag(args []string) {
+	for i, arg := range args {
+		if arg == "--" {
+			return
+		}
+		if name, ok := strings.CutPrefix(arg, "--profile="); ok {
+			internalConfig.SetProfile(name)
+			return
+		}
+		if arg == "--profile" && i+1 < len(args) {
+			internalConfig.SetProfile(args[i+1])
+			return
+		}
+	}
+}
+
+// requireActiveProfile exits when the selected profile hasn't been created. Profile management
+// commands are exempt so a missing profile can still be created or switched away from.
+func requireActiveProfile(cmd *cobra.Command) {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == profileCmd {
+			return
+		}
+	}
+	if profile := internalConfig.ActiveProfile(); profile != "" && !internalConfig.ProfileExists(profile) {
+		fmt.Fprintf(os.Stderr, "Profile '%s' does not exist. Create it with: octrafic profile create %s\n", profile, profile)
+		os.Exit(exitConfig)
+	}
+}
+
+func init() {
+	profileCreateCmd.Flags().StringVar(&authType, "auth", "none", "Default authentication type (none|bearer|apikey|basic|custom|oauth2|awssigv4|digest)")
+	profileCreateCmd.Flags().StringVar(&authToken, "token", "", "Bearer token")
+	profileCreateCmd.Flags().StringVar(&authTokenCommand, "token-command", "", "Shell command printing a new bearer token, run when the API rejects the current one with 401")
+	profileCreateCmd.Flags().StringVar(&authKey, "key", "", "API key name (e.g., X-API-Key)")
+	profileCreateCmd.Flags().StringArrayVar(&authValues, "value", nil, "API key value (repeat to rotate several keys)")
+	profileCreateCmd.Flags().StringVar(&authUser, "user", "", "Username for basic or digest auth")
+	profileCreateCmd.Flags().StringVar(&authPass, "pass", "", "Password for basic or digest auth")
+	profileCreateCmd.Flags().StringVar(&authHeader, "header", "", "Header name for custom auth (e.g., Authorization)")
+	profileCreateCmd.Flags().StringVar(&authTemplate, "template", "{value}", "Header value template for custom auth (e.g., \"Token {value}\")")
+	profileCreateCmd.Flags().StringVar(&authLocation, "key-location", "header", "Where to send the API key (header|query)")
+	profileCreateCmd.Flags().StringVar(&authTokenURL, "token-url", "", "OAuth2 token endpoint for the client-credentials grant")
+	profileCreateCmd.Flags().StringVar(&authClientID, "client-id", "", "OAuth2 client ID")
+	profileCreateCmd.Flags().StringVar(&authClientSecret, "client-secret", "", "OAuth2 client secret")
+	profileCreateCmd.Flags().StringVar(&authScopes, "scopes", "", "OAuth2 scopes to request (space- or comma-separated)")
+	profileCreateCmd.Flags().StringVar(&authAccessKey, "access-key", "", "AWS access key ID for SigV4 signing")
+	profileCreateCmd.Flags().StringVar(&authSecretKey, "secret-key", "", "AWS secret access key for SigV4 signing")
+	profileCreateCmd.Flags().StringVar(&authRegion, "region", "", "AWS region for SigV4 signing (e.g., us-east-1)")
+	profileCreateCmd.Flags().StringVar(&authService, "service", defaultAWSService, "AWS service name for SigV4 signing")
+	profileCreateCmd.Flags().BoolVar(&profileSkipOnboarding, "skip-onboarding", false, "Don't run the provider setup (configure later with --onboarding)")
+
+	profileCmd.AddCommand(profileCreateCmd, profileListCmd, profileUseCmd)
+	rootCmd.AddCommand(profileCmd)
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Config profile to use (see 'octrafic profile list')")
+}
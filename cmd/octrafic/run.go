@@ -0,0 +1,346 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	agent "github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+	"github.com/Octrafic/octrafic-cli/internal/core/reporter"
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runProjectName string
+	runURL         string
+	runSpec        string
+	runPlanFile    string
+	runJUnitFile   string
+	runQuiet       bool
+	runJSON        bool
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run [prompt]",
+	Short: "Run tests without the interactive UI, for scripts and CI",
+	Long: `Run a saved test plan (--plan) or tests generated from a prompt against the API, print a summary and exit.
+Progress goes to stderr, so stdout only carries --json output.
+Exits with 1 when a test fails, 2 on a configuration error, 3 when the API can't be reached
+and 4 when the LLM provider fails.`,
+	Example: `  octrafic run -n my-api --plan tests.json
+  octrafic run -u https://api.example.com -s openapi.yaml "test the users endpoints" --junit results.xml
+  octrafic run -n my-api --plan tests.json --quiet --json > results.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prompt := strings.TrimSpace(strings.Join(args, " "))
+		if (prompt == "") == (runPlanFile == "") {
+			return fmt.Errorf("give either a prompt or --plan")
+		}
+		if runProjectName == "" && runURL == "" {
+			return fmt.Errorf("--name or --url is required")
+		}
+		cmd.SilenceUsage = true
+
+		project := &storage.Project{BaseURL: runURL, SpecPath: runSpec}
+		if runProjectName != "" {
+			saved, err := storage.FindProjectByName(runProjectName)
+			if err != nil {
+				return err
+			}
+			project = saved
+			if runURL != "" {
+				project.BaseURL = runURL
+			}
+			if runSpec != "" {
+				project.SpecPath = runSpec
+			}
+		}
+
+		tests, err := loadRunTests(project, prompt)
+		if err != nil {
+			return err
+		}
+		if len(tests) == 0 {
+			return fmt.Errorf("no tests to run")
+		}
+
+		authProvider := resolveAuth(project)
+		if err := authProvider.Validate(); err != nil {
+			return fmt.Errorf("invalid authentication configuration: %w", err)
+		}
+		baseURL := resolveBaseURL(project, nil)
+		executor := tester.NewExecutor(baseURL, authProvider)
+		executor.SetSpecPath(project.SpecPath)
+		if timeout := resolveRequestTimeout(); timeout > 0 {
+			executor.SetTimeout(timeout)
+		}
+		executor.SetRetryPolicy(retryPolicy())
+		if err := executor.SetProxy(proxyURL); err != nil {
+			return withExitCode(exitConfig, err)
+		}
+		if err := executor.SetTLS(tlsOptions()); err != nil {
+			return withExitCode(exitConfig, err)
+		}
+		if keepCookies {
+			executor.EnableCookies()
+		}
+
+		// Progress goes to stderr so stdout stays clean for --json; --quiet drops it
+		var progress io.Writer = cmd.ErrOrStderr()
+		if runQuiet {
+			progress = nil
+		}
+		results := runPlan(executor, tests, progress)
+
+		if runJSON {
+			if err := writeRunJSON(cmd.OutOrStdout(), results); err != nil {
+				return err
+			}
+		}
+
+		if runJUnitFile != "" {
+			data, err := reporter.JUnitXML(results)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(runJUnitFile, data, 0o644); err != nil {
+				return fmt.Errorf("failed to write JUnit report: %w", err)
+			}
+			if progress != nil {
+				_, _ = fmt.Fprintf(progress, "JUnit report: %s\n", runJUnitFile)
+			}
+		}
+
+		if unreachable(results) {
+			return withExitCode(exitNetwork, fmt.Errorf("couldn't reach %s", baseURL))
+		}
+		if failed := countFailed(results); failed > 0 {
+			return withExitCode(exitTestsFailed, fmt.Errorf("%d of %d tests failed", failed, len(results)))
+		}
+		return nil
+	},
+}
+
+// loadRunTests reads the --plan file, or asks the agent for tests matching prompt
+func loadRunTests(project *storage.Project, prompt string) ([]agent.TestCase, error) {
+	if runPlanFile != "" {
+		// LoadTestPlan treats a missing file as an empty plan, which is a mistake here
+		if _, err := os.Stat(runPlanFile); err != nil {
+			return nil, fmt.Errorf("failed to read test plan: %w", err)
+		}
+		plan, err := agent.LoadTestPlan(runPlanFile)
+		if err != nil {
+			return nil, err
+		}
+		return plan.Tests, nil
+	}
+
+	var endpoints []parser.Endpoint
+	if project.ID != "" {
+		endpoints, _ = storage.LoadEndpoints(project.ID, project.IsTemporary)
+	}
+	if len(endpoints) == 0 {
+		if project.SpecPath == "" {
+			return nil, fmt.Errorf("--spec is required to generate tests from a prompt")
+		}
+		spec, err := parser.ParseSpecification(project.SpecPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load endpoints: %w", err)
+		}
+		endpoints = spec.Endpoints
+	}
+	details, err := json.Marshal(endpoints)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal endpoints: %w", err)
+	}
+
+	a, err := agent.NewAgent(project.BaseURL, project.ModelOverride())
+	if err != nil {
+		return nil, withExitCode(exitLLM, fmt.Errorf("failed to initialize agent: %w", err))
+	}
+	defer func() { _ = a.Close() }()
+
+	generated, _, err := a.GenerateTestPlan("Endpoints:\n"+string(details), prompt)
+	if err != nil {
+		return nil, withExitCode(exitLLM, err)
+	}
+	tests := make([]agent.TestCase, len(generated))
+	for i, t := range generated {
+		tests[i] = t.TestCase
+	}
+	return tests, nil
+}
+
+// runPlan sends the tests in order, printing a line per test and a summary to out unless it is nil.
+// Like the interactive session it honors requires_auth, skip_unless, capture and download.
+func runPlan(executor *tester.Executor, tests []agent.TestCase, out io.Writer) []reporter.TestResult {
+	variables := tester.Variables{}
+	results := make([]reporter.TestResult, 0, len(tests))
+	progress := tester.NewProgress(out, len(tests))
+
+	for _, tc := range tests {
+		r := reporter.TestResult{Name: tc.Description, Method: tc.Method, Endpoint: tc.Endpoint, ExpectedStatus: tc.ExpectedStatus}
+		if r.Name == "" {
+			r.Name = tc.Name
+		}
+
+		if tc.SkipUnless != "" {
+			if met, err := variables.EvaluateCondition(tc.SkipUnless); !met {
+				r.Skipped = "precondition not met: " + tc.SkipUnless
+				if err != nil {
+					r.Skipped = "precondition not met: " + err.Error()
+				}
+			}
+		}
+		endpoint, missing := tester.FillPath(tc.Endpoint, nil)
+		if r.Skipped == "" && len(missing) > 0 {
+			r.Skipped = "path parameters not provided: " + strings.Join(missing, ", ")
+		}
+		if r.Skipped != "" {
+			progress.Skipped(tc.Method, tc.Endpoint, r.Skipped)
+			results = append(results, r)
+			continue
+		}
+
+		req := tester.Request{Method: tc.Method, Endpoint: endpoint, Headers: tc.Headers, Body: tc.RequestBody(), Expect: tc.Expect, Download: tc.Download}
+		if !tc.RequiresAuth {
+			req.Auth = &auth.NoAuth{}
+		}
+		result, err := executor.ExecuteRequest(req)
+		if tc.Name != "" {
+			variables.Capture(tc.Name, result, err)
+		}
+
+		if result != nil {
+			r.StatusCode = result.StatusCode
+			r.Duration = result.Duration
+			for _, f := range result.FailedAssertions {
+				r.Failures = append(r.Failures, f.Message)
+			}
+			if result.Download != nil {
+				r.Failures = append(r.Failures, result.Download.Problems...)
+			}
+		}
+		if err != nil {
+			r.Error = err.Error()
+		}
+		results = append(results, r)
+
+		failures := r.Failures
+		if status := r.StatusFailure(); status != "" {
+			failures = append([]string{status}, failures...)
+		}
+		progress.Checked(tc.Method, tc.Endpoint, result, err, !r.Failed(), failures)
+
+		if err == nil && len(tc.Capture) > 0 {
+			if _, err := executor.Capture(result, tc.Capture); err != nil && out != nil {
+				_, _ = fmt.Fprintf(out, "  ⚠ capture failed: %s\n", err)
+			}
+		}
+	}
+
+	progress.Summary()
+	return results
+}
+
+// runTestJSON is one test in the --json output
+type runTestJSON struct {
+	Name           string   `json:"name,omitempty"`
+	Method         string   `json:"method"`
+	Endpoint       string   `json:"endpoint"`
+	Outcome        string   `json:"outcome"` // passed, failed or skipped
+	StatusCode     int      `json:"status_code,omitempty"`
+	ExpectedStatus int      `json:"expected_status,omitempty"`
+	DurationMS     int64    `json:"duration_ms"`
+	Error          string   `json:"error,omitempty"`
+	Failures       []string `json:"failures,omitempty"`
+	SkipReason     string   `json:"skip_reason,omitempty"`
+}
+
+// writeRunJSON writes the results of a run as a JSON document with per-outcome counts
+func writeRunJSON(w io.Writer, results []reporter.TestResult) error {
+	report := struct {
+		Passed  int           `json:"passed"`
+		Failed  int           `json:"failed"`
+		Skipped int           `json:"skipped"`
+		Tests   []runTestJSON `json:"tests"`
+	}{Tests: make([]runTestJSON, 0, len(results))}
+
+	for _, r := range results {
+		test := runTestJSON{
+			Name:           r.Name,
+			Method:         r.Method,
+			Endpoint:       r.Endpoint,
+			StatusCode:     r.StatusCode,
+			ExpectedStatus: r.ExpectedStatus,
+			DurationMS:     r.Duration.Milliseconds(),
+			Error:          r.Error,
+			Failures:       r.Failures,
+			SkipReason:     r.Skipped,
+		}
+		switch {
+		case r.Skipped != "":
+			test.Outcome = "skipped"
+			report.Skipped++
+		case r.Failed():
+			test.Outcome = "failed"
+			if status := r.StatusFailure(); status != "" {
+				test.Failures = append([]string{status}, test.Failures...)
+			}
+			report.Failed++
+		default:
+			test.Outcome = "passed"
+			report.Passed++
+		}
+		report.Tests = append(report.Tests, test)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// unreachable reports whether none of the tests that ran got a response, e.g. because the API is down
+func unreachable(results []reporter.TestResult) bool {
+	ran := 0
+	for _, r := range results {
+		if r.Skipped != "" {
+			continue
+		}
+		if r.Error == "" || r.StatusCode != 0 {
+			return false
+		}
+		ran++
+	}
+	return ran > 0
+}
+
+// countFailed counts the tests that ran and failed
+func countFailed(results []reporter.TestResult) int {
+	failed := 0
+	for _, r := range results {
+		if r.Failed() {
+			failed++
+		}
+	}
+	return failed
+}
+
+func init() {
+	runCmd.Flags().StringVarP(&runProjectName, "name", "n", "", "Saved project to test")
+	runCmd.Flags().StringVarP(&runURL, "url", "u", "", "Base URL of the API to test (overrides the project's)")
+	runCmd.Flags().StringVarP(&runSpec, "spec", "s", "", "Path to the API specification, used to generate tests from a prompt")
+	runCmd.Flags().StringVarP(&runPlanFile, "plan", "p", "", "Test plan file to run instead of generating tests")
+	runCmd.Flags().StringVar(&runJUnitFile, "junit", "", "Write the results as JUnit XML to this file")
+	runCmd.Flags().BoolVarP(&runQuiet, "quiet", "q", false, "Don't print progress; the exit code (and --json) report the outcome")
+	runCmd.Flags().BoolVar(&runJSON, "json", false, "Print the results as JSON to stdout")
+	runCmd.Flags().BoolVar(&basePathFromSpec, "base-path-from-spec", false, "Prefix requests with the base path of the spec's servers/basePath (e.g. /api/v1)")
+	addAuthFlags(runCmd)
+	addRequestFlags(runCmd)
+	rootCmd.AddCommand(runCmd)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	agent "github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
+)
+
+func TestRunCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(authTypeEnvVar, "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/items" && r.Method == http.MethodPost:
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":42,"name":"widget"}`))
+		case r.URL.Path == "/items/42":
+			_, _ = w.Write([]byte(`{"id":42,"name":"widget"}`))
+		case r.URL.Path == "/health":
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	plan := agent.TestPlan{Tests: []agent.TestCase{
+		{Description: "Health check", Method: "GET", Endpoint: "/health", ExpectedStatus: 200,
+			Expect: []tester.Assertion{{Path: "status", Equals: "ok"}}},
+		{Name: "create", Method: "POST", Endpoint: "/items", ExpectedStatus: 201, RequiresAuth: true,
+			Body: map[string]any{"name": "widget"}, Capture: map[string]string{"item_id": "$.id"}},
+		{Method: "GET", Endpoint: "/items/{{item_id}}", ExpectedStatus: 200,
+			SkipUnless: "{{create.status_code}} == 201", Expect: []tester.Assertion{{Path: "name", Equals: "gadget"}}},
+		{Method: "GET", Endpoint: "/users/{user_id}", ExpectedStatus: 200},
+		{Method: "GET", Endpoint: "/missing", ExpectedStatus: 200},
+	}}
+	planPath := filepath.Join(dir, "plan.json")
+	data, _ := json.Marshal(plan)
+	if err := os.WriteFile(planPath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	junitPath := filepath.Join(dir, "results.xml")
+
+	var out, errOut bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&errOut)
+	rootCmd.SetArgs([]string{"run", "--url", server.URL, "--plan", planPath, "--auth", "bearer", "--token", "secret", "--junit", junitPath})
+	err := rootCmd.Execute()
+
+	if err == nil || err.Error() != "2 of 5 tests failed" || exitCode(err) != exitTestsFailed {
+		t.Fatalf("Execute() error = %v, want 2 of 5 tests failed with exit code %d\n%s", err, exitTestsFailed, errOut.String())
+	}
+	// Progress goes to stderr, leaving stdout for --json
+	if out.Len() != 0 {
+		t.Errorf("stdout without --json = %q, want empty", out.String())
+	}
+	output := errOut.String()
+	for _, want := range []string{
+		"[1/5] GET /health → 200 (",
+		"[2/5] POST /items → 201 (",
+		"[3/5] GET /items/{{item_id}} → 200 (",
+		`FAIL: name: expected "gadget"`,
+		"[4/5] GET /users/{user_id} → skipped (path parameters not provided: user_id)",
+		"[5/5] GET /missing → 404 (",
+		"FAIL: status 404, expected 200",
+		"5 tests: 2 passed, 2 failed, 1 skipped in ",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output is missing %q:\n%s", want, output)
+		}
+	}
+
+	report, err := os.ReadFile(junitPath)
+	if err != nil {
+		t.Fatalf("JUnit report not written: %v", err)
+	}
+	if !strings.Contains(string(report), `tests="5" failures="2" errors="0" skipped="1"`) {
+		t.Errorf("unexpected JUnit report:\n%s", report)
+	}
+
+	// --quiet --json prints only the JSON results
+	out.Reset()
+	errOut.Reset()
+	rootCmd.SetArgs([]string{"run", "--url", server.URL, "--plan", planPath, "--auth", "bearer", "--token", "secret", "--junit", "", "--quiet", "--json"})
+	_ = rootCmd.Execute()
+	t.Cleanup(func() { runQuiet, runJSON = false, false })
+	if strings.Contains(errOut.String(), "[1/5]") {
+		t.Errorf("--quiet still printed progress:\n%s", errOut.String())
+	}
+	var results struct {
+		Passed, Failed, Skipped int
+		Tests                   []struct {
+			Endpoint string
+			Outcome  string
+			Failures []string
+		}
+	}
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("--json output is not JSON: %v\n%s", err, out.String())
+	}
+	if results.Passed != 2 || results.Failed != 2 || results.Skipped != 1 || len(results.Tests) != 5 {
+		t.Errorf("--json counts = %+v", results)
+	}
+	if last := results.Tests[len(results.Tests)-1]; last.Outcome != "failed" || len(last.Failures) == 0 || last.Failures[0] != "status 404, expected 200" {
+		t.Errorf("--json last test = %+v, want a failed status", last)
+	}
+
+	// With the API down every request fails to connect, which is reported apart from failed tests
+	server.Close()
+	rootCmd.SetArgs([]string{"run", "--url", server.URL, "--plan", planPath, "--junit", ""})
+	if err := rootCmd.Execute(); exitCode(err) != exitNetwork {
+		t.Errorf("Execute() against a stopped server: error = %v, exit code %d, want %d", err, exitCode(err), exitNetwork)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if got := exitCode(withExitCode(exitLLM, errors.New("provider down"))); got != exitLLM {
+		t.Errorf("exitCode() = %d, want %d", got, exitLLM)
+	}
+	if got := exitCode(fmt.Errorf("wrapped: %w", withExitCode(exitNetwork, errors.New("refused")))); got != exitNetwork {
+		t.Errorf("exitCode() of a wrapped error = %d, want %d", got, exitNetwork)
+	}
+	if got := exitCode(errors.New("unknown flag: --nope")); got != exitConfig {
+		t.Errorf("exitCode() of an untagged error = %d, want %d", got, exitConfig)
+	}
+}
+
+func TestCreateProjectLLMFailureExitCode(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("OCTRAFIC_PROVIDER", "unavailable")
+	specPath := filepath.Join(t.TempDir(), "api.raml")
+	if err := os.WriteFile(specPath, []byte("#%RAML 1.0\ntitle: API\n/users:\n  get:\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := createProject(generateUUID(), "raml", "http://localhost", specPath, false)
+	if err == nil || exitCode(err) != exitLLM {
+		t.Errorf("createProject() error = %v, exit code %d, want %d", err, exitCode(err), exitLLM)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Octrafic/octrafic-cli/internal/updater"
+	"github.com/spf13/cobra"
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update octrafic to the latest release",
+	Long: `Download the latest release for this OS and architecture from GitHub, verify it against the
+release checksums and replace the running binary. Development builds can't update themselves.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Printf("Checking for updates (current version %s)...\n", version)
+		info, err := updater.SelfUpdate(version)
+		if errors.Is(err, updater.ErrDevBuild) {
+			return withExitCode(exitConfig, err)
+		}
+		if err != nil {
+			return withExitCode(exitNetwork, err)
+		}
+		if !info.IsNewer {
+			fmt.Printf("✓ Already up to date (%s)\n", info.CurrentVersion)
+			return nil
+		}
+
+		fmt.Printf("✓ Updated octrafic %s → %s\n", info.CurrentVersion, info.LatestVersion)
+		if info.HTMLURL != "" {
+			fmt.Printf("  Release notes: %s\n", info.HTMLURL)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(updateCmd)
+}
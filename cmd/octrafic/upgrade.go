@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/converter"
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	upgradeSpecFile string
+	upgradeNoCache  bool
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade a Swagger 2.0 specification to OpenAPI 3.0",
+	Long:  `Convert a Swagger 2.0 specification to OpenAPI 3.0 using the configured LLM. The result is written next to the source file as <name>.openapi.json.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := storage.ValidateSpecPath(upgradeSpecFile); err != nil {
+			return err
+		}
+
+		spec, err := parser.ParseSpecification(upgradeSpecFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse specification: %w", err)
+		}
+		if !spec.IsSwagger2() {
+			return fmt.Errorf("%s is not a Swagger 2.0 specification", upgradeSpecFile)
+		}
+
+		fmt.Printf("Upgrading Swagger %s to OpenAPI 3.0...\n", spec.Version)
+		outputPath, err := converter.UpgradeSwagger2(upgradeSpecFile, !upgradeNoCache)
+		if err != nil {
+			return err
+		}
+
+		upgraded, err := parser.ParseSpecification(outputPath)
+		if err != nil {
+			return fmt.Errorf("upgraded specification is invalid: %w", err)
+		}
+
+		fmt.Printf("Upgraded specification saved to: %s\n", outputPath)
+		fmt.Printf("📊 %s\n", parser.ComputeStats(upgraded.Endpoints).Summary())
+		return nil
+	},
+}
+
+func init() {
+	upgradeCmd.Flags().StringVarP(&upgradeSpecFile, "spec", "s", "", "Path to the Swagger 2.0 specification file")
+	upgradeCmd.Flags().BoolVar(&upgradeNoCache, "no-cache", false, "Upgrade with the LLM again even if the file is unchanged")
+	_ = upgradeCmd.MarkFlagRequired("spec")
+	rootCmd.AddCommand(upgradeCmd)
+}
@@ -1,13 +1,16 @@
 package agent
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"github.com/Octrafic/octrafic-cli/internal/config"
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
 	"github.com/Octrafic/octrafic-cli/internal/infra/logger"
 	"github.com/Octrafic/octrafic-cli/internal/llm"
 	"github.com/Octrafic/octrafic-cli/internal/llm/common"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -20,6 +23,7 @@ const (
 type Agent struct {
 	baseAgent *BaseAgent
 	baseURL   string
+	model     string // For attributing usage in cost summaries
 }
 
 type TestStatus string
@@ -67,31 +71,128 @@ type ProcessSpecResult struct {
 	Messages  []ChatMessage
 }
 
-func NewAgent(baseURL string) (*Agent, error) {
-	// Try loading config from file first (onboarding users)
-	cfg, err := config.Load()
-	if err == nil && cfg.Onboarded && (cfg.APIKey != "" || config.IsLocalProvider(cfg.Provider)) {
-		// Use config from file
+// ModelOverride replaces parts of the global LLM settings, e.g. for a single project.
+// Empty fields keep the global value.
+type ModelOverride struct {
+	Provider string
+	Model    string
+	BaseURL  string
+}
+
+// IsZero reports whether the override changes nothing
+func (o ModelOverride) IsZero() bool {
+	return o == ModelOverride{}
+}
+
+// Apply returns providerConfig with the override's fields. Switching to another provider drops the
+// global API key, base URL and Azure settings, which belong to the old provider; the key then comes
+// from the new provider's usual environment variable.
+func (o ModelOverride) Apply(providerConfig common.ProviderConfig) common.ProviderConfig {
+	if o.Provider != "" && o.Provider != providerConfig.Provider {
+		providerConfig.Provider = o.Provider
+		providerConfig.APIKey = legacyAPIKey(o.Provider)
+		providerConfig.BaseURL = ""
+		providerConfig.Deployment = ""
+		providerConfig.APIVersion = ""
+	}
+	if o.Model != "" {
+		providerConfig.Model = o.Model
+	}
+	if o.BaseURL != "" {
+		providerConfig.BaseURL = o.BaseURL
+	}
+	return providerConfig
+}
+
+// NewAgent creates the agent for chatting about the API at baseURL, using the global LLM settings
+// with override applied
+func NewAgent(baseURL string, override ModelOverride) (*Agent, error) {
+	providerConfig, fromOnboarding := ResolveProviderConfig()
+	if fromOnboarding {
 		logger.Info("Using LLM config from onboarding",
-			logger.String("provider", cfg.Provider),
-			logger.String("model", cfg.Model))
-
-		providerConfig := common.ProviderConfig{
-			Provider: cfg.Provider,
-			APIKey:   cfg.APIKey,
-			BaseURL:  cfg.BaseURL,
-			Model:    cfg.Model,
-		}
+			logger.String("provider", providerConfig.Provider),
+			logger.String("model", providerConfig.Model))
+	}
+	if !override.IsZero() {
+		providerConfig = override.Apply(providerConfig)
+		logger.Info("Using project LLM override",
+			logger.String("provider", providerConfig.Provider),
+			logger.String("model", providerConfig.Model))
+	}
 
-		llmProvider, err := llm.CreateProvider(providerConfig)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create provider: %w", err)
-		}
+	llmProvider, err := llm.CreateProvider(providerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create provider: %w", err)
+	}
 
-		return &Agent{
-			baseAgent: NewBaseAgent(llmProvider),
-			baseURL:   baseURL,
-		}, nil
+	if !fromOnboarding {
+		logger.Info("Using LLM provider", logger.String("provider", providerConfig.Provider))
+	}
+	baseAgent := NewBaseAgent(llmProvider)
+	baseAgent.streamingDisabled = providerConfig.DisableStreaming
+	return &Agent{
+		baseAgent: baseAgent,
+		baseURL:   baseURL,
+		model:     providerConfig.Model,
+	}, nil
+}
+
+// recordUsage adds a response's tokens to the session usage reported in cost summaries
+func (a *Agent) recordUsage(operation string, response *ChatResponse) {
+	common.RecordUsage(operation, a.model, response.InputTokens, response.OutputTokens)
+}
+
+// NewSpecAgent creates an agent for spec processing, which runs on the conversion model when one
+// is configured so extraction can use a cheaper model than interactive testing
+func NewSpecAgent(baseURL string) (*Agent, error) {
+	providerConfig, _ := ResolveProviderConfig()
+	if model := ResolveConversionModel(); model != "" {
+		providerConfig.Model = model
+		logger.Info("Using conversion model for spec processing", logger.String("model", model))
+	}
+
+	llmProvider, err := llm.CreateProvider(providerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create provider: %w", err)
+	}
+	baseAgent := NewBaseAgent(llmProvider)
+	baseAgent.streamingDisabled = providerConfig.DisableStreaming
+	return &Agent{
+		baseAgent: baseAgent,
+		baseURL:   baseURL,
+		model:     providerConfig.Model,
+	}, nil
+}
+
+// ResolveConversionModel returns the model for spec processing: OCTRAFIC_CONVERSION_MODEL, then
+// conversion_model from the config file, then the older SEARCH_SPEC_MODEL. Empty means the main model.
+func ResolveConversionModel() string {
+	if model := config.GetEnv("CONVERSION_MODEL"); model != "" {
+		return model
+	}
+	if cfg, err := config.Load(); err == nil && cfg.ConversionModel != "" {
+		return cfg.ConversionModel
+	}
+	return os.Getenv("SEARCH_SPEC_MODEL")
+}
+
+// ResolveProviderConfig returns the LLM provider settings from the onboarding config file,
+// falling back to OCTRAFIC_ environment variables. The bool reports whether the config file was used.
+func ResolveProviderConfig() (common.ProviderConfig, bool) {
+	// Try loading config from file first (onboarding users)
+	cfg, err := config.Load()
+	if err == nil && cfg.Onboarded && (cfg.APIKey != "" || config.IsLocalProvider(cfg.Provider)) {
+		return common.ProviderConfig{
+			Provider:         cfg.Provider,
+			APIKey:           cfg.APIKey,
+			BaseURL:          cfg.BaseURL,
+			Model:            cfg.Model,
+			DisableStreaming: cfg.DisableStreaming || streamingDisabledByEnv(),
+			Deployment:       cfg.Deployment,
+			APIVersion:       cfg.APIVersion,
+			MaxTokens:        cmp.Or(cfg.MaxTokens, maxTokensFromEnv()),
+			Temperature:      cmp.Or(cfg.Temperature, temperatureFromEnv()),
+		}, true
 	}
 
 	// Fallback to environment variables with OCTRAFIC_ prefix
@@ -103,31 +204,75 @@ func NewAgent(baseURL string) (*Agent, error) {
 	apiKey := config.GetEnv("API_KEY")
 	if apiKey == "" {
 		// Legacy fallback for backwards compatibility
-		if provider == "openai" || provider == "openrouter" {
-			apiKey = os.Getenv("OPENAI_API_KEY")
-		} else {
-			apiKey = os.Getenv("ANTHROPIC_API_KEY")
-		}
+		apiKey = legacyAPIKey(provider)
 	}
 
-	providerConfig := common.ProviderConfig{
-		Provider: provider,
-		APIKey:   apiKey,
-		BaseURL:  config.GetEnv("BASE_URL"),
-		Model:    config.GetEnv("MODEL"),
+	return common.ProviderConfig{
+		Provider:         provider,
+		APIKey:           apiKey,
+		BaseURL:          config.GetEnv("BASE_URL"),
+		Model:            config.GetEnv("MODEL"),
+		DisableStreaming: streamingDisabledByEnv(),
+		Deployment:       config.GetEnv("AZURE_DEPLOYMENT"),
+		APIVersion:       config.GetEnv("AZURE_API_VERSION"),
+		MaxTokens:        maxTokensFromEnv(),
+		Temperature:      temperatureFromEnv(),
+	}, false
+}
+
+// maxTokensFromEnv returns OCTRAFIC_MAX_TOKENS, or 0 if it isn't a positive number
+func maxTokensFromEnv() int {
+	maxTokens, err := strconv.Atoi(config.GetEnv("MAX_TOKENS"))
+	if err != nil || maxTokens < 0 {
+		return 0
 	}
+	return maxTokens
+}
 
-	// Create provider
-	llmProvider, err := llm.CreateProvider(providerConfig)
+// temperatureFromEnv returns OCTRAFIC_TEMPERATURE, or nil if it isn't set to a number
+func temperatureFromEnv() *float64 {
+	temperature, err := strconv.ParseFloat(config.GetEnv("TEMPERATURE"), 64)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create provider: %w", err)
+		return nil
 	}
+	return &temperature
+}
 
-	logger.Info("Using LLM provider", logger.String("provider", provider))
-	return &Agent{
-		baseAgent: NewBaseAgent(llmProvider),
-		baseURL:   baseURL,
-	}, nil
+// legacyAPIKey returns the API key from the provider's own environment variable, e.g. OPENAI_API_KEY
+func legacyAPIKey(provider string) string {
+	switch provider {
+	case "openai", "openrouter":
+		return os.Getenv("OPENAI_API_KEY")
+	case "gemini":
+		return os.Getenv("GEMINI_API_KEY")
+	case "azure":
+		return os.Getenv("AZURE_OPENAI_API_KEY")
+	case "ollama", "llamacpp":
+		return ""
+	default:
+		return os.Getenv("ANTHROPIC_API_KEY")
+	}
+}
+
+// streamingDisabledByEnv reports whether OCTRAFIC_DISABLE_STREAMING is set to a true value
+func streamingDisabledByEnv() bool {
+	disabled, _ := strconv.ParseBool(config.GetEnv("DISABLE_STREAMING"))
+	return disabled
+}
+
+// Close releases the underlying LLM provider, canceling any in-flight requests
+func (a *Agent) Close() error {
+	return a.baseAgent.Close()
+}
+
+// SetRetryNotifier sets the function told when the LLM provider retries a rate-limited or failed request
+func (a *Agent) SetRetryNotifier(notify common.RetryNotifier) {
+	a.baseAgent.SetRetryNotifier(notify)
+}
+
+// RateLimits returns the rate limit state reported by the LLM provider on its latest response
+func (a *Agent) RateLimits() *common.RateLimits {
+	return a.baseAgent.RateLimits()
 }
 
 func (a *Agent) GenerateTestPlan(what, focus string) ([]Test, int64, error) {
@@ -142,6 +287,7 @@ func (a *Agent) GenerateTestPlan(what, focus string) ([]Test, int64, error) {
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to generate test plan: %w", err)
 	}
+	a.recordUsage(common.OperationTestGeneration, response)
 
 	jsonResponse := extractJSONFromMarkdown(response.Message)
 
@@ -297,6 +443,7 @@ START by using SearchSpec tool to find all endpoints!`,
 	if err != nil {
 		return nil, fmt.Errorf("failed to process specification: %w", err)
 	}
+	a.recordUsage(common.OperationSpecProcessing, chatResponse)
 
 	if len(chatResponse.ToolCalls) > 0 {
 		var toolCalls []ToolCall
@@ -399,6 +546,7 @@ Requirements:
 	if err != nil {
 		return nil, fmt.Errorf("failed to process specification: %w", err)
 	}
+	a.recordUsage(common.OperationSpecProcessing, chatResponse)
 
 	var endpoints []APIEndpoint
 	lines := strings.Split(strings.TrimSpace(chatResponse.Message), "\n")
@@ -421,7 +569,7 @@ Requirements:
 	}
 
 	if len(endpoints) == 0 {
-		return nil, fmt.Errorf("no endpoints found in response")
+		return nil, fmt.Errorf("%w in response", parser.ErrNoEndpoints)
 	}
 
 	return endpoints, nil
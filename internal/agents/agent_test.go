@@ -0,0 +1,209 @@
+package agent
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/config"
+	"github.com/Octrafic/octrafic-cli/internal/llm/common"
+	"github.com/Octrafic/octrafic-cli/internal/llm/gemini"
+	"github.com/tidwall/gjson"
+)
+
+func TestResolveConversionModel(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("OCTRAFIC_PROFILE", "")
+	t.Setenv("OCTRAFIC_CONVERSION_MODEL", "")
+	t.Setenv("SEARCH_SPEC_MODEL", "")
+
+	if got := ResolveConversionModel(); got != "" {
+		t.Errorf("ResolveConversionModel() = %q, want empty without configuration", got)
+	}
+
+	t.Setenv("SEARCH_SPEC_MODEL", "gpt-4o-mini")
+	if got := ResolveConversionModel(); got != "gpt-4o-mini" {
+		t.Errorf("ResolveConversionModel() = %q, want SEARCH_SPEC_MODEL", got)
+	}
+
+	cfg := &config.Config{Provider: "claude", ConversionModel: "from-config"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got := ResolveConversionModel(); got != "from-config" {
+		t.Errorf("ResolveConversionModel() = %q, want conversion_model from config over SEARCH_SPEC_MODEL", got)
+	}
+
+	t.Setenv("OCTRAFIC_CONVERSION_MODEL", "claude-haiku")
+	if got := ResolveConversionModel(); got != "claude-haiku" {
+		t.Errorf("ResolveConversionModel() = %q, want OCTRAFIC_CONVERSION_MODEL over the config file", got)
+	}
+}
+
+func TestNewAgentUsesProjectOverride(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("OCTRAFIC_PROFILE", "")
+	t.Setenv("OCTRAFIC_MODEL", "")
+
+	cfg := &config.Config{Onboarded: true, Provider: "openai", APIKey: "sk-global", Model: "gpt-4o"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	a, err := NewAgent("https://api.example.com", ModelOverride{})
+	if err != nil {
+		t.Fatalf("NewAgent() error = %v", err)
+	}
+	if a.model != "gpt-4o" {
+		t.Errorf("model = %q, want the global gpt-4o without an override", a.model)
+	}
+
+	a, err = NewAgent("https://api.example.com", ModelOverride{Model: "gpt-4o-mini"})
+	if err != nil {
+		t.Fatalf("NewAgent() error = %v", err)
+	}
+	if a.model != "gpt-4o-mini" {
+		t.Errorf("model = %q, want the project's gpt-4o-mini", a.model)
+	}
+}
+
+func TestModelOverrideApply(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "sk-ant")
+	global := common.ProviderConfig{Provider: "azure", APIKey: "azure-key", BaseURL: "https://x.openai.azure.com", Model: "gpt-4o", Deployment: "prod", APIVersion: "2024-10-21"}
+
+	got := ModelOverride{Model: "gpt-4o-mini"}.Apply(global)
+	if got.Provider != "azure" || got.APIKey != "azure-key" || got.Deployment != "prod" || got.Model != "gpt-4o-mini" {
+		t.Errorf("model-only override = %+v, want the global provider settings kept", got)
+	}
+
+	got = ModelOverride{Provider: "claude", Model: "claude-sonnet-4-5"}.Apply(global)
+	want := common.ProviderConfig{Provider: "claude", APIKey: "sk-ant", Model: "claude-sonnet-4-5"}
+	if got != want {
+		t.Errorf("provider override = %+v, want %+v", got, want)
+	}
+
+	got = ModelOverride{Provider: "ollama", Model: "llama3", BaseURL: "http://gpu:11434"}.Apply(global)
+	if got.APIKey != "" || got.BaseURL != "http://gpu:11434" {
+		t.Errorf("ollama override = %+v, want no key and the project's base URL", got)
+	}
+}
+
+// fallbackProvider refuses to stream and answers Chat with a fixed response
+type fallbackProvider struct {
+	streamCalls, chatCalls int
+}
+
+func (p *fallbackProvider) Chat(messages []common.Message, tools []common.Tool, thinkingEnabled bool) (*common.ChatResponse, error) {
+	p.chatCalls++
+	return &common.ChatResponse{Message: "hello", Reasoning: "thinking"}, nil
+}
+
+func (p *fallbackProvider) ChatStream(messages []common.Message, tools []common.Tool, thinkingEnabled bool, callback common.StreamCallback) (*common.ChatResponse, error) {
+	p.streamCalls++
+	return nil, fmt.Errorf("%w: server answered with application/json", common.ErrStreamingUnsupported)
+}
+
+func (p *fallbackProvider) Close() error { return nil }
+
+func TestChatStreamFallsBackToChat(t *testing.T) {
+	provider := &fallbackProvider{}
+	agent := NewBaseAgent(provider)
+
+	for range 2 {
+		var text, thought string
+		resp, err := agent.ChatStream("system", nil, []ChatMessage{{Role: "user", Content: "hi"}}, false, func(chunk string, isThought bool) {
+			if isThought {
+				thought += chunk
+			} else {
+				text += chunk
+			}
+		})
+		if err != nil {
+			t.Fatalf("ChatStream() error = %v", err)
+		}
+		if resp.Message != "hello" || text != "hello" || thought != "thinking" {
+			t.Errorf("ChatStream() = %q, callback text %q thought %q", resp.Message, text, thought)
+		}
+	}
+	if provider.streamCalls != 1 || provider.chatCalls != 2 {
+		t.Errorf("streamCalls = %d, chatCalls = %d, want streaming tried once", provider.streamCalls, provider.chatCalls)
+	}
+}
+
+func TestChatStreamGeminiToolRoundTrip(t *testing.T) {
+	var requests []gjson.Result
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/models/gemini-2.5-flash:streamGenerateContent") || r.Header.Get("x-goog-api-key") != "key" {
+			t.Errorf("unexpected request %s with key %q", r.URL.Path, r.Header.Get("x-goog-api-key"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, gjson.ParseBytes(body))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		if len(requests) == 1 {
+			_, _ = fmt.Fprint(w, `data: {"candidates":[{"content":{"role":"model","parts":[{"text":"Checking the endpoints.","thought":true}]}}]}`+"\n\n")
+			_, _ = fmt.Fprint(w, `data: {"candidates":[{"content":{"role":"model","parts":[{"functionCall":{"name":"get_endpoints_details","args":{"endpoints":["GET /users"]}},"thoughtSignature":"c2ln"}]},"finishReason":"STOP"}],"usageMetadata":{"promptTokenCount":12,"candidatesTokenCount":5}}`+"\n\n")
+			return
+		}
+		_, _ = fmt.Fprint(w, `data: {"candidates":[{"content":{"role":"model","parts":[{"text":"GET /users lists "}]}}]}`+"\n\n")
+		_, _ = fmt.Fprint(w, `data: {"candidates":[{"content":{"role":"model","parts":[{"text":"all users."}]},"finishReason":"STOP"}]}`+"\n\n")
+	}))
+	defer server.Close()
+
+	provider, err := gemini.NewGeminiProvider(common.ProviderConfig{Provider: "gemini", APIKey: "key", Model: "gemini-2.5-flash", BaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	agent := NewBaseAgent(provider)
+	tools := getMainAgentTools()
+
+	history := []ChatMessage{{Role: "user", Content: "What does GET /users do?"}}
+	var thought string
+	resp, err := agent.ChatStream("system prompt", tools, history, true, func(chunk string, isThought bool) {
+		if isThought {
+			thought += chunk
+		}
+	})
+	if err != nil {
+		t.Fatalf("ChatStream() error = %v", err)
+	}
+	if len(resp.ToolCalls) != 1 || resp.ToolCalls[0].Name != "get_endpoints_details" || resp.ToolCalls[0].ID == "" {
+		t.Fatalf("ToolCalls = %+v, want one get_endpoints_details call with an ID", resp.ToolCalls)
+	}
+	if thought != "Checking the endpoints." || resp.InputTokens != 12 || resp.OutputTokens != 5 {
+		t.Errorf("thought = %q, tokens = %d/%d", thought, resp.InputTokens, resp.OutputTokens)
+	}
+
+	call := resp.ToolCalls[0]
+	history = append(history,
+		ChatMessage{Role: "assistant", FunctionCalls: resp.ToolCalls},
+		ChatMessage{Role: "user", FunctionResponse: &FunctionResponseData{ID: call.ID, Name: call.Name, Response: map[string]any{"endpoints": "GET /users: list users"}}},
+	)
+	resp, err = agent.ChatStream("system prompt", tools, history, true, func(string, bool) {})
+	if err != nil {
+		t.Fatalf("ChatStream() with the tool result error = %v", err)
+	}
+	if resp.Message != "GET /users lists all users." {
+		t.Errorf("Message = %q", resp.Message)
+	}
+
+	sent := requests[1]
+	if sent.Get("systemInstruction.parts.0.text").String() != "system prompt" {
+		t.Errorf("systemInstruction = %s", sent.Get("systemInstruction").Raw)
+	}
+	if !sent.Get("tools.0.functionDeclarations.0.parametersJsonSchema").Exists() {
+		t.Errorf("tools were not declared with their JSON schemas: %s", sent.Get("tools").Raw)
+	}
+	modelTurn, toolTurn := sent.Get("contents.1"), sent.Get("contents.2")
+	if modelTurn.Get("role").String() != "model" || modelTurn.Get("parts.0.functionCall.name").String() != "get_endpoints_details" ||
+		modelTurn.Get("parts.0.thoughtSignature").String() != "c2ln" || modelTurn.Get("parts.0.functionCall.id").Exists() {
+		t.Errorf("model turn = %s, want the call with its thought signature and no made-up id", modelTurn.Raw)
+	}
+	if toolTurn.Get("role").String() != "user" || toolTurn.Get("parts.0.functionResponse.name").String() != "get_endpoints_details" ||
+		toolTurn.Get("parts.0.functionResponse.response.endpoints").String() != "GET /users: list users" {
+		t.Errorf("function response turn = %s", toolTurn.Raw)
+	}
+}
@@ -2,12 +2,17 @@ package agent
 
 import (
 	"fmt"
+	"github.com/Octrafic/octrafic-cli/internal/infra/logger"
 	"github.com/Octrafic/octrafic-cli/internal/llm/common"
 )
 
 type BaseAgent struct {
 	provider common.Provider
 	messages []common.Message
+
+	// streamingDisabled makes ChatStream use the provider's non-streaming Chat, either because the
+	// config asks for it or because the provider turned out not to support streaming
+	streamingDisabled bool
 }
 
 func NewBaseAgent(provider common.Provider) *BaseAgent {
@@ -111,12 +116,7 @@ func (a *BaseAgent) ChatStream(systemPrompt string, tools []common.Tool, inputMe
 		messages = append(messages, commonMsg)
 	}
 
-	response, err := a.provider.ChatStream(messages, tools, thinkingEnabled, func(chunk string, isThought bool) {
-		if chunk != "" {
-			callback(chunk, isThought)
-		}
-	})
-
+	response, err := a.streamOrChat(messages, tools, thinkingEnabled, callback)
 	if err != nil {
 		return nil, fmt.Errorf("chat stream failed: %w", err)
 	}
@@ -147,3 +147,52 @@ func (a *BaseAgent) ChatStream(systemPrompt string, tools []common.Tool, inputMe
 
 	return chatResp, nil
 }
+
+// streamOrChat streams a response through callback. When streaming is disabled or the provider
+// can't stream, it waits for the full response and emits it through callback in one piece.
+func (a *BaseAgent) streamOrChat(messages []common.Message, tools []common.Tool, thinkingEnabled bool, callback ReasoningCallback) (*common.ChatResponse, error) {
+	if !a.streamingDisabled {
+		response, err := a.provider.ChatStream(messages, tools, thinkingEnabled, func(chunk string, isThought bool) {
+			if chunk != "" {
+				callback(chunk, isThought)
+			}
+		})
+		if err == nil || !common.IsStreamingUnsupported(err) {
+			return response, err
+		}
+		logger.Warn("Streaming unavailable, falling back to non-streaming responses", logger.Err(err))
+		a.streamingDisabled = true
+	}
+
+	response, err := a.provider.Chat(messages, tools, thinkingEnabled)
+	if err != nil {
+		return nil, err
+	}
+	if response.Reasoning != "" {
+		callback(response.Reasoning, true)
+	}
+	if response.Message != "" {
+		callback(response.Message, false)
+	}
+	return response, nil
+}
+
+// Close releases the provider and cancels any in-flight requests
+func (a *BaseAgent) Close() error {
+	return a.provider.Close()
+}
+
+// SetRetryNotifier tells the provider whom to notify when it retries a request, if it retries at all
+func (a *BaseAgent) SetRetryNotifier(notify common.RetryNotifier) {
+	if reporter, ok := a.provider.(common.RetryReporter); ok {
+		reporter.SetRetryNotifier(notify)
+	}
+}
+
+// RateLimits returns the provider's latest rate limit state, or nil if it doesn't report one
+func (a *BaseAgent) RateLimits() *common.RateLimits {
+	if reporter, ok := a.provider.(common.RateLimitReporter); ok {
+		return reporter.RateLimits()
+	}
+	return nil
+}
@@ -11,6 +11,8 @@ type ChatMessage struct {
 	ReasoningContent string                `json:"reasoning_content,omitempty"`
 	FunctionCalls    []ToolCall            `json:"function_calls,omitempty"`
 	FunctionResponse *FunctionResponseData `json:"function_response,omitempty"`
+	InputTokens      int64                 `json:"input_tokens,omitempty"`  // Input tokens of the turn that produced this message (assistant only)
+	OutputTokens     int64                 `json:"output_tokens,omitempty"` // Output tokens of that turn
 }
 
 type FunctionResponseData struct {
@@ -71,6 +73,51 @@ func getMainAgentTools() []common.Tool {
 				"required": []string{"endpoints"},
 			},
 		},
+		{
+			Name:        "sample_endpoint",
+			Description: "Fetch a trimmed sample of real data with a single GET request to a list or read endpoint. Use it before generating create/update tests to see the actual data shape and realistic values.",
+			InputSchema: map[string]any{
+				"type":                 "object",
+				"additionalProperties": false,
+				"properties": map[string]any{
+					"endpoint": map[string]any{
+						"type":        "string",
+						"description": "Path of a GET endpoint, optionally with query parameters (e.g., /users?limit=3)",
+					},
+				},
+				"required": []string{"endpoint"},
+			},
+		},
+		{
+			Name:        "compare_representations",
+			Description: "Request one endpoint once per Accept value and compare the representations returned (content types, bodies). Use it to test content negotiation.",
+			InputSchema: map[string]any{
+				"type":                 "object",
+				"additionalProperties": false,
+				"properties": map[string]any{
+					"method": map[string]any{
+						"type":        "string",
+						"enum":        []string{"GET", "HEAD"},
+						"description": "HTTP method; only read-only GET and HEAD are allowed",
+					},
+					"endpoint": map[string]any{
+						"type":        "string",
+						"description": "API endpoint path (e.g., /users/1)",
+					},
+					"accept": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Accept header values to compare (e.g., [\"application/json\", \"application/xml\"])",
+					},
+					"headers": map[string]any{
+						"type":                 []any{"object", "null"},
+						"additionalProperties": map[string]any{"type": "string"},
+						"description":          "Optional headers sent with every request, e.g. {\"Prefer\": \"return=representation\"}",
+					},
+				},
+				"required": []string{"method", "endpoint", "accept", "headers"},
+			},
+		},
 		{
 			Name:        "GenerateTestPlan",
 			Description: "Generate test cases for API endpoints. Describe endpoints with all relevant details from get_endpoints_details.",
@@ -113,28 +160,152 @@ func getMainAgentTools() []common.Tool {
 								},
 								"headers": map[string]any{
 									"type":                 []any{"object", "null"},
-									"additionalProperties": false,
-									"description":          "Optional HTTP headers",
+									"additionalProperties": map[string]any{"type": "string"},
+									"description":          "Optional HTTP headers, e.g. {\"Accept\": \"application/xml\", \"Prefer\": \"return=representation\"}",
 								},
 								"body": map[string]any{
 									"type":        []any{"string", "null"},
-									"description": "Optional request body (JSON string)",
+									"description": "Optional request body (JSON string). For XML APIs, the XML document as a string; it is sent as-is with Content-Type application/xml unless headers set another",
 								},
 								"requires_auth": map[string]any{
 									"type":        "boolean",
 									"description": "Whether authentication is required for this test",
 								},
+								"expect": map[string]any{
+									"type":        []any{"array", "null"},
+									"description": "Optional response body assertions evaluated locally (e.g., [{\"path\": \"$.status\", \"equals\": \"ok\"}])",
+									"items": map[string]any{
+										"type":                 "object",
+										"additionalProperties": false,
+										"properties": map[string]any{
+											"path": map[string]any{
+												"type":        "string",
+												"description": "JSONPath into the response body (e.g., $.data[0].id)",
+											},
+											"equals": map[string]any{
+												"type":        []any{"string", "number", "boolean", "null"},
+												"description": "Expected value at path",
+											},
+										},
+										"required": []string{"path", "equals"},
+									},
+								},
+								"name": map[string]any{
+									"type":        []any{"string", "null"},
+									"description": "Optional name so later tests can reference this result in skip_unless (e.g., login)",
+								},
+								"capture": map[string]any{
+									"type":                 []any{"object", "null"},
+									"additionalProperties": map[string]any{"type": "string"},
+									"description":          "Optional values to extract from the response as variables, name → JSONPath (e.g., {\"id\": \"$.id\"}); later tests reference them as {{id}} in endpoint, headers or body",
+								},
+								"skip_unless": map[string]any{
+									"type":        []any{"string", "null"},
+									"description": "Optional precondition on earlier named tests; the test is skipped when it fails (e.g., {{login.status_code}} == 200)",
+								},
+								"download": map[string]any{
+									"type":                 []any{"object", "null"},
+									"additionalProperties": false,
+									"description":          "Optional for endpoints returning files (exports, generated reports): saves a successful response to a file and verifies it instead of reading it as text",
+									"properties": map[string]any{
+										"save_to": map[string]any{
+											"type":        "string",
+											"description": "Relative path inside the download directory (--save-binary, else downloads/) to save to, or a sub directory ending in / to save under a generated name (e.g., report.pdf or exports/)",
+										},
+										"overwrite": map[string]any{
+											"type":        []any{"boolean", "null"},
+											"description": "Replace an existing file at save_to; without it the download fails instead",
+										},
+										"expect_content_type": map[string]any{
+											"type":        []any{"string", "null"},
+											"description": "Expected media type, e.g. application/pdf or image/*",
+										},
+										"expect_size": map[string]any{
+											"type":        []any{"integer", "null"},
+											"description": "Expected exact size in bytes",
+										},
+										"expect_checksum": map[string]any{
+											"type":        []any{"string", "null"},
+											"description": "Expected checksum as sha256:<hex>, sha1:<hex> or md5:<hex>",
+										},
+									},
+									"required": []string{"save_to", "overwrite", "expect_content_type", "expect_size", "expect_checksum"},
+								},
+								"multipart": map[string]any{
+									"type":                 []any{"object", "null"},
+									"additionalProperties": false,
+									"description":          "Optional multipart/form-data body for upload endpoints, sent instead of body",
+									"properties": map[string]any{
+										"fields": map[string]any{
+											"type":                 []any{"object", "null"},
+											"additionalProperties": map[string]any{"type": "string"},
+											"description":          "Text fields, name → value",
+										},
+										"files": map[string]any{
+											"type":        []any{"object", "null"},
+											"description": "File fields by name, e.g. {\"avatar\": {\"filename\": \"a.png\", \"content\": \"...\"}}",
+											"additionalProperties": map[string]any{
+												"type":                 "object",
+												"additionalProperties": false,
+												"properties": map[string]any{
+													"path": map[string]any{
+														"type":        []any{"string", "null"},
+														"description": "File to upload, relative to the working directory or the spec's directory",
+													},
+													"content": map[string]any{
+														"type":        []any{"string", "null"},
+														"description": "Inline file content, used instead of path",
+													},
+													"filename": map[string]any{
+														"type":        []any{"string", "null"},
+														"description": "File name sent to the server (defaults to the base name of path)",
+													},
+													"content_type": map[string]any{
+														"type":        []any{"string", "null"},
+														"description": "Media type of the file (guessed from the file name when null)",
+													},
+												},
+												"required": []string{"path", "content", "filename", "content_type"},
+											},
+										},
+									},
+									"required": []string{"fields", "files"},
+								},
 							},
-							"required": []string{"method", "endpoint", "headers", "body", "requires_auth"},
+							"required": []string{"method", "endpoint", "headers", "body", "requires_auth", "expect", "name", "skip_unless", "capture", "download", "multipart"},
 						},
 					},
 				},
 				"required": []string{"tests"},
 			},
 		},
+		{
+			Name:        "RecordFinding",
+			Description: "Record an issue found while testing (a bug, spec mismatch, security or performance problem) in the session's findings list. Findings are listed with /findings and included in reports automatically.",
+			InputSchema: map[string]any{
+				"type":                 "object",
+				"additionalProperties": false,
+				"properties": map[string]any{
+					"severity": map[string]any{
+						"type":        "string",
+						"enum":        []string{"critical", "high", "medium", "low", "info"},
+						"description": "How serious the issue is",
+					},
+					"endpoint": map[string]any{
+						"type":        []any{"string", "null"},
+						"description": "Affected endpoint as METHOD /path (e.g., POST /users), or null for API-wide issues",
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "One or two sentences: what is wrong and the evidence (status, body, expected behavior)",
+					},
+				},
+				"required": []string{"severity", "endpoint", "description"},
+			},
+		},
 		{
 			Name:        "GenerateReport",
-			Description: "Generate a PDF report from test results. Call this AFTER tests have been executed to create a professional report. Write the report content in Markdown format — it will be converted to a styled PDF. Include: title, summary, test results table (method, endpoint, status, duration), and analysis.",
+			Description: "Generate a PDF or HTML report from test results. Call this AFTER tests have been executed to create a professional report. Write the report content in Markdown format — it will be converted to a styled PDF (or HTML page). Include: title, summary, test results table (method, endpoint, status, duration, response size), total bytes transferred, and analysis. Issues recorded with RecordFinding and a Cost section with token usage and estimated spend are appended automatically, so don't write those sections.",
 			InputSchema: map[string]any{
 				"type":                 "object",
 				"additionalProperties": false,
@@ -147,6 +318,11 @@ func getMainAgentTools() []common.Tool {
 						"type":        "string",
 						"description": "Optional output file name for the PDF (e.g., 'api-test-report.pdf'). If not provided, a timestamped name will be used.",
 					},
+					"format": map[string]any{
+						"type":        "string",
+						"enum":        []string{"pdf", "html", "junit"},
+						"description": "Optional report format: pdf (default) or html. Use html when the user asks for it or PDF generation isn't available. junit exports every test run this session as JUnit XML for CI; report_content is ignored then (pass an empty string).",
+					},
 				},
 				"required": []string{"report_content"},
 			},
@@ -157,7 +333,7 @@ func getMainAgentTools() []common.Tool {
 func (a *Agent) Chat(messages []ChatMessage, thinkingEnabled bool, endpointsList ...string) (*ChatResponse, error) {
 	systemPrompt := buildSystemPrompt(a.baseURL, endpointsList...)
 	tools := getMainAgentTools()
-	return a.baseAgent.Chat(systemPrompt, tools, messages, thinkingEnabled)
+	return a.chatUsage(a.baseAgent.Chat(systemPrompt, tools, messages, thinkingEnabled))
 }
 
 func buildSystemPrompt(baseURL string, endpointsList ...string) string {
@@ -187,28 +363,79 @@ Fetch detailed specs (params, auth, schemas). Use when:
 - Need technical details for response/tests
 - User asks about specific endpoint behavior
 
+## sample_endpoint
+GET a live endpoint and return a trimmed sample of its response. Use before planning POST/PUT/PATCH tests to base request bodies on real data (field names, formats, existing IDs). Only GET is allowed.
+
+## compare_representations
+Send the same request with each given Accept value and compare what comes back. Use it when the user asks about content negotiation or the spec lists several response media types. Set Prefer (e.g. return=representation, return=minimal) in headers to test preference handling. Only GET and HEAD are allowed.
+
 ## GenerateTestPlan
 Generate tests. Parameters:
 - what: endpoint details from get_endpoints_details
 - focus: default "happy path", or user's choice
 
 ## ExecuteTestGroup
-Run tests after GenerateTestPlan.
+Run tests after GenerateTestPlan. Use "expect" to assert on response body fields via JSONPath; a test fails when an assertion doesn't hold.
+Tests run in order. Keep "name" and "skip_unless" from the plan so dependent steps are skipped when a precondition fails (e.g. login didn't return 200).
+Use "capture" to chain requests: {"id": "$.id"} on a create test, then "/users/{{id}}" in the following read. Captured variables last for the session.
+Use "download" for file endpoints (exports, reports, images): the file is saved to "save_to" (relative to the download directory) and checked against expect_content_type, expect_size and expect_checksum instead of being read as text.
+Use "multipart" for upload endpoints: text "fields" and "files" (inline content or a path) are sent as multipart/form-data instead of "body".
+
+## RecordFinding
+Record each real issue as soon as you confirm it from test results: failing behavior, spec mismatches, security or performance problems. One call per issue; don't record passing tests or duplicates of earlier findings.
 
 ## GenerateReport
-Generate a PDF report from test results. Use AFTER tests are executed and user asks for a report.
-Write a complete Markdown report with: title, summary, results table, analysis.
+Generate a PDF report (or HTML with format "html") from test results. Use AFTER tests are executed and user asks for a report.
+For CI or JUnit requests use format "junit": the session's test results are exported as JUnit XML, so no Markdown is needed.
+Write a complete Markdown report with: title, summary, results table (include response sizes), total bytes transferred, analysis. Call out unexpectedly large responses. Don't add issues or cost sections; recorded findings, token usage and estimated spend are appended automatically.
 
 # Behavior
 - User says "users" → fetch details, show info OR generate tests
 - User says "test X" → fetch details, generate & run tests
 - User says "list endpoints" → show list from above (no tool call)
 - Default focus: "happy path"
-- requires_auth=true → send auth, requires_auth=false → no auth`, baseURL, endpointsInfo)
+- requires_auth=true → send auth, requires_auth=false → no auth
+- A result with auth_likely_required=true was rejected with 401/403 although it should have been authorized: don't just report the failure, ask the user for credentials and point them to /auth (or Ctrl+G) and /retry-auth
+- A result with missing_scopes lists OAuth scopes the spec requires but the configured token doesn't grant: mention them when explaining a 401/403 and suggest a token with those scopes
+- Endpoint details may include named request "examples" curated by the spec authors: prefer them over invented bodies, and when the user asks for a specific example by name, use that one
+- A result with timed_out=true got no response within the request timeout: report the endpoint as hanging rather than failing, and don't retry it in a loop
+- A result with attempts>1 was resent after transient failures (see --retry-on); mention the flakiness even if the last attempt passed
+- A result with schema_valid=false returned a body that doesn't match the spec's response schema (schema_violations lists missing required fields and type mismatches): report it as a spec mismatch, and include schema conformance in reports
+- A result with response_truncated=true only holds the start of the response body (response_bytes is the full size): don't draw conclusions about the missing part
+- Endpoint details with XML content_types (and xml_root) belong to XML APIs: send XML document bodies, not JSON. A result with xml_error means the response claimed to be XML but is malformed; report it
+- A compare_representations result with matches_accept=false means the server ignored that Accept value (or answered 406): report which media types are actually supported
+- Endpoints with method SUB or PUB are AsyncAPI channels (message-based, not HTTP requests): describe them and their message payloads, but don't try to execute them
+- Endpoints marked [deprecated] are scheduled for removal: warn the user before testing or relying on them, and leave them out of bulk test generation unless explicitly asked`, baseURL, endpointsInfo)
 }
 
 func (a *Agent) ChatStream(messages []ChatMessage, thinkingEnabled bool, callback ReasoningCallback, endpointsList ...string) (*ChatResponse, error) {
 	systemPrompt := buildSystemPrompt(a.baseURL, endpointsList...)
 	tools := getMainAgentTools()
-	return a.baseAgent.ChatStream(systemPrompt, tools, messages, thinkingEnabled, callback)
+	return a.chatUsage(a.baseAgent.ChatStream(systemPrompt, tools, messages, thinkingEnabled, callback))
+}
+
+// chatUsage records the usage of a successful chat response, passing both results through
+func (a *Agent) chatUsage(response *ChatResponse, err error) (*ChatResponse, error) {
+	if err == nil {
+		a.recordUsage(common.OperationChat, response)
+	}
+	return response, err
+}
+
+// Ask answers a single question about the API without tools, so it can't execute requests
+func (a *Agent) Ask(question, endpointsDetails string) (*ChatResponse, error) {
+	systemPrompt := fmt.Sprintf(`Role: API reference assistant
+Base URL: %s
+
+# Endpoints
+%s
+
+# Rules
+1. Answer the question using only the endpoint details above
+2. You cannot call tools or execute requests; this is read-only Q&A
+3. Be concise; include example requests when they help
+4. If the specification doesn't cover the question, say so`, a.baseURL, endpointsDetails)
+
+	messages := []ChatMessage{{Role: "user", Content: question}}
+	return a.chatUsage(a.baseAgent.Chat(systemPrompt, nil, messages, false))
 }
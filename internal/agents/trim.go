@@ -0,0 +1,26 @@
+package agent
+
+// TrimHistory returns the last maxTurns turns of history. A turn starts with a user prompt and runs
+// up to the next one, so tool calls always stay together with their results and the result starts
+// with a plain user message as providers require. maxTurns <= 0 disables trimming.
+func TrimHistory(history []ChatMessage, maxTurns int) []ChatMessage {
+	if maxTurns <= 0 {
+		return history
+	}
+	turns := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		if !isPromptMessage(history[i]) {
+			continue
+		}
+		turns++
+		if turns == maxTurns {
+			return history[i:]
+		}
+	}
+	return history
+}
+
+// isPromptMessage reports whether msg is a user message that starts a turn (not a tool result)
+func isPromptMessage(msg ChatMessage) bool {
+	return msg.Role == "user" && msg.FunctionResponse == nil
+}
@@ -0,0 +1,49 @@
+package agent
+
+import "testing"
+
+func TestTrimHistory(t *testing.T) {
+	history := []ChatMessage{
+		{Role: "user", Content: "list endpoints"},
+		{Role: "assistant", Content: "sure"},
+		{Role: "user", Content: "test GET /users"},
+		{Role: "assistant", FunctionCalls: []ToolCall{{ID: "1", Name: "ExecuteTest"}}},
+		{Role: "user", FunctionResponse: &FunctionResponseData{ID: "1", Name: "ExecuteTest"}},
+		{Role: "assistant", FunctionCalls: []ToolCall{{ID: "2", Name: "ExecuteTest"}}},
+		{Role: "user", FunctionResponse: &FunctionResponseData{ID: "2", Name: "ExecuteTest"}},
+		{Role: "assistant", Content: "passed"},
+		{Role: "user", Content: "thanks"},
+	}
+
+	tests := []struct {
+		name      string
+		maxTurns  int
+		wantStart int
+	}{
+		{"unlimited", 0, 0},
+		{"under cap", 10, 0},
+		{"exactly the cap", 3, 0},
+		{"keeps the whole turn with its tool calls", 2, 2},
+		{"last turn only", 1, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TrimHistory(history, tt.maxTurns)
+			if want := len(history) - tt.wantStart; len(got) != want {
+				t.Fatalf("TrimHistory() kept %d messages, want %d", len(got), want)
+			}
+			if got[0].Role != "user" || got[0].FunctionResponse != nil {
+				t.Errorf("TrimHistory() starts with %+v, want a user prompt", got[0])
+			}
+		})
+	}
+
+	t.Run("a turn mid tool chain is kept whole", func(t *testing.T) {
+		chain := history[:7]
+		got := TrimHistory(chain, 1)
+		if len(got) != 5 || got[0].Content != "test GET /users" {
+			t.Errorf("TrimHistory() = %+v, want the last turn", got)
+		}
+	})
+}
@@ -1,7 +1,11 @@
 package agent
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
 )
 
 type APIEndpoint struct {
@@ -16,16 +20,68 @@ type TestPlan struct {
 	Tests []TestCase `json:"tests"`
 }
 
+// LoadTestPlan reads a test plan file. A missing file yields an empty plan.
+func LoadTestPlan(path string) (*TestPlan, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &TestPlan{Tests: []TestCase{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read test plan: %w", err)
+	}
+
+	var plan TestPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse test plan: %w", err)
+	}
+	return &plan, nil
+}
+
+// Save writes the test plan as indented JSON
+func (p *TestPlan) Save(path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal test plan: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write test plan: %w", err)
+	}
+	return nil
+}
+
+// Add appends a test case, assigning the next free ID
+func (p *TestPlan) Add(tc TestCase) {
+	tc.ID = 1
+	for _, existing := range p.Tests {
+		tc.ID = max(tc.ID, existing.ID+1)
+	}
+	p.Tests = append(p.Tests, tc)
+}
+
 type TestCase struct {
-	ID             int               `json:"id"`
-	Description    string            `json:"description"`
-	Method         string            `json:"method"`
-	Endpoint       string            `json:"endpoint"`
-	Headers        map[string]string `json:"headers,omitempty"`
-	Body           interface{}       `json:"body,omitempty"`
-	ExpectedStatus int               `json:"expected_status"`
-	Reasoning      string            `json:"reasoning"`
-	RequiresAuth   bool              `json:"requires_auth"`
+	ID             int                `json:"id"`
+	Description    string             `json:"description"`
+	Method         string             `json:"method"`
+	Endpoint       string             `json:"endpoint"`
+	Headers        map[string]string  `json:"headers,omitempty"`
+	Body           interface{}        `json:"body,omitempty"`
+	ExpectedStatus int                `json:"expected_status"`
+	Reasoning      string             `json:"reasoning"`
+	RequiresAuth   bool               `json:"requires_auth"`
+	Expect         []tester.Assertion `json:"expect,omitempty"`
+	Name           string             `json:"name,omitempty"`        // Lets later tests reference this result in skip_unless
+	SkipUnless     string             `json:"skip_unless,omitempty"` // Condition on earlier results, e.g. "{{login.status_code}} == 200"
+	Capture        map[string]string  `json:"capture,omitempty"`     // Variables to extract from the response, e.g. {"id": "$.id"}
+	Download       *tester.Download   `json:"download,omitempty"`    // Save a file response and verify its type, size or checksum
+	Multipart      *tester.Multipart  `json:"multipart,omitempty"`   // Send a multipart/form-data body with fields and files instead of Body
+}
+
+// RequestBody returns the body to send: the multipart form when the test has one, Body otherwise
+func (tc TestCase) RequestBody() any {
+	if tc.Multipart != nil {
+		return tc.Multipart
+	}
+	return tc.Body
 }
 
 // BuildTestPlanPrompt generates tests based on detailed endpoint description
@@ -44,7 +100,12 @@ CRITICAL RULES:
 2. Use Security field to determine requires_auth:
    - Security: [] or empty → false
    - Security: [{"bearer":[]}] → true
-3. Use Request Body for expected data
+3. Use Request Body for expected data; when Examples are listed, use an example's value as the body
+   (the one the user named, otherwise the first) and mention its name in the description
+   When the endpoint only accepts XML content types, write the body as an XML document string
+   (root element from xml_root) and set the Content-Type header to that media type
+   Set "headers" when a test depends on them, e.g. Accept for a specific representation or
+   "Prefer": "return=representation" to ask for the created/updated resource in the response
 4. Use Responses for expected_status
 5. Generate tests per focus level:
    - "happy path" → 1 test (success)
@@ -81,8 +142,26 @@ Pure JSON, no markdown:
   ]
 }
 
+For multi-step flows (e.g. login, then an authenticated call), give the first test a "name" and add
+"skip_unless" to dependent tests, e.g. "name": "login" and "skip_unless": "{{login.status_code}} == 200".
+References: {{<name>.status_code}}, {{<name>.passed}}, {{<name>.body.<json path>}}. Omit both fields otherwise.
+
+To reuse a value from a response (e.g. create, then read the created resource), add "capture" with
+variable names and JSONPaths, e.g. "capture": {"id": "$.id"}, and reference {{id}} in a later test's
+endpoint, headers or body: "endpoint": "/users/{{id}}".
+
+For endpoints that return files (exports, PDFs, images), add "download" to save and verify the file
+instead of reading it, e.g. "download": {"save_to": "reports/", "expect_content_type": "application/pdf"}.
+"save_to" is relative to the download directory; an existing file is only replaced with "overwrite": true.
+"expect_size" (bytes) and "expect_checksum" ("sha256:<hex>") are optional.
+
+For file uploads (multipart/form-data endpoints), use "multipart" instead of "body", with text
+"fields" and "files" by field name, e.g. "multipart": {"fields": {"title": "Avatar"},
+"files": {"file": {"filename": "avatar.png", "content": "test"}}}. A file can also be a relative path
+inside the working directory or the spec's directory.
+
 Requirements:
-- No code fences, comments, or extra fields
+- No code fences, comments, or extra fields beyond headers/name/skip_unless/capture/download/multipart
 - Double quotes for keys/strings
 - No trailing commas
 - Sequential IDs starting from 1`, what, focus)
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	agent "github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAuthHintKey(t *testing.T) {
+	m := NewTestUIModel("https://api.example.com", "", nil, &auth.NoAuth{}, "test")
+	m.authHint = true
+
+	// Ctrl+A stays the textarea's go-to-line-start
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	if got := next.(TestUIModel); got.agentState == StateWizard {
+		t.Fatal("Ctrl+A opened the auth wizard")
+	}
+
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	got := next.(TestUIModel)
+	if got.agentState != StateWizard {
+		t.Fatalf("Ctrl+G left the state at %v, want the auth wizard", got.agentState)
+	}
+	if got.authHint {
+		t.Error("the auth hint is still shown after opening the wizard")
+	}
+}
+
+func TestRetryAuthWithProfile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(storage.SecretEnvVar, "")
+
+	var lastAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastAuth = r.Header.Get("Authorization")
+		if lastAuth != "Bearer prod-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	project := &storage.Project{ID: "retry-id", Name: "retry", BaseURL: server.URL}
+	project.SetAuthProfile("prod", &storage.AuthConfig{Type: "bearer", Token: "prod-token"})
+	if err := storage.SaveProject(project); err != nil {
+		t.Fatal(err)
+	}
+	m := NewTestUIModel(server.URL, "", nil, &auth.NoAuth{}, "test")
+	m.currentProject = project
+
+	handleStartTestGroup(m, startTestGroupMsg{tests: []map[string]any{
+		{"method": "GET", "endpoint": "/me"},
+	}})
+	handleRunNextTest(m, runNextTestMsg{})
+	if len(m.authRejectedTests) != 1 {
+		t.Fatalf("recorded %d rejected requests, want 1", len(m.authRejectedTests))
+	}
+
+	// An unknown profile keeps the rejected requests for another try
+	if _, cmd, _ := handleSlashCommands(m, "/retry-auth staging"); cmd != nil || len(m.authRejectedTests) != 1 {
+		t.Fatalf("/retry-auth with an unknown profile started a re-run")
+	}
+
+	_, cmd, handled := handleSlashCommands(m, "/retry-auth prod")
+	if !handled {
+		t.Fatal("/retry-auth wasn't handled")
+	}
+	start, ok := findMsg[startTestGroupMsg](cmd)
+	if !ok {
+		t.Fatal("/retry-auth didn't start a test group")
+	}
+	if m.authProvider.Type() != "bearer" {
+		t.Errorf("auth provider = %s, want the prod profile's bearer", m.authProvider.Type())
+	}
+	if len(start.tests) != 1 || start.tests[0]["requires_auth"] != true {
+		t.Fatalf("re-run tests = %v, want the rejected request with auth", start.tests)
+	}
+
+	handleStartTestGroup(m, start)
+	handleRunNextTest(m, runNextTestMsg{})
+	if lastAuth != "Bearer prod-token" {
+		t.Errorf("re-run sent Authorization %q, want the prod token", lastAuth)
+	}
+	if len(m.authRejectedTests) != 0 {
+		t.Errorf("re-run with valid credentials recorded %d rejections", len(m.authRejectedTests))
+	}
+
+	if _, cmd, _ := handleSlashCommands(m, "/retry-auth"); cmd != nil {
+		t.Error("/retry-auth with nothing rejected started a re-run")
+	}
+}
+
+func TestRetryAuthAfterAgentRequest(t *testing.T) {
+	m := NewTestUIModel("https://api.example.com", "", nil, &auth.NoAuth{}, "test")
+	m.currentToolCall = &agent.ToolCall{Name: "ExecuteTest", Arguments: map[string]any{
+		"method": "POST", "endpoint": "/orders", "body": map[string]any{"sku": "A1"},
+	}}
+	m.handleToolResult("ExecuteTest", "", map[string]any{
+		"method": "POST", "endpoint": "/orders", "status_code": 401, "auth_likely_required": true,
+	})
+
+	if !m.authHint || len(m.authRejectedTests) != 1 {
+		t.Fatalf("authHint = %v, rejected = %v, want the request queued for /retry-auth", m.authHint, m.authRejectedTests)
+	}
+	test := m.authRejectedTests[0]
+	if test["method"] != "POST" || test["endpoint"] != "/orders" || test["requires_auth"] != true {
+		t.Errorf("rejected test = %v, want POST /orders with auth", test)
+	}
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/Octrafic/octrafic-cli/internal/config"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+)
+
+// saveAuthProfile stores credentials under a name in the current project, so "auth use" can switch back to them
+func (m *TestUIModel) saveAuthProfile(name string, authConfig *storage.AuthConfig) error {
+	if m.currentProject == nil {
+		return fmt.Errorf("no active project to save the profile in")
+	}
+	if err := config.ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	m.currentProject.SetAuthProfile(name, authConfig)
+	return storage.SaveProject(m.currentProject)
+}
+
+// useAuthProfile handles "auth use <profile>", switching the executor to a saved profile's
+// credentials. It reports whether the switch was made.
+func (m *TestUIModel) useAuthProfile(name string) bool {
+	defer m.addMessage("")
+
+	if m.currentProject == nil {
+		m.addAgentMessage(m.subtleStyle.Render("No active project"))
+		return false
+	}
+	profile, ok := m.currentProject.AuthProfiles[name]
+	if !ok {
+		m.addAgentMessage(m.errorStyle.Render(fmt.Sprintf("No auth profile named '%s'", name)))
+		if names := m.currentProject.AuthProfileNames(); len(names) > 0 {
+			m.addMessage(m.subtleStyle.Render(fmt.Sprintf("Saved profiles: %v", names)))
+		}
+		return false
+	}
+	if profile.Locked() {
+		m.addAgentMessage(m.errorStyle.Render(fmt.Sprintf("Profile '%s' is encrypted; set %s to the passphrase it was saved with", name, storage.SecretEnvVar)))
+		return false
+	}
+
+	provider := profile.AuthProvider()
+	if err := provider.Validate(); err != nil {
+		m.addAgentMessage(m.errorStyle.Render(fmt.Sprintf("Profile '%s' is invalid: %v", name, err)))
+		return false
+	}
+	m.authProvider = provider
+	m.testExecutor.UpdateAuthProvider(provider)
+	m.addAgentMessage(m.successStyle.Render(fmt.Sprintf("✓ Using auth profile '%s' (%s)", name, profile.Type)))
+	return true
+}
+
+// listAuthProfiles handles "auth profiles", showing each saved profile with its secrets redacted
+func (m *TestUIModel) listAuthProfiles() {
+	defer m.addMessage("")
+
+	if m.currentProject == nil || len(m.currentProject.AuthProfiles) == 0 {
+		m.addAgentMessage(m.subtleStyle.Render("No auth profiles saved; save one with /auth and a profile name"))
+		return
+	}
+
+	m.addAgentMessage(m.agentStyle.Render(fmt.Sprintf("Auth profiles (%d):", len(m.currentProject.AuthProfiles))))
+	for _, name := range m.currentProject.AuthProfileNames() {
+		profile := m.currentProject.AuthProfiles[name]
+		description := profile.Type
+		if profile.Locked() {
+			description += " (encrypted)"
+		} else if stringer, ok := profile.AuthProvider().Redact().(fmt.Stringer); ok {
+			description = stringer.String()
+		}
+		m.addMessage(fmt.Sprintf("  %s  %s", name, m.subtleStyle.Render(description)))
+	}
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+)
+
+// newProfileTestModel returns a chat model with a saved project in a temporary home
+func newProfileTestModel(t *testing.T) *TestUIModel {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(storage.SecretEnvVar, "")
+
+	project := &storage.Project{ID: "profiles-id", Name: "profiles", BaseURL: "https://api.example.com"}
+	if err := storage.SaveProject(project); err != nil {
+		t.Fatal(err)
+	}
+	m := NewTestUIModel(project.BaseURL, "", nil, &auth.NoAuth{}, "test")
+	m.currentProject = project
+	return m
+}
+
+// submitBearerWizard fills in and submits the /auth wizard for a bearer token
+func submitBearerWizard(t *testing.T, m *TestUIModel, token, profile string) *TestUIModel {
+	t.Helper()
+	m.wizardState = NewAuthWizard()
+	m.wizardState.SelectedType = "bearer"
+	m.wizardState.FormFields = CreateAuthFormFields("bearer")
+	for i := range m.wizardState.FormFields {
+		switch m.wizardState.FormFields[i].Name {
+		case "token":
+			m.wizardState.FormFields[i].Value = token
+		case "profile_name":
+			m.wizardState.FormFields[i].Value = profile
+		}
+	}
+	next, _ := submitAuthForm(*m)
+	result := next.(TestUIModel)
+	return &result
+}
+
+func TestAuthProfilesSaveListAndSwitch(t *testing.T) {
+	m := newProfileTestModel(t)
+	m = submitBearerWizard(t, m, "dev-token-123456", "dev")
+	m = submitBearerWizard(t, m, "prod-token-654321", "prod")
+
+	loaded, err := storage.LoadProject("profiles-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.AuthProfileNames(); len(got) != 2 || got[0] != "dev" || got[1] != "prod" {
+		t.Fatalf("saved profiles = %v, want [dev prod]", got)
+	}
+	if loaded.AuthProfiles["dev"].Token != "dev-token-123456" {
+		t.Errorf("dev profile = %+v, want the dev token", loaded.AuthProfiles["dev"])
+	}
+
+	m.messages = nil
+	handleAuthCommand(m, "auth profiles")
+	listing := strings.Join(m.messages, "\n")
+	if !strings.Contains(listing, "dev") || !strings.Contains(listing, "prod") {
+		t.Errorf("auth profiles output is missing a profile:\n%s", listing)
+	}
+	if strings.Contains(listing, "dev-token-123456") || strings.Contains(listing, "prod-token-654321") {
+		t.Errorf("auth profiles output shows a token in full:\n%s", listing)
+	}
+
+	handleAuthCommand(m, "auth use dev")
+	if bearer, ok := m.authProvider.(*auth.BearerAuth); !ok || bearer.Token != "dev-token-123456" {
+		t.Errorf("auth provider after 'auth use dev' = %#v, want the dev bearer token", m.authProvider)
+	}
+
+	m.messages = nil
+	handleAuthCommand(m, "auth use staging")
+	if bearer, _ := m.authProvider.(*auth.BearerAuth); bearer == nil || bearer.Token != "dev-token-123456" {
+		t.Errorf("an unknown profile replaced the credentials: %#v", m.authProvider)
+	}
+	if !strings.Contains(strings.Join(m.messages, "\n"), "No auth profile named 'staging'") {
+		t.Errorf("missing error for an unknown profile:\n%s", strings.Join(m.messages, "\n"))
+	}
+}
+
+func TestAuthProfileRequiresValidName(t *testing.T) {
+	m := newProfileTestModel(t)
+	m = submitBearerWizard(t, m, "token", "my profile")
+
+	if loaded, _ := storage.LoadProject("profiles-id"); len(loaded.AuthProfiles) != 0 {
+		t.Errorf("saved profiles = %v, want none for a name with a space", loaded.AuthProfileNames())
+	}
+	if bearer, ok := m.authProvider.(*auth.BearerAuth); !ok || bearer.Token != "token" {
+		t.Errorf("auth provider = %#v, want the token applied even though the profile wasn't saved", m.authProvider)
+	}
+}
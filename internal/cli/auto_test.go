@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+)
+
+func TestAutoFlagSetsInitialMode(t *testing.T) {
+	m := NewTestUIModel("http://localhost", "", nil, &auth.NoAuth{}, "test")
+	if err := m.applyStartOptions(StartOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if m.executionMode != ModeAsk {
+		t.Errorf("without --auto the mode is %v, want ModeAsk", m.executionMode)
+	}
+
+	if err := m.applyStartOptions(StartOptions{AutoExecute: true}); err != nil {
+		t.Fatal(err)
+	}
+	if m.executionMode != ModeAutoExecute {
+		t.Errorf("with --auto the mode is %v, want ModeAutoExecute", m.executionMode)
+	}
+}
+
+func TestAutoCommandToggles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewTestUIModel("http://localhost", "", nil, &auth.NoAuth{}, "test")
+	m.currentProject = &storage.Project{ID: "auto-test", Name: "auto-test"}
+
+	handleSlashCommands(m, "/auto")
+	if m.executionMode != ModeAutoExecute {
+		t.Fatalf("after /auto the mode is %v, want ModeAutoExecute", m.executionMode)
+	}
+	saved, err := storage.LoadProject("auto-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Preferences == nil || saved.Preferences.ExecutionMode != "auto" {
+		t.Errorf("saved preferences = %+v, want execution mode auto", saved.Preferences)
+	}
+	handleSlashCommands(m, "/auto")
+	if m.executionMode != ModeAsk {
+		t.Errorf("after a second /auto the mode is %v, want ModeAsk", m.executionMode)
+	}
+}
+
+func TestAutoModeRunsStreamedToolsWithoutAsking(t *testing.T) {
+	compare := agent.ToolCall{ID: "call-1", Name: "compare_representations", Arguments: map[string]any{
+		"method": "GET", "endpoint": "/users/1", "accept": []any{"application/json", "application/xml"},
+	}}
+	tests := []struct {
+		mode ExecutionMode
+		want AgentState
+	}{
+		{ModeAsk, StateAskingConfirmation},
+		{ModeAutoExecute, StateThinking},
+	}
+	for _, tt := range tests {
+		m := NewTestUIModel("http://localhost", "", nil, &auth.NoAuth{}, "test")
+		m.executionMode = tt.mode
+		m.streamedToolCalls = []agent.ToolCall{compare}
+
+		handleProcessToolCalls(m, processToolCallsMsg{})
+		if m.agentState != tt.want {
+			t.Errorf("mode %v: state %v, want %v", tt.mode, m.agentState, tt.want)
+		}
+	}
+}
+
+func TestAutoModeRunsTestPlanUnlessDestructive(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     ExecutionMode
+		method   string
+		wantPlan bool
+	}{
+		{"ask mode shows the plan", ModeAsk, "GET", true},
+		{"auto mode runs it", ModeAutoExecute, "GET", false},
+		{"auto mode shows a destructive plan", ModeAutoExecute, "DELETE", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewTestUIModel("http://localhost", "", nil, &auth.NoAuth{}, "test")
+			m.executionMode = tt.mode
+			m.streamedToolCalls = []agent.ToolCall{{ID: "call-1", Name: "ExecuteTestGroup", Arguments: map[string]any{
+				"tests": []any{map[string]any{"method": tt.method, "endpoint": "/users/1"}},
+			}}}
+
+			_, cmd := handleProcessToolCalls(m, processToolCallsMsg{})
+			selection, ok := findMsg[showTestSelectionMsg](cmd)
+			if !ok {
+				t.Fatal("ExecuteTestGroup didn't propose a test plan")
+			}
+			_, cmd = handleShowTestSelection(m, selection)
+
+			if showsPlan := m.agentState == StateShowingTestPlan; showsPlan != tt.wantPlan {
+				t.Errorf("plan shown = %v, want %v (state %v)", showsPlan, tt.wantPlan, m.agentState)
+			}
+			if _, started := findMsg[startTestGroupMsg](cmd); started == tt.wantPlan {
+				t.Errorf("tests started = %v, want %v", started, !tt.wantPlan)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Octrafic/octrafic-cli/internal/infra/logger"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// chatSessionWrites orders session saves, which run as commands in no set order: a save older
+// than the last one written for the same session is dropped
+var chatSessionWrites struct {
+	sync.Mutex
+	savedAt map[string]time.Time
+}
+
+// saveChatSession returns a command saving the conversation so /resume or --resume can continue it
+// after the TUI is closed. Each session is saved to its own file, so starting a new one doesn't
+// replace the last; a resumed session keeps saving to the file it was loaded from.
+// Temporary projects aren't kept, so their conversations aren't saved either.
+func (m *TestUIModel) saveChatSession() tea.Cmd {
+	if m.currentProject == nil || m.currentProject.IsTemporary || len(m.conversationHistory) == 0 {
+		return nil
+	}
+	if m.chatSessionID == "" {
+		m.chatSessionID = storage.NewChatSessionID()
+	}
+	projectID := m.currentProject.ID
+	session := &storage.ChatSession{
+		ID:           m.chatSessionID,
+		SavedAt:      time.Now(),
+		Messages:     slices.Clone(m.conversationHistory),
+		InputTokens:  m.inputTokens,
+		OutputTokens: m.outputTokens,
+	}
+	return func() tea.Msg {
+		writeChatSession(projectID, session)
+		return nil
+	}
+}
+
+// writeChatSession saves a session unless a later save of it was already written
+func writeChatSession(projectID string, session *storage.ChatSession) {
+	chatSessionWrites.Lock()
+	defer chatSessionWrites.Unlock()
+
+	if chatSessionWrites.savedAt == nil {
+		chatSessionWrites.savedAt = map[string]time.Time{}
+	}
+	if session.SavedAt.Before(chatSessionWrites.savedAt[session.ID]) {
+		return
+	}
+	if err := storage.SaveChatSession(projectID, session); err != nil {
+		logger.Warn("Failed to save session", logger.Err(err))
+		return
+	}
+	chatSessionWrites.savedAt[session.ID] = session.SavedAt
+}
+
+// resumeChatSession handles /resume and --resume, replacing the conversation with the project's last
+// saved session other than the one in progress
+func (m *TestUIModel) resumeChatSession() {
+	defer m.addMessage("")
+
+	if m.currentProject == nil || m.currentProject.IsTemporary {
+		m.addAgentMessage(m.subtleStyle.Render("Sessions are only saved for named projects"))
+		return
+	}
+	session, err := storage.LoadChatSession(m.currentProject.ID, m.chatSessionID)
+	if errors.Is(err, storage.ErrNoChatSession) {
+		m.addAgentMessage(m.subtleStyle.Render("No saved session for this project yet"))
+		return
+	}
+	if err != nil {
+		m.addAgentMessage(m.errorStyle.Render("Failed to load the session: " + err.Error()))
+		return
+	}
+
+	m.conversationHistory = session.Messages
+	m.chatSessionID = session.ID
+	m.inputTokens = session.InputTokens
+	m.outputTokens = session.OutputTokens
+
+	// Replay the text of the conversation; tool calls and results stay in the history for the agent
+	promptStyle := lipgloss.NewStyle().Foreground(Theme.TextMuted)
+	for _, msg := range session.Messages {
+		if msg.Content == "" || msg.FunctionResponse != nil {
+			continue
+		}
+		if msg.Role == "assistant" {
+			m.addMessage(renderMarkdown(msg.Content))
+		} else {
+			m.addMessage(promptStyle.Render("> ") + msg.Content)
+		}
+		m.addMessage("")
+	}
+	m.addAgentMessage(m.successStyle.Render(fmt.Sprintf("✓ Resumed the session from %s (%d messages)", session.SavedAt.Local().Format("2006-01-02 15:04"), len(session.Messages))))
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+)
+
+func TestChatSessionSavedPerSession(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	project := &storage.Project{ID: "chat-session-id", Name: "chat"}
+	newModel := func(content string) *TestUIModel {
+		m := NewTestUIModel("http://localhost", "", nil, &auth.NoAuth{}, "test")
+		m.currentProject = project
+		m.conversationHistory = []agent.ChatMessage{{Role: "user", Content: content}}
+		return m
+	}
+	latest := func() string {
+		t.Helper()
+		session, err := storage.LoadChatSession(project.ID, "")
+		if err != nil {
+			t.Fatalf("LoadChatSession() error = %v", err)
+		}
+		return session.Messages[len(session.Messages)-1].Content
+	}
+
+	// Saving is left to the returned command
+	first := newModel("first")
+	save := first.saveChatSession()
+	if _, err := storage.LoadChatSession(project.ID, ""); !errors.Is(err, storage.ErrNoChatSession) {
+		t.Fatalf("session saved before the command ran: %v", err)
+	}
+	save()
+	if got := latest(); got != "first" {
+		t.Fatalf("saved session ends with %q, want first", got)
+	}
+
+	// A new session gets its own file instead of replacing the first one
+	second := newModel("second")
+	second.saveChatSession()()
+	projectPath, _ := storage.GetProjectPathByType(project.ID, false)
+	if entries, _ := os.ReadDir(filepath.Join(projectPath, "sessions")); len(entries) != 2 {
+		t.Errorf("got %d saved sessions, want 2", len(entries))
+	}
+
+	// Resuming mid-session loads the previous session rather than reloading the current one
+	secondID := second.chatSessionID
+	second.resumeChatSession()
+	if second.chatSessionID != first.chatSessionID {
+		t.Errorf("/resume in the second session loaded %q, want the first %q", second.chatSessionID, first.chatSessionID)
+	}
+
+	// A resumed session keeps saving to its file, and a stale save doesn't overwrite a later one
+	resumed := NewTestUIModel("http://localhost", "", nil, &auth.NoAuth{}, "test")
+	resumed.currentProject = project
+	resumed.resumeChatSession()
+	if resumed.chatSessionID != secondID {
+		t.Fatalf("resumed session %q, want %q", resumed.chatSessionID, secondID)
+	}
+	resumed.conversationHistory = append(resumed.conversationHistory, agent.ChatMessage{Role: "user", Content: "older"})
+	stale := resumed.saveChatSession()
+	resumed.conversationHistory = append(resumed.conversationHistory, agent.ChatMessage{Role: "user", Content: "newer"})
+	resumed.saveChatSession()()
+	stale()
+	if got := latest(); got != "newer" {
+		t.Errorf("resumed session ends with %q, want newer", got)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(projectPath, "sessions")); len(entries) != 2 {
+		t.Errorf("got %d saved sessions after resuming, want 2", len(entries))
+	}
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/Octrafic/octrafic-cli/internal/infra/logger"
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// copyHintDuration is how long the result of Ctrl+Y stays below the input
+const copyHintDuration = 2 * time.Second
+
+// writeClipboard puts text on the system clipboard; replaced in tests
+var writeClipboard = clipboard.WriteAll
+
+type copyHintTimeoutMsg struct{}
+
+// copyLastResponse handles Ctrl+Y: it copies the last manual response body in manual mode, and
+// the agent's last reply (as Markdown) otherwise
+func (m *TestUIModel) copyLastResponse() tea.Cmd {
+	text, what := m.lastAgentReply(), "agent reply"
+	if m.manualMode && m.lastResponseBody != "" {
+		text, what = m.lastResponseBody, "response body"
+	}
+
+	if text == "" {
+		m.copyHint, m.copyHintErr = "Nothing to copy yet", true
+	} else if err := writeClipboard(text); err != nil {
+		logger.Warn("Failed to copy to clipboard", logger.Err(err))
+		m.copyHint, m.copyHintErr = "Clipboard not available", true
+	} else {
+		m.copyHint, m.copyHintErr = "Copied "+what, false
+	}
+
+	m.copyHintAt = time.Now()
+	return tea.Tick(copyHintDuration, func(time.Time) tea.Msg {
+		return copyHintTimeoutMsg{}
+	})
+}
+
+// lastAgentReply returns the text of the agent's most recent reply
+func (m *TestUIModel) lastAgentReply() string {
+	for i := len(m.conversationHistory) - 1; i >= 0; i-- {
+		if msg := m.conversationHistory[i]; msg.Role == "assistant" && msg.Content != "" {
+			return msg.Content
+		}
+	}
+	return ""
+}
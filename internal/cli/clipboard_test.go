@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+)
+
+func TestCopyLastResponse(t *testing.T) {
+	original := writeClipboard
+	t.Cleanup(func() { writeClipboard = original })
+
+	var copied string
+	writeClipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+
+	m := NewTestUIModel("https://api.example.com", "", nil, &auth.NoAuth{}, "test")
+	m.copyLastResponse()
+	if !m.copyHintErr || copied != "" {
+		t.Errorf("copied %q with hint %q, want nothing to copy without a reply", copied, m.copyHint)
+	}
+
+	m.conversationHistory = []agent.ChatMessage{
+		{Role: "assistant", Content: "```bash\ncurl https://api.example.com/users\n```"},
+		{Role: "user", Content: "thanks"},
+		{Role: "assistant", FunctionCalls: []agent.ToolCall{{Name: "ExecuteTestGroup"}}},
+	}
+	m.copyLastResponse()
+	if copied != m.conversationHistory[0].Content || m.copyHint != "Copied agent reply" {
+		t.Errorf("copied %q with hint %q, want the last reply with text", copied, m.copyHint)
+	}
+
+	m.manualMode = true
+	m.lastResponseBody = `{"id":1}`
+	m.copyLastResponse()
+	if copied != `{"id":1}` || m.copyHint != "Copied response body" {
+		t.Errorf("copied %q with hint %q, want the last response body in manual mode", copied, m.copyHint)
+	}
+
+	writeClipboard = func(string) error { return errors.New("no clipboard utility") }
+	m.copyLastResponse()
+	if !m.copyHintErr || m.copyHint != "Clipboard not available" {
+		t.Errorf("hint = %q, want a clipboard error", m.copyHint)
+	}
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+)
+
+// conversationExport is the JSON form of an exported conversation
+type conversationExport struct {
+	Project    string              `json:"project,omitempty"`
+	BaseURL    string              `json:"base_url,omitempty"`
+	ExportedAt time.Time           `json:"exported_at"`
+	Messages   []agent.ChatMessage `json:"messages"`
+}
+
+// handleExportCommand handles /export [path], writing the conversation to a Markdown file, or JSON
+// when the path ends in .json. Without a path it goes to a timestamped file in the project directory.
+func (m *TestUIModel) handleExportCommand(args []string) {
+	defer m.addMessage("")
+
+	if len(m.conversationHistory) == 0 {
+		m.addAgentMessage(m.subtleStyle.Render("Nothing to export yet"))
+		return
+	}
+
+	path := strings.Join(args, " ")
+	if path == "" {
+		dir, err := m.exportDir()
+		if err != nil {
+			m.addAgentMessage(m.errorStyle.Render("Failed to export the conversation: " + err.Error()))
+			return
+		}
+		path = filepath.Join(dir, "conversation_"+time.Now().Format("20060102-150405")+".md")
+	}
+
+	if err := m.exportConversation(path); err != nil {
+		m.addAgentMessage(m.errorStyle.Render("Failed to export the conversation: " + err.Error()))
+		return
+	}
+	m.addAgentMessage(m.successStyle.Render(fmt.Sprintf("✓ Conversation saved to %s", path)))
+}
+
+// exportDir returns the current project's directory, or the working directory without a project
+func (m *TestUIModel) exportDir() (string, error) {
+	if m.currentProject == nil {
+		return os.Getwd()
+	}
+	return storage.GetProjectPathByType(m.currentProject.ID, m.currentProject.IsTemporary)
+}
+
+// exportConversation writes the conversation history to path, as JSON for .json files and Markdown otherwise
+func (m *TestUIModel) exportConversation(path string) error {
+	export := conversationExport{
+		BaseURL:    m.baseURL,
+		ExportedAt: time.Now(),
+		Messages:   m.conversationHistory,
+	}
+	if m.currentProject != nil {
+		export.Project = m.currentProject.Name
+	}
+
+	var data []byte
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		var err error
+		if data, err = json.MarshalIndent(export, "", "  "); err != nil {
+			return err
+		}
+	} else {
+		data = []byte(conversationMarkdown(export))
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// conversationMarkdown renders an exported conversation as Markdown, with tool calls and their
+// results as JSON code blocks and the token usage of each agent turn
+func conversationMarkdown(export conversationExport) string {
+	var b strings.Builder
+	b.WriteString("# Octrafic conversation\n\n")
+	if export.Project != "" {
+		fmt.Fprintf(&b, "- Project: %s\n", export.Project)
+	}
+	if export.BaseURL != "" {
+		fmt.Fprintf(&b, "- API: %s\n", export.BaseURL)
+	}
+	fmt.Fprintf(&b, "- Exported: %s\n", export.ExportedAt.Format(time.RFC3339))
+	var input, output int64
+	for _, msg := range export.Messages {
+		input += msg.InputTokens
+		output += msg.OutputTokens
+	}
+	if input > 0 || output > 0 {
+		fmt.Fprintf(&b, "- Tokens: %d input, %d output\n", input, output)
+	}
+
+	for _, msg := range export.Messages {
+		if msg.FunctionResponse != nil {
+			fmt.Fprintf(&b, "\n### Result: %s\n\n", msg.FunctionResponse.Name)
+			writeJSONBlock(&b, msg.FunctionResponse.Response)
+			continue
+		}
+
+		if msg.Role == "assistant" {
+			b.WriteString("\n## Agent\n\n")
+		} else {
+			b.WriteString("\n## User\n\n")
+		}
+		if content := strings.TrimSpace(msg.Content); content != "" {
+			b.WriteString(content + "\n")
+		}
+		for _, call := range msg.FunctionCalls {
+			fmt.Fprintf(&b, "\n### Tool call: %s\n\n", call.Name)
+			writeJSONBlock(&b, call.Arguments)
+		}
+		if msg.InputTokens > 0 || msg.OutputTokens > 0 {
+			fmt.Fprintf(&b, "\n_Tokens: %d input, %d output_\n", msg.InputTokens, msg.OutputTokens)
+		}
+	}
+	return b.String()
+}
+
+// writeJSONBlock writes v as an indented JSON code block
+func writeJSONBlock(b *strings.Builder, v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		data = fmt.Appendf(nil, "%v", v)
+	}
+	b.WriteString("```json\n" + string(data) + "\n```\n")
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+)
+
+// newExportTestModel returns a chat model with a saved project and a short conversation with one tool round trip
+func newExportTestModel(t *testing.T) *TestUIModel {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	project := &storage.Project{ID: "export-id", Name: "export", BaseURL: "https://api.example.com"}
+	if err := storage.SaveProject(project); err != nil {
+		t.Fatal(err)
+	}
+	m := NewTestUIModel(project.BaseURL, "", nil, &auth.NoAuth{}, "test")
+	m.currentProject = project
+	m.conversationHistory = []agent.ChatMessage{
+		{Role: "user", Content: "Test GET /users"},
+		{Role: "assistant", Content: "Running the test.", InputTokens: 1200, OutputTokens: 85, FunctionCalls: []agent.ToolCall{
+			{ID: "call-1", Name: "ExecuteTestGroup", Arguments: map[string]any{"method": "GET", "endpoint": "/users"}},
+		}},
+		{Role: "user", FunctionResponse: &agent.FunctionResponseData{
+			ID: "call-1", Name: "ExecuteTestGroup", Response: map[string]any{"status_code": 200},
+		}},
+		{Role: "assistant", Content: "GET /users returned 200.", InputTokens: 1350, OutputTokens: 40},
+	}
+	return m
+}
+
+func TestExportCommandMarkdown(t *testing.T) {
+	m := newExportTestModel(t)
+	path := filepath.Join(t.TempDir(), "session.md")
+	m.handleExportCommand([]string{path})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"- Project: export", "## User\n\nTest GET /users", "### Tool call: ExecuteTestGroup", `"endpoint": "/users"`, "### Result: ExecuteTestGroup", `"status_code": 200`, "## Agent\n\nGET /users returned 200."} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("export is missing %q:\n%s", want, data)
+		}
+	}
+	if !strings.Contains(strings.Join(m.messages, "\n"), path) {
+		t.Errorf("chat doesn't show where the conversation was saved: %v", m.messages)
+	}
+}
+
+func TestConversationMarkdownGolden(t *testing.T) {
+	m := newExportTestModel(t)
+	got := conversationMarkdown(conversationExport{
+		Project:    m.currentProject.Name,
+		BaseURL:    m.baseURL,
+		ExportedAt: time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC),
+		Messages:   m.conversationHistory,
+	})
+
+	want, err := os.ReadFile(filepath.Join("testdata", "conversation_export.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("Markdown export differs from testdata/conversation_export.md:\n%s", got)
+	}
+}
+
+func TestExportCommandJSON(t *testing.T) {
+	m := newExportTestModel(t)
+	path := filepath.Join(t.TempDir(), "session.json")
+	m.handleExportCommand([]string{path})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var export conversationExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("export is not JSON: %v", err)
+	}
+	if export.Project != "export" || len(export.Messages) != 4 {
+		t.Errorf("export = %+v, want the project and its 4 messages", export)
+	}
+	if call := export.Messages[1].FunctionCalls; len(call) != 1 || call[0].Name != "ExecuteTestGroup" {
+		t.Errorf("tool calls = %+v, want the ExecuteTestGroup call", call)
+	}
+}
+
+func TestExportCommandDefaultPath(t *testing.T) {
+	m := newExportTestModel(t)
+	m.handleExportCommand(nil)
+
+	dir, err := storage.GetProjectPathByType("export-id", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "conversation_*.md"))
+	if len(matches) != 1 {
+		t.Fatalf("found %v in the project directory, want one timestamped export", matches)
+	}
+}
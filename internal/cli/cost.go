@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Octrafic/octrafic-cli/internal/llm/common"
+)
+
+// estimateCost returns what the tokens cost at the model's list price, and false when the
+// model has no known price (local models, new releases)
+func estimateCost(model string, inputTokens, outputTokens int64) (float64, bool) {
+	price, ok := common.LookupPrice(model)
+	if !ok {
+		return 0, false
+	}
+	return price.Cost(inputTokens, outputTokens), true
+}
+
+// handleCostCommand handles /cost, estimating the session's spend from its token counts
+func (m *TestUIModel) handleCostCommand() {
+	defer m.addMessage("")
+
+	if m.inputTokens == 0 && m.outputTokens == 0 {
+		m.addAgentMessage(m.subtleStyle.Render("No tokens used yet"))
+		return
+	}
+
+	tokens := fmt.Sprintf("↑%d ↓%d tokens with %s", m.inputTokens, m.outputTokens, m.llmModel)
+	cost, ok := estimateCost(m.llmModel, m.inputTokens, m.outputTokens)
+	if !ok {
+		m.addAgentMessage(m.agentStyle.Render("Estimated cost: unknown pricing"))
+		m.addMessage(m.subtleStyle.Render(tokens + "; no list price is known for this model"))
+		return
+	}
+
+	price, _ := common.LookupPrice(m.llmModel)
+	m.addAgentMessage(m.agentStyle.Render(fmt.Sprintf("Estimated cost: $%.4f", cost)))
+	m.addMessage(m.subtleStyle.Render(fmt.Sprintf("%s at $%s / $%s per million input / output tokens", tokens, formatPrice(price.Input), formatPrice(price.Output))))
+	m.addMessage(m.subtleStyle.Render("List prices; discounts, prompt caching and taxes are not included"))
+}
+
+// formatPrice renders a per-million price without trailing zeros, e.g. 3 or 0.15
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', -1, 64)
+}
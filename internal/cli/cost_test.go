@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+)
+
+func TestEstimateCost(t *testing.T) {
+	// claude-sonnet-4 lists at $3 input and $15 output per million tokens
+	cost, ok := estimateCost("claude-sonnet-4-20250514", 200_000, 10_000)
+	if !ok {
+		t.Fatal("estimateCost() found no price for claude-sonnet-4")
+	}
+	if want := 0.6 + 0.15; math.Abs(cost-want) > 1e-9 {
+		t.Errorf("estimateCost() = %v, want %v", cost, want)
+	}
+
+	if _, ok := estimateCost("llama3.1:8b", 1000, 1000); ok {
+		t.Error("estimateCost() priced a local model")
+	}
+}
+
+func TestHandleCostCommand(t *testing.T) {
+	m := NewTestUIModel("https://api.example.com", "", nil, &auth.NoAuth{}, "test")
+	m.llmModel = "gpt-4o-mini"
+	m.inputTokens = 1_000_000
+	m.outputTokens = 500_000
+
+	m.handleCostCommand()
+	if output := strings.Join(m.messages, "\n"); !strings.Contains(output, "$0.4500") {
+		t.Errorf("/cost output doesn't show $0.4500:\n%s", output)
+	}
+
+	m.messages = nil
+	m.llmModel = "my-local-model"
+	m.handleCostCommand()
+	if output := strings.Join(m.messages, "\n"); !strings.Contains(output, "unknown pricing") {
+		t.Errorf("/cost output for an unknown model doesn't say so:\n%s", output)
+	}
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultRequestDelay is the pause between requests in a test group unless /delay changes it
+const defaultRequestDelay = 100 * time.Millisecond
+
+// nextTestDelay returns the pause before the next request: the configured delay, moved up or
+// down at random by up to requestJitter percent of it
+func (m *TestUIModel) nextTestDelay() time.Duration {
+	delay := m.requestDelay
+	if spread := int64(delay) * int64(m.requestJitter) / 100; spread > 0 {
+		delay += time.Duration(rand.Int63n(2*spread+1) - spread)
+	}
+	return delay
+}
+
+// handleDelayCommand handles /delay [duration] [jitter%], which shows or sets the pause between requests
+func (m *TestUIModel) handleDelayCommand(args []string) {
+	defer m.addMessage("")
+
+	if len(args) == 0 {
+		m.addAgentMessage(m.subtleStyle.Render("Delay between requests: " + m.describeDelay()))
+		return
+	}
+
+	usage := m.errorStyle.Render("Usage: /delay <duration> [jitter%]") + " " +
+		m.subtleStyle.Render("(e.g. /delay 500ms or /delay 1s 20%)")
+	if len(args) > 2 {
+		m.addAgentMessage(usage)
+		return
+	}
+
+	delay, err := parseDelay(args[0])
+	if err != nil {
+		m.addAgentMessage(usage)
+		return
+	}
+	jitter := 0
+	if len(args) == 2 {
+		jitter, err = strconv.Atoi(strings.TrimSuffix(args[1], "%"))
+		if err != nil || jitter < 0 || jitter > 100 {
+			m.addAgentMessage(m.errorStyle.Render("Jitter must be a percentage between 0% and 100%"))
+			return
+		}
+	}
+
+	m.requestDelay = delay
+	m.requestJitter = jitter
+	m.addAgentMessage(m.successStyle.Render("✓ Delay between requests: " + m.describeDelay()))
+}
+
+// parseDelay reads a /delay duration; a bare 0 turns the pause off
+func parseDelay(value string) (time.Duration, error) {
+	if value == "0" {
+		return 0, nil
+	}
+	delay, err := time.ParseDuration(value)
+	if err != nil || delay < 0 {
+		return 0, fmt.Errorf("invalid delay %q", value)
+	}
+	return delay, nil
+}
+
+// describeDelay renders the current pause, e.g. "500ms ± 20%"
+func (m *TestUIModel) describeDelay() string {
+	if m.requestJitter == 0 {
+		return m.requestDelay.String()
+	}
+	return fmt.Sprintf("%s ± %d%%", m.requestDelay, m.requestJitter)
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+)
+
+func TestDelayCommand(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantDelay  time.Duration
+		wantJitter int
+		wantOutput string
+	}{
+		{"/delay", defaultRequestDelay, 0, "Delay between requests: 100ms"},
+		{"/delay 500ms", 500 * time.Millisecond, 0, "✓ Delay between requests: 500ms"},
+		{"/delay 1s 20%", time.Second, 20, "✓ Delay between requests: 1s ± 20%"},
+		{"/delay 2s 15", 2 * time.Second, 15, "✓ Delay between requests: 2s ± 15%"},
+		{"/delay 0", 0, 0, "✓ Delay between requests: 0s"},
+		{"/delay soon", defaultRequestDelay, 0, "Usage: /delay <duration> [jitter%]"},
+		{"/delay -1s", defaultRequestDelay, 0, "Usage: /delay <duration> [jitter%]"},
+		{"/delay 1s 20% extra", defaultRequestDelay, 0, "Usage: /delay <duration> [jitter%]"},
+		{"/delay 1s 150%", defaultRequestDelay, 0, "Jitter must be a percentage between 0% and 100%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			m := NewTestUIModel("https://api.example.com", "", nil, &auth.NoAuth{}, "test")
+			m.handleDelayCommand(strings.Fields(tt.input)[1:])
+
+			if m.requestDelay != tt.wantDelay || m.requestJitter != tt.wantJitter {
+				t.Errorf("delay = %s ± %d%%, want %s ± %d%%", m.requestDelay, m.requestJitter, tt.wantDelay, tt.wantJitter)
+			}
+			if output := strings.Join(m.messages, "\n"); !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("/delay printed:\n%s\nwant %q", output, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestNextTestDelay(t *testing.T) {
+	m := NewTestUIModel("https://api.example.com", "", nil, &auth.NoAuth{}, "test")
+	m.requestDelay = time.Second
+	if got := m.nextTestDelay(); got != time.Second {
+		t.Errorf("without jitter nextTestDelay() = %s, want 1s", got)
+	}
+
+	m.requestJitter = 20
+	varied := false
+	for range 200 {
+		got := m.nextTestDelay()
+		if got < 800*time.Millisecond || got > 1200*time.Millisecond {
+			t.Fatalf("nextTestDelay() = %s, want within 1s ± 20%%", got)
+		}
+		varied = varied || got != time.Second
+	}
+	if !varied {
+		t.Error("nextTestDelay() never moved away from 1s with 20% jitter")
+	}
+
+	m.requestDelay = 0
+	if got := m.nextTestDelay(); got != 0 {
+		t.Errorf("with the delay off nextTestDelay() = %s, want 0", got)
+	}
+}
+
+func TestRunNextTestWaitsForDelay(t *testing.T) {
+	m := NewTestUIModel("https://api.example.com", "", nil, &auth.NoAuth{}, "test")
+	m.requestDelay = 50 * time.Millisecond
+
+	start := time.Now()
+	if _, ok := m.runNextTest()().(runNextTestMsg); !ok {
+		t.Fatal("runNextTest() didn't schedule the next test")
+	}
+	if elapsed := time.Since(start); elapsed < m.requestDelay {
+		t.Errorf("next test ran after %s, want at least %s", elapsed, m.requestDelay)
+	}
+}
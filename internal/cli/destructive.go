@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultDestructiveMethods are the HTTP methods that always ask before being sent, unless
+// destructive_methods in the config lists others
+var defaultDestructiveMethods = []string{"DELETE"}
+
+// isDestructiveMethod reports whether requests with method may change data and must be confirmed
+func (m *TestUIModel) isDestructiveMethod(method string) bool {
+	return slices.ContainsFunc(m.destructiveMethods, func(d string) bool { return strings.EqualFold(d, method) })
+}
+
+// destructiveRequest returns the request a tool call would send, e.g. "DELETE /users/42", when its
+// method is destructive. Such calls are confirmed even in auto-execute mode.
+func (m *TestUIModel) destructiveRequest(toolCall agent.ToolCall) (string, bool) {
+	method, _ := toolCall.Arguments["method"].(string)
+	if method == "" || !m.isDestructiveMethod(method) {
+		return "", false
+	}
+	endpoint, _ := toolCall.Arguments["endpoint"].(string)
+	return strings.ToUpper(method) + " " + endpoint, true
+}
+
+// selectedDestructiveTests returns the selected tests of the plan that would send destructive
+// requests, e.g. "DELETE /users/42"
+func (m *TestUIModel) selectedDestructiveTests() []string {
+	var requests []string
+	for _, test := range m.tests {
+		if test.Selected && m.isDestructiveMethod(test.Method) {
+			requests = append(requests, test.Method+" "+test.Endpoint)
+		}
+	}
+	return requests
+}
+
+// destructiveWarning renders the banner shown above a confirmation or test plan that would send
+// destructive requests to the API
+func (m *TestUIModel) destructiveWarning(requests ...string) string {
+	style := lipgloss.NewStyle().Foreground(Theme.Error).Bold(true)
+	target := m.baseURL
+	if target == "" {
+		target = "the API"
+	}
+	if len(requests) == 1 {
+		return style.Render(fmt.Sprintf("⚠ %s may change or delete data on %s", requests[0], target)) + "\n"
+	}
+	return style.Render(fmt.Sprintf("⚠ %d requests may change or delete data on %s", len(requests), target)) + "\n"
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDestructiveRequestsAskInAutoMode(t *testing.T) {
+	tests := []struct {
+		method string
+		want   AgentState
+	}{
+		{"GET", StateThinking},
+		{"delete", StateAskingConfirmation},
+	}
+	for _, tt := range tests {
+		m := NewTestUIModel("http://localhost", "", nil, &auth.NoAuth{}, "test")
+		m.executionMode = ModeAutoExecute
+		m.destructiveMethods = defaultDestructiveMethods
+		m.streamedToolCalls = []agent.ToolCall{{
+			ID:        "call-1",
+			Name:      "compare_representations",
+			Arguments: map[string]any{"method": tt.method, "endpoint": "/users/42", "accept": []any{"application/json"}},
+		}}
+
+		handleProcessToolCalls(m, processToolCallsMsg{})
+		if m.agentState != tt.want {
+			t.Fatalf("%s in auto mode: state %v, want %v", tt.method, m.agentState, tt.want)
+		}
+		if tt.want != StateAskingConfirmation {
+			continue
+		}
+
+		// Approving sends the call through the usual dispatch
+		_, cmd := handleConfirmationState(m, tea.KeyMsg{Type: tea.KeyEnter})
+		if m.agentState != StateThinking || m.currentTestToolName != "compare_representations" || cmd == nil {
+			t.Errorf("after Yes: state %v, tool %q, want compare_representations running", m.agentState, m.currentTestToolName)
+		}
+		if m.streamedToolCalls != nil || m.streamedToolConfirmed {
+			t.Error("the approved call is still pending")
+		}
+	}
+}
+
+func TestDestructiveMethodsFromConfig(t *testing.T) {
+	m := NewTestUIModel("http://localhost", "", nil, &auth.NoAuth{}, "test")
+	m.destructiveMethods = []string{"DELETE", "PUT"}
+
+	if request, ok := m.destructiveRequest(agent.ToolCall{Arguments: map[string]any{"method": "put", "endpoint": "/users/42"}}); !ok || request != "PUT /users/42" {
+		t.Errorf("destructiveRequest(PUT) = %q, %v, want PUT /users/42", request, ok)
+	}
+	if _, ok := m.destructiveRequest(agent.ToolCall{Arguments: map[string]any{"method": "POST"}}); ok {
+		t.Error("POST should not be destructive")
+	}
+	if _, ok := m.destructiveRequest(agent.ToolCall{Name: "ListEndpoints"}); ok {
+		t.Error("a tool call without a method should not be destructive")
+	}
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/reporter"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// parseFinding validates the arguments of a RecordFinding tool call
+func parseFinding(args map[string]any) (reporter.Finding, error) {
+	severity, _ := args["severity"].(string)
+	severity = strings.ToLower(strings.TrimSpace(severity))
+	if !slices.Contains(reporter.Severities, severity) {
+		return reporter.Finding{}, fmt.Errorf("invalid severity %q (use one of: %s)", severity, strings.Join(reporter.Severities, ", "))
+	}
+	description, _ := args["description"].(string)
+	if description = strings.TrimSpace(description); description == "" {
+		return reporter.Finding{}, fmt.Errorf("missing required parameter: description")
+	}
+	endpoint, _ := args["endpoint"].(string)
+	return reporter.Finding{
+		Severity:    severity,
+		Endpoint:    strings.TrimSpace(endpoint),
+		Description: description,
+	}, nil
+}
+
+// addFinding appends a finding to the session list and shows it in the chat
+func (m *TestUIModel) addFinding(f reporter.Finding) {
+	m.findings = append(m.findings, f)
+	m.addMessage(m.findingLine(f))
+	m.addMessage("")
+}
+
+// showFindings handles /findings, listing the session's findings by severity
+func (m *TestUIModel) showFindings() {
+	defer m.addMessage("")
+
+	if len(m.findings) == 0 {
+		m.addAgentMessage(m.subtleStyle.Render("No findings recorded yet"))
+		return
+	}
+
+	m.addAgentMessage(m.agentStyle.Render(fmt.Sprintf("Findings (%d):", len(m.findings))))
+	for _, f := range reporter.SortFindings(m.findings) {
+		m.addMessage(m.findingLine(f))
+	}
+}
+
+// findingLine renders a finding as "  ● HIGH POST /users - description"
+func (m *TestUIModel) findingLine(f reporter.Finding) string {
+	style := m.subtleStyle
+	switch f.Severity {
+	case "critical", "high":
+		style = m.errorStyle
+	case "medium":
+		style = lipgloss.NewStyle().Foreground(Theme.Warning)
+	}
+	line := "  " + style.Render("● "+strings.ToUpper(f.Severity))
+	if f.Endpoint != "" {
+		line += " " + f.Endpoint
+	}
+	return line + " - " + f.Description
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/core/reporter"
+)
+
+// recordFinding runs a RecordFinding tool call and hands its result to the model
+func recordFinding(t *testing.T, m *TestUIModel, args map[string]any) error {
+	t.Helper()
+	msg, ok := m.executeTool(agent.ToolCall{Name: "RecordFinding", Arguments: args})().(toolResultMsg)
+	if !ok {
+		t.Fatal("RecordFinding didn't return a tool result")
+	}
+	if msg.err == nil {
+		m.handleToolResult(msg.toolName, msg.toolID, msg.result)
+	}
+	return msg.err
+}
+
+func TestRecordFinding(t *testing.T) {
+	m := NewTestUIModel("https://api.example.com", "", nil, &auth.NoAuth{}, "test")
+
+	if err := recordFinding(t, m, map[string]any{"severity": " HIGH ", "endpoint": "POST /users", "description": "Accepts an empty email"}); err != nil {
+		t.Fatalf("RecordFinding error = %v", err)
+	}
+	want := reporter.Finding{Severity: "high", Endpoint: "POST /users", Description: "Accepts an empty email"}
+	if len(m.findings) != 1 || m.findings[0] != want {
+		t.Fatalf("findings = %+v, want [%+v]", m.findings, want)
+	}
+	if output := strings.Join(m.messages, "\n"); !strings.Contains(output, "HIGH") || !strings.Contains(output, "POST /users - Accepts an empty email") {
+		t.Errorf("chat doesn't show the finding:\n%s", output)
+	}
+
+	for _, args := range []map[string]any{
+		{"severity": "urgent", "description": "Slow"},
+		{"severity": "low", "description": "  "},
+	} {
+		if err := recordFinding(t, m, args); err == nil {
+			t.Errorf("RecordFinding(%v) error = nil, want an error", args)
+		}
+	}
+	if len(m.findings) != 1 {
+		t.Errorf("invalid findings were recorded: %+v", m.findings)
+	}
+}
+
+func TestFindingsCommand(t *testing.T) {
+	m := NewTestUIModel("https://api.example.com", "", nil, &auth.NoAuth{}, "test")
+	handleSlashCommands(m, "/findings")
+	if output := strings.Join(m.messages, "\n"); !strings.Contains(output, "No findings recorded yet") {
+		t.Errorf("/findings without findings printed:\n%s", output)
+	}
+
+	m.messages = nil
+	m.findings = []reporter.Finding{
+		{Severity: "low", Description: "Missing cache headers"},
+		{Severity: "critical", Endpoint: "GET /admin", Description: "Reachable without credentials"},
+	}
+	handleSlashCommands(m, "/findings")
+	output := strings.Join(m.messages, "\n")
+	if !strings.Contains(output, "Findings (2):") {
+		t.Errorf("/findings doesn't count the findings:\n%s", output)
+	}
+	critical, low := strings.Index(output, "Reachable without credentials"), strings.Index(output, "Missing cache headers")
+	if critical < 0 || low < 0 || critical > low {
+		t.Errorf("/findings doesn't list critical findings before low ones:\n%s", output)
+	}
+}
+
+func TestReportIncludesFindings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	m := NewTestUIModel("https://api.example.com", "", nil, &auth.NoAuth{}, "test")
+	m.findings = []reporter.Finding{{Severity: "medium", Endpoint: "GET /users", Description: "Returns 500 for page=0"}}
+
+	msg, ok := m.executeTool(agent.ToolCall{Name: "GenerateReport", Arguments: map[string]any{
+		"report_content": "# API report",
+		"format":         "html",
+		"file_name":      "findings",
+	}})().(toolResultMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("GenerateReport = %+v", msg)
+	}
+
+	data, err := os.ReadFile(msg.result.(map[string]any)["file_path"].(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Issues</h2>", "GET /users", "Returns 500 for page=0"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("report is missing %q", want)
+		}
+	}
+}
@@ -2,13 +2,19 @@ package cli
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Octrafic/octrafic-cli/internal/agents"
 	"github.com/Octrafic/octrafic-cli/internal/core/parser"
 	"github.com/Octrafic/octrafic-cli/internal/core/reporter"
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
 	"github.com/Octrafic/octrafic-cli/internal/infra/logger"
 	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"github.com/Octrafic/octrafic-cli/internal/llm/common"
 	"maps"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -24,9 +30,11 @@ type ToolCallData struct {
 
 type runNextTestMsg struct{}
 
-func runNextTest() tea.Cmd {
+// runNextTest schedules the next queued test after the configured pause between requests
+func (m *TestUIModel) runNextTest() tea.Cmd {
+	delay := m.nextTestDelay()
 	return func() tea.Msg {
-		time.Sleep(100 * time.Millisecond)
+		time.Sleep(delay)
 		return runNextTestMsg{}
 	}
 }
@@ -49,13 +57,19 @@ func (m *TestUIModel) sendChatMessage(_ string) tea.Cmd {
 
 			if m.localAgent == nil {
 				var err error
-				m.localAgent, err = agent.NewAgent(m.baseURL)
+				m.localAgent, err = agent.NewAgent(m.baseURL, m.currentProject.ModelOverride())
 				if err != nil {
 					streamChan <- "\x00ERROR:Failed to initialize local agent: " + err.Error()
 					return
 				}
 			}
 
+			// Show retries of rate-limited requests; reset before streamChan is closed
+			m.localAgent.SetRetryNotifier(func(event common.RetryEvent) {
+				streamChan <- "\x00STATUS:" + event.String()
+			})
+			defer m.localAgent.SetRetryNotifier(nil)
+
 			endpointsList := ""
 			if m.currentProject != nil {
 				if endpoints, err := m.loadProjectEndpoints(); err == nil && len(endpoints) > 0 {
@@ -63,7 +77,12 @@ func (m *TestUIModel) sendChatMessage(_ string) tea.Cmd {
 				}
 			}
 
-			response, err := m.localAgent.ChatStream(m.conversationHistory, m.thinkingEnabled,
+			history := agent.TrimHistory(m.conversationHistory, m.maxTurns)
+			if len(history) < len(m.conversationHistory) {
+				logger.Debug("Trimmed conversation", zap.Int("from", len(m.conversationHistory)), zap.Int("to", len(history)))
+			}
+
+			response, err := m.localAgent.ChatStream(history, m.thinkingEnabled,
 				func(chunk string, isThought bool) {
 					// Send chunk with isThought flag
 					if isThought {
@@ -168,6 +187,21 @@ func (m *TestUIModel) executeTool(toolCall agent.ToolCall) tea.Cmd {
 							if len(ep.Responses) > 0 {
 								result["responses"] = ep.Responses
 							}
+							if ep.Deprecated {
+								result["deprecated"] = true
+							}
+							if len(ep.Examples) > 0 {
+								result["examples"] = ep.Examples
+							}
+							if len(ep.Scopes) > 0 {
+								result["scopes"] = ep.Scopes
+							}
+							if len(ep.ContentTypes) > 0 {
+								result["content_types"] = ep.ContentTypes
+							}
+							if ep.XMLRoot != nil {
+								result["xml_root"] = ep.XMLRoot
+							}
 							results = append(results, result)
 							break
 						}
@@ -209,10 +243,17 @@ func (m *TestUIModel) executeTool(toolCall agent.ToolCall) tea.Cmd {
 			if b, ok := toolCall.Arguments["body"]; ok {
 				body = b
 			}
+			if form := tester.ParseMultipart(toolCall.Arguments["multipart"]); form != nil {
+				body = form
+			}
 
-			result, err := m.testExecutor.ExecuteTest(method, endpoint, headers, body)
+			requestAuth := m.endpointAuth(method, endpoint)
+			result, err := m.testExecutor.ExecuteRequest(tester.Request{
+				Method: method, Endpoint: endpoint, Headers: headers, Body: body, Auth: requestAuth,
+			})
 
 			if err != nil {
+				m.recordHistory(method, endpoint, 0, 0, false, err)
 				return toolResultMsg{
 					toolID:   toolCall.ID,
 					toolName: toolCall.Name,
@@ -225,21 +266,158 @@ func (m *TestUIModel) executeTool(toolCall agent.ToolCall) tea.Cmd {
 				}
 			}
 
+			m.recordHistory(method, endpoint, result.StatusCode, result.Duration, result.Passed(), nil)
+			resultMap := map[string]any{
+				"method":         method,
+				"endpoint":       endpoint,
+				"status_code":    result.StatusCode,
+				"duration_ms":    result.Duration.Milliseconds(),
+				"request_bytes":  result.RequestBytes,
+				"response_bytes": result.ResponseBytes,
+			}
+			m.modelBody(resultMap, result.ResponseBody)
+			if m.authRejected(result.StatusCode, true) {
+				resultMap["auth_likely_required"] = true
+			}
+			if missing := m.missingScopes(requestAuth, method, endpoint); len(missing) > 0 {
+				resultMap["missing_scopes"] = missing
+			}
+			if result.XMLError != nil {
+				resultMap["xml_error"] = result.XMLError.Error()
+			}
+			return toolResultMsg{
+				toolID:   toolCall.ID,
+				toolName: toolCall.Name,
+				result:   resultMap,
+				err:      nil,
+			}
+		}
+
+		if toolCall.Name == "sample_endpoint" {
+			endpoint, _ := toolCall.Arguments["endpoint"].(string)
+			if endpoint == "" {
+				return toolResultMsg{
+					toolID:   toolCall.ID,
+					toolName: toolCall.Name,
+					err:      fmt.Errorf("missing required parameter: endpoint"),
+				}
+			}
+
+			// Sampling only ever reads: the request is always a GET, whatever the model asked for
+			result, err := m.testExecutor.ExecuteTest(http.MethodGet, endpoint, nil, nil)
+			if err != nil {
+				return toolResultMsg{
+					toolID:   toolCall.ID,
+					toolName: toolCall.Name,
+					err:      fmt.Errorf("failed to sample %s: %w", endpoint, err),
+				}
+			}
+
+			sample, truncated := tester.SampleBody(result.ResponseBody)
+			resultMap := map[string]any{
+				"method":         http.MethodGet,
+				"endpoint":       endpoint,
+				"status_code":    result.StatusCode,
+				"sample":         sample,
+				"truncated":      truncated,
+				"response_bytes": result.ResponseBytes,
+			}
+			if result.ContentType != "" {
+				resultMap["content_type"] = result.ContentType
+			}
+			if m.authRejected(result.StatusCode, true) {
+				resultMap["auth_likely_required"] = true
+			}
+			return toolResultMsg{
+				toolID:   toolCall.ID,
+				toolName: toolCall.Name,
+				result:   resultMap,
+			}
+		}
+
+		if toolCall.Name == "compare_representations" {
+			method, _ := toolCall.Arguments["method"].(string)
+			endpoint, _ := toolCall.Arguments["endpoint"].(string)
+			var accepts []string
+			if list, ok := toolCall.Arguments["accept"].([]any); ok {
+				for _, v := range list {
+					if accept, ok := v.(string); ok && accept != "" {
+						accepts = append(accepts, accept)
+					}
+				}
+			}
+			if endpoint == "" || len(accepts) == 0 {
+				return toolResultMsg{
+					toolID:   toolCall.ID,
+					toolName: toolCall.Name,
+					err:      fmt.Errorf("missing required parameters: endpoint and accept"),
+				}
+			}
+			if method == "" {
+				method = http.MethodGet
+			}
+			method = strings.ToUpper(method)
+			if method != http.MethodGet && method != http.MethodHead {
+				return toolResultMsg{
+					toolID:   toolCall.ID,
+					toolName: toolCall.Name,
+					err:      fmt.Errorf("compare_representations only sends GET or HEAD requests, got %s", method),
+				}
+			}
+
+			headers := make(map[string]string)
+			if h, ok := toolCall.Arguments["headers"].(map[string]any); ok {
+				for k, v := range h {
+					if vs, ok := v.(string); ok {
+						headers[k] = vs
+					}
+				}
+			}
+
+			negotiation := m.testExecutor.CompareRepresentations(method, endpoint, headers, nil, accepts)
+			representations := make([]map[string]any, 0, len(negotiation.Representations))
+			for _, r := range negotiation.Representations {
+				entry := map[string]any{"accept": r.Accept}
+				if r.Error != nil {
+					entry["error"] = r.Error.Error()
+				} else {
+					entry["status_code"] = r.StatusCode
+					entry["content_type"] = r.ContentType
+					entry["matches_accept"] = r.MatchesAccept
+					m.modelBody(entry, r.Body)
+				}
+				representations = append(representations, entry)
+			}
+
 			return toolResultMsg{
 				toolID:   toolCall.ID,
 				toolName: toolCall.Name,
 				result: map[string]any{
-					"method":        method,
-					"endpoint":      endpoint,
-					"status_code":   result.StatusCode,
-					"response_body": result.ResponseBody,
-					"duration_ms":   result.Duration.Milliseconds(),
+					"method":          method,
+					"endpoint":        endpoint,
+					"representations": representations,
+					"content_types":   negotiation.ContentTypes,
+					"bodies_differ":   negotiation.BodiesDiffer,
 				},
-				err: nil,
+			}
+		}
+
+		if toolCall.Name == "RecordFinding" {
+			finding, err := parseFinding(toolCall.Arguments)
+			return toolResultMsg{
+				toolID:   toolCall.ID,
+				toolName: toolCall.Name,
+				result:   finding,
+				err:      err,
 			}
 		}
 
 		if toolCall.Name == "GenerateReport" {
+			fileName, _ := toolCall.Arguments["file_name"].(string)
+			if format, _ := toolCall.Arguments["format"].(string); strings.EqualFold(format, "junit") {
+				return m.generateJUnitReport(toolCall, fileName)
+			}
+
 			reportContent, _ := toolCall.Arguments["report_content"].(string)
 			if reportContent == "" {
 				return toolResultMsg{
@@ -249,9 +427,23 @@ func (m *TestUIModel) executeTool(toolCall agent.ToolCall) tea.Cmd {
 				}
 			}
 
-			fileName, _ := toolCall.Arguments["file_name"].(string)
+			reportContent += reporter.FindingsSection(m.findings)
+			reportContent += reporter.CostSection(common.SessionUsage())
+
+			generate := reporter.GeneratePDF
+			switch format, _ := toolCall.Arguments["format"].(string); strings.ToLower(format) {
+			case "", "pdf":
+			case "html":
+				generate = reporter.GenerateHTML
+			default:
+				return toolResultMsg{
+					toolID:   toolCall.ID,
+					toolName: toolCall.Name,
+					err:      fmt.Errorf("unsupported report format %q (use pdf, html or junit)", format),
+				}
+			}
 
-			pdfPath, err := reporter.GeneratePDF(reportContent, fileName)
+			reportPath, err := generate(reportContent, fileName)
 			if err != nil {
 				return toolResultMsg{
 					toolID:   toolCall.ID,
@@ -266,7 +458,7 @@ func (m *TestUIModel) executeTool(toolCall agent.ToolCall) tea.Cmd {
 				toolName: toolCall.Name,
 				result: map[string]any{
 					"status":    "success",
-					"file_path": pdfPath,
+					"file_path": reportPath,
 				},
 				err: nil,
 			}
@@ -330,6 +522,8 @@ func (m *TestUIModel) handleToolResult(toolName string, toolID string, result an
 			statusCode, _ := resultMap["status_code"].(int)
 			responseBody, _ := resultMap["response_body"].(string)
 			durationMs, _ := resultMap["duration_ms"].(int64)
+			requestBytes, _ := resultMap["request_bytes"].(int)
+			responseBytes, _ := resultMap["response_bytes"].(int)
 
 			methodStyle, ok := m.methodStyles[method]
 			if !ok {
@@ -337,23 +531,25 @@ func (m *TestUIModel) handleToolResult(toolName string, toolID string, result an
 			}
 			methodFormatted := methodStyle.Render(method)
 
-			statusStyle := m.successStyle
-			statusIcon := "✓"
-			if statusCode >= 400 {
-				statusStyle = m.errorStyle
-				statusIcon = "✗"
-			}
+			statusIcon, statusStyle := statusClassIndicator(statusCode)
 
 			m.addMessage("")
 			m.addMessage(statusStyle.Render(statusIcon) + " " + methodFormatted + " " + endpoint)
-			m.addMessage(m.subtleStyle.Render(fmt.Sprintf("   Status: %d | Duration: %dms", statusCode, durationMs)))
+			m.addMessage(m.subtleStyle.Render(fmt.Sprintf("   Status: %d | Duration: %dms | %s", statusCode, durationMs, formatSizes(requestBytes, responseBytes))))
 
 			if len(responseBody) > 0 {
-				preview := responseBody
-				if len(preview) > 200 {
-					preview = preview[:200] + "..."
-				}
-				m.addMessage(m.subtleStyle.Render("   Response: " + preview))
+				m.addMessage(m.subtleStyle.Render("   Response: " + m.displayBody(responseBody)))
+			}
+			if curl := m.testExecutor.LastCurl(true); curl != "" {
+				m.addMessage(m.subtleStyle.Render("   " + curl))
+			}
+			if missing, _ := resultMap["missing_scopes"].([]string); len(missing) > 0 {
+				m.addMessage(lipgloss.NewStyle().Foreground(Theme.Warning).Render(
+					"   ⚠ Token lacks required scope(s): " + strings.Join(missing, ", ")))
+			}
+			if authLikely, _ := resultMap["auth_likely_required"].(bool); authLikely {
+				m.authRejectedTests = []map[string]any{m.rejectedToolTest()}
+				m.showAuthHint(1)
 			}
 
 			if toolID != "" {
@@ -388,6 +584,8 @@ func (m *TestUIModel) handleToolResult(toolName string, toolID string, result an
 				endpoint, _ := testResult["endpoint"].(string)
 				statusCode, _ := testResult["status_code"].(int)
 				durationMs, _ := testResult["duration_ms"].(int64)
+				requestBytes, _ := testResult["request_bytes"].(int)
+				responseBytes, _ := testResult["response_bytes"].(int)
 				requiresAuth := false
 				if ra, ok := testResult["requires_auth"].(bool); ok {
 					requiresAuth = ra
@@ -405,16 +603,11 @@ func (m *TestUIModel) handleToolResult(toolName string, toolID string, result an
 					authIndicator = " " + lipgloss.NewStyle().Foreground(Theme.Warning).Render("• Auth")
 				}
 
-				statusStyle := m.successStyle
-				statusIcon := "✓"
-				if statusCode >= 400 {
-					statusStyle = m.errorStyle
-					statusIcon = "✗"
-				}
+				statusIcon, statusStyle := statusClassIndicator(statusCode)
 
 				m.addMessage("")
 				m.addMessage(statusStyle.Render(statusIcon) + " " + methodFormatted + " " + endpoint + authIndicator)
-				m.addMessage(m.subtleStyle.Render(fmt.Sprintf("   Status: %d | Duration: %dms", statusCode, durationMs)))
+				m.addMessage(m.subtleStyle.Render(fmt.Sprintf("   Status: %d | Duration: %dms | %s", statusCode, durationMs, formatSizes(requestBytes, responseBytes))))
 			}
 
 			// Add tool result to conversation history as function response
@@ -460,6 +653,89 @@ func (m *TestUIModel) handleToolResult(toolName string, toolID string, result an
 		return nil // No tool_use, so don't send response back
 	}
 
+	if toolName == "sample_endpoint" {
+		if resultMap, ok := result.(map[string]any); ok {
+			statusCode, _ := resultMap["status_code"].(int)
+			responseBytes, _ := resultMap["response_bytes"].(int)
+			statusIcon, statusStyle := statusClassIndicator(statusCode)
+			m.addMessage(m.subtleStyle.Render(fmt.Sprintf("    %s Status: %d | %s",
+				statusStyle.Render(statusIcon), statusCode, formatSizes(0, responseBytes))))
+			if authLikely, _ := resultMap["auth_likely_required"].(bool); authLikely {
+				m.authRejectedTests = []map[string]any{m.rejectedToolTest()}
+				m.showAuthHint(1)
+			}
+
+			if toolID != "" {
+				m.conversationHistory = append(m.conversationHistory, agent.ChatMessage{
+					Role: "user",
+					FunctionResponse: &agent.FunctionResponseData{
+						ID:       toolID,
+						Name:     "sample_endpoint",
+						Response: resultMap,
+					},
+				})
+				return m.sendChatMessage("")
+			}
+			return nil
+		}
+	}
+
+	if toolName == "compare_representations" {
+		if resultMap, ok := result.(map[string]any); ok {
+			representations, _ := resultMap["representations"].([]map[string]any)
+			for _, r := range representations {
+				accept, _ := r["accept"].(string)
+				if errMsg, ok := r["error"].(string); ok {
+					m.addMessage(m.subtleStyle.Render(fmt.Sprintf("    ✗ Accept: %s | Error: %s", accept, errMsg)))
+					continue
+				}
+				statusCode, _ := r["status_code"].(int)
+				contentType, _ := r["content_type"].(string)
+				statusIcon, statusStyle := statusClassIndicator(statusCode)
+				line := fmt.Sprintf("    %s Accept: %s → %d %s", statusStyle.Render(statusIcon), accept, statusCode, contentType)
+				if matches, _ := r["matches_accept"].(bool); !matches {
+					line += " (not the requested type)"
+				}
+				m.addMessage(m.subtleStyle.Render(line))
+			}
+			if differ, _ := resultMap["bodies_differ"].(bool); differ {
+				m.addMessage(m.subtleStyle.Render("    Bodies differ between representations"))
+			}
+
+			if toolID != "" {
+				m.conversationHistory = append(m.conversationHistory, agent.ChatMessage{
+					Role: "user",
+					FunctionResponse: &agent.FunctionResponseData{
+						ID:       toolID,
+						Name:     "compare_representations",
+						Response: resultMap,
+					},
+				})
+				return m.sendChatMessage("")
+			}
+			return nil
+		}
+	}
+
+	if toolName == "RecordFinding" {
+		if finding, ok := result.(reporter.Finding); ok {
+			m.addFinding(finding)
+
+			if toolID != "" {
+				m.conversationHistory = append(m.conversationHistory, agent.ChatMessage{
+					Role: "user",
+					FunctionResponse: &agent.FunctionResponseData{
+						ID:       toolID,
+						Name:     "RecordFinding",
+						Response: map[string]any{"status": "recorded", "total_findings": len(m.findings)},
+					},
+				})
+				return m.sendChatMessage("")
+			}
+			return nil
+		}
+	}
+
 	if toolName == "GenerateReport" {
 		if resultMap, ok := result.(map[string]any); ok {
 			filePath, _ := resultMap["file_path"].(string)
@@ -468,6 +744,11 @@ func (m *TestUIModel) handleToolResult(toolName string, toolID string, result an
 			m.addMessage(m.successStyle.Render("✓ Report generated"))
 			m.addMessage(m.subtleStyle.Render("   " + filePath))
 
+			m.lastArtifactPath = filePath
+			if m.openReports {
+				m.openArtifact(filePath)
+			}
+
 			if toolID != "" {
 				m.conversationHistory = append(m.conversationHistory, agent.ChatMessage{
 					Role: "user",
@@ -511,6 +792,93 @@ func (m *TestUIModel) handleToolResult(toolName string, toolID string, result an
 	return nil
 }
 
+// endpointCache holds the current project's endpoints. It is shared by copies of the model and
+// by tool calls running in the background, hence the lock.
+type endpointCache struct {
+	mu        sync.Mutex
+	projectID string
+	endpoints []parser.Endpoint
+}
+
+// loadProjectEndpoints returns the current project's endpoints, reading them from disk only the
+// first time in a session
 func (m *TestUIModel) loadProjectEndpoints() ([]parser.Endpoint, error) {
-	return storage.LoadEndpoints(m.currentProject.ID, m.currentProject.IsTemporary)
+	cache := m.endpoints
+	if cache == nil {
+		return storage.LoadEndpoints(m.currentProject.ID, m.currentProject.IsTemporary)
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.endpoints != nil && cache.projectID == m.currentProject.ID {
+		return cache.endpoints, nil
+	}
+	endpoints, err := storage.LoadEndpoints(m.currentProject.ID, m.currentProject.IsTemporary)
+	if err != nil {
+		return nil, err
+	}
+	cache.projectID, cache.endpoints = m.currentProject.ID, endpoints
+	return endpoints, nil
+}
+
+// shutdown cancels in-flight agent requests, prunes this run's temporary project unless
+// keep_temp_projects is set, and flushes logs
+func (m *TestUIModel) shutdown() {
+	if m.localAgent != nil {
+		if err := m.localAgent.Close(); err != nil {
+			logger.Warn("Failed to close agent", logger.Err(err))
+		}
+		m.localAgent = nil
+	}
+
+	if m.currentProject != nil && m.currentProject.IsTemporary && !m.keepTempProject {
+		if err := storage.DeleteProject(m.currentProject); err != nil {
+			logger.Warn("Failed to remove temporary project", logger.Err(err))
+		}
+	}
+
+	logger.Close()
+}
+
+// openArtifact opens a generated file in the default viewer; headless environments are a no-op
+func (m *TestUIModel) openArtifact(path string) {
+	if err := reporter.OpenFile(path); err != nil {
+		if !errors.Is(err, reporter.ErrNoViewer) {
+			m.addMessage(m.errorStyle.Render("   Failed to open " + path + ": " + err.Error()))
+		}
+		logger.Debug("Could not open artifact", logger.String("path", path), logger.Err(err))
+	}
+}
+
+// generateJUnitReport exports every test run this session as JUnit XML; the Markdown content of
+// the GenerateReport call isn't used
+func (m *TestUIModel) generateJUnitReport(toolCall agent.ToolCall, fileName string) toolResultMsg {
+	if len(m.testResults) == 0 {
+		return toolResultMsg{
+			toolID:   toolCall.ID,
+			toolName: toolCall.Name,
+			err:      fmt.Errorf("no tests have been run this session"),
+		}
+	}
+
+	reportPath, err := reporter.GenerateJUnit(m.testResults, fileName)
+	if err != nil {
+		return toolResultMsg{toolID: toolCall.ID, toolName: toolCall.Name, err: err}
+	}
+
+	failed := 0
+	for _, r := range m.testResults {
+		if r.Failed() {
+			failed++
+		}
+	}
+	return toolResultMsg{
+		toolID:   toolCall.ID,
+		toolName: toolCall.Name,
+		result: map[string]any{
+			"status":    "success",
+			"file_path": reportPath,
+			"tests":     len(m.testResults),
+			"failed":    failed,
+		},
+	}
 }
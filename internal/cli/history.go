@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/Octrafic/octrafic-cli/internal/infra/logger"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sparkBlocks are the bar glyphs used to draw latency sparklines, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// historyPath maps a concrete request path to the spec path it belongs to (e.g. /users/42 → /users/{id})
+func (m *TestUIModel) historyPath(method, endpoint string) string {
+	if i := strings.IndexByte(endpoint, '?'); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+	if m.currentProject == nil {
+		return endpoint
+	}
+	endpoints, err := m.loadProjectEndpoints()
+	if err != nil {
+		return endpoint
+	}
+	for _, ep := range endpoints {
+		if strings.EqualFold(ep.Method, method) && ep.MatchesPath(endpoint) {
+			return ep.Path
+		}
+	}
+	return endpoint
+}
+
+// recordHistory appends a test run to the endpoint's history in the current project
+func (m *TestUIModel) recordHistory(method, endpoint string, statusCode int, duration time.Duration, passed bool, runErr error) {
+	if m.currentProject == nil {
+		return
+	}
+
+	entry := storage.HistoryEntry{
+		Timestamp:  time.Now(),
+		StatusCode: statusCode,
+		DurationMs: duration.Milliseconds(),
+		Passed:     passed,
+	}
+	if runErr != nil {
+		entry.Error = runErr.Error()
+	}
+
+	path := m.historyPath(method, endpoint)
+	if err := storage.AppendHistory(m.currentProject.ID, m.currentProject.IsTemporary, method, path, entry); err != nil {
+		logger.Warn("Failed to record test history", logger.Err(err))
+	}
+}
+
+// showHistory handles /history <method> <path>
+func (m *TestUIModel) showHistory(args []string) {
+	defer m.addMessage("")
+
+	if len(args) != 2 {
+		m.addAgentMessage(m.subtleStyle.Render("Usage: /history <method> <path>  (e.g. /history GET /users/{id})"))
+		return
+	}
+	if m.currentProject == nil {
+		m.addAgentMessage(m.subtleStyle.Render("No active project"))
+		return
+	}
+
+	method := strings.ToUpper(args[0])
+	path := m.historyPath(method, args[1])
+
+	entries, err := storage.LoadHistory(m.currentProject.ID, m.currentProject.IsTemporary, method, path)
+	if err != nil {
+		m.addAgentMessage(m.errorStyle.Render("Failed to load history: " + err.Error()))
+		return
+	}
+	if len(entries) == 0 {
+		m.addAgentMessage(m.subtleStyle.Render(fmt.Sprintf("No history for %s %s yet", method, path)))
+		return
+	}
+
+	durations := make([]int64, len(entries))
+	passedCount := 0
+	var minMs, maxMs, totalMs int64
+	for i, e := range entries {
+		durations[i] = e.DurationMs
+		totalMs += e.DurationMs
+		if i == 0 || e.DurationMs < minMs {
+			minMs = e.DurationMs
+		}
+		if e.DurationMs > maxMs {
+			maxMs = e.DurationMs
+		}
+		if e.Passed {
+			passedCount++
+		}
+	}
+
+	m.addAgentMessage(m.agentStyle.Render(fmt.Sprintf("History for %s %s (last %d runs)", method, path, len(entries))))
+	m.addMessage(fmt.Sprintf("  Latency  %s", lipgloss.NewStyle().Foreground(Theme.Cyan).Render(sparkline(durations))))
+	m.addMessage(m.subtleStyle.Render(fmt.Sprintf("           min %dms · avg %dms · max %dms", minMs, totalMs/int64(len(entries)), maxMs)))
+
+	var outcomes strings.Builder
+	for _, e := range entries {
+		if e.Passed {
+			outcomes.WriteString(m.successStyle.Render("✓"))
+		} else {
+			outcomes.WriteString(m.errorStyle.Render("✗"))
+		}
+	}
+	m.addMessage(fmt.Sprintf("  Results  %s", outcomes.String()))
+
+	streak, passing := currentStreak(entries)
+	streakText := fmt.Sprintf("%d failing in a row", streak)
+	streakStyle := m.errorStyle
+	if passing {
+		streakText = fmt.Sprintf("%d passing in a row", streak)
+		streakStyle = m.successStyle
+	}
+	m.addMessage(fmt.Sprintf("  Streak   %s %s", streakStyle.Render(streakText),
+		m.subtleStyle.Render(fmt.Sprintf("(%d/%d passed)", passedCount, len(entries)))))
+
+	last := entries[len(entries)-1]
+	m.addMessage(m.subtleStyle.Render(fmt.Sprintf("  Last run %s", last.Timestamp.Format("2006-01-02 15:04"))))
+}
+
+// currentStreak returns the length of the latest run of identical outcomes and whether it is passing
+func currentStreak(entries []storage.HistoryEntry) (int, bool) {
+	if len(entries) == 0 {
+		return 0, false
+	}
+	passing := entries[len(entries)-1].Passed
+	streak := 0
+	for i := len(entries) - 1; i >= 0 && entries[i].Passed == passing; i-- {
+		streak++
+	}
+	return streak, passing
+}
+
+// sparkline renders values as a row of block glyphs scaled between their min and max
+func sparkline(values []int64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if hi > lo {
+			idx = int((v - lo) * int64(len(sparkBlocks)-1) / (hi - lo))
+		}
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
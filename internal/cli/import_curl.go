@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type curlResultMsg struct {
+	method   string
+	endpoint string
+	project  bool // the request targeted the project's base URL
+	result   *tester.TestResult
+	err      error
+}
+
+// importCurl parses a curl command and runs it. Requests to the project's base URL go through
+// the session executor (auth, replay, record); other hosts are called directly without auth.
+func (m *TestUIModel) importCurl(command string) tea.Cmd {
+	if command == "" {
+		m.addAgentMessage(m.subtleStyle.Render("Usage: /import-curl curl -X POST https://api.example.com/users -H 'Content-Type: application/json' -d '{...}'"))
+		m.addMessage("")
+		return nil
+	}
+
+	req, err := tester.ParseCurl(command)
+	if err != nil {
+		m.addAgentMessage(m.errorStyle.Render("Failed to parse curl command: " + err.Error()))
+		m.addMessage("")
+		return nil
+	}
+	baseURL, endpoint, err := req.SplitURL()
+	if err != nil {
+		m.addAgentMessage(m.errorStyle.Render(err.Error()))
+		m.addMessage("")
+		return nil
+	}
+
+	executor := tester.NewExecutor(baseURL, nil)
+	executor.SetTimeout(m.testExecutor.Timeout())
+	executor.SetRetryPolicy(m.testExecutor.RetryPolicy())
+	_ = executor.SetProxy(m.testExecutor.Proxy())
+	_ = executor.SetTLS(m.testExecutor.TLS())
+	project := false
+	if projectBase := strings.TrimSuffix(m.baseURL, "/"); projectBase != "" && strings.HasPrefix(req.URL, projectBase) {
+		executor = m.testExecutor
+		endpoint = strings.TrimPrefix(req.URL, projectBase)
+		project = true
+	}
+
+	m.addAgentMessage(m.subtleStyle.Render(fmt.Sprintf("Running imported curl: %s %s", req.Method, req.URL)))
+	m.agentState = StateRunningTests
+	return func() tea.Msg {
+		result, err := executor.ExecuteTest(req.Method, endpoint, req.Headers, req.Body)
+		return curlResultMsg{method: req.Method, endpoint: endpoint, project: project, result: result, err: err}
+	}
+}
+
+// handleCurlResult shows the outcome of an imported curl or manual request
+func handleCurlResult(m *TestUIModel, msg curlResultMsg) (tea.Model, tea.Cmd) {
+	m.agentState = StateIdle
+
+	methodStyle, ok := m.methodStyles[msg.method]
+	if !ok {
+		methodStyle = lipgloss.NewStyle().Foreground(Theme.TextSubtle)
+	}
+
+	if msg.err != nil {
+		if msg.project {
+			m.recordHistory(msg.method, msg.endpoint, 0, 0, false, msg.err)
+		}
+		m.addMessage(fmt.Sprintf("  ✗ %s %s", methodStyle.Render(msg.method), msg.endpoint))
+		m.addMessage(m.subtleStyle.Render(fmt.Sprintf("    Error: %s", msg.err.Error())))
+	} else {
+		if msg.project {
+			m.recordHistory(msg.method, msg.endpoint, msg.result.StatusCode, msg.result.Duration, msg.result.Passed(), nil)
+		}
+		m.lastResponseBody = msg.result.ResponseBody
+		icon, style := statusClassIndicator(msg.result.StatusCode)
+		m.addMessage(fmt.Sprintf("  %s %s %s", style.Render(icon), methodStyle.Render(msg.method), msg.endpoint))
+		m.addMessage(m.subtleStyle.Render(fmt.Sprintf("    Status: %d | Duration: %dms | %s",
+			msg.result.StatusCode, msg.result.Duration.Milliseconds(), formatSizes(msg.result.RequestBytes, msg.result.ResponseBytes))))
+		if preview := strings.TrimSpace(msg.result.ResponseBody); preview != "" {
+			m.addMessage(m.subtleStyle.Render("    Response: " + m.displayBody(preview)))
+		}
+	}
+	m.addMessage("")
+	m.updateViewport()
+	return m, nil
+}
+
+// showLastCurl handles /curl, printing a curl command that repeats the last request sent to the API.
+// Credentials are redacted unless "/curl full" is used.
+func (m *TestUIModel) showLastCurl(args []string) {
+	defer m.addMessage("")
+
+	full := len(args) == 1 && args[0] == "full"
+	if len(args) > 0 && !full {
+		m.addAgentMessage(m.errorStyle.Render("Usage: /curl [full]"))
+		return
+	}
+	command := m.testExecutor.LastCurl(!full)
+	if command == "" {
+		m.addAgentMessage(m.subtleStyle.Render("No request sent yet"))
+		return
+	}
+
+	m.addAgentMessage(command)
+	if !full && m.authProvider != nil && m.authProvider.Type() != "none" {
+		m.addMessage(m.subtleStyle.Render("Credentials are redacted; /curl full shows them"))
+	}
+}
@@ -3,9 +3,11 @@ package cli
 import (
 	"github.com/Octrafic/octrafic-cli/internal/core/analyzer"
 	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
 	"github.com/Octrafic/octrafic-cli/internal/infra/logger"
 	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
 	"os"
+	"time"
 
 	"github.com/charmbracelet/bubbletea"
 )
@@ -20,16 +22,91 @@ func Start(baseURL string, specPath string, analysis *analyzer.Analysis, authPro
 	}
 }
 
-func StartWithProject(baseURL string, analysis *analyzer.Analysis, project *storage.Project, authProvider auth.AuthProvider, version string) {
+// StartOptions holds command-line options that affect the interactive session
+type StartOptions struct {
+	OpenReports bool               // Open generated reports in the default viewer
+	Replayer    *tester.Replayer   // Serve saved responses instead of hitting the network
+	Recorder    *tester.Recorder   // Save real responses as replay fixtures
+	BinaryDir   string             // Save binary response bodies here instead of only summarizing them
+	Timeout     time.Duration      // Per-request timeout; zero keeps the executor default
+	Retry       tester.RetryPolicy // Resend requests failing with connection errors or chosen statuses
+	Validate    bool               // Validate JSON responses against the spec's response schemas
+	Concurrency int                // Tests of a group sent at once; 1 sends them one at a time, 0 keeps the project's /parallel setting
+	Resume      bool               // Continue the project's last saved conversation
+	Proxy       string             // Send test requests through this proxy instead of the one in the environment
+	TLS         tester.TLSOptions  // Certificate verification for test requests
+	Cookies     bool               // Carry cookies set by responses to later requests
+	AutoExecute bool               // Run tools without asking, overriding the project's saved mode for this session
+}
+
+// StartWithProject runs the interactive session for project until the user quits
+func StartWithProject(baseURL string, analysis *analyzer.Analysis, project *storage.Project, authProvider auth.AuthProvider, version string, opts StartOptions) error {
 	specPath := project.SpecPath
 
 	model := NewTestUIModel(baseURL, specPath, analysis, authProvider, version)
 
 	model.currentProject = project
+	model.refreshLLMInfo()
+	model.applyPreferences()
+	model.testExecutor.SetVolatileFields(project.VolatileFields)
+	if err := model.applyStartOptions(opts); err != nil {
+		return err
+	}
 
 	p := tea.NewProgram(model, tea.WithMouseCellMotion())
-	if _, err := p.Run(); err != nil {
-		logger.Error("Error running interactive mode", logger.Err(err))
-		os.Exit(1)
+	final, err := p.Run()
+	// The program has stopped, so the last save runs here rather than as a command
+	switch finalModel := final.(type) {
+	case TestUIModel:
+		if save := finalModel.saveChatSession(); save != nil {
+			save()
+		}
+	case *TestUIModel:
+		if save := finalModel.saveChatSession(); save != nil {
+			save()
+		}
+	}
+	return err
+}
+
+// applyStartOptions applies the command-line options of an interactive session. It runs after the
+// project's saved preferences are restored, so flags win for the session.
+func (m *TestUIModel) applyStartOptions(opts StartOptions) error {
+	m.openReports = opts.OpenReports
+	m.validateSchemas = opts.Validate
+	if opts.Concurrency > 0 {
+		m.testConcurrency = min(opts.Concurrency, maxTestConcurrency)
+	}
+	if opts.Replayer != nil {
+		m.testExecutor.SetReplayer(opts.Replayer)
+		m.replaying = true
+	}
+	if opts.Recorder != nil {
+		m.testExecutor.SetRecorder(opts.Recorder)
+		m.recording = true
+	}
+	if opts.BinaryDir != "" {
+		m.testExecutor.SetBinaryDir(opts.BinaryDir)
+	}
+	if opts.Timeout > 0 {
+		m.testExecutor.SetTimeout(opts.Timeout)
+	}
+	m.testExecutor.SetRetryPolicy(opts.Retry)
+	if err := m.testExecutor.SetProxy(opts.Proxy); err != nil {
+		return err
+	}
+	if err := m.testExecutor.SetTLS(opts.TLS); err != nil {
+		return err
+	}
+	if opts.Cookies {
+		m.testExecutor.EnableCookies()
+		m.cookieSession = true
+	}
+	if opts.AutoExecute {
+		m.executionMode = ModeAutoExecute
+	}
+	if opts.Resume {
+		m.resumeChatSession()
 	}
+	return nil
 }
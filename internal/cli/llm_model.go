@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/infra/logger"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"github.com/Octrafic/octrafic-cli/internal/llm"
+)
+
+// refreshLLMInfo updates the provider and model shown in status messages from the global
+// settings and the current project's override
+func (m *TestUIModel) refreshLLMInfo() {
+	providerConfig, _ := agent.ResolveProviderConfig()
+	providerConfig = m.currentProject.ModelOverride().Apply(providerConfig)
+	m.llmProvider = providerConfig.Provider
+	m.llmModel = providerConfig.Model
+}
+
+// handleModelCommand handles /model: show the LLM in use, or change it for the current project.
+//
+//	/model                             show the provider and model
+//	/model <model>                     use another model of the same provider
+//	/model <provider> <model> [url]    use another provider, optionally at a custom base URL
+//	/model reset                       go back to the global settings
+func (m *TestUIModel) handleModelCommand(args []string) {
+	defer m.addMessage("")
+
+	if len(args) == 0 {
+		m.addAgentMessage(m.agentStyle.Render(fmt.Sprintf("LLM: %s / %s", m.llmProvider, m.llmModel)))
+		if m.currentProject.ModelOverride().IsZero() {
+			m.addMessage(m.subtleStyle.Render("Global settings. Change for this project: /model <model> or /model <provider> <model> [base-url]"))
+		} else {
+			m.addMessage(m.subtleStyle.Render("Set for this project. Back to the global settings: /model reset"))
+		}
+		return
+	}
+
+	if m.currentProject == nil {
+		m.addAgentMessage(m.subtleStyle.Render("No active project"))
+		return
+	}
+
+	var settings *storage.LLMSettings
+	switch {
+	case len(args) == 1 && args[0] == "reset":
+		settings = nil
+	case len(args) == 1:
+		settings = &storage.LLMSettings{Model: args[0]}
+	case len(args) <= 3:
+		provider := strings.ToLower(args[0])
+		if !slices.Contains(llm.SupportedProviders, provider) {
+			m.addAgentMessage(m.errorStyle.Render(fmt.Sprintf("Unknown provider %q (use one of: %s)", args[0], strings.Join(llm.SupportedProviders, ", "))))
+			return
+		}
+		settings = &storage.LLMSettings{Provider: provider, Model: args[1]}
+		if len(args) == 3 {
+			settings.BaseURL = args[2]
+		}
+	default:
+		m.addAgentMessage(m.errorStyle.Render("Usage: /model [<provider>] <model> [base-url] | /model reset"))
+		return
+	}
+
+	m.currentProject.LLM = settings
+	if err := storage.SaveProject(m.currentProject); err != nil {
+		logger.Warn("Failed to save project LLM settings", logger.Err(err))
+		m.addAgentMessage(m.errorStyle.Render("Failed to save the model: " + err.Error()))
+		return
+	}
+
+	// The agent is created again with the new settings on the next message
+	if m.localAgent != nil {
+		if err := m.localAgent.Close(); err != nil {
+			logger.Warn("Failed to close agent", logger.Err(err))
+		}
+		m.localAgent = nil
+	}
+	m.refreshLLMInfo()
+
+	if settings == nil {
+		m.addAgentMessage(m.successStyle.Render(fmt.Sprintf("✓ Using the global LLM settings: %s / %s", m.llmProvider, m.llmModel)))
+		return
+	}
+	m.addAgentMessage(m.successStyle.Render(fmt.Sprintf("✓ This project now uses %s / %s", m.llmProvider, m.llmModel)))
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// manualMethods are the methods accepted at the start of a manual request line
+var manualMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+const (
+	agentPlaceholder  = "Chat with the testing agent..."
+	manualPlaceholder = "GET /users  •  POST /users {\"name\": \"Ada\"}  •  curl ..."
+)
+
+// setManualMode switches between chatting with the agent and sending typed requests directly
+func (m *TestUIModel) setManualMode(manual bool) {
+	m.manualMode = manual
+	m.textarea.Placeholder = m.inputPlaceholder()
+	if manual {
+		m.addAgentMessage(m.agentStyle.Render("Manual mode: ") +
+			m.subtleStyle.Render("lines like GET /users run directly against "+m.baseURL+". Ctrl+R or /mode agent to switch back."))
+	} else {
+		m.addAgentMessage(m.agentStyle.Render("Agent mode: ") + m.subtleStyle.Render("messages go to the testing agent again."))
+	}
+	m.addMessage("")
+}
+
+// inputPlaceholder returns the input hint for the current mode
+func (m *TestUIModel) inputPlaceholder() string {
+	if m.manualMode {
+		return manualPlaceholder
+	}
+	return agentPlaceholder
+}
+
+// handleModeCommand handles /mode [agent|manual]; without an argument it toggles
+func (m *TestUIModel) handleModeCommand(args []string) {
+	if len(args) == 0 {
+		m.setManualMode(!m.manualMode)
+		return
+	}
+	switch strings.ToLower(args[0]) {
+	case "manual":
+		m.setManualMode(true)
+	case "agent":
+		m.setManualMode(false)
+	default:
+		m.addAgentMessage(m.errorStyle.Render("Usage: /mode [agent|manual]"))
+		m.addMessage("")
+	}
+}
+
+// runManualRequest sends a typed "METHOD /path [body]" line through the session executor.
+// JSON bodies are sent as JSON, anything else (e.g. XML) as-is; curl commands are imported.
+func (m *TestUIModel) runManualRequest(line string) tea.Cmd {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "curl ") {
+		return m.importCurl(line)
+	}
+
+	method, rest, _ := strings.Cut(line, " ")
+	method = strings.ToUpper(method)
+	endpoint, rawBody, _ := strings.Cut(strings.TrimSpace(rest), " ")
+	if !slices.Contains(manualMethods, method) || !strings.HasPrefix(endpoint, "/") {
+		m.addAgentMessage(m.errorStyle.Render("Usage: METHOD /path [body]") + " " +
+			m.subtleStyle.Render("(e.g. GET /users?limit=5 or POST /users {\"name\": \"Ada\"})"))
+		m.addMessage("")
+		return nil
+	}
+
+	var body any
+	if rawBody = strings.TrimSpace(rawBody); rawBody != "" {
+		body = rawBody
+		var decoded any
+		if err := json.Unmarshal([]byte(rawBody), &decoded); err == nil {
+			body = decoded
+		}
+	}
+
+	return m.sendManualRequest(method, endpoint, body)
+}
+
+// sendManualRequest runs a manual request, first asking for any path parameters without a value
+func (m *TestUIModel) sendManualRequest(method, endpoint string, body any) tea.Cmd {
+	filled, missing := m.fillPath(endpoint)
+	if len(missing) > 0 {
+		m.requestPathValues(&pathPrompt{method: method, template: endpoint, endpoint: filled, body: body, missing: missing})
+		return nil
+	}
+
+	m.addMessage("")
+	m.addMessage(lipgloss.NewStyle().Foreground(Theme.TextMuted).Render("$ ") + fmt.Sprintf("%s %s", method, filled))
+	m.agentState = StateRunningTests
+	executor := m.testExecutor
+	return func() tea.Msg {
+		result, err := executor.ExecuteTest(method, filled, nil, body)
+		return curlResultMsg{method: method, endpoint: filled, project: true, result: result, err: err}
+	}
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+)
+
+func TestCompareRepresentationsOnlyReads(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Header().Set("Content-Type", r.Header.Get("Accept"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		method  string
+		wantErr bool
+	}{
+		{"GET", false},
+		{"head", false},
+		{"DELETE", true},
+		{"POST", true},
+	}
+	for _, tt := range tests {
+		methods = nil
+		m := NewTestUIModel(server.URL, "", nil, &auth.NoAuth{}, "test")
+		msg := m.executeTool(agent.ToolCall{Name: "compare_representations", Arguments: map[string]any{
+			"method": tt.method, "endpoint": "/users/42", "accept": []any{"application/json"},
+		}})().(toolResultMsg)
+
+		if gotErr := msg.err != nil; gotErr != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.method, msg.err, tt.wantErr)
+		}
+		if tt.wantErr && len(methods) != 0 {
+			t.Errorf("%s: sent %v, want no requests", tt.method, methods)
+		}
+	}
+}
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/Octrafic/octrafic-cli/internal/config"
+	"github.com/Octrafic/octrafic-cli/internal/llm/gemini"
+	"github.com/Octrafic/octrafic-cli/internal/llm/openai"
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/option"
@@ -26,6 +29,7 @@ const (
 	OnboardingProvider
 	OnboardingAPIKey
 	OnboardingServerURL
+	OnboardingDeployment
 	OnboardingSelectModel
 	OnboardingComplete
 )
@@ -34,11 +38,14 @@ const (
 type OnboardingModel struct {
 	state            OnboardingState
 	provider         string
-	selectedProvider int // 0 = anthropic, 1 = openrouter, 2 = openai, 3 = ollama, 4 = llamacpp
+	selectedProvider int // 0 = anthropic, 1 = openrouter, 2 = openai, 3 = gemini, 4 = azure, 5 = ollama, 6 = llamacpp
 	apiKey           string
 	apiKeyInput      textinput.Model
 	serverURL        string
 	serverURLInput   textinput.Model
+	deploymentInput  textinput.Model // Azure OpenAI deployment name
+	apiVersionInput  textinput.Model // Azure OpenAI API version
+	deploymentFocus  int             // 0 = deployment, 1 = API version
 	models           []string
 	filteredModels   []string // Filtered list based on search
 	selectedModel    int
@@ -79,6 +86,17 @@ func NewOnboardingModel() OnboardingModel {
 	serverURLInput.CharLimit = 200
 	serverURLInput.Width = 50
 
+	// Azure OpenAI deployment and API version inputs
+	deploymentInput := textinput.New()
+	deploymentInput.Placeholder = "gpt-4o"
+	deploymentInput.CharLimit = 100
+	deploymentInput.Width = 50
+
+	apiVersionInput := textinput.New()
+	apiVersionInput.Placeholder = openai.DefaultAzureAPIVersion
+	apiVersionInput.CharLimit = 40
+	apiVersionInput.Width = 50
+
 	// Model search input
 	searchInput := textinput.New()
 	searchInput.Placeholder = "Search models..."
@@ -93,6 +111,8 @@ func NewOnboardingModel() OnboardingModel {
 		filteredModels:   []string{},
 		apiKeyInput:      ti,
 		serverURLInput:   serverURLInput,
+		deploymentInput:  deploymentInput,
+		apiVersionInput:  apiVersionInput,
 		modelSearchInput: searchInput,
 		selectedProvider: 0, // Default to Anthropic
 	}
@@ -148,6 +168,12 @@ func (m OnboardingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if len(m.apiKeyInput.Value()) > 0 {
 					m.apiKey = m.apiKeyInput.Value()
 					m.errorMsg = ""
+					// Azure keys are tested together with the deployment they give access to
+					if m.provider == "azure" {
+						m.state = OnboardingDeployment
+						m.focusDeploymentInput(0)
+						return m, nil
+					}
 					m.isTestingKey = true
 					return m, m.testAPIKey()
 				}
@@ -157,6 +183,10 @@ func (m OnboardingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.apiKeyInput.SetValue("")
 				m.errorMsg = ""
 				m.state = OnboardingProvider
+				if m.provider == "azure" {
+					m.state = OnboardingServerURL
+					m.serverURLInput.Focus()
+				}
 				return m, nil
 			case "ctrl+c":
 				return m, tea.Quit
@@ -172,6 +202,16 @@ func (m OnboardingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch msg.String() {
 			case "enter":
 				url := m.serverURLInput.Value()
+				if m.provider == "azure" {
+					if url == "" {
+						return m, nil
+					}
+					m.serverURL = url
+					m.errorMsg = ""
+					m.state = OnboardingAPIKey
+					m.apiKeyInput.Focus()
+					return m, nil
+				}
 				if url == "" {
 					// Use placeholder as default
 					if m.provider == "ollama" {
@@ -197,6 +237,37 @@ func (m OnboardingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Azure OpenAI deployment and API version; tab switches between the two inputs
+		if m.state == OnboardingDeployment {
+			switch msg.String() {
+			case "tab", "shift+tab", "up", "down":
+				m.focusDeploymentInput(1 - m.deploymentFocus)
+				return m, nil
+			case "enter":
+				if m.deploymentInput.Value() == "" {
+					m.focusDeploymentInput(0)
+					return m, nil
+				}
+				m.errorMsg = ""
+				m.isTestingKey = true
+				return m, m.testAzureDeployment()
+			case "esc":
+				m.errorMsg = ""
+				m.state = OnboardingAPIKey
+				m.apiKeyInput.Focus()
+				return m, nil
+			case "ctrl+c":
+				return m, tea.Quit
+			default:
+				if m.deploymentFocus == 0 {
+					m.deploymentInput, cmd = m.deploymentInput.Update(msg)
+				} else {
+					m.apiVersionInput, cmd = m.apiVersionInput.Update(msg)
+				}
+				return m, cmd
+			}
+		}
+
 		// Special handling for model selection state - let textinput handle typing
 		if m.state == OnboardingSelectModel {
 			// Handle special keys first
@@ -224,6 +295,9 @@ func (m OnboardingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if config.IsLocalProvider(m.provider) {
 					m.state = OnboardingServerURL
 					m.serverURLInput.Focus()
+				} else if m.provider == "azure" {
+					m.state = OnboardingDeployment
+					m.focusDeploymentInput(0)
 				} else {
 					m.apiKeyInput.SetValue("")
 					m.state = OnboardingAPIKey
@@ -285,7 +359,7 @@ func (m *OnboardingModel) handleKeyPress(keyMsg tea.KeyMsg) (tea.Model, tea.Cmd)
 				m.selectedProvider--
 			}
 		case "down", "j":
-			if m.selectedProvider < 4 { // 0=anthropic, 1=openrouter, 2=openai, 3=ollama, 4=llamacpp
+			if m.selectedProvider < 6 { // 0=anthropic, 1=openrouter, 2=openai, 3=gemini, 4=azure, 5=ollama, 6=llamacpp
 				m.selectedProvider++
 			}
 		case "enter":
@@ -303,11 +377,21 @@ func (m *OnboardingModel) handleKeyPress(keyMsg tea.KeyMsg) (tea.Model, tea.Cmd)
 				m.state = OnboardingAPIKey
 				m.apiKeyInput.Focus()
 			case 3:
+				m.provider = "gemini"
+				m.state = OnboardingAPIKey
+				m.apiKeyInput.Focus()
+			case 4:
+				m.provider = "azure"
+				m.serverURLInput.SetValue("")
+				m.serverURLInput.Placeholder = "https://my-resource.openai.azure.com"
+				m.state = OnboardingServerURL
+				m.serverURLInput.Focus()
+			case 5:
 				m.provider = "ollama"
 				m.serverURLInput.SetValue("http://localhost:11434")
 				m.state = OnboardingServerURL
 				m.serverURLInput.Focus()
-			case 4:
+			case 6:
 				m.provider = "llamacpp"
 				m.serverURLInput.SetValue("http://localhost:8080")
 				m.state = OnboardingServerURL
@@ -351,6 +435,8 @@ func (m *OnboardingModel) testAPIKey() tea.Cmd {
 			models, err = fetchOpenRouterModels(apiKey)
 		case "openai":
 			models, err = fetchOpenAIModels(apiKey)
+		case "gemini":
+			models, err = fetchGeminiModels(apiKey)
 		default:
 			return KeyTestResult{
 				Success:  false,
@@ -376,6 +462,37 @@ func (m *OnboardingModel) testAPIKey() tea.Cmd {
 	}
 }
 
+// focusDeploymentInput focuses the Azure deployment (0) or API version (1) input
+func (m *OnboardingModel) focusDeploymentInput(i int) {
+	m.deploymentFocus = i
+	if i == 0 {
+		m.apiVersionInput.Blur()
+		m.deploymentInput.Focus()
+	} else {
+		m.deploymentInput.Blur()
+		m.apiVersionInput.Focus()
+	}
+}
+
+// testAzureDeployment checks the endpoint, key and deployment with a minimal chat request.
+// Azure has no data-plane model list, so the deployment is offered as the only model.
+func (m *OnboardingModel) testAzureDeployment() tea.Cmd {
+	endpoint, apiKey := m.serverURL, m.apiKey
+	deployment, apiVersion := m.deploymentInput.Value(), m.apiVersionInput.Value()
+
+	return func() tea.Msg {
+		client, err := openai.NewAzureClient(apiKey, endpoint, deployment, deployment, apiVersion)
+		if err == nil {
+			defer client.Close()
+			_, _, err = client.Chat([]openai.Message{{Role: "user", Content: "ping"}}, nil)
+		}
+		if err != nil {
+			return KeyTestResult{Success: false, Error: err.Error(), Provider: "azure"}
+		}
+		return KeyTestResult{Success: true, Models: []string{deployment}, Provider: "azure (1 deployment)"}
+	}
+}
+
 func (m *OnboardingModel) testServerConnection() tea.Cmd {
 	provider := m.provider
 	serverURL := m.serverURL
@@ -407,6 +524,10 @@ func (m *OnboardingModel) saveConfig() tea.Cmd {
 			Model:     m.filteredModels[m.selectedModel],
 			Onboarded: true,
 		}
+		if m.provider == "azure" {
+			cfg.Deployment = m.deploymentInput.Value()
+			cfg.APIVersion = m.apiVersionInput.Value()
+		}
 
 		if err := cfg.Save(); err != nil {
 			return tea.Quit()
@@ -427,6 +548,8 @@ func (m OnboardingModel) View() string {
 		return m.renderAPIKey()
 	case OnboardingServerURL:
 		return m.renderServerURL()
+	case OnboardingDeployment:
+		return m.renderDeployment()
 	case OnboardingSelectModel:
 		return m.renderModel()
 	case OnboardingComplete:
@@ -489,7 +612,7 @@ func (m OnboardingModel) renderProvider() string {
 		Foreground(Theme.TextMuted).
 		Render("Let's configure your AI provider")
 
-	providers := []string{"Anthropic", "OpenRouter", "OpenAI", "Ollama (local)", "llama.cpp (local)"}
+	providers := []string{"Anthropic", "OpenRouter", "OpenAI", "Google Gemini", "Azure OpenAI", "Ollama (local)", "llama.cpp (local)"}
 	var providerItems []string
 
 	for i, provider := range providers {
@@ -537,6 +660,10 @@ func providerDisplayName(provider string) string {
 		return "OpenRouter"
 	case "openai":
 		return "OpenAI"
+	case "gemini":
+		return "Google Gemini"
+	case "azure":
+		return "Azure OpenAI"
 	case "ollama":
 		return "Ollama"
 	case "llamacpp":
@@ -789,6 +916,60 @@ func (m OnboardingModel) renderServerURL() string {
 	)
 }
 
+func (m OnboardingModel) renderDeployment() string {
+	title := lipgloss.NewStyle().
+		Foreground(Theme.Primary).
+		Bold(true).
+		Render("Enter your Azure deployment")
+
+	providerLabel := lipgloss.NewStyle().
+		Foreground(Theme.TextMuted).
+		Render("Endpoint: ") +
+		lipgloss.NewStyle().
+			Foreground(Theme.Primary).
+			Bold(true).
+			Render(m.serverURL)
+
+	label := lipgloss.NewStyle().Foreground(Theme.TextMuted)
+	deployment := label.Render("Deployment:  ") + m.deploymentInput.View()
+	apiVersion := label.Render("API version: ") + m.apiVersionInput.View()
+
+	var statusLine string
+	if m.isTestingKey {
+		spinner := lipgloss.NewStyle().Foreground(Theme.Primary).Render("⠋")
+		statusLine = spinner + " " + lipgloss.NewStyle().Foreground(Theme.TextMuted).Render("Testing deployment...")
+	} else if m.errorMsg != "" {
+		statusLine = lipgloss.NewStyle().Foreground(Theme.Error).Render("✗ " + m.errorMsg)
+	}
+
+	help := lipgloss.NewStyle().
+		Foreground(Theme.TextSubtle).
+		Render("Tab to switch fields • Enter to test • ESC to go back")
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		"",
+		title,
+		"",
+		providerLabel,
+		"",
+		"",
+		deployment,
+		apiVersion,
+	)
+
+	if statusLine != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, "", statusLine)
+	}
+
+	content = lipgloss.JoinVertical(lipgloss.Left, content, "", "", help)
+
+	return lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
+
 // fetchLocalModels fetches available models from a local OpenAI-compatible server (Ollama/llama.cpp)
 func fetchLocalModels(serverURL string) ([]string, error) {
 	url := strings.TrimSuffix(serverURL, "/") + "/v1/models"
@@ -1014,3 +1195,49 @@ func fetchOpenAIModels(apiKey string) ([]string, error) {
 
 	return modelIDs, nil
 }
+
+// fetchGeminiModels fetches the models that support generateContent from the Gemini API
+func fetchGeminiModels(apiKey string) ([]string, error) {
+	req, err := http.NewRequest("GET", gemini.DefaultBaseURL+"/models?pageSize=1000", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Add("x-goog-api-key", apiKey)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch models: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != 200 {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("API returned status %d: %s", res.StatusCode, string(body))
+	}
+
+	var response struct {
+		Models []struct {
+			Name                       string   `json:"name"`
+			SupportedGenerationMethods []string `json:"supportedGenerationMethods"`
+		} `json:"models"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	var modelIDs []string
+	for _, model := range response.Models {
+		// Filter for chat models; embedding and image models don't support generateContent
+		if strings.HasPrefix(model.Name, "models/gemini-") && slices.Contains(model.SupportedGenerationMethods, "generateContent") {
+			modelIDs = append(modelIDs, strings.TrimPrefix(model.Name, "models/"))
+		}
+	}
+
+	if len(modelIDs) == 0 {
+		return nil, fmt.Errorf("no Gemini chat models available for this API key")
+	}
+
+	return modelIDs, nil
+}
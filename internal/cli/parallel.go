@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxTestConcurrency caps /parallel so a typo doesn't flood the API under test
+const maxTestConcurrency = 32
+
+// testBatchDoneMsg carries the outcomes of a test batch, in the order of its tests
+type testBatchDoneMsg struct {
+	tests    []queuedTest
+	outcomes []tester.Outcome
+}
+
+// parallelBatch decodes the remaining tests for sending at once. Groups whose tests depend on each
+// other (skip_unless, capture) or still need path parameter values aren't batched and run one by one.
+func (m *TestUIModel) parallelBatch() ([]queuedTest, bool) {
+	batch := make([]queuedTest, 0, len(m.pendingTests))
+	for _, testMap := range m.pendingTests {
+		if condition, _ := testMap["skip_unless"].(string); condition != "" {
+			return nil, false
+		}
+		if len(tester.ParseCaptures(testMap["capture"])) > 0 {
+			return nil, false
+		}
+		test := newQueuedTest(testMap)
+		endpoint, missing := m.fillPath(test.Endpoint)
+		if len(missing) > 0 {
+			return nil, false
+		}
+		test.Endpoint = endpoint
+		if test.requiresAuth {
+			test.Auth = m.endpointAuth(test.Method, test.Endpoint)
+		}
+		batch = append(batch, test)
+	}
+	return batch, true
+}
+
+// runTestBatch sends the batch in waves of testConcurrency requests, pausing between waves as /delay
+// sets. Results are shown together once all of them are back, in the order of the tests.
+func (m *TestUIModel) runTestBatch(batch []queuedTest) tea.Cmd {
+	m.pendingTests = nil
+	m.addMessage(m.subtleStyle.Render(fmt.Sprintf("  Sending %d tests, up to %d at a time…", len(batch), m.testConcurrency)))
+	m.updateViewport()
+
+	executor := m.testExecutor
+	concurrency := max(1, m.testConcurrency)
+	var delays []time.Duration
+	for start := concurrency; start < len(batch); start += concurrency {
+		delays = append(delays, m.nextTestDelay())
+	}
+	return func() tea.Msg {
+		outcomes := make([]tester.Outcome, 0, len(batch))
+		for start := 0; start < len(batch); start += concurrency {
+			if start > 0 {
+				time.Sleep(delays[start/concurrency-1])
+			}
+			wave := batch[start:min(start+concurrency, len(batch))]
+			requests := make([]tester.Request, len(wave))
+			for i, test := range wave {
+				requests[i] = test.Request
+			}
+			outcomes = append(outcomes, executor.ExecuteParallel(requests, concurrency)...)
+		}
+		return testBatchDoneMsg{tests: batch, outcomes: outcomes}
+	}
+}
+
+// handleTestBatchDone shows the results of a parallel batch and finishes the group
+func handleTestBatchDone(m *TestUIModel, msg testBatchDoneMsg) (tea.Model, tea.Cmd) {
+	for i, test := range msg.tests {
+		m.showTestOutcome(test, msg.outcomes[i].Result, msg.outcomes[i].Err)
+	}
+	return handleRunNextTest(m, runNextTestMsg{})
+}
+
+// handleParallelCommand handles /parallel [n], which shows or sets how many tests of a group are
+// sent at once
+func (m *TestUIModel) handleParallelCommand(args []string) {
+	defer m.addMessage("")
+
+	if len(args) == 0 {
+		m.addAgentMessage(m.subtleStyle.Render("Parallel tests: " + m.describeConcurrency()))
+		return
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if len(args) > 1 || err != nil || n < 1 || n > maxTestConcurrency {
+		m.addAgentMessage(m.errorStyle.Render(fmt.Sprintf("Usage: /parallel <1-%d>", maxTestConcurrency)) + " " +
+			m.subtleStyle.Render("(e.g. /parallel 4, or /parallel 1 to send tests one at a time)"))
+		return
+	}
+
+	m.testConcurrency = n
+	m.savePreferences()
+	m.addAgentMessage(m.successStyle.Render("✓ Parallel tests: " + m.describeConcurrency()))
+}
+
+// describeConcurrency renders the current setting, e.g. "up to 4 at a time"
+func (m *TestUIModel) describeConcurrency() string {
+	if m.testConcurrency <= 1 {
+		return "off (one at a time)"
+	}
+	return fmt.Sprintf("up to %d at a time", m.testConcurrency)
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+)
+
+func TestParallelSettingSavedWithProject(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	project := &storage.Project{ID: "parallel-id", Name: "parallel"}
+	m := NewTestUIModel("http://localhost", "", nil, &auth.NoAuth{}, "test")
+	m.currentProject = project
+
+	handleSlashCommands(m, "/parallel 4")
+	saved, err := storage.LoadProject(project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Preferences == nil || saved.Preferences.Concurrency != 4 {
+		t.Fatalf("saved preferences = %+v, want concurrency 4", saved.Preferences)
+	}
+
+	restored := NewTestUIModel("http://localhost", "", nil, &auth.NoAuth{}, "test")
+	restored.currentProject = saved
+	restored.applyPreferences()
+	if err := restored.applyStartOptions(StartOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if restored.testConcurrency != 4 {
+		t.Errorf("restored concurrency = %d, want the saved 4", restored.testConcurrency)
+	}
+
+	// --concurrency wins for the session
+	if err := restored.applyStartOptions(StartOptions{Concurrency: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if restored.testConcurrency != 2 {
+		t.Errorf("concurrency with --concurrency 2 = %d, want 2", restored.testConcurrency)
+	}
+}
+
+func TestParallelWavesWaitForDelay(t *testing.T) {
+	var mu sync.Mutex
+	var sent []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		sent = append(sent, time.Now())
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	m := NewTestUIModel(server.URL, "", nil, &auth.NoAuth{}, "test")
+	m.testConcurrency = 2
+	m.requestDelay = 100 * time.Millisecond
+
+	handleStartTestGroup(m, startTestGroupMsg{tests: []map[string]any{
+		{"method": "GET", "endpoint": "/a"},
+		{"method": "GET", "endpoint": "/b"},
+		{"method": "GET", "endpoint": "/c"},
+	}})
+	_, cmd := handleRunNextTest(m, runNextTestMsg{})
+	done, ok := findMsg[testBatchDoneMsg](cmd)
+	if !ok {
+		t.Fatal("the group wasn't sent as a parallel batch")
+	}
+	if len(done.outcomes) != 3 || len(sent) != 3 {
+		t.Fatalf("got %d outcomes for %d requests, want 3", len(done.outcomes), len(sent))
+	}
+
+	// /a and /b go out together, /c after the pause
+	last := sent[0]
+	if sent[1].After(last) {
+		last = sent[1]
+	}
+	if gap := sent[2].Sub(last); gap < m.requestDelay {
+		t.Errorf("third request sent %s after the first wave, want at least %s", gap, m.requestDelay)
+	}
+}
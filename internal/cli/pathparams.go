@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/reporter"
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pathPrompt is a request whose path still has {param} placeholders without a value
+type pathPrompt struct {
+	method   string
+	template string         // Path as requested, whose placeholders the entered values belong to
+	endpoint string         // Path with the known placeholders already filled in
+	body     any            // Body of a manual request
+	test     map[string]any // Queued test to resume once filled; nil for manual requests
+	missing  []string       // Placeholders still to ask for, in path order
+}
+
+// pathTemplate returns the path of an endpoint without its query string. Path values are kept per
+// template, so an {id} entered for /users/{id} isn't sent to /orders/{id}.
+func pathTemplate(endpoint string) string {
+	path, _, _ := strings.Cut(endpoint, "?")
+	return path
+}
+
+// fillPath fills the {param} placeholders of an endpoint with the values entered for it this session,
+// returning the names that have no value yet
+func (m *TestUIModel) fillPath(endpoint string) (string, []string) {
+	return tester.FillPath(endpoint, m.pathValues[pathTemplate(endpoint)])
+}
+
+// requestPathValues starts asking for the missing path parameters of a request instead of sending
+// it with a literal {param}, which would only get a 404
+func (m *TestUIModel) requestPathValues(prompt *pathPrompt) {
+	m.pathPrompt = prompt
+	m.addMessage("")
+	m.addAgentMessage(m.agentStyle.Render(fmt.Sprintf("%s %s needs path parameters: {%s}",
+		prompt.method, prompt.endpoint, strings.Join(prompt.missing, "}, {"))))
+	m.askPathValue()
+}
+
+// askPathValue prompts for the next missing path parameter
+func (m *TestUIModel) askPathValue() {
+	name := m.pathPrompt.missing[0]
+	m.addMessage(m.subtleStyle.Render(fmt.Sprintf("  Enter a value for {%s} (/cancel to skip the request)", name)))
+	m.textarea.Placeholder = fmt.Sprintf("Value for {%s}", name)
+	m.updateViewport()
+}
+
+// handlePathValue takes a typed value for the current placeholder. Values are remembered for the
+// endpoint for the rest of the session, and once every placeholder is filled the request is sent (or the test run resumes).
+func (m *TestUIModel) handlePathValue(input string) tea.Cmd {
+	prompt := m.pathPrompt
+	value := strings.TrimSpace(input)
+	if value == "/cancel" {
+		m.pathPrompt = nil
+		m.textarea.Placeholder = m.inputPlaceholder()
+		m.addMessage(m.subtleStyle.Render(fmt.Sprintf("  Skipped %s %s", prompt.method, prompt.endpoint)))
+		m.addMessage("")
+		if prompt.test == nil {
+			return nil
+		}
+		m.testGroupResults = append(m.testGroupResults, map[string]any{
+			"method":        prompt.method,
+			"endpoint":      prompt.endpoint,
+			"skipped":       true,
+			"skip_reason":   "path parameters not provided: " + strings.Join(prompt.missing, ", "),
+			"requires_auth": prompt.test["requires_auth"],
+		})
+		m.testResults = append(m.testResults, reporter.TestResult{
+			Method: prompt.method, Endpoint: prompt.endpoint, Skipped: "path parameters not provided",
+		})
+		m.testGroupCompletedCount++
+		m.agentState = StateRunningTests
+		return m.runNextTest()
+	}
+	if value == "" || strings.ContainsAny(value, "{}") {
+		m.addMessage(m.errorStyle.Render("  A path parameter value can't be empty or contain { }"))
+		m.askPathValue()
+		return nil
+	}
+
+	name := prompt.missing[0]
+	key := pathTemplate(prompt.template)
+	if m.pathValues[key] == nil {
+		m.pathValues[key] = map[string]string{}
+	}
+	m.pathValues[key][name] = value
+	m.addMessage(lipgloss.NewStyle().Foreground(Theme.TextMuted).Render(fmt.Sprintf("  {%s} = %s", name, value)))
+	if prompt.missing = prompt.missing[1:]; len(prompt.missing) > 0 {
+		m.askPathValue()
+		return nil
+	}
+
+	m.pathPrompt = nil
+	m.textarea.Placeholder = m.inputPlaceholder()
+	if prompt.test != nil {
+		m.pendingTests = append([]map[string]any{prompt.test}, m.pendingTests...)
+		m.agentState = StateRunningTests
+		return m.runNextTest()
+	}
+	return m.sendManualRequest(prompt.method, prompt.template, prompt.body)
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPathValuesPerEndpoint(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+	}))
+	defer server.Close()
+	m := NewTestUIModel(server.URL, "", nil, &auth.NoAuth{}, "test")
+
+	send := func(cmd tea.Cmd) {
+		t.Helper()
+		if cmd == nil {
+			t.Fatal("request wasn't sent")
+		}
+		if msg, ok := cmd().(curlResultMsg); !ok || msg.err != nil {
+			t.Fatalf("request failed: %+v", msg)
+		}
+	}
+
+	// Every placeholder of a new endpoint is asked for, then the request is sent
+	if cmd := m.sendManualRequest("GET", "/users/{id}/posts/{postId}", nil); cmd != nil || m.pathPrompt == nil {
+		t.Fatal("request with unknown path parameters was sent without asking")
+	}
+	m.handlePathValue("42")
+	send(m.handlePathValue("7"))
+
+	// The same endpoint reuses its values
+	send(m.sendManualRequest("GET", "/users/{id}/posts/{postId}?expand=author", nil))
+
+	// Another endpoint with an {id} placeholder asks again
+	if cmd := m.sendManualRequest("DELETE", "/orders/{id}", nil); cmd != nil || m.pathPrompt == nil {
+		t.Fatal("{id} entered for /users/{id}/posts/{postId} was reused for /orders/{id}")
+	}
+	send(m.handlePathValue("A-1"))
+
+	want := []string{"/users/42/posts/7", "/users/42/posts/7", "/orders/A-1"}
+	if len(paths) != len(want) {
+		t.Fatalf("server got %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("request %d went to %s, want %s", i, paths[i], want[i])
+		}
+	}
+}
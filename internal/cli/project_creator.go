@@ -3,7 +3,9 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
 	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -23,7 +25,7 @@ type FormatInfo struct {
 
 // detectSpecFormat analyzes file content to determine the API specification format
 func detectSpecFormat(filePath string) (*FormatInfo, error) {
-	content, err := os.ReadFile(filePath)
+	content, err := readSpecHead(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -52,9 +54,10 @@ func detectSpecFormat(filePath string) (*FormatInfo, error) {
 		return &FormatInfo{Name: "Swagger", Version: version, NativeSupport: true}, nil
 	}
 
-	// AsyncAPI
+	// AsyncAPI (native support for 2.x)
 	if strings.Contains(textLower, "asyncapi:") || strings.Contains(textLower, `"asyncapi"`) {
-		return &FormatInfo{Name: "AsyncAPI", NeedsConversion: true}, nil
+		version := extractVersion(text, "asyncapi")
+		return asyncAPIFormat(version), nil
 	}
 
 	// RAML
@@ -76,9 +79,12 @@ func detectSpecFormat(filePath string) (*FormatInfo, error) {
 		return &FormatInfo{Name: "GraphQL Schema", NativeSupport: true}, nil
 	}
 
-	// Protocol Buffers
-	if ext == ".proto" || strings.Contains(text, "syntax = \"proto") {
-		return &FormatInfo{Name: "Protocol Buffers", NeedsConversion: true}, nil
+	// Protocol Buffers (native support for .proto files)
+	if ext == ".proto" {
+		return &FormatInfo{Name: "Protocol Buffers", Version: extractVersion(text, `syntax = "proto`), NativeSupport: true}, nil
+	}
+	if strings.Contains(text, "syntax = \"proto") {
+		return &FormatInfo{Name: "Protocol Buffers", Version: extractVersion(text, `syntax = "proto`), NeedsConversion: true}, nil
 	}
 
 	// WSDL
@@ -113,6 +119,27 @@ func detectSpecFormat(filePath string) (*FormatInfo, error) {
 	return &FormatInfo{Name: "API Description", NeedsConversion: true}, nil
 }
 
+// formatSniffSize is how much of a large spec is read to detect its format
+const formatSniffSize = 1 << 20
+
+// readSpecHead reads the whole spec, or only its beginning when the file is too large to load
+func readSpecHead(filePath string) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() <= parser.LargeSpecThreshold {
+		return io.ReadAll(file)
+	}
+	return io.ReadAll(io.LimitReader(file, formatSniffSize))
+}
+
 // hasStructuredAPIFormat checks if markdown has structured API endpoint format
 // like "## GET /users" or "### POST /api/items"
 func hasStructuredAPIFormat(content string) bool {
@@ -133,6 +160,14 @@ func hasStructuredAPIFormat(content string) bool {
 	return false
 }
 
+// asyncAPIFormat describes an AsyncAPI document; only 2.x is parsed natively
+func asyncAPIFormat(version string) *FormatInfo {
+	if strings.HasPrefix(version, "2.") {
+		return &FormatInfo{Name: "AsyncAPI", Version: version, NativeSupport: true}
+	}
+	return &FormatInfo{Name: "AsyncAPI", Version: version, NeedsConversion: true}
+}
+
 // detectJSONFormat detects format from parsed JSON
 func detectJSONFormat(data map[string]interface{}) *FormatInfo {
 	// OpenAPI 3.x
@@ -147,7 +182,8 @@ func detectJSONFormat(data map[string]interface{}) *FormatInfo {
 
 	// AsyncAPI
 	if _, ok := data["asyncapi"]; ok {
-		return &FormatInfo{Name: "AsyncAPI", NeedsConversion: true}
+		v, _ := data["asyncapi"].(string)
+		return asyncAPIFormat(v)
 	}
 
 	// Postman Collection (native support)
@@ -170,7 +206,7 @@ func detectJSONFormat(data map[string]interface{}) *FormatInfo {
 	// HAR
 	if log, ok := data["log"].(map[string]interface{}); ok {
 		if _, hasEntries := log["entries"]; hasEntries {
-			return &FormatInfo{Name: "HAR (HTTP Archive)", NeedsConversion: true}
+			return &FormatInfo{Name: "HAR (HTTP Archive)", NativeSupport: true}
 		}
 	}
 
@@ -236,10 +272,11 @@ type ProjectCreatorModel struct {
 
 	// Format detection
 	formatInfo *FormatInfo
+	specServer string // Base URL taken from the spec's servers when no URL was entered
 
 	// Auth configuration
 	configureAuth    bool
-	authType         string   // "bearer", "apikey", "basic", "none"
+	authType         string   // "bearer", "apikey", "basic", "digest", "custom", "oauth2", "awssigv4", "none"
 	authMenuItems    []string // Menu options for auth type selection
 	authMenuIndex    int      // Selected menu item index
 	authFields       []FormField
@@ -316,11 +353,8 @@ func (m ProjectCreatorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			switch m.step {
 			case ProjectStepURL:
+				// Left empty, the URL is taken from the spec's servers
 				m.url = strings.TrimSpace(m.input.Value())
-				if m.url == "" {
-					m.validationError = "API URL is required"
-					return m, nil
-				}
 				m.step = ProjectStepSpecPath
 				m.input.SetValue("")
 				m.input.Placeholder = "./spec.yaml, ./api.raml, ./schema.graphql..."
@@ -344,13 +378,25 @@ func (m ProjectCreatorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.validationError = fmt.Sprintf("Failed to analyze file: %s", err.Error())
 					return m, nil
 				}
+				m.specServer = ""
+				if m.url == "" {
+					if formatInfo.NativeSupport {
+						if servers, err := parser.DetectServers(m.specPath); err == nil && len(servers) > 0 {
+							m.specServer = servers[0]
+						}
+					}
+					if m.specServer == "" {
+						m.validationError = "The spec declares no server URL; go back (Esc) and enter the API URL"
+						return m, nil
+					}
+				}
 				m.formatInfo = formatInfo
 				m.step = ProjectStepFormatDetected
 				m.input.Blur()
 				return m, nil
 
 			case ProjectStepFormatDetected:
-				// User confirmed format, continue to name
+				// User confirmed format and the spec's server, continue to name
 				m.step = ProjectStepName
 				m.input.SetValue("")
 				m.input.Placeholder = "my-api-project"
@@ -382,6 +428,14 @@ func (m ProjectCreatorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.authType = "apikey"
 				case "Basic Auth":
 					m.authType = "basic"
+				case "Digest Auth":
+					m.authType = "digest"
+				case "Custom Header":
+					m.authType = "custom"
+				case "OAuth2 Client Credentials":
+					m.authType = "oauth2"
+				case "AWS SigV4":
+					m.authType = "awssigv4"
 				case "None":
 					m.authType = "none"
 				}
@@ -422,7 +476,7 @@ func (m ProjectCreatorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "y", "Y":
 			if m.step == ProjectStepAuthPrompt {
 				m.configureAuth = true
-				m.authMenuItems = []string{"Bearer Token", "API Key", "Basic Auth", "None"}
+				m.authMenuItems = []string{"Bearer Token", "API Key", "Basic Auth", "Digest Auth", "Custom Header", "OAuth2 Client Credentials", "AWS SigV4", "None"}
 				m.authMenuIndex = 0
 				m.step = ProjectStepAuthType
 				return m, nil
@@ -565,12 +619,17 @@ func (m ProjectCreatorModel) renderURLStep() string {
 		Foreground(Theme.TextMuted).
 		Render("Enter the API base URL")
 
+	hint := lipgloss.NewStyle().
+		Foreground(Theme.TextSubtle).
+		Render("Leave empty to use the server declared in an OpenAPI spec")
+
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
 		"",
 		title,
 		"",
 		subtitle,
+		hint,
 		"",
 		"",
 		m.input.View(),
@@ -743,6 +802,16 @@ func (m ProjectCreatorModel) renderFormatDetected() string {
 		statusLine,
 	)
 
+	if m.specServer != "" && m.url == "" {
+		serverLine := lipgloss.NewStyle().
+			Foreground(Theme.TextMuted).
+			Render("Base URL from the spec: ") +
+			lipgloss.NewStyle().
+				Foreground(Theme.Primary).
+				Render(m.specServer)
+		content = lipgloss.JoinVertical(lipgloss.Left, content, "", serverLine)
+	}
+
 	if warning != "" {
 		content = lipgloss.JoinVertical(lipgloss.Left, content, "", warning)
 	}
@@ -764,7 +833,7 @@ func (m ProjectCreatorModel) renderConfirmation() string {
 	labelStyle := lipgloss.NewStyle().Foreground(Theme.TextMuted)
 	valueStyle := lipgloss.NewStyle().Foreground(Theme.Text)
 
-	urlLine := labelStyle.Render("API URL: ") + valueStyle.Render(m.url)
+	urlLine := labelStyle.Render("API URL: ") + valueStyle.Render(m.baseURL())
 	specLine := labelStyle.Render("Specification: ") + valueStyle.Render(m.specPath)
 	nameLine := labelStyle.Render("Project Name: ") + valueStyle.Render(m.name)
 
@@ -819,9 +888,17 @@ func (m ProjectCreatorModel) IsConfirmed() bool {
 	return m.confirmed
 }
 
+// baseURL returns the URL entered in the wizard, or the spec's server when it was left empty
+func (m ProjectCreatorModel) baseURL() string {
+	if m.url == "" {
+		return m.specServer
+	}
+	return m.url
+}
+
 // GetProjectData returns the project data entered by the user
 func (m ProjectCreatorModel) GetProjectData() (url, specPath, name string) {
-	return m.url, m.specPath, m.name
+	return m.baseURL(), m.specPath, m.name
 }
 
 // NeedsConversion returns true if the spec file needs LLM conversion
@@ -843,24 +920,13 @@ func (m ProjectCreatorModel) GetAuthConfig() (authType string, authData map[stri
 		return "none", nil
 	}
 
-	authData = make(map[string]string)
-	for _, field := range m.authFields {
-		if field.IsRadio {
-			if field.RadioIndex < len(field.Options) {
-				authData[field.Name] = field.Options[field.RadioIndex]
-			}
-		} else {
-			authData[field.Name] = field.Value
-		}
-	}
-
-	return m.authType, authData
+	return m.authType, formValues(m.authFields)
 }
 
 // validateAuthFields checks if all required auth fields are filled
 func validateAuthFields(authType string, fields []FormField) bool {
 	for _, field := range fields {
-		if field.Name == "profile_name" {
+		if field.Name == "profile_name" || field.Name == "scopes" {
 			continue // Optional
 		}
 		if !field.IsRadio && strings.TrimSpace(field.Value) == "" {
@@ -969,6 +1035,14 @@ func (m ProjectCreatorModel) renderAuthDetailsForm() string {
 		title = "API Key Authentication"
 	case "basic":
 		title = "Basic Authentication"
+	case "digest":
+		title = "Digest Authentication"
+	case "custom":
+		title = "Custom Header Authentication"
+	case "oauth2":
+		title = "OAuth2 Client Credentials"
+	case "awssigv4":
+		title = "AWS Signature Version 4"
 	}
 
 	b.WriteString(titleStyle.Render(title))
@@ -33,6 +33,12 @@ var (
 
 	searchStyle = lipgloss.NewStyle().
 			Foreground(Theme.PrimaryDark)
+
+	noticeStyle = lipgloss.NewStyle().
+			Foreground(Theme.Success)
+
+	noticeErrorStyle = lipgloss.NewStyle().
+				Foreground(Theme.Error)
 )
 
 // ProjectListModel represents the interactive project list UI
@@ -45,6 +51,12 @@ type ProjectListModel struct {
 	selected         *storage.Project
 	createNew        bool // Set to true when user selects "Create new project"
 	err              error
+
+	deleting    *storage.Project // Project waiting for the user to confirm its deletion
+	renaming    *storage.Project // Project whose name is being edited in renameInput
+	renameInput textinput.Model
+	notice      string // Result of the last delete or rename
+	noticeErr   bool
 }
 
 func NewProjectListModel(projects []*storage.Project) ProjectListModel {
@@ -52,6 +64,10 @@ func NewProjectListModel(projects []*storage.Project) ProjectListModel {
 	ti.Placeholder = "Search projects..."
 	ti.CharLimit = 50
 
+	ri := textinput.New()
+	ri.Placeholder = "Project name"
+	ri.CharLimit = 100
+
 	// Default cursor to first project (position 1), or "Create new" (position 0) if no projects
 	cursor := 1
 	if len(projects) == 0 {
@@ -64,6 +80,7 @@ func NewProjectListModel(projects []*storage.Project) ProjectListModel {
 		cursor:           cursor,
 		searchInput:      ti,
 		searching:        false,
+		renameInput:      ri,
 	}
 }
 
@@ -74,6 +91,13 @@ func (m ProjectListModel) Init() tea.Cmd {
 func (m ProjectListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.deleting != nil {
+			return m.updateDeleteConfirm(msg)
+		}
+		if m.renaming != nil {
+			return m.updateRename(msg)
+		}
+
 		// If searching, handle search input
 		if m.searching {
 			switch msg.String() {
@@ -122,6 +146,22 @@ func (m ProjectListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cursor--
 			}
 
+		case "d":
+			if project := m.projectAtCursor(); project != nil {
+				m.deleting = project
+				m.notice = ""
+			}
+
+		case "r":
+			if project := m.projectAtCursor(); project != nil {
+				m.renaming = project
+				m.notice = ""
+				m.renameInput.SetValue(project.Name)
+				m.renameInput.CursorEnd()
+				m.renameInput.Focus()
+				return m, textinput.Blink
+			}
+
 		case "down", "j":
 			// Max cursor is len(filteredProjects) - "Create new project" is at 0, projects start at 1
 			if m.cursor < len(m.filteredProjects) {
@@ -145,6 +185,100 @@ func (m ProjectListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// projectAtCursor returns the highlighted project, or nil when "Create new project" is
+func (m ProjectListModel) projectAtCursor() *storage.Project {
+	if m.cursor > 0 && m.cursor-1 < len(m.filteredProjects) {
+		return m.filteredProjects[m.cursor-1]
+	}
+	return nil
+}
+
+// updateDeleteConfirm deletes the project waiting for confirmation on "y" and cancels on any other key
+func (m ProjectListModel) updateDeleteConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	project := m.deleting
+	m.deleting = nil
+	if msg.String() != "y" && msg.String() != "Y" {
+		return m, nil
+	}
+
+	if err := storage.DeleteProject(project); err != nil {
+		m.setNotice(err.Error(), true)
+		return m, nil
+	}
+
+	remaining := make([]*storage.Project, 0, len(m.projects))
+	for _, p := range m.projects {
+		if p.ID != project.ID {
+			remaining = append(remaining, p)
+		}
+	}
+	m.projects = remaining
+	m.filterProjects()
+	m.cursor = min(m.cursor, len(m.filteredProjects))
+	m.setNotice(fmt.Sprintf("Deleted '%s'", project.Name), false)
+	return m, nil
+}
+
+// updateRename edits the new name, saving it on enter unless another project already has it
+func (m ProjectListModel) updateRename(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.stopRenaming()
+		return m, nil
+
+	case "enter":
+		project := m.renaming
+		name := strings.TrimSpace(m.renameInput.Value())
+		if name == "" {
+			m.setNotice("Project name can't be empty", true)
+			return m, nil
+		}
+		if name == project.Name {
+			m.stopRenaming()
+			return m, nil
+		}
+
+		conflict, err := storage.CheckNameConflict(name, project.ID)
+		if err != nil {
+			m.setNotice(err.Error(), true)
+			return m, nil
+		}
+		if conflict != nil {
+			m.setNotice(fmt.Sprintf("A project named '%s' already exists", name), true)
+			return m, nil
+		}
+
+		oldName := project.Name
+		project.Name = name
+		if err := storage.SaveProject(project); err != nil {
+			project.Name = oldName
+			m.setNotice(err.Error(), true)
+			return m, nil
+		}
+
+		m.stopRenaming()
+		m.filterProjects()
+		m.cursor = min(m.cursor, len(m.filteredProjects))
+		m.setNotice(fmt.Sprintf("Renamed '%s' to '%s'", oldName, name), false)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.renameInput, cmd = m.renameInput.Update(msg)
+	return m, cmd
+}
+
+func (m *ProjectListModel) stopRenaming() {
+	m.renaming = nil
+	m.renameInput.Blur()
+	m.renameInput.SetValue("")
+}
+
+func (m *ProjectListModel) setNotice(notice string, isErr bool) {
+	m.notice = notice
+	m.noticeErr = isErr
+}
+
 func (m *ProjectListModel) filterProjects() {
 	query := strings.ToLower(m.searchInput.Value())
 	if query == "" {
@@ -186,8 +320,15 @@ func (m ProjectListModel) View() string {
 	s.WriteString(titleStyle.Render("Select a Project"))
 	s.WriteString("\n\n")
 
-	// Search bar
-	if m.searching {
+	// Search bar, or the delete confirmation and rename input in its place
+	if m.deleting != nil {
+		s.WriteString(noticeErrorStyle.Render(fmt.Sprintf("Delete '%s' with its endpoints and history? (y/N)", m.deleting.Name)))
+		s.WriteString("\n\n")
+	} else if m.renaming != nil {
+		s.WriteString(searchStyle.Render("Rename: "))
+		s.WriteString(m.renameInput.View())
+		s.WriteString("\n\n")
+	} else if m.searching {
 		s.WriteString(searchStyle.Render("Search: "))
 		s.WriteString(m.searchInput.View())
 		s.WriteString("\n\n")
@@ -210,6 +351,15 @@ func (m ProjectListModel) View() string {
 	}
 	s.WriteString("\n\n")
 
+	if m.notice != "" {
+		style := noticeStyle
+		if m.noticeErr {
+			style = noticeErrorStyle
+		}
+		s.WriteString(style.Render(m.notice))
+		s.WriteString("\n\n")
+	}
+
 	// Projects list (starting at position 1)
 	if len(m.filteredProjects) == 0 && !m.searching {
 		s.WriteString(helpStyle.Render("No projects found. Create one to get started!"))
@@ -247,8 +397,12 @@ func (m ProjectListModel) View() string {
 	}
 
 	// Help text
-	help := "↑/k up • ↓/j down • enter select • / search • q quit"
-	if m.searching {
+	help := "↑/k up • ↓/j down • enter select • / search • r rename • d delete • q quit"
+	if m.deleting != nil {
+		help = "y delete • any other key cancel"
+	} else if m.renaming != nil {
+		help = "enter save • esc cancel"
+	} else if m.searching {
 		help = "esc cancel search • enter apply"
 	}
 	s.WriteString(helpStyle.Render(help))
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// saveTestProjects stores named projects in a temporary home and returns them as the list shows them
+func saveTestProjects(t *testing.T, names ...string) []*storage.Project {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	var projects []*storage.Project
+	for _, name := range names {
+		project := &storage.Project{ID: name + "-id", Name: name, BaseURL: "https://api.example.com"}
+		if err := storage.SaveProject(project); err != nil {
+			t.Fatal(err)
+		}
+		projects = append(projects, project)
+	}
+	return projects
+}
+
+// press sends keys to the model one at a time
+func press(m ProjectListModel, keys ...string) ProjectListModel {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "ctrl+u":
+			msg = tea.KeyMsg{Type: tea.KeyCtrlU}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		next, _ := m.Update(msg)
+		m = next.(ProjectListModel)
+	}
+	return m
+}
+
+func TestProjectListDelete(t *testing.T) {
+	projects := saveTestProjects(t, "alpha", "beta")
+	m := NewProjectListModel(projects)
+
+	// Anything but "y" cancels
+	m = press(m, "d", "n")
+	if _, err := storage.LoadProject("alpha-id"); err != nil {
+		t.Fatalf("project deleted without confirmation: %v", err)
+	}
+
+	m = press(m, "d", "y")
+	if _, err := storage.LoadProject("alpha-id"); err == nil {
+		t.Error("alpha is still on disk after confirming the deletion")
+	}
+	if len(m.filteredProjects) != 1 || m.filteredProjects[0].Name != "beta" {
+		t.Errorf("list after delete = %v, want only beta", m.filteredProjects)
+	}
+	if got := m.projectAtCursor(); got == nil || got.Name != "beta" {
+		t.Errorf("cursor on %v after delete, want beta", got)
+	}
+
+	// Deleting the last project moves the cursor to "Create new project"
+	m = press(m, "d", "y")
+	if len(m.projects) != 0 || m.cursor != 0 {
+		t.Errorf("after deleting everything: %d projects, cursor %d; want 0 and 0", len(m.projects), m.cursor)
+	}
+}
+
+func TestProjectListRename(t *testing.T) {
+	projects := saveTestProjects(t, "alpha", "beta")
+	m := NewProjectListModel(projects)
+
+	// A name taken by another project is refused and the rename stays open
+	m = press(m, "r", "ctrl+u", "b", "e", "t", "a", "enter")
+	if m.renaming == nil || !m.noticeErr {
+		t.Fatalf("renaming to an existing name: renaming = %v, notice = %q; want an error and the input kept open", m.renaming, m.notice)
+	}
+
+	m = press(m, "ctrl+u", "g", "a", "m", "m", "a", "enter")
+	if m.renaming != nil || m.noticeErr {
+		t.Fatalf("rename not saved: notice = %q", m.notice)
+	}
+	loaded, err := storage.LoadProject("alpha-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Name != "gamma" {
+		t.Errorf("name on disk = %q, want gamma", loaded.Name)
+	}
+	if m.filteredProjects[0].Name != "gamma" {
+		t.Errorf("list shows %q, want gamma", m.filteredProjects[0].Name)
+	}
+
+	// Esc leaves the name alone
+	m = press(m, "down", "r", "x", "esc")
+	if loaded, _ := storage.LoadProject("beta-id"); loaded.Name != "beta" {
+		t.Errorf("name after canceled rename = %q, want beta", loaded.Name)
+	}
+	if m.renaming != nil {
+		t.Error("still renaming after esc")
+	}
+}
+
+func TestProjectListRenameRefreshesSearch(t *testing.T) {
+	projects := saveTestProjects(t, "alpha", "beta")
+	m := NewProjectListModel(projects)
+
+	m = press(m, "/", "a", "l", "enter")
+	if len(m.filteredProjects) != 1 {
+		t.Fatalf("search matched %d projects, want 1", len(m.filteredProjects))
+	}
+
+	m = press(m, "r", "ctrl+u", "z", "e", "d", "enter")
+	if len(m.filteredProjects) != 0 || m.cursor != 0 {
+		t.Errorf("after renaming away from the search: %d matches, cursor %d; want 0 and 0", len(m.filteredProjects), m.cursor)
+	}
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+)
+
+// runReauth handles /reauth and, when it starts renewing credentials, reports the outcome.
+// It returns what /reauth printed.
+func runReauth(t *testing.T, m *TestUIModel) string {
+	t.Helper()
+	m.messages = nil
+	_, cmd, handled := handleSlashCommands(m, "/reauth")
+	if !handled {
+		t.Fatal("/reauth wasn't handled")
+	}
+	if cmd != nil {
+		msg, ok := cmd().(reauthMsg)
+		if !ok {
+			t.Fatal("/reauth didn't report a reauthMsg")
+		}
+		m.handleReauth(msg)
+	}
+	return strings.Join(m.messages, "\n")
+}
+
+func TestReauth(t *testing.T) {
+	var issued int
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issued++
+		_, _ = w.Write([]byte(`{"access_token":"fresh","token_type":"Bearer"}`))
+	}))
+	defer tokenServer.Close()
+
+	failingBearer := auth.NewBearerAuth("old")
+	failingBearer.RefreshFunc = func() (string, error) { return "", errors.New("login server down") }
+	renewingBearer := auth.NewBearerAuth("old")
+	renewingBearer.RefreshFunc = func() (string, error) { return "new", nil }
+
+	tests := []struct {
+		name     string
+		provider auth.AuthProvider
+		cookies  bool
+		want     string
+	}{
+		{"oauth2 fetches a new token", auth.NewOAuth2ClientCredentials(tokenServer.URL, "client", "secret", nil), false, "✓ Re-authenticated"},
+		{"bearer runs its token command", renewingBearer, false, "✓ Re-authenticated"},
+		{"bearer token command failure", failingBearer, false, "Re-authentication failed: failed to refresh bearer token: login server down"},
+		{"bearer without a token command", auth.NewBearerAuth("old"), false, "no --token-command is configured"},
+		{"cookie login", &auth.NoAuth{}, true, "A cookie session is renewed by logging in again"},
+		{"static credentials", auth.NewBasicAuth("user", "pass"), false, "Current authentication (basic) can't renew its credentials"},
+		{"no credentials, login step captured a token", &auth.NoAuth{}, false, "a token captured from a login request is renewed by sending that request again"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewTestUIModel("https://api.example.com", "", nil, tt.provider, "test")
+			m.cookieSession = tt.cookies
+			if output := runReauth(t, m); !strings.Contains(output, tt.want) {
+				t.Errorf("/reauth printed:\n%s\nwant %q", output, tt.want)
+			}
+		})
+	}
+
+	if issued != 1 {
+		t.Errorf("token endpoint called %d times, want once for the OAuth2 case", issued)
+	}
+	if renewingBearer.Token != "new" {
+		t.Errorf("bearer token = %q after /reauth, want the refreshed one", renewingBearer.Token)
+	}
+}
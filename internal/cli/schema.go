@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxShownViolations caps the schema violations listed in the chat; the model gets all of them
+const maxShownViolations = 5
+
+// checkResponseSchema validates a JSON response against the schema the spec declares for its
+// status, recording the outcome in testResult. Responses without a declared schema are skipped.
+func (m *TestUIModel) checkResponseSchema(method, endpoint string, result *tester.TestResult, testResult map[string]any) {
+	if !m.validateSchemas || m.currentProject == nil || result.Binary {
+		return
+	}
+	endpoints, err := m.loadProjectEndpoints()
+	if err != nil {
+		return
+	}
+
+	schema := ""
+	for _, ep := range endpoints {
+		if strings.EqualFold(ep.Method, method) && ep.MatchesPath(endpoint) {
+			schema = ep.ResponseSchema(result.StatusCode)
+			break
+		}
+	}
+	if schema == "" {
+		return
+	}
+
+	var messages []string
+	violations, err := tester.ValidateSchema(result.ResponseBody, schema)
+	if err != nil {
+		messages = []string{err.Error()}
+	}
+	for _, v := range violations {
+		messages = append(messages, v.String())
+	}
+
+	testResult["schema_valid"] = len(messages) == 0
+	if len(messages) == 0 {
+		m.addMessage(m.subtleStyle.Render(fmt.Sprintf("    Schema: ✓ matches the %d response schema", result.StatusCode)))
+		return
+	}
+	testResult["schema_violations"] = messages
+
+	warning := lipgloss.NewStyle().Foreground(Theme.Warning)
+	m.addMessage(warning.Render(fmt.Sprintf("    ⚠ Response doesn't match the %d schema (%d issue(s))", result.StatusCode, len(messages))))
+	for i, msg := range messages {
+		if i == maxShownViolations {
+			m.addMessage(m.subtleStyle.Render(fmt.Sprintf("      … and %d more", len(messages)-i)))
+			break
+		}
+		m.addMessage(m.subtleStyle.Render("      " + msg))
+	}
+}
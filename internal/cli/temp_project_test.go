@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+)
+
+func TestShutdownPrunesTemporaryProjectUnlessKept(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, keep := range []bool{false, true} {
+		project := &storage.Project{ID: "shutdown-" + filepath.Base(t.TempDir()), IsTemporary: true}
+		if err := storage.SaveProject(project); err != nil {
+			t.Fatalf("SaveProject() error = %v", err)
+		}
+		projectPath, _ := storage.GetProjectPathByType(project.ID, true)
+		t.Cleanup(func() { _ = os.RemoveAll(projectPath) })
+
+		m := NewTestUIModel("http://localhost", "", nil, &auth.NoAuth{}, "test")
+		m.currentProject = project
+		m.keepTempProject = keep
+		m.shutdown()
+
+		_, err := os.Stat(filepath.Join(projectPath, "project.json"))
+		if kept := err == nil; kept != keep {
+			t.Errorf("keepTempProject = %v: project kept = %v", keep, kept)
+		}
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"github.com/Octrafic/octrafic-cli/internal/config"
 	"github.com/Octrafic/octrafic-cli/internal/core/analyzer"
 	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/core/reporter"
 	"github.com/Octrafic/octrafic-cli/internal/core/tester"
 	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"github.com/Octrafic/octrafic-cli/internal/llm/common"
 	"github.com/Octrafic/octrafic-cli/internal/updater"
 	"strings"
 	"time"
@@ -49,12 +51,31 @@ type Command struct {
 var availableCommands = []Command{
 	{Name: "/think", Description: "Toggle thinking mode (Ctrl+T)"},
 	{Name: "/clear", Description: "Clear the conversation history"},
+	{Name: "/resume", Description: "Continue this project's last saved conversation"},
+	{Name: "/trim", Description: "Keep only the last N conversation turns (/trim 10)"},
 	{Name: "/help", Description: "Show help and available commands"},
 	{Name: "/logout", Description: "Logout and clear session"},
 	{Name: "/exit", Description: "Exit the application"},
 	{Name: "/auth", Description: "Open authentication wizard"},
+	{Name: "/reauth", Description: "Renew the current credentials (e.g. fetch a new OAuth2 token)"},
+	{Name: "/retry-auth", Description: "Re-run the requests rejected with 401/403, optionally with a saved auth profile (/retry-auth prod)"},
 	{Name: "/info", Description: "Show current project info"},
 	{Name: "/release-notes", Description: "Show latest release notes"},
+	{Name: "/open", Description: "Open the most recent report"},
+	{Name: "/findings", Description: "List the issues the agent recorded this session"},
+	{Name: "/export", Description: "Save the conversation as Markdown, or JSON for a .json path (/export session.json)"},
+	{Name: "/limits", Description: "Show LLM provider rate limits"},
+	{Name: "/model", Description: "Show or change the LLM for this project (/model openai gpt-4o, /model reset)"},
+	{Name: "/tokens", Description: "Toggle per-turn token usage next to agent replies"},
+	{Name: "/auto", Description: "Toggle running test plans and requests without asking (destructive requests still ask)"},
+	{Name: "/cost", Description: "Estimate what the session's tokens cost at the model's list price"},
+	{Name: "/history", Description: "Show recent results for an endpoint (/history GET /users)"},
+	{Name: "/import-curl", Description: "Run a pasted curl command as a test"},
+	{Name: "/curl", Description: "Show a curl command repeating the last request (/curl full to include credentials)"},
+	{Name: "/mode", Description: "Switch between the agent and manual requests (Ctrl+R)"},
+	{Name: "/volatile", Description: "List or change response fields ignored when comparing (/volatile add $.meta.request_id)"},
+	{Name: "/parallel", Description: "Send up to N tests of a group at once (/parallel 4, /parallel 1 to turn off)"},
+	{Name: "/delay", Description: "Set the pause between test requests, with optional jitter (/delay 500ms 20%)"},
 }
 
 type Test struct {
@@ -66,6 +87,7 @@ type Test struct {
 	Result      string
 	BackendTest *agent.TestCase
 	Selected    bool
+	Deprecated  bool
 }
 
 type agentResponseMsg struct {
@@ -120,6 +142,7 @@ type TestUIModel struct {
 	baseURL        string
 	specPath       string           // Path to spec file for SearchSpec
 	currentProject *storage.Project // Currently active project
+	endpoints      *endpointCache   // The project's endpoints, read from disk once per session
 	localAgent     *agent.Agent
 	testExecutor   *tester.Executor
 	authProvider   auth.AuthProvider
@@ -128,12 +151,21 @@ type TestUIModel struct {
 	agentState               AgentState
 	executionMode            ExecutionMode
 	thinkingEnabled          bool   // Whether to use /think tag for reasoning
+	manualMode               bool   // Typed lines are sent as requests instead of chat messages
+	lastResponseBody         string // Body of the last manual or imported curl request, for Ctrl+Y
 	lastMessageRole          string // Track who sent the last message ("user" or "assistant")
 	conversationHistory      []agent.ChatMessage
+	chatSessionID            string   // File the conversation is saved to; set on the first save or by /resume
+	maxTurns                 int      // Cap on turns sent to the LLM (0 = unlimited)
+	displayBodyLimit         int      // Response body bytes shown in the chat (0 = no limit)
+	destructiveMethods       []string // HTTP methods confirmed before every request, even in auto-execute mode
+	modelBodyLimit           int      // Response body bytes sent to the model (0 = no limit)
+	keepTempProject          bool     // Leave the temporary project on disk when quitting
 	currentToolCall          *agent.ToolCall
 	pendingToolCall          *agent.ToolCall
 	pendingTestGroupToolCall *agent.ToolCall  // Saved ExecuteTestGroup tool call for test selection
 	streamedToolCalls        []agent.ToolCall // Tool calls received from stream, processed when DONE
+	streamedToolConfirmed    bool             // The next streamed tool call was approved in the confirmation dialog
 	streamedAgentMessage     string           // Agent message received from stream, saved to history when DONE
 	streamedReasoningChunk   string
 	streamedTextChunk        string
@@ -154,12 +186,34 @@ type TestUIModel struct {
 	textarea      textarea.Model
 	lastEscPress  time.Time // Track last ESC press for double-ESC detection
 	showClearHint bool      // Show "Press ESC again to clear" hint
+	copyHint      string    // Result of the last Ctrl+Y, shown below the input for a moment
+	copyHintErr   bool      // Show copyHint as a failure
+	copyHintAt    time.Time // When copyHint was set, to hide it after copyHintDuration
+	authHint      bool      // Offer Ctrl+G to open the auth wizard after 401/403 responses
+
+	authRejectedTests []map[string]any // Requests last rejected with 401/403, sent again by /retry-auth
+	cookieSession     bool             // Cookies set by responses are sent with later requests (--cookies)
 
 	// Command history
 	commandHistory []string // List of previous commands
 	historyIndex   int      // Current position in history (-1 = not browsing)
 	temporaryInput string   // Temporary storage for current input while browsing history
 
+	// Messages typed while the agent is busy, submitted once it returns to idle
+	queuedInputs []string
+
+	openReports      bool   // Open generated reports automatically
+	lastArtifactPath string // Most recently generated report
+
+	rateLimits *common.RateLimits // Latest rate limits reported by the LLM provider
+
+	llmProvider string // Provider name, used for status messages
+	llmModel    string // Model name, used for status messages
+	modelLoaded bool   // The provider has answered at least once this session
+
+	replaying bool // Responses are served from saved fixtures
+	recording bool // Responses are saved as fixtures
+
 	// Spinner
 	spinner spinner.Model
 
@@ -167,8 +221,11 @@ type TestUIModel struct {
 	animationFrame int
 
 	// Token usage tracking
-	inputTokens  int64
-	outputTokens int64
+	inputTokens      int64
+	outputTokens     int64
+	turnInputTokens  int64 // Usage of the turn being streamed, attributed to its message when DONE
+	turnOutputTokens int64
+	showTurnTokens   bool // Show each turn's usage after the agent's reply (/tokens)
 
 	// Tests
 	tests                   []Test
@@ -178,9 +235,24 @@ type TestUIModel struct {
 	currentTestGroupLabel   string           // Header for test group (e.g., "Testing users api")
 	testGroupCompletedCount int              // Number of tests completed in current group
 	testGroupResults        []map[string]any // Results from current test group for FunctionResponse
+	testVariables           tester.Variables // Results of named tests in the current group, for skip_unless
 	currentTestToolName     string           // Name of the tool being executed (e.g., "ExecuteTestGroup")
 	currentTestToolID       string           // ID of the tool_use for FunctionResponse
 
+	// Path parameters
+	pathValues map[string]map[string]string // Values entered for {param} placeholders this session by endpoint template, reused by later requests to it
+	pathPrompt *pathPrompt                  // Request waiting for path parameter values, nil when not prompting
+
+	// Pacing between requests in a test group
+	requestDelay    time.Duration // Fixed pause before each request
+	requestJitter   int           // Random variation of the pause, in percent of requestDelay
+	testConcurrency int           // Tests of a group sent at once; 1 or less sends them one at a time
+
+	findings    []reporter.Finding    // Issues the agent recorded this session, included in reports
+	testResults []reporter.TestResult // Tests run this session, exported by JUnit reports
+
+	validateSchemas bool // Check JSON responses against the spec's response schemas
+
 	// Version
 	currentVersion string
 	latestVersion  string
@@ -203,7 +275,7 @@ type TestUIModel struct {
 func NewTestUIModel(baseURL string, specPath string, analysis *analyzer.Analysis, authProvider auth.AuthProvider, version string) *TestUIModel {
 	// Textarea
 	ta := textarea.New()
-	ta.Placeholder = "Chat with the testing agent..."
+	ta.Placeholder = agentPlaceholder
 	ta.Focus()
 	ta.ShowLineNumbers = false
 	ta.SetHeight(1) // Start with 1 line, will grow to max 6
@@ -235,6 +307,7 @@ func NewTestUIModel(baseURL string, specPath string, analysis *analyzer.Analysis
 		analysis:            analysis,
 		baseURL:             baseURL,
 		specPath:            specPath,
+		endpoints:           &endpointCache{},
 		localAgent:          nil, // Will be initialized when needed
 		testExecutor:        tester.NewExecutor(baseURL, authProvider),
 		authProvider:        authProvider,
@@ -243,6 +316,10 @@ func NewTestUIModel(baseURL string, specPath string, analysis *analyzer.Analysis
 		thinkingEnabled:     true, // Thinking enabled by default
 		lastMessageRole:     "",   // Empty = no messages yet, so first message will show label
 		conversationHistory: []agent.ChatMessage{},
+		pathValues:          map[string]map[string]string{},
+		requestDelay:        defaultRequestDelay,
+		displayBodyLimit:    defaultDisplayBodyLimit,
+		destructiveMethods:  defaultDestructiveMethods,
 		viewport:            vp,
 		messages:            []string{},
 		textarea:            ta,
@@ -263,9 +340,22 @@ func NewTestUIModel(baseURL string, specPath string, analysis *analyzer.Analysis
 		toolStyle:           lipgloss.NewStyle().Foreground(Theme.Warning),
 	}
 
+	model.testExecutor.SetSpecPath(specPath)
 	model.currentVersion = version
-	if cfg, err := config.Load(); err == nil && cfg.LatestVersion != "" && updater.IsNewer(cfg.LatestVersion, version) {
-		model.latestVersion = cfg.LatestVersion
+	providerConfig, _ := agent.ResolveProviderConfig()
+	model.llmProvider = providerConfig.Provider
+	model.llmModel = providerConfig.Model
+	if cfg, err := config.Load(); err == nil {
+		if cfg.LatestVersion != "" && updater.IsNewer(cfg.LatestVersion, version) {
+			model.latestVersion = cfg.LatestVersion
+		}
+		model.maxTurns = cfg.MaxTurns
+		model.displayBodyLimit = bodyLimit(cfg.DisplayBodyLimit, defaultDisplayBodyLimit)
+		model.modelBodyLimit = bodyLimit(cfg.ModelBodyLimit, 0)
+		if cfg.DestructiveMethods != nil {
+			model.destructiveMethods = cfg.DestructiveMethods
+		}
+		model.keepTempProject = cfg.KeepTempProjects
 	}
 
 	// Welcome message with header style
@@ -324,6 +414,10 @@ func renderTestPlanWithCheckboxes(m *TestUIModel) string {
 	subtitle := lipgloss.NewStyle().Foreground(Theme.TextMuted).Render(fmt.Sprintf("(%d tests)", len(m.tests)))
 	s.WriteString(title + " " + subtitle + "\n\n")
 
+	if destructive := m.selectedDestructiveTests(); len(destructive) > 0 {
+		s.WriteString(m.destructiveWarning(destructive...) + "\n")
+	}
+
 	// List of tests with checkboxes
 	for i, test := range m.tests {
 		// Checkbox indicator
@@ -348,8 +442,16 @@ func renderTestPlanWithCheckboxes(m *TestUIModel) string {
 		method := methodStyle.Render(fmt.Sprintf("%-6s", test.Method))
 		endpoint := lipgloss.NewStyle().Foreground(Theme.Text).Render(test.Endpoint)
 		description := lipgloss.NewStyle().Foreground(Theme.TextMuted).Render(test.Description)
+		if test.Deprecated {
+			endpoint += " " + lipgloss.NewStyle().Foreground(Theme.Warning).Render("[deprecated]")
+		}
 
 		s.WriteString(fmt.Sprintf("%s %s %s %s → %s\n", indicator, checkbox, method, endpoint, description))
+		if test.BackendTest != nil {
+			if uploads := uploadPaths(test.BackendTest.Multipart); uploads != "" {
+				s.WriteString(lipgloss.NewStyle().Foreground(Theme.Warning).Render("           ↑ uploads "+uploads) + "\n")
+			}
+		}
 	}
 
 	// Help text
@@ -361,6 +463,14 @@ func renderTestPlanWithCheckboxes(m *TestUIModel) string {
 	return s.String()
 }
 
+// uploadPaths lists the local files a multipart request uploads, so they are seen before it is sent
+func uploadPaths(form *tester.Multipart) string {
+	if form == nil {
+		return ""
+	}
+	return strings.Join(form.FilePaths(), ", ")
+}
+
 // View renders the UI
 func (m TestUIModel) View() string {
 	var s strings.Builder
@@ -390,10 +500,19 @@ func (m TestUIModel) View() string {
 			toolName = m.pendingToolCall.Name
 		}
 
+		if m.pendingToolCall != nil {
+			if request, ok := m.destructiveRequest(*m.pendingToolCall); ok {
+				s.WriteString(m.destructiveWarning(request))
+			}
+		}
 		s.WriteString(lipgloss.NewStyle().Foreground(Theme.Warning).Bold(true).Render("Execute tool: "+toolName) + "\n")
 
 		// Show test details if executing a test
 		if strings.HasPrefix(toolName, "ExecuteTest") {
+			var form *tester.Multipart
+			if m.pendingToolCall != nil {
+				form = tester.ParseMultipart(m.pendingToolCall.Arguments["multipart"])
+			}
 			// Find next pending test to show details
 			for _, test := range m.tests {
 				if test.Status == "pending" {
@@ -405,9 +524,22 @@ func (m TestUIModel) View() string {
 					if test.Description != "" {
 						s.WriteString(m.subtleStyle.Render("Description: "+test.Description) + "\n")
 					}
+					if test.BackendTest != nil && test.BackendTest.Multipart != nil {
+						form = test.BackendTest.Multipart
+					}
 					break
 				}
 			}
+			if uploads := uploadPaths(form); uploads != "" {
+				s.WriteString(m.subtleStyle.Render("Uploads: ") + lipgloss.NewStyle().Foreground(Theme.Warning).Render(uploads) + "\n")
+			}
+		} else if m.pendingToolCall != nil {
+			// Streamed tools that send requests, such as compare_representations
+			method, _ := m.pendingToolCall.Arguments["method"].(string)
+			endpoint, _ := m.pendingToolCall.Arguments["endpoint"].(string)
+			if method != "" && endpoint != "" {
+				s.WriteString(m.subtleStyle.Render("Request: ") + strings.ToUpper(method) + " " + endpoint + "\n")
+			}
 		}
 
 		s.WriteString("\n")
@@ -452,7 +584,7 @@ func (m TestUIModel) View() string {
 		var statusMsg string
 		if m.agentState == StateThinking || m.agentState == StateProcessing || m.agentState == StateRunningTests || m.agentState == StateUsingTool {
 			icon = m.spinner.View()
-			statusMsg = generateGradientText("Working...", m.animationFrame)
+			statusMsg = generateGradientText(m.workingMessage(), m.animationFrame)
 		} else if m.textarea.Value() == "" {
 			icon = "○"
 			statusMsg = "Write a message"
@@ -471,7 +603,43 @@ func (m TestUIModel) View() string {
 			updateDisplay = lipgloss.NewStyle().Foreground(Theme.Warning).Render(fmt.Sprintf(" • v%s available", m.latestVersion))
 		}
 
-		s.WriteString(icon + " " + statusMsg + tokenDisplay + updateDisplay + "\n")
+		// Low rate limit warning
+		limitsDisplay := ""
+		if m.rateLimits.IsLow() {
+			limitsDisplay = lipgloss.NewStyle().Foreground(Theme.Warning).Render(" • rate limit low: " + m.rateLimits.Summary())
+		}
+
+		// Queued messages indicator
+		queueDisplay := ""
+		if n := len(m.queuedInputs); n > 0 {
+			label := "message"
+			if n > 1 {
+				label = "messages"
+			}
+			queueDisplay = lipgloss.NewStyle().Foreground(Theme.Cyan).Render(fmt.Sprintf(" • %d %s queued", n, label))
+		}
+
+		// Replay/record mode indicator
+		replayDisplay := ""
+		if m.replaying {
+			replayDisplay = lipgloss.NewStyle().Foreground(Theme.Warning).Render(" • replay")
+		} else if m.recording {
+			replayDisplay = lipgloss.NewStyle().Foreground(Theme.Error).Render(" • rec")
+		}
+
+		// Certificate verification is off with --insecure
+		tlsDisplay := ""
+		if m.testExecutor.TLS().Insecure {
+			tlsDisplay = lipgloss.NewStyle().Foreground(Theme.Error).Render(" • TLS unverified")
+		}
+
+		// Tools run without asking after /auto or --auto
+		modeDisplay := ""
+		if m.executionMode == ModeAutoExecute {
+			modeDisplay = lipgloss.NewStyle().Foreground(Theme.Warning).Render(" • auto")
+		}
+
+		s.WriteString(icon + " " + statusMsg + tokenDisplay + limitsDisplay + queueDisplay + replayDisplay + tlsDisplay + modeDisplay + updateDisplay + "\n")
 
 		// Input AFTER status line
 		s.WriteString(m.textarea.View() + "\n")
@@ -513,11 +681,25 @@ func (m TestUIModel) View() string {
 			// Show ESC clear hint if active
 			if m.showClearHint {
 				helpText = lipgloss.NewStyle().Foreground(Theme.Warning).Render("Press ESC again to clear input")
+			} else if m.copyHint != "" {
+				if m.copyHintErr {
+					helpText = m.subtleStyle.Render(m.copyHint)
+				} else {
+					helpText = lipgloss.NewStyle().Foreground(Theme.Success).Render("✓ " + m.copyHint)
+				}
 			} else {
-				if m.thinkingEnabled {
-					helpText += lipgloss.NewStyle().Foreground(Theme.Violet).Render("Think") + " • "
+				if m.authHint {
+					helpText += lipgloss.NewStyle().Foreground(Theme.Warning).Render("Ctrl+G set up auth") + " • "
+				}
+				if m.manualMode {
+					helpText += lipgloss.NewStyle().Foreground(Theme.Warning).Render("Manual") + " • "
+					helpText += "Ctrl+R agent mode • Ctrl+C to quit"
+				} else {
+					if m.thinkingEnabled {
+						helpText += lipgloss.NewStyle().Foreground(Theme.Violet).Render("Think") + " • "
+					}
+					helpText += "Ctrl+T thinking • Ctrl+R manual requests • Ctrl+C to quit"
 				}
-				helpText += "Ctrl+T thinking • Ctrl+C to quit"
 			}
 			// Wrap help text if too long
 			if m.width > 0 {
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/config"
 	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
 	"github.com/Octrafic/octrafic-cli/internal/infra/logger"
 	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
 	"github.com/Octrafic/octrafic-cli/internal/updater"
@@ -23,8 +26,20 @@ type releaseNotesMsg struct {
 	err   error
 }
 
+// reauthMsg carries the outcome of renewing provider's credentials with /reauth
+type reauthMsg struct {
+	provider auth.AuthProvider
+	err      error
+}
+
 // Update handles messages and updates the model
 func (m TestUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	return submitQueuedInput(model, cmd)
+}
+
+// update dispatches a message to the handler for the current state
+func (m TestUIModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
@@ -75,6 +90,7 @@ func (m TestUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				ReasoningContent: msg.reasoning,
 				FunctionCalls:    msg.toolCalls,
 			})
+			save := m.saveChatSession()
 
 			if len(msg.toolCalls) > 0 {
 				toolCall := msg.toolCalls[0]
@@ -85,14 +101,15 @@ func (m TestUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.agentState = StateUsingTool
 					m.animationFrame = 0
 					m.spinner.Style = lipgloss.NewStyle().Foreground(Theme.PrimaryDark)
-					return m, tea.Batch(animationTick(), m.executeTool(toolCall))
+					return m, tea.Batch(animationTick(), m.executeTool(toolCall), save)
 				} else {
 					m.pendingToolCall = &toolCall
 					m.confirmationChoice = 0
 					m.agentState = StateAskingConfirmation
-					return m, nil
+					return m, save
 				}
 			}
+			return m, save
 		}
 
 	case toolResultMsg:
@@ -117,6 +134,9 @@ func (m TestUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.agentState = StateIdle
 		}
 
+	case curlResultMsg:
+		return handleCurlResult(&m, msg)
+
 	case releaseNotesMsg:
 		if msg.err != nil {
 			m.addMessage(m.errorStyle.Render("Failed to fetch release notes: " + msg.err.Error()))
@@ -133,6 +153,17 @@ func (m TestUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateViewport()
 		return m, nil
 
+	case reauthMsg:
+		m.handleReauth(msg)
+		m.updateViewport()
+		return m, nil
+
+	case copyHintTimeoutMsg:
+		if time.Since(m.copyHintAt) >= copyHintDuration {
+			m.copyHint = ""
+		}
+		return m, nil
+
 	case clearHintTimeoutMsg:
 		if m.showClearHint && time.Since(m.lastEscPress) >= 700*time.Millisecond {
 			m.showClearHint = false
@@ -160,11 +191,27 @@ func (m TestUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return handleCommandsState(&m, msg)
 		}
 
+		if m.isBusy() {
+			return handleBusyInput(&m, msg)
+		}
+
 		if m.agentState == StateIdle {
 			switch msg.Type {
 			case tea.KeyCtrlT:
 				m.thinkingEnabled = !m.thinkingEnabled
+				m.savePreferences()
+				return m, nil
+			case tea.KeyCtrlR:
+				m.setManualMode(!m.manualMode)
+				m.updateViewport()
 				return m, nil
+			case tea.KeyCtrlG:
+				if m.authHint {
+					m.authHint = false
+					m.wizardState = NewAuthWizard()
+					m.agentState = StateWizard
+					return m, nil
+				}
 			case tea.KeyEnter:
 				userInput := m.textarea.Value()
 				if strings.HasSuffix(userInput, "\\") {
@@ -185,43 +232,7 @@ func (m TestUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				}
 				if userInput != "" {
-					m.commandHistory = append(m.commandHistory, userInput)
-					m.historyIndex = -1
-					m.temporaryInput = ""
-
-					m.textarea.SetValue("")
-					m.textarea.SetHeight(1)
-					m.showClearHint = false
-
-					if newM, cmd, handled := handleSlashCommands(&m, userInput); handled {
-						return *newM, cmd
-					}
-
-					if newM, cmd, handled := handleAuthCommand(&m, userInput); handled {
-						return *newM, cmd
-					}
-
-					userMessage := lipgloss.NewStyle().
-						Foreground(Theme.TextMuted).
-						Render("> ") + userInput
-					m.addMessage("")
-					m.addMessage(userMessage)
-					m.addMessage("")
-
-					m.lastMessageRole = "user"
-
-					m.conversationHistory = append(m.conversationHistory, agent.ChatMessage{
-						Role:    "user",
-						Content: userInput,
-					})
-
-					m.agentState = StateProcessing
-					m.animationFrame = 0
-					m.spinner.Style = lipgloss.NewStyle().Foreground(Theme.Primary)
-					return m, tea.Batch(
-						animationTick(),
-						m.sendChatMessage(userInput),
-					)
+					return handleUserInput(&m, userInput)
 				}
 			case tea.KeyUp:
 				if len(m.commandHistory) > 0 {
@@ -314,6 +325,9 @@ func (m TestUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case runNextTestMsg:
 		return handleRunNextTest(&m, msg)
 
+	case testBatchDoneMsg:
+		return handleTestBatchDone(&m, msg)
+
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
@@ -323,6 +337,99 @@ func (m TestUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleUserInput submits a line typed by the user: slash commands, auth commands, a manual request or a chat message
+func handleUserInput(m *TestUIModel, userInput string) (tea.Model, tea.Cmd) {
+	m.commandHistory = append(m.commandHistory, userInput)
+	m.historyIndex = -1
+	m.temporaryInput = ""
+
+	m.textarea.SetValue("")
+	m.textarea.SetHeight(1)
+	m.showClearHint = false
+
+	if m.pathPrompt != nil {
+		return *m, m.handlePathValue(userInput)
+	}
+
+	if newM, cmd, handled := handleSlashCommands(m, userInput); handled {
+		return *newM, cmd
+	}
+
+	if newM, cmd, handled := handleAuthCommand(m, userInput); handled {
+		return *newM, cmd
+	}
+
+	if m.manualMode {
+		return *m, m.runManualRequest(userInput)
+	}
+
+	userMessage := lipgloss.NewStyle().
+		Foreground(Theme.TextMuted).
+		Render("> ") + userInput
+	m.addMessage("")
+	m.addMessage(userMessage)
+	m.addMessage("")
+
+	m.lastMessageRole = "user"
+
+	m.conversationHistory = append(m.conversationHistory, agent.ChatMessage{
+		Role:    "user",
+		Content: userInput,
+	})
+
+	m.agentState = StateProcessing
+	m.animationFrame = 0
+	m.spinner.Style = lipgloss.NewStyle().Foreground(Theme.Primary)
+	return *m, tea.Batch(
+		animationTick(),
+		m.sendChatMessage(userInput),
+	)
+}
+
+// isBusy reports whether the agent is in the middle of a turn
+func (m *TestUIModel) isBusy() bool {
+	return m.agentState == StateThinking || m.agentState == StateProcessing || m.agentState == StateUsingTool || m.agentState == StateRunningTests
+}
+
+// handleBusyInput lets the user keep typing while the agent works; Enter queues the message
+func handleBusyInput(m *TestUIModel, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyEnter {
+		userInput := strings.TrimSpace(m.textarea.Value())
+		if userInput != "" {
+			m.queuedInputs = append(m.queuedInputs, userInput)
+			m.textarea.SetValue("")
+			m.textarea.SetHeight(1)
+		}
+		return *m, nil
+	}
+
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return *m, cmd
+}
+
+// submitQueuedInput submits the next queued message once the agent returns to idle
+func submitQueuedInput(model tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	var m *TestUIModel
+	switch v := model.(type) {
+	case TestUIModel:
+		m = &v
+	case *TestUIModel:
+		m = v
+	default:
+		return model, cmd
+	}
+
+	if m.agentState != StateIdle || len(m.queuedInputs) == 0 {
+		return model, cmd
+	}
+
+	userInput := m.queuedInputs[0]
+	m.queuedInputs = m.queuedInputs[1:]
+	next, submitCmd := handleUserInput(m, userInput)
+	return next, tea.Batch(cmd, submitCmd)
+}
+
 // handleStreamingMsg handles streaming reasoning chunks from the backend
 func handleStreamingMsg(m *TestUIModel, msg reasoningChunkMsg) (tea.Model, tea.Cmd) {
 	// Log message type for debugging
@@ -341,8 +448,13 @@ func handleStreamingMsg(m *TestUIModel, msg reasoningChunkMsg) (tea.Model, tea.C
 		msgType = "THINK"
 	} else if strings.HasPrefix(msg.chunk, "\x00TEXT:") {
 		msgType = "TEXT"
+	} else if strings.HasPrefix(msg.chunk, "\x00STATUS:") {
+		msgType = "STATUS"
 	}
 	logger.Debug("Received streaming message", logger.String("type", msgType), zap.Int("length", len(msg.chunk)))
+	if msgType != "ERROR" {
+		m.modelLoaded = true
+	}
 
 	if strings.HasPrefix(msg.chunk, "\x00ERROR:") {
 		errMsg := strings.TrimPrefix(msg.chunk, "\x00ERROR:")
@@ -380,8 +492,15 @@ func handleStreamingMsg(m *TestUIModel, msg reasoningChunkMsg) (tea.Model, tea.C
 		if _, err := fmt.Sscanf(tokenData, "%d,%d", &input, &output); err == nil {
 			m.inputTokens += input
 			m.outputTokens += output
+			m.turnInputTokens = input
+			m.turnOutputTokens = output
 			logger.Debug("Token counts updated", zap.Int64("input", m.inputTokens), zap.Int64("output", m.outputTokens))
 		}
+		if m.localAgent != nil {
+			if limits := m.localAgent.RateLimits(); limits != nil {
+				m.rateLimits = limits
+			}
+		}
 		return m, waitForReasoning(msg.channel)
 	} else if strings.HasPrefix(msg.chunk, "\x00DONE:") {
 		// Display any accumulated content before finishing
@@ -394,28 +513,36 @@ func handleStreamingMsg(m *TestUIModel, msg reasoningChunkMsg) (tea.Model, tea.C
 		m.streamedTextChunk = ""
 
 		chatMsg := agent.ChatMessage{
-			Role:    "assistant",
-			Content: m.streamedAgentMessage,
+			Role:         "assistant",
+			Content:      m.streamedAgentMessage,
+			InputTokens:  m.turnInputTokens,
+			OutputTokens: m.turnOutputTokens,
 		}
 		if len(m.streamedToolCalls) > 0 {
 			chatMsg.FunctionCalls = m.streamedToolCalls
 		}
 		m.conversationHistory = append(m.conversationHistory, chatMsg)
+		save := m.saveChatSession()
+		if m.showTurnTokens && (m.turnInputTokens > 0 || m.turnOutputTokens > 0) {
+			m.addMessage(m.subtleStyle.Render(fmt.Sprintf("↑%d ↓%d tokens", m.turnInputTokens, m.turnOutputTokens)))
+			m.updateViewport()
+		}
+		m.turnInputTokens, m.turnOutputTokens = 0, 0
 
 		m.streamedAgentMessage = ""
 
 		if len(m.streamedToolCalls) > 0 {
-			return m, tea.Tick(time.Second*1, func(time.Time) tea.Msg {
+			return m, tea.Batch(save, tea.Tick(time.Second*1, func(time.Time) tea.Msg {
 				return processToolCallsMsg{}
-			})
+			}))
 		}
 
 		if m.agentState == StateShowingTestPlan {
-			return m, nil
+			return m, save
 		}
 
 		m.agentState = StateIdle
-		return m, nil
+		return m, save
 	} else if strings.HasPrefix(msg.chunk, "\x00TOOLS:") {
 		toolCallsJSON := strings.TrimPrefix(msg.chunk, "\x00TOOLS:")
 		var toolCalls []agent.ToolCall
@@ -432,6 +559,11 @@ func handleStreamingMsg(m *TestUIModel, msg reasoningChunkMsg) (tea.Model, tea.C
 		chunk := strings.TrimPrefix(msg.chunk, "\x00TEXT:")
 		m.streamedTextChunk += chunk
 		return m, waitForReasoning(msg.channel)
+	} else if strings.HasPrefix(msg.chunk, "\x00STATUS:") {
+		status := strings.TrimPrefix(msg.chunk, "\x00STATUS:")
+		m.addMessage(m.subtleStyle.Render("⟳ " + status))
+		m.updateViewport()
+		return m, waitForReasoning(msg.channel)
 	}
 
 	// Fallback for unknown chunk types - just continue waiting
@@ -441,14 +573,20 @@ func handleStreamingMsg(m *TestUIModel, msg reasoningChunkMsg) (tea.Model, tea.C
 // handleGlobalKeyboard handles global keyboard shortcuts
 func handleGlobalKeyboard(m *TestUIModel, msg tea.KeyMsg) (*TestUIModel, tea.Cmd, bool) {
 	if msg.Type == tea.KeyCtrlC {
+		m.shutdown()
 		return m, tea.Quit, true
 	}
 
+	if msg.Type == tea.KeyCtrlY {
+		return m, m.copyLastResponse(), true
+	}
+
 	if msg.Type == tea.KeyEsc {
 		if m.agentState != StateIdle {
 			m.agentState = StateIdle
 			m.currentToolCall = nil
 			m.pendingToolCall = nil
+			m.queuedInputs = nil
 			if m.lastMessageRole != "assistant" {
 				m.addMessage(renderAgentLabel())
 			}
@@ -485,13 +623,67 @@ func handleSlashCommands(m *TestUIModel, userInput string) (*TestUIModel, tea.Cm
 		return m, nil, false
 	}
 
+	if command, ok := strings.CutPrefix(userInput, "/import-curl"); ok && (command == "" || command[0] == ' ' || command[0] == '\n') {
+		return m, m.importCurl(strings.TrimSpace(command)), true
+	}
+
+	switch fields := strings.Fields(userInput); fields[0] {
+	case "/history":
+		m.showHistory(fields[1:])
+		return m, nil, true
+	case "/trim":
+		m.trimConversation(fields[1:])
+		return m, nil, true
+	case "/mode":
+		m.handleModeCommand(fields[1:])
+		return m, nil, true
+	case "/delay":
+		m.handleDelayCommand(fields[1:])
+		return m, nil, true
+	case "/parallel":
+		m.handleParallelCommand(fields[1:])
+		return m, nil, true
+	case "/retry-auth":
+		return m, m.retryRejectedTests(fields[1:]), true
+	case "/volatile":
+		m.handleVolatileCommand(fields[1:])
+		return m, nil, true
+	case "/model":
+		m.handleModelCommand(fields[1:])
+		return m, nil, true
+	case "/export":
+		m.handleExportCommand(fields[1:])
+		return m, nil, true
+	case "/resume":
+		m.resumeChatSession()
+		return m, nil, true
+	case "/curl":
+		m.showLastCurl(fields[1:])
+		return m, nil, true
+	}
+
 	switch userInput {
 	case "/think":
 		m.thinkingEnabled = !m.thinkingEnabled
+		m.savePreferences()
+		return m, nil, true
+
+	case "/tokens":
+		m.toggleTurnTokens()
+		return m, nil, true
+
+	case "/auto":
+		m.toggleAutoExecute()
+		return m, nil, true
+
+	case "/cost":
+		m.handleCostCommand()
 		return m, nil, true
 
 	case "/clear":
+		// The cleared conversation stays resumable; what follows is saved as a new session
 		m.conversationHistory = []agent.ChatMessage{}
+		m.chatSessionID = ""
 		m.recreateHeader()
 		return m, nil, true
 
@@ -514,9 +706,57 @@ func handleSlashCommands(m *TestUIModel, userInput string) (*TestUIModel, tea.Cm
 		return m, nil, true
 
 	case "/exit":
+		m.shutdown()
 		return m, tea.Quit, true
 
+	case "/open", "/open last":
+		if m.lastArtifactPath == "" {
+			m.addAgentMessage(m.subtleStyle.Render("No report generated yet"))
+		} else {
+			m.addAgentMessage(m.subtleStyle.Render("Opening " + m.lastArtifactPath))
+			m.openArtifact(m.lastArtifactPath)
+		}
+		m.addMessage("")
+		return m, nil, true
+
+	case "/limits":
+		if m.localAgent != nil {
+			if limits := m.localAgent.RateLimits(); limits != nil {
+				m.rateLimits = limits
+			}
+		}
+		if m.rateLimits == nil {
+			m.addAgentMessage(m.subtleStyle.Render("No rate limit information yet (send a message first, or the provider doesn't report limits)"))
+			m.addMessage("")
+			return m, nil, true
+		}
+
+		style := m.agentStyle
+		if m.rateLimits.IsLow() {
+			style = m.errorStyle
+		}
+		m.addAgentMessage(style.Render("Rate limits: " + m.rateLimits.Summary()))
+		if m.rateLimits.RequestsReset != "" {
+			m.addMessage(m.subtleStyle.Render("  Requests reset: " + m.rateLimits.RequestsReset))
+		}
+		if m.rateLimits.TokensReset != "" {
+			m.addMessage(m.subtleStyle.Render("  Tokens reset: " + m.rateLimits.TokensReset))
+		}
+		for _, name := range m.rateLimits.SortedHeaderNames() {
+			m.addMessage(m.subtleStyle.Render(fmt.Sprintf("  %s: %s", name, m.rateLimits.Headers[name])))
+		}
+		m.addMessage("")
+		return m, nil, true
+
+	case "/reauth":
+		return m, m.reauth(), true
+
+	case "/findings":
+		m.showFindings()
+		return m, nil, true
+
 	case "/auth":
+		m.authHint = false
 		m.wizardState = NewAuthWizard()
 		m.agentState = StateWizard
 		return m, nil, true
@@ -546,6 +786,18 @@ func handleSlashCommands(m *TestUIModel, userInput string) (*TestUIModel, tea.Cm
 			}
 		}
 		m.addMessage(m.subtleStyle.Render(fmt.Sprintf("  Created: %s", m.currentProject.CreatedAt.Format("2006-01-02 15:04"))))
+		profile := config.ActiveProfile()
+		if profile == "" {
+			profile = config.DefaultProfile
+		}
+		m.addMessage(fmt.Sprintf("  Profile: %s", profile))
+		if m.llmProvider != "" {
+			llm := m.llmProvider
+			if m.llmModel != "" {
+				llm += " / " + m.llmModel
+			}
+			m.addMessage(fmt.Sprintf("  LLM: %s", llm))
+		}
 		m.addMessage("")
 		return m, nil, true
 	}
@@ -562,7 +814,7 @@ func handleAuthCommand(m *TestUIModel, userInput string) (*TestUIModel, tea.Cmd,
 	parts := strings.Fields(userInput)
 	if len(parts) < 2 {
 		m.addAgentMessage(m.errorStyle.Render("Usage: auth <command>"))
-		m.addMessage(m.subtleStyle.Render("Commands: bearer <token> | apikey <key> <value> | basic <user> <pass> | show | clear"))
+		m.addMessage(m.subtleStyle.Render("Commands: bearer <token> | apikey <key> <value> [header|query] | basic <user> <pass> | digest <user> <pass> | use <profile> | profiles | show | clear"))
 		m.addMessage("")
 		return m, nil, true
 	}
@@ -583,14 +835,24 @@ func handleAuthCommand(m *TestUIModel, userInput string) (*TestUIModel, tea.Cmd,
 
 	case "apikey":
 		if len(parts) < 4 {
-			m.addAgentMessage(m.errorStyle.Render("Usage: auth apikey <key> <value>"))
+			m.addAgentMessage(m.errorStyle.Render("Usage: auth apikey <key> <value> [header|query]"))
 			m.addMessage(m.subtleStyle.Render("Example: auth apikey X-API-Key your-key-here"))
 			m.addMessage("")
 			return m, nil, true
 		}
-		m.authProvider = auth.NewAPIKeyAuth(parts[2], parts[3], "header")
+		location := "header"
+		if len(parts) > 4 {
+			location = strings.ToLower(parts[4])
+		}
+		apiKeyAuth := auth.NewAPIKeyAuth(parts[2], parts[3], location)
+		if err := apiKeyAuth.Validate(); err != nil {
+			m.addAgentMessage(m.errorStyle.Render("Invalid API key configuration: " + err.Error()))
+			m.addMessage("")
+			return m, nil, true
+		}
+		m.authProvider = apiKeyAuth
 		m.testExecutor.UpdateAuthProvider(m.authProvider)
-		m.addAgentMessage(m.successStyle.Render(fmt.Sprintf("✓ API Key authentication configured (%s)", parts[2])))
+		m.addAgentMessage(m.successStyle.Render(fmt.Sprintf("✓ API Key authentication configured (%s in %s)", parts[2], location)))
 		m.addMessage("")
 		return m, nil, true
 
@@ -606,6 +868,18 @@ func handleAuthCommand(m *TestUIModel, userInput string) (*TestUIModel, tea.Cmd,
 		m.addMessage("")
 		return m, nil, true
 
+	case "digest":
+		if len(parts) < 4 {
+			m.addAgentMessage(m.errorStyle.Render("Usage: auth digest <username> <password>"))
+			m.addMessage("")
+			return m, nil, true
+		}
+		m.authProvider = auth.NewDigestAuth(parts[2], parts[3])
+		m.testExecutor.UpdateAuthProvider(m.authProvider)
+		m.addAgentMessage(m.successStyle.Render(fmt.Sprintf("✓ Digest authentication configured (%s)", parts[2])))
+		m.addMessage("")
+		return m, nil, true
+
 	case "show":
 		if m.authProvider == nil {
 			m.addAgentMessage(m.subtleStyle.Render("No authentication configured"))
@@ -620,6 +894,20 @@ func handleAuthCommand(m *TestUIModel, userInput string) (*TestUIModel, tea.Cmd,
 		m.addMessage("")
 		return m, nil, true
 
+	case "use":
+		if len(parts) < 3 {
+			m.addAgentMessage(m.errorStyle.Render("Usage: auth use <profile>"))
+			m.addMessage(m.subtleStyle.Render("Save a profile with /auth, then list them with: auth profiles"))
+			m.addMessage("")
+			return m, nil, true
+		}
+		m.useAuthProfile(parts[2])
+		return m, nil, true
+
+	case "profiles":
+		m.listAuthProfiles()
+		return m, nil, true
+
 	case "clear":
 		m.authProvider = &auth.NoAuth{}
 		m.testExecutor.UpdateAuthProvider(m.authProvider)
@@ -629,7 +917,7 @@ func handleAuthCommand(m *TestUIModel, userInput string) (*TestUIModel, tea.Cmd,
 
 	default:
 		m.addAgentMessage(m.errorStyle.Render(fmt.Sprintf("Unknown auth command: %s", subCmd)))
-		m.addMessage(m.subtleStyle.Render("Commands: bearer | apikey | basic | show | clear"))
+		m.addMessage(m.subtleStyle.Render("Commands: bearer | apikey | basic | digest | use | profiles | show | clear"))
 		m.addMessage("")
 		return m, nil, true
 	}
@@ -654,73 +942,84 @@ func handleTestPlanState(m *TestUIModel, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 	case tea.KeyEnter:
-		if m.lastMessageRole != "assistant" {
-			m.addMessage(renderAgentLabel())
-		}
+		return runSelectedTests(m)
+	case tea.KeyEsc:
+		m.agentState = StateIdle
+		return m, nil
+	default:
+		return m, nil
+	}
+	return m, nil
+}
 
-		var selectedTests []Test
-		for _, test := range m.tests {
-			if test.Selected && test.Status == "pending" {
-				selectedTests = append(selectedTests, test)
-			}
-		}
+// runSelectedTests runs the tests selected in the plan, answering the ExecuteTestGroup call that proposed them
+func runSelectedTests(m *TestUIModel) (tea.Model, tea.Cmd) {
+	if m.lastMessageRole != "assistant" {
+		m.addMessage(renderAgentLabel())
+	}
 
-		if len(selectedTests) == 0 {
-			m.addMessage("No tests selected for execution.")
-			m.addMessage("")
-			m.lastMessageRole = "assistant"
-			m.agentState = StateIdle
-			m.pendingTestGroupToolCall = nil
-			return m, nil
+	var selectedTests []Test
+	for _, test := range m.tests {
+		if test.Selected && test.Status == "pending" {
+			selectedTests = append(selectedTests, test)
 		}
+	}
 
+	if len(selectedTests) == 0 {
+		m.addMessage("No tests selected for execution.")
+		m.addMessage("")
 		m.lastMessageRole = "assistant"
+		m.agentState = StateIdle
+		m.pendingTestGroupToolCall = nil
+		return m, nil
+	}
 
-		tests := make([]map[string]any, 0)
-		for _, test := range selectedTests {
-			tests = append(tests, map[string]any{
-				"method":        test.Method,
-				"endpoint":      test.Endpoint,
-				"headers":       test.BackendTest.Headers,
-				"body":          test.BackendTest.Body,
-				"requires_auth": test.BackendTest.RequiresAuth,
-			})
-		}
-
-		label := "Running tests"
-		if len(tests) > 0 {
-			label = fmt.Sprintf("Testing %s %s", tests[0]["method"], tests[0]["endpoint"])
-			if len(tests) > 1 {
-				label = fmt.Sprintf("Testing %d endpoints", len(tests))
-			}
-		}
+	m.lastMessageRole = "assistant"
+
+	tests := make([]map[string]any, 0)
+	for _, test := range selectedTests {
+		tests = append(tests, map[string]any{
+			"method":        test.Method,
+			"endpoint":      test.Endpoint,
+			"headers":       test.BackendTest.Headers,
+			"body":          test.BackendTest.Body,
+			"requires_auth": test.BackendTest.RequiresAuth,
+			"expect":        test.BackendTest.Expect,
+			"name":          test.BackendTest.Name,
+			"skip_unless":   test.BackendTest.SkipUnless,
+			"capture":       test.BackendTest.Capture,
+			"download":      test.BackendTest.Download,
+			"multipart":     test.BackendTest.Multipart,
+		})
+	}
 
-		toolID := ""
-		toolName := "ExecuteTestGroup"
-		if m.pendingTestGroupToolCall != nil {
-			toolID = m.pendingTestGroupToolCall.ID
-			toolName = m.pendingTestGroupToolCall.Name
+	label := "Running tests"
+	if len(tests) > 0 {
+		label = fmt.Sprintf("Testing %s %s", tests[0]["method"], tests[0]["endpoint"])
+		if len(tests) > 1 {
+			label = fmt.Sprintf("Testing %d endpoints", len(tests))
 		}
-		m.pendingTestGroupToolCall = nil
+	}
 
-		m.agentState = StateUsingTool
-		m.animationFrame = 0
-		m.spinner.Style = lipgloss.NewStyle().Foreground(Theme.PrimaryDark)
-		return m, tea.Batch(animationTick(), func() tea.Msg {
-			return startTestGroupMsg{
-				tests:    tests,
-				label:    label,
-				toolName: toolName,
-				toolID:   toolID,
-			}
-		})
-	case tea.KeyEsc:
-		m.agentState = StateIdle
-		return m, nil
-	default:
-		return m, nil
+	toolID := ""
+	toolName := "ExecuteTestGroup"
+	if m.pendingTestGroupToolCall != nil {
+		toolID = m.pendingTestGroupToolCall.ID
+		toolName = m.pendingTestGroupToolCall.Name
 	}
-	return m, nil
+	m.pendingTestGroupToolCall = nil
+
+	m.agentState = StateUsingTool
+	m.animationFrame = 0
+	m.spinner.Style = lipgloss.NewStyle().Foreground(Theme.PrimaryDark)
+	return m, tea.Batch(animationTick(), func() tea.Msg {
+		return startTestGroupMsg{
+			tests:    tests,
+			label:    label,
+			toolName: toolName,
+			toolID:   toolID,
+		}
+	})
 }
 
 // handleConfirmationState handles StateAskingConfirmation keyboard input
@@ -741,6 +1040,11 @@ func handleConfirmationState(m *TestUIModel, msg tea.KeyMsg) (tea.Model, tea.Cmd
 	case tea.KeyEnter:
 		switch m.confirmationChoice {
 		case 0:
+			if m.streamedToolCalls != nil {
+				m.pendingToolCall = nil
+				m.streamedToolConfirmed = true
+				return handleProcessToolCalls(m, processToolCallsMsg{})
+			}
 			m.currentToolCall = m.pendingToolCall
 			m.pendingToolCall = nil
 			m.agentState = StateUsingTool
@@ -749,6 +1053,7 @@ func handleConfirmationState(m *TestUIModel, msg tea.KeyMsg) (tea.Model, tea.Cmd
 			return m, tea.Batch(animationTick(), m.executeTool(*m.currentToolCall))
 		case 1:
 			m.pendingToolCall = nil
+			m.streamedToolCalls = nil
 			m.agentState = StateIdle
 			if m.lastMessageRole != "assistant" {
 				m.addMessage(renderAgentLabel())
@@ -760,6 +1065,7 @@ func handleConfirmationState(m *TestUIModel, msg tea.KeyMsg) (tea.Model, tea.Cmd
 		default:
 			isExecuteTest := m.pendingToolCall != nil && strings.HasPrefix(m.pendingToolCall.Name, "ExecuteTest")
 			m.pendingToolCall = nil
+			m.streamedToolCalls = nil
 
 			if isExecuteTest {
 				for i, test := range m.tests {
@@ -877,9 +1183,45 @@ func showToolWidget(m *TestUIModel, title string, details string) {
 	m.updateViewport()
 }
 
+// streamedToolOrder is the order handleProcessToolCalls picks a streamed tool call to run in
+var streamedToolOrder = []string{
+	"get_endpoints_details", "sample_endpoint", "compare_representations", "GenerateTestPlan",
+	"ExecuteTestGroup", "RecordFinding", "GenerateReport",
+}
+
+// nextStreamedToolCall returns the streamed tool call handleProcessToolCalls will run
+func nextStreamedToolCall(toolCalls []agent.ToolCall) (agent.ToolCall, bool) {
+	for _, name := range streamedToolOrder {
+		for _, toolCall := range toolCalls {
+			if toolCall.Name == name {
+				return toolCall, true
+			}
+		}
+	}
+	return agent.ToolCall{}, false
+}
+
+// streamedToolNeedsConfirmation reports whether a streamed tool call waits for the user before
+// running: tools that aren't safe ask unless auto-execute is on, and destructive requests always ask.
+func (m *TestUIModel) streamedToolNeedsConfirmation(toolCall agent.ToolCall) bool {
+	if _, destructive := m.destructiveRequest(toolCall); destructive {
+		return true
+	}
+	return m.executionMode == ModeAsk && m.shouldAskForConfirmation(toolCall.Name)
+}
+
 // handleProcessToolCalls processes tool calls from the agent
 func handleProcessToolCalls(m *TestUIModel, _ processToolCallsMsg) (tea.Model, tea.Cmd) {
 	if len(m.streamedToolCalls) > 0 {
+		// A call that needs approval waits in the confirmation dialog; Yes brings it back here
+		if toolCall, ok := nextStreamedToolCall(m.streamedToolCalls); ok && !m.streamedToolConfirmed && m.streamedToolNeedsConfirmation(toolCall) {
+			m.pendingToolCall = &toolCall
+			m.confirmationChoice = 0
+			m.agentState = StateAskingConfirmation
+			return m, nil
+		}
+		m.streamedToolConfirmed = false
+
 		for _, toolCall := range m.streamedToolCalls {
 			if toolCall.Name == "get_endpoints_details" {
 				m.streamedToolCalls = nil
@@ -905,6 +1247,33 @@ func handleProcessToolCalls(m *TestUIModel, _ processToolCallsMsg) (tea.Model, t
 			}
 		}
 
+		for _, toolCall := range m.streamedToolCalls {
+			if toolCall.Name == "sample_endpoint" {
+				m.streamedToolCalls = nil
+				m.currentTestToolID = toolCall.ID
+				m.currentTestToolName = "sample_endpoint"
+				m.agentState = StateThinking
+
+				endpoint, _ := toolCall.Arguments["endpoint"].(string)
+				showToolWidget(m, "Sampling live data", "GET "+endpoint)
+				return m, m.executeTool(toolCall)
+			}
+		}
+
+		for _, toolCall := range m.streamedToolCalls {
+			if toolCall.Name == "compare_representations" {
+				m.streamedToolCalls = nil
+				m.currentTestToolID = toolCall.ID
+				m.currentTestToolName = "compare_representations"
+				m.agentState = StateThinking
+
+				method, _ := toolCall.Arguments["method"].(string)
+				endpoint, _ := toolCall.Arguments["endpoint"].(string)
+				showToolWidget(m, "Comparing representations", strings.TrimSpace(method+" "+endpoint))
+				return m, m.executeTool(toolCall)
+			}
+		}
+
 		for _, toolCall := range m.streamedToolCalls {
 			if toolCall.Name == "GenerateTestPlan" {
 				m.streamedToolCalls = nil
@@ -931,7 +1300,7 @@ func handleProcessToolCalls(m *TestUIModel, _ processToolCallsMsg) (tea.Model, t
 					func() tea.Msg {
 						if m.localAgent == nil {
 							var err error
-							m.localAgent, err = agent.NewAgent(m.baseURL)
+							m.localAgent, err = agent.NewAgent(m.baseURL, m.currentProject.ModelOverride())
 							if err != nil {
 								return backendErrorMsg{err: fmt.Errorf("failed to initialize agent: %w", err)}
 							}
@@ -964,6 +1333,16 @@ func handleProcessToolCalls(m *TestUIModel, _ processToolCallsMsg) (tea.Model, t
 			}
 		}
 
+		for _, toolCall := range m.streamedToolCalls {
+			if toolCall.Name == "RecordFinding" {
+				m.streamedToolCalls = nil
+				m.currentTestToolID = toolCall.ID
+				m.currentTestToolName = "RecordFinding"
+				m.agentState = StateThinking
+				return m, m.executeTool(toolCall)
+			}
+		}
+
 		for _, toolCall := range m.streamedToolCalls {
 			if toolCall.Name == "GenerateReport" {
 				m.streamedToolCalls = nil
@@ -979,6 +1358,7 @@ func handleProcessToolCalls(m *TestUIModel, _ processToolCallsMsg) (tea.Model, t
 			}
 		}
 
+		m.streamedToolCalls = nil
 		m.agentState = StateIdle
 	}
 
@@ -988,6 +1368,11 @@ func handleProcessToolCalls(m *TestUIModel, _ processToolCallsMsg) (tea.Model, t
 
 // handleShowTestSelection displays test selection UI
 func handleShowTestSelection(m *TestUIModel, msg showTestSelectionMsg) (tea.Model, tea.Cmd) {
+	var endpoints []parser.Endpoint
+	if m.currentProject != nil {
+		endpoints, _ = m.loadProjectEndpoints()
+	}
+
 	m.tests = make([]Test, 0, len(msg.tests))
 	for i, testMap := range msg.tests {
 		method, _ := testMap["method"].(string)
@@ -998,10 +1383,10 @@ func handleShowTestSelection(m *TestUIModel, msg showTestSelectionMsg) (tea.Mode
 			requiresAuth = ra
 		}
 
-		headers := make(map[string]string)
-		if h, ok := testMap["headers"].(map[string]string); ok {
-			headers = h
-		}
+		headers := testHeaders(testMap["headers"])
+
+		name, _ := testMap["name"].(string)
+		skipUnless, _ := testMap["skip_unless"].(string)
 
 		testCase := &agent.TestCase{
 			Method:       method,
@@ -1009,23 +1394,48 @@ func handleShowTestSelection(m *TestUIModel, msg showTestSelectionMsg) (tea.Mode
 			Headers:      headers,
 			Body:         testMap["body"],
 			RequiresAuth: requiresAuth,
+			Expect:       tester.ParseAssertions(testMap["expect"]),
+			Name:         name,
+			SkipUnless:   skipUnless,
+			Capture:      tester.ParseCaptures(testMap["capture"]),
+			Download:     tester.ParseDownload(testMap["download"]),
+			Multipart:    tester.ParseMultipart(testMap["multipart"]),
 		}
 
+		// Deprecated endpoints are left unselected by default
+		deprecated := isDeprecatedEndpoint(endpoints, method, endpoint)
+
 		m.tests = append(m.tests, Test{
 			ID:          i + 1,
 			Method:      method,
 			Endpoint:    endpoint,
 			Description: fmt.Sprintf("%s %s", method, endpoint),
 			Status:      "pending",
-			Selected:    true,
+			Selected:    !deprecated,
 			BackendTest: testCase,
+			Deprecated:  deprecated,
 		})
 	}
 
 	m.pendingTestGroupToolCall = &msg.toolCall
 
+	// In auto-execute mode the plan runs as proposed, unless it would send destructive requests
+	if m.executionMode == ModeAutoExecute && len(m.selectedDestructiveTests()) == 0 {
+		return runSelectedTests(m)
+	}
+
 	m.selectedTestIndex = 0
 	m.agentState = StateShowingTestPlan
 
 	return m, nil
 }
+
+// isDeprecatedEndpoint reports whether the spec marks the endpoint matching method and path as deprecated
+func isDeprecatedEndpoint(endpoints []parser.Endpoint, method, path string) bool {
+	for _, ep := range endpoints {
+		if strings.EqualFold(ep.Method, method) && ep.MatchesPath(path) {
+			return ep.Deprecated
+		}
+	}
+	return false
+}
@@ -1,9 +1,13 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"github.com/Octrafic/octrafic-cli/internal/agents"
 	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/core/reporter"
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
+	"maps"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -24,6 +28,11 @@ func handleGenerateTestPlanResult(m *TestUIModel, msg generateTestPlanResultMsg)
 			"body":          bt.TestCase.Body,
 			"requires_auth": bt.TestCase.RequiresAuth,
 			"description":   bt.TestCase.Description,
+			"name":          bt.TestCase.Name,
+			"skip_unless":   bt.TestCase.SkipUnless,
+			"capture":       bt.TestCase.Capture,
+			"download":      bt.TestCase.Download,
+			"multipart":     bt.TestCase.Multipart,
 		})
 	}
 
@@ -95,6 +104,8 @@ func handleStartTestGroup(m *TestUIModel, msg startTestGroupMsg) (tea.Model, tea
 	m.testGroupCompletedCount = 0
 	m.totalTestsInProgress = len(msg.tests)
 	m.testGroupResults = make([]map[string]any, 0, len(msg.tests))
+	m.testVariables = tester.Variables{}
+	m.authRejectedTests = nil
 	m.agentState = StateRunningTests
 
 	// Don't add new "Agent:" label - continue with current agent message
@@ -104,7 +115,7 @@ func handleStartTestGroup(m *TestUIModel, msg startTestGroupMsg) (tea.Model, tea
 	m.updateViewport()
 
 	// Start running first test
-	return m, runNextTest()
+	return m, m.runNextTest()
 }
 
 // handleRunNextTest executes the next test in the queue
@@ -119,14 +130,37 @@ func handleRunNextTest(m *TestUIModel, _ runNextTestMsg) (tea.Model, tea.Cmd) {
 		hadToolID := m.currentTestToolID != ""      // Check before cleanup
 		completedCount := m.testGroupCompletedCount // Save before cleanup
 
+		// Aggregate payload sizes so large responses stand out in the report
+		var totalRequestBytes, totalResponseBytes, rejected int
+		for _, r := range m.testGroupResults {
+			requestBytes, _ := r["request_bytes"].(int)
+			responseBytes, _ := r["response_bytes"].(int)
+			totalRequestBytes += requestBytes
+			totalResponseBytes += responseBytes
+			if authLikely, _ := r["auth_likely_required"].(bool); authLikely {
+				rejected++
+			}
+		}
+		if totalRequestBytes+totalResponseBytes > 0 {
+			m.addMessage(m.subtleStyle.Render(fmt.Sprintf("  Transferred %s (sent %s, received %s)",
+				tester.FormatBytes(totalRequestBytes+totalResponseBytes), tester.FormatBytes(totalRequestBytes), tester.FormatBytes(totalResponseBytes))))
+			m.addMessage("")
+		}
+		if rejected > 0 {
+			m.showAuthHint(rejected)
+			m.addMessage("")
+		}
+
 		if hadToolID {
 			// Add FunctionResponse to conversation history
 			funcResp := &agent.FunctionResponseData{
 				ID:   m.currentTestToolID, // tool_use_id from original tool call
 				Name: m.currentTestToolName,
 				Response: map[string]any{
-					"count":   m.testGroupCompletedCount,
-					"results": m.testGroupResults,
+					"count":                m.testGroupCompletedCount,
+					"results":              m.testGroupResults,
+					"total_request_bytes":  totalRequestBytes,
+					"total_response_bytes": totalResponseBytes,
 				},
 			}
 			m.conversationHistory = append(m.conversationHistory, agent.ChatMessage{
@@ -141,6 +175,7 @@ func handleRunNextTest(m *TestUIModel, _ runNextTestMsg) (tea.Model, tea.Cmd) {
 		m.testGroupCompletedCount = 0
 		m.totalTestsInProgress = 0
 		m.testGroupResults = nil
+		m.testVariables = nil
 		m.currentTestToolName = ""
 		m.currentTestToolID = ""
 		m.agentState = StateProcessing // Keep spinner visible until agent responds
@@ -159,94 +194,255 @@ func handleRunNextTest(m *TestUIModel, _ runNextTestMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if m.testConcurrency > 1 && len(m.pendingTests) > 1 {
+		if batch, ok := m.parallelBatch(); ok {
+			return m, m.runTestBatch(batch)
+		}
+	}
+
 	// Get next test from queue
 	testMap := m.pendingTests[0]
 	m.pendingTests = m.pendingTests[1:]
+	test := newQueuedTest(testMap)
 
-	// Execute the test (this is a blocking operation, so we do it here)
-	method, _ := testMap["method"].(string)
-	endpoint, _ := testMap["endpoint"].(string)
-	requiresAuth := false
-	if ra, ok := testMap["requires_auth"].(bool); ok {
-		requiresAuth = ra
-	}
-
-	// Get headers and body
-	headers := make(map[string]string)
-	if h, ok := testMap["headers"].(map[string]any); ok {
-		for k, v := range h {
-			if vs, ok := v.(string); ok {
-				headers[k] = vs
+	// Skip the test when its precondition on earlier results doesn't hold
+	if condition, _ := testMap["skip_unless"].(string); condition != "" {
+		if met, err := m.testVariables.EvaluateCondition(condition); !met {
+			reason := condition
+			if err != nil {
+				reason = err.Error()
 			}
+			m.addMessage(m.testLine(m.subtleStyle.Render("⊘"), test))
+			m.addMessage(m.subtleStyle.Render(fmt.Sprintf("    Skipped (precondition not met: %s)", reason)))
+
+			m.testGroupResults = append(m.testGroupResults, map[string]any{
+				"method":        test.Method,
+				"endpoint":      test.Endpoint,
+				"skipped":       true,
+				"skip_reason":   "precondition not met: " + reason,
+				"requires_auth": test.requiresAuth,
+			})
+			m.testResults = append(m.testResults, reporter.TestResult{
+				Name: test.name, Method: test.Method, Endpoint: test.Endpoint, Skipped: "precondition not met: " + reason,
+			})
+			m.testGroupCompletedCount++
+			m.updateViewport()
+			return m, m.runNextTest()
 		}
 	}
 
-	var body any
-	if b, ok := testMap["body"]; ok {
-		body = b
+	// Placeholders like {id} are filled from values entered earlier, or asked for before sending
+	endpoint, missingParams := m.fillPath(test.Endpoint)
+	if len(missingParams) > 0 {
+		m.agentState = StateIdle
+		m.requestPathValues(&pathPrompt{method: test.Method, template: test.Endpoint, endpoint: endpoint, test: testMap, missing: missingParams})
+		return m, nil
+	}
+	test.Endpoint = endpoint
+	if test.requiresAuth {
+		test.Auth = m.endpointAuth(test.Method, test.Endpoint)
 	}
 
-	// Choose auth provider based on requires_auth flag
-	originalAuth := m.authProvider
-	if !requiresAuth {
-		m.testExecutor.UpdateAuthProvider(&auth.NoAuth{})
+	// Execute the test (this is a blocking operation, so we do it here)
+	result, err := m.testExecutor.ExecuteRequest(test.Request)
+	m.showTestOutcome(test, result, err)
+
+	// Schedule next test
+	return m, m.runNextTest()
+}
+
+// queuedTest is a test from the queue, decoded into the request to send
+type queuedTest struct {
+	tester.Request
+	name         string         // Name later tests use to refer to this test's result
+	requiresAuth bool           // Whether the request is sent with credentials
+	raw          map[string]any // The test as queued
+}
+
+// testHeaders reads a test's headers. Tool arguments decode them as map[string]any, while tests
+// queued from the test plan carry map[string]string; non-string values such as numbers are formatted.
+func testHeaders(v any) map[string]string {
+	headers := make(map[string]string)
+	switch h := v.(type) {
+	case map[string]string:
+		maps.Copy(headers, h)
+	case map[string]any:
+		for k, value := range h {
+			if value == nil {
+				continue
+			}
+			if s, ok := value.(string); ok {
+				headers[k] = s
+			} else {
+				headers[k] = fmt.Sprint(value)
+			}
+		}
 	}
+	return headers
+}
 
-	// Execute test
-	result, err := m.testExecutor.ExecuteTest(method, endpoint, headers, body)
+// newQueuedTest decodes a queued test. Tests that don't require auth carry their own NoAuth
+// provider, so the shared executor's provider is never swapped out.
+func newQueuedTest(testMap map[string]any) queuedTest {
+	method, _ := testMap["method"].(string)
+	endpoint, _ := testMap["endpoint"].(string)
+	requiresAuth, _ := testMap["requires_auth"].(bool)
+	name, _ := testMap["name"].(string)
+
+	headers := testHeaders(testMap["headers"])
 
-	// Restore original auth
+	test := queuedTest{
+		Request: tester.Request{
+			Method:   method,
+			Endpoint: endpoint,
+			Headers:  headers,
+			Body:     testMap["body"],
+			Expect:   tester.ParseAssertions(testMap["expect"]),
+			Download: tester.ParseDownload(testMap["download"]),
+		},
+		name:         name,
+		requiresAuth: requiresAuth,
+		raw:          testMap,
+	}
+	if form := tester.ParseMultipart(testMap["multipart"]); form != nil {
+		test.Body = form
+	}
 	if !requiresAuth {
-		m.testExecutor.UpdateAuthProvider(originalAuth)
+		test.Auth = &auth.NoAuth{}
 	}
+	return test
+}
 
-	// Display result immediately with indentation
-	methodStyle, ok := m.methodStyles[method]
+// testLine renders the headline of a test result, e.g. "✓ GET /users • Auth"
+func (m *TestUIModel) testLine(icon string, test queuedTest) string {
+	methodStyle, ok := m.methodStyles[test.Method]
 	if !ok {
 		methodStyle = lipgloss.NewStyle().Foreground(Theme.TextSubtle)
 	}
-	methodFormatted := methodStyle.Render(method)
 
 	// Build auth indicator - only show if auth is required
 	authIndicator := ""
-	if requiresAuth {
+	if test.requiresAuth {
 		authIndicator = " " + lipgloss.NewStyle().Foreground(Theme.Warning).Render("• Auth")
 	}
+	return fmt.Sprintf("  %s %s %s%s", icon, methodStyle.Render(test.Method), test.Endpoint, authIndicator)
+}
+
+// showTestOutcome displays the result of a sent test and adds it to the group's results
+func (m *TestUIModel) showTestOutcome(test queuedTest, result *tester.TestResult, err error) {
+	method, endpoint, requiresAuth := test.Method, test.Endpoint, test.requiresAuth
+
+	var missingScopes []string
+	if requiresAuth {
+		missingScopes = m.missingScopes(test.Auth, method, endpoint)
+	}
+
+	if test.name != "" {
+		m.testVariables.Capture(test.name, result, err)
+	}
 
 	if err != nil {
-		m.addMessage(fmt.Sprintf("  ✗ %s %s%s", methodFormatted, endpoint, authIndicator))
-		m.addMessage(m.subtleStyle.Render(fmt.Sprintf("    Error: %s", err.Error())))
+		m.recordHistory(method, endpoint, 0, 0, false, err)
+		m.addMessage(m.testLine("✗", test))
+		m.addMessage(m.subtleStyle.Render(fmt.Sprintf("    Error: %s%s", err.Error(), retriedNote(result))))
 
 		// Add to results for FunctionResponse
-		m.testGroupResults = append(m.testGroupResults, map[string]any{
+		errorResult := map[string]any{
 			"method":        method,
 			"endpoint":      endpoint,
 			"error":         err.Error(),
 			"requires_auth": requiresAuth,
-		})
+		}
+		if len(missingScopes) > 0 {
+			errorResult["missing_scopes"] = missingScopes
+		}
+		if errors.Is(err, tester.ErrTimeout) {
+			errorResult["timed_out"] = true
+		}
+		if result != nil && result.Attempts > 1 {
+			errorResult["attempts"] = result.Attempts
+		}
+		m.testGroupResults = append(m.testGroupResults, errorResult)
 	} else {
-		statusIcon := "✓"
-		statusStyle := m.successStyle
-		if result.StatusCode >= 400 {
+		m.recordHistory(method, endpoint, result.StatusCode, result.Duration, result.Passed(), nil)
+		statusIcon, statusStyle := statusClassIndicator(result.StatusCode)
+		if len(result.FailedAssertions) > 0 || (result.Download != nil && len(result.Download.Problems) > 0) {
 			statusIcon = "✗"
 			statusStyle = m.errorStyle
 		}
-		m.addMessage(fmt.Sprintf("  %s %s %s%s", statusStyle.Render(statusIcon), methodFormatted, endpoint, authIndicator))
-		m.addMessage(m.subtleStyle.Render(fmt.Sprintf("    Status: %d | Duration: %dms", result.StatusCode, result.Duration.Milliseconds())))
+		m.addMessage(m.testLine(statusStyle.Render(statusIcon), test))
+		m.addMessage(m.subtleStyle.Render(fmt.Sprintf("    Status: %d | Duration: %dms | %s%s",
+			result.StatusCode, result.Duration.Milliseconds(), formatSizes(result.RequestBytes, result.ResponseBytes), retriedNote(result))))
+
+		failedAssertions := make([]string, 0, len(result.FailedAssertions))
+		for _, f := range result.FailedAssertions {
+			failedAssertions = append(failedAssertions, f.Message)
+			m.addMessage(m.errorStyle.Render(fmt.Sprintf("    Assertion failed: %s", f.Message)))
+		}
 
 		// Add to results for FunctionResponse
-		m.testGroupResults = append(m.testGroupResults, map[string]any{
-			"method":        method,
-			"endpoint":      endpoint,
-			"status_code":   result.StatusCode,
-			"response_body": result.ResponseBody,
-			"duration_ms":   result.Duration.Milliseconds(),
-			"requires_auth": requiresAuth,
-		})
+		testResult := map[string]any{
+			"method":         method,
+			"endpoint":       endpoint,
+			"status_code":    result.StatusCode,
+			"duration_ms":    result.Duration.Milliseconds(),
+			"request_bytes":  result.RequestBytes,
+			"response_bytes": result.ResponseBytes,
+			"requires_auth":  requiresAuth,
+		}
+		m.modelBody(testResult, result.ResponseBody)
+		if m.authRejected(result.StatusCode, requiresAuth) {
+			testResult["auth_likely_required"] = true
+			m.authRejectedTests = append(m.authRejectedTests, rejectedTest(test.raw))
+		}
+		if len(missingScopes) > 0 {
+			testResult["missing_scopes"] = missingScopes
+		}
+		if result.Attempts > 1 {
+			testResult["attempts"] = result.Attempts
+		}
+		if captures := tester.ParseCaptures(test.raw["capture"]); len(captures) > 0 {
+			m.captureVariables(result, captures, testResult)
+		}
+		if result.XMLError != nil {
+			testResult["xml_error"] = result.XMLError.Error()
+			m.addMessage(lipgloss.NewStyle().Foreground(Theme.Warning).Render(
+				fmt.Sprintf("    ⚠ Malformed XML response: %s", result.XMLError)))
+		}
+		if len(test.Expect) > 0 {
+			testResult["assertions_passed"] = len(failedAssertions) == 0
+			testResult["failed_assertions"] = failedAssertions
+		}
+		if result.Download != nil {
+			m.showDownload(result.Download, testResult)
+		}
+		m.checkResponseSchema(method, endpoint, result, testResult)
+		m.testGroupResults = append(m.testGroupResults, testResult)
+	}
+	if len(missingScopes) > 0 {
+		m.addMessage(lipgloss.NewStyle().Foreground(Theme.Warning).Render(
+			fmt.Sprintf("    ⚠ Token lacks required scope(s): %s", strings.Join(missingScopes, ", "))))
 	}
+	m.testResults = append(m.testResults, sessionResult(test, result, err))
 	m.testGroupCompletedCount++
 	m.updateViewport()
+}
 
-	// Schedule next test
-	return m, runNextTest()
+// sessionResult converts a test outcome for session-wide reports such as JUnit
+func sessionResult(test queuedTest, result *tester.TestResult, err error) reporter.TestResult {
+	r := reporter.TestResult{Name: test.name, Method: test.Method, Endpoint: test.Endpoint}
+	if result != nil {
+		r.StatusCode = result.StatusCode
+		r.Duration = result.Duration
+		for _, f := range result.FailedAssertions {
+			r.Failures = append(r.Failures, f.Message)
+		}
+		if result.Download != nil {
+			r.Failures = append(r.Failures, result.Download.Problems...)
+		}
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return r
 }
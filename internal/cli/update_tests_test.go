@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSelectedTestHeadersReachRequest(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	m := NewTestUIModel(server.URL, "", nil, &auth.NoAuth{}, "test")
+
+	// Headers arrive from the tool call arguments as map[string]any
+	handleShowTestSelection(m, showTestSelectionMsg{tests: []map[string]any{{
+		"method":   "GET",
+		"endpoint": "/users",
+		"headers":  map[string]any{"X-Tenant": "acme", "X-Version": float64(2)},
+	}}})
+	if len(m.tests) != 1 {
+		t.Fatalf("got %d tests, want 1", len(m.tests))
+	}
+
+	// Confirming the plan queues the selected tests
+	_, cmd := handleTestPlanState(m, tea.KeyMsg{Type: tea.KeyEnter})
+	start, ok := findMsg[startTestGroupMsg](cmd)
+	if !ok {
+		t.Fatal("confirming the test plan didn't start the test group")
+	}
+	handleStartTestGroup(m, start)
+	handleRunNextTest(m, runNextTestMsg{})
+
+	if got == nil {
+		t.Fatal("the test request was never sent")
+	}
+	if got.Get("X-Tenant") != "acme" || got.Get("X-Version") != "2" {
+		t.Errorf("request headers X-Tenant=%q X-Version=%q, want acme and 2", got.Get("X-Tenant"), got.Get("X-Version"))
+	}
+}
+
+func TestOAuth2TokenRequestsEndpointScopes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var requestedScope string
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		requestedScope = r.Form.Get("scope")
+		_, _ = w.Write([]byte(`{"access_token":"scoped","token_type":"Bearer"}`))
+	}))
+	defer tokenServer.Close()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer api.Close()
+
+	project := &storage.Project{ID: "scopes-id", Name: "scopes", BaseURL: api.URL}
+	if err := storage.SaveProject(project); err != nil {
+		t.Fatal(err)
+	}
+	endpoints := []parser.Endpoint{{Method: "POST", Path: "/orders", Scopes: []string{"orders:write"}}}
+	if err := storage.SaveEndpoints(project.ID, endpoints, false); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := auth.NewOAuth2ClientCredentials(tokenServer.URL, "client", "secret", []string{"read"})
+	m := NewTestUIModel(api.URL, "", nil, provider, "test")
+	m.currentProject = project
+
+	handleStartTestGroup(m, startTestGroupMsg{tests: []map[string]any{
+		{"method": "POST", "endpoint": "/orders", "requires_auth": true},
+	}})
+	handleRunNextTest(m, runNextTestMsg{})
+	if requestedScope != "read orders:write" {
+		t.Errorf("token requested with scope %q, want the endpoint's scopes added", requestedScope)
+	}
+	if missing := m.testGroupResults[0]["missing_scopes"]; missing != nil {
+		t.Errorf("missing_scopes = %v, want none for the scoped token", missing)
+	}
+
+	// Endpoints are read once per session
+	if err := storage.SaveEndpoints(project.ID, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	if cached, err := m.loadProjectEndpoints(); err != nil || len(cached) != 1 {
+		t.Errorf("loadProjectEndpoints() = %v, %v; want the endpoints loaded earlier", cached, err)
+	}
+}
+
+func TestTestHeaders(t *testing.T) {
+	if got := testHeaders(map[string]string{"A": "1"}); got["A"] != "1" {
+		t.Errorf("testHeaders(map[string]string) = %v", got)
+	}
+	got := testHeaders(map[string]any{"A": "1", "B": true, "C": nil})
+	if got["A"] != "1" || got["B"] != "true" {
+		t.Errorf("testHeaders(map[string]any) = %v", got)
+	}
+	if _, ok := got["C"]; ok {
+		t.Errorf("testHeaders kept a nil header: %v", got)
+	}
+	if got := testHeaders(nil); len(got) != 0 {
+		t.Errorf("testHeaders(nil) = %v, want empty", got)
+	}
+}
+
+// findMsg runs cmd, descending into batches, and returns the first message of type T
+func findMsg[T any](cmd tea.Cmd) (T, bool) {
+	var zero T
+	if cmd == nil {
+		return zero, false
+	}
+	switch msg := cmd().(type) {
+	case T:
+		return msg, true
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if found, ok := findMsg[T](c); ok {
+				return found, true
+			}
+		}
+	}
+	return zero, false
+}
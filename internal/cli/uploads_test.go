@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
+)
+
+func TestUploadPathsShownBeforeSending(t *testing.T) {
+	m := NewTestUIModel("http://localhost", "", nil, &auth.NoAuth{}, "test")
+
+	// The confirmation of a single request lists the files it reads
+	m.agentState = StateAskingConfirmation
+	m.pendingToolCall = &agent.ToolCall{Name: "ExecuteTest", Arguments: map[string]any{
+		"method": "POST", "endpoint": "/documents",
+		"multipart": map[string]any{"files": map[string]any{"doc": "fixtures/report.pdf", "note": map[string]any{"content": "inline"}}},
+	}}
+	if view := m.View(); !strings.Contains(view, "Uploads: fixtures/report.pdf\n") {
+		t.Errorf("confirmation doesn't show the uploaded file:\n%s", view)
+	}
+
+	// So do the test plan and the confirmation of a planned test
+	m.tests = []Test{{Method: "POST", Endpoint: "/avatars", Status: "pending", Selected: true, BackendTest: &agent.TestCase{
+		Method: "POST", Endpoint: "/avatars",
+		Multipart: &tester.Multipart{Files: map[string]tester.MultipartFile{"avatar": {Path: "fixtures/avatar.png"}}},
+	}}}
+	m.pendingToolCall = &agent.ToolCall{Name: "ExecuteTestGroup"}
+	if view := m.View(); !strings.Contains(view, "Uploads: fixtures/avatar.png") {
+		t.Errorf("confirmation of a planned test doesn't show the uploaded file:\n%s", view)
+	}
+	m.agentState = StateShowingTestPlan
+	if view := m.View(); !strings.Contains(view, "↑ uploads fixtures/avatar.png") {
+		t.Errorf("test plan doesn't show the uploaded file:\n%s", view)
+	}
+}
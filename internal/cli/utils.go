@@ -1,7 +1,18 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	agent "github.com/Octrafic/octrafic-cli/internal/agents"
+	"github.com/Octrafic/octrafic-cli/internal/config"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
+	"github.com/Octrafic/octrafic-cli/internal/infra/logger"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"maps"
+	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -9,6 +20,36 @@ import (
 	"github.com/muesli/reflow/wordwrap"
 )
 
+// defaultDisplayBodyLimit is how many bytes of a response body the chat shows unless configured
+const defaultDisplayBodyLimit = 200
+
+// bodyLimit resolves a configured body limit: 0 means fallback, negative means no limit (0)
+func bodyLimit(configured, fallback int) int {
+	switch {
+	case configured < 0:
+		return 0
+	case configured == 0:
+		return fallback
+	default:
+		return configured
+	}
+}
+
+// modelBody shortens a response body for the model per model_body_limit, flagging the result when cut
+func (m *TestUIModel) modelBody(result map[string]any, body string) {
+	body, truncated := tester.TruncateBody(body, m.modelBodyLimit)
+	result["response_body"] = body
+	if truncated {
+		result["response_truncated"] = true
+	}
+}
+
+// displayBody shortens a response body for the chat per display_body_limit
+func (m *TestUIModel) displayBody(body string) string {
+	body, _ = tester.TruncateBody(body, m.displayBodyLimit)
+	return body
+}
+
 func renderAgentLabel() string {
 	text := "Agent:"
 	// Theme.Primary (#38BDF8), Theme.Blue (#60A5FA), Theme.Indigo (#818CF8)
@@ -87,9 +128,12 @@ func (m *TestUIModel) shouldAskForConfirmation(toolName string) bool {
 	// Tools that are safe and don't need confirmation
 	// ExecuteTestGroup is safe - user already approved the plan via checkboxes
 	safeTools := map[string]bool{
-		"GenerateTestPlan": true, // Planning is safe, doesn't execute anything
-		"ExecuteTestGroup": true, // Plan was already approved via checkboxes
-		"GenerateReport":   true, // Generating a report is safe
+		"get_endpoints_details": true, // Reads the local spec, sends nothing
+		"GenerateTestPlan":      true, // Planning is safe, doesn't execute anything
+		"ExecuteTestGroup":      true, // Plan was already approved via checkboxes
+		"GenerateReport":        true, // Generating a report is safe
+		"sample_endpoint":       true, // A single read-only GET
+		"RecordFinding":         true, // Only appends to the session's findings list
 	}
 
 	return !safeTools[toolName]
@@ -97,7 +141,7 @@ func (m *TestUIModel) shouldAskForConfirmation(toolName string) bool {
 
 func (m *TestUIModel) addAgentMessage(msg string) tea.Cmd {
 	m.lastMessageRole = "assistant"
-	return tea.Println(msg)
+	return m.addMessage(msg)
 }
 
 func (m *TestUIModel) addMessage(msg string) tea.Cmd {
@@ -206,3 +250,395 @@ func FormatToolResult(title string, details []string) []string {
 
 	return messages
 }
+
+// statusClassIndicator returns the icon and style for an HTTP status code class:
+// 2xx success, 3xx redirect, 4xx client error, 5xx server error
+func statusClassIndicator(statusCode int) (string, lipgloss.Style) {
+	style := lipgloss.NewStyle().Bold(true)
+	switch {
+	case statusCode >= 500:
+		return "✗", style.Foreground(Theme.Error)
+	case statusCode >= 400:
+		return "!", style.Foreground(Theme.Warning)
+	case statusCode >= 300:
+		return "↪", style.Foreground(Theme.Cyan)
+	case statusCode >= 200:
+		return "✓", style.Foreground(Theme.Success)
+	default:
+		return "•", style.Foreground(Theme.TextSubtle)
+	}
+}
+
+// formatSizes describes request and response body sizes for a result line
+func formatSizes(requestBytes, responseBytes int) string {
+	if requestBytes == 0 {
+		return "Size: " + tester.FormatBytes(responseBytes)
+	}
+	return fmt.Sprintf("Size: %s (sent %s)", tester.FormatBytes(responseBytes), tester.FormatBytes(requestBytes))
+}
+
+// retriedNote marks a result line for a request the retry policy sent more than once
+func retriedNote(result *tester.TestResult) string {
+	if result == nil || result.Attempts <= 1 {
+		return ""
+	}
+	return fmt.Sprintf(" | retried %d×", result.Attempts-1)
+}
+
+// authRejected reports whether a 401/403 likely means credentials are missing or wrong,
+// rather than an intentional request without auth
+func (m *TestUIModel) authRejected(statusCode int, authSent bool) bool {
+	if statusCode != http.StatusUnauthorized && statusCode != http.StatusForbidden {
+		return false
+	}
+	return authSent || m.authProvider == nil || m.authProvider.Type() == "none"
+}
+
+// showAuthHint offers a one-key way to fix credentials after unauthorized responses, and a way to
+// send the rejected requests again once they are
+func (m *TestUIModel) showAuthHint(rejected int) {
+	m.authHint = true
+	requests := "A request was"
+	if rejected > 1 {
+		requests = fmt.Sprintf("%d requests were", rejected)
+	}
+	m.addMessage(lipgloss.NewStyle().Foreground(Theme.Warning).Render(
+		fmt.Sprintf("  🔒 %s rejected as unauthorized (401/403). Press Ctrl+G to set up authentication, then /retry-auth to re-run.", requests)))
+	if m.currentProject != nil && len(m.currentProject.AuthProfiles) > 0 {
+		m.addMessage(m.subtleStyle.Render(fmt.Sprintf("     Or re-run with a saved auth profile: /retry-auth <%s>",
+			strings.Join(m.currentProject.AuthProfileNames(), "|"))))
+	}
+}
+
+// rejectedTest queues a test rejected with 401/403 to be sent again by /retry-auth, this time with
+// credentials. Its precondition is dropped, as the tests it refers to aren't re-run.
+func rejectedTest(test map[string]any) map[string]any {
+	retry := maps.Clone(test)
+	retry["requires_auth"] = true
+	delete(retry, "skip_unless")
+	return retry
+}
+
+// rejectedToolTest is the test for the tool call being executed, for requests the agent sent directly
+func (m *TestUIModel) rejectedToolTest() map[string]any {
+	if m.currentToolCall == nil {
+		return nil
+	}
+	args := m.currentToolCall.Arguments
+	method, _ := args["method"].(string)
+	if method == "" {
+		method = http.MethodGet
+	}
+	return rejectedTest(map[string]any{
+		"method":    method,
+		"endpoint":  args["endpoint"],
+		"headers":   args["headers"],
+		"body":      args["body"],
+		"multipart": args["multipart"],
+	})
+}
+
+// retryRejectedTests handles /retry-auth [profile], which sends the requests last rejected with
+// 401/403 again, after switching to the named auth profile when one is given
+func (m *TestUIModel) retryRejectedTests(args []string) tea.Cmd {
+	if len(m.authRejectedTests) == 0 {
+		m.addAgentMessage(m.subtleStyle.Render("No rejected requests to re-run"))
+		m.addMessage("")
+		return nil
+	}
+	if len(args) > 0 && !m.useAuthProfile(args[0]) {
+		return nil
+	}
+
+	tests := m.authRejectedTests
+	m.authRejectedTests = nil
+	m.authHint = false
+	label := fmt.Sprintf("Re-running %d rejected request(s) with %s auth", len(tests), m.authProvider.Type())
+	return func() tea.Msg {
+		return startTestGroupMsg{tests: tests, label: label}
+	}
+}
+
+// reauth renews the current credentials in the background (fetching a new OAuth2 token, or
+// running the bearer --token-command), keeping the conversation and test state. Credentials that
+// can't renew themselves are rejected with how to renew them instead: a bearer token without a
+// token command, and logins done by the tests themselves, a session cookie or a token captured
+// from a login request, which only repeating that request renews.
+func (m *TestUIModel) reauth() tea.Cmd {
+	refresher, ok := m.authProvider.(auth.TokenRefresher)
+	if bearer, isBearer := m.authProvider.(*auth.BearerAuth); isBearer && bearer.RefreshFunc == nil {
+		ok = false
+	}
+	if !ok {
+		authType := "none"
+		if m.authProvider != nil {
+			authType = m.authProvider.Type()
+		}
+		switch {
+		case authType == "bearer":
+			m.addAgentMessage(m.errorStyle.Render("The bearer token can't renew itself: no --token-command is configured"))
+			m.addMessage(m.subtleStyle.Render("Set a new token with auth bearer <token>, or restart with --token-command '<command printing a token>'"))
+		case m.cookieSession:
+			m.addAgentMessage(m.errorStyle.Render("A cookie session is renewed by logging in again, not by /reauth"))
+			m.addMessage(m.subtleStyle.Render("Send the login request again (or ask the agent to); the cookies it sets replace the expired ones"))
+		default:
+			m.addAgentMessage(m.errorStyle.Render(fmt.Sprintf("Current authentication (%s) can't renew its credentials", authType)))
+			m.addMessage(m.subtleStyle.Render("Set new credentials with /auth (Ctrl+G) or auth <type> ...; a token captured from a login request is renewed by sending that request again"))
+		}
+		m.addMessage("")
+		return nil
+	}
+
+	m.addAgentMessage(m.subtleStyle.Render("Re-authenticating..."))
+	provider := m.authProvider
+	return func() tea.Msg {
+		return reauthMsg{provider: provider, err: refresher.Refresh()}
+	}
+}
+
+// handleReauth reports the outcome of /reauth and hands the renewed credentials to the executor
+func (m *TestUIModel) handleReauth(msg reauthMsg) {
+	if msg.provider != m.authProvider {
+		return // Credentials were replaced while renewing
+	}
+
+	switch {
+	case errors.Is(msg.err, auth.ErrRefreshUnsupported):
+		m.addMessage(m.errorStyle.Render(fmt.Sprintf("✗ No way to renew %s credentials is configured; set new ones with /auth", msg.provider.Type())))
+	case msg.err != nil:
+		m.addMessage(m.errorStyle.Render("✗ Re-authentication failed: " + msg.err.Error()))
+	default:
+		m.testExecutor.UpdateAuthProvider(m.authProvider)
+		m.authHint = false
+		description := msg.provider.Type()
+		if stringer, ok := msg.provider.Redact().(fmt.Stringer); ok {
+			description = stringer.String()
+		}
+		m.addMessage(m.successStyle.Render("✓ Re-authenticated: " + description))
+	}
+	m.addMessage("")
+}
+
+// missingScopes returns the scopes the spec requires for an endpoint that the token of requestAuth
+// (nil for the current provider) doesn't grant. Nil when the endpoint declares none or the
+// provider can't report its scopes.
+func (m *TestUIModel) missingScopes(requestAuth auth.AuthProvider, method, endpoint string) []string {
+	if requestAuth == nil {
+		requestAuth = m.authProvider
+	}
+	scoped, ok := requestAuth.(auth.ScopedProvider)
+	if !ok {
+		return nil
+	}
+	required := m.endpointScopes(method, endpoint)
+	if len(required) == 0 {
+		return nil
+	}
+	granted, ok := scoped.Scopes()
+	if !ok {
+		return nil
+	}
+	return auth.MissingScopes(required, granted)
+}
+
+// endpointScopes returns the OAuth scopes the spec declares for the endpoint a request goes to
+func (m *TestUIModel) endpointScopes(method, endpoint string) []string {
+	if m.currentProject == nil {
+		return nil
+	}
+	endpoints, err := m.loadProjectEndpoints()
+	if err != nil {
+		return nil
+	}
+	for _, ep := range endpoints {
+		if strings.EqualFold(ep.Method, method) && ep.MatchesPath(endpoint) {
+			return ep.Scopes
+		}
+	}
+	return nil
+}
+
+// endpointAuth returns the provider for a request to an endpoint that declares scopes, when the
+// current provider can ask for them (OAuth2 requests a token with those scopes). nil means the
+// executor's provider is used as is.
+func (m *TestUIModel) endpointAuth(method, endpoint string) auth.AuthProvider {
+	requester, ok := m.authProvider.(auth.ScopeRequester)
+	if !ok {
+		return nil
+	}
+	scopes := m.endpointScopes(method, endpoint)
+	if len(scopes) == 0 {
+		return nil
+	}
+	return requester.ForScopes(scopes)
+}
+
+// applyPreferences restores the current project's saved UI preferences
+func (m *TestUIModel) applyPreferences() {
+	if m.currentProject == nil || m.currentProject.Preferences == nil {
+		return
+	}
+	prefs := m.currentProject.Preferences
+	if prefs.ThinkingEnabled != nil {
+		m.thinkingEnabled = *prefs.ThinkingEnabled
+	}
+	switch prefs.ExecutionMode {
+	case "auto":
+		m.executionMode = ModeAutoExecute
+	case "ask":
+		m.executionMode = ModeAsk
+	}
+	if prefs.Concurrency > 0 {
+		m.testConcurrency = min(prefs.Concurrency, maxTestConcurrency)
+	}
+}
+
+// savePreferences persists the current UI preferences to the project
+func (m *TestUIModel) savePreferences() {
+	if m.currentProject == nil {
+		return
+	}
+	thinking := m.thinkingEnabled
+	mode := "ask"
+	if m.executionMode == ModeAutoExecute {
+		mode = "auto"
+	}
+	m.currentProject.Preferences = &storage.Preferences{
+		ThinkingEnabled: &thinking,
+		ExecutionMode:   mode,
+		Concurrency:     m.testConcurrency,
+	}
+	if err := storage.SaveProject(m.currentProject); err != nil {
+		logger.Warn("Failed to save project preferences", logger.Err(err))
+	}
+}
+
+// toggleAutoExecute handles /auto, switching between approving test plans and live requests before
+// they run and running them directly. Destructive requests still ask. The mode is saved with the project.
+func (m *TestUIModel) toggleAutoExecute() {
+	defer m.addMessage("")
+
+	if m.executionMode == ModeAutoExecute {
+		m.executionMode = ModeAsk
+		m.addAgentMessage(m.subtleStyle.Render("Auto-execute off: test plans and live requests wait for your approval"))
+	} else {
+		m.executionMode = ModeAutoExecute
+		m.addAgentMessage(m.successStyle.Render("✓ Auto-execute on: test plans and live requests run without asking, except destructive requests"))
+	}
+	m.savePreferences()
+}
+
+// toggleTurnTokens handles /tokens, switching the per-turn usage line shown after agent replies
+func (m *TestUIModel) toggleTurnTokens() {
+	defer m.addMessage("")
+
+	m.showTurnTokens = !m.showTurnTokens
+	if !m.showTurnTokens {
+		m.addAgentMessage(m.subtleStyle.Render("Per-turn token usage hidden"))
+		return
+	}
+
+	var turns int
+	var input, output int64
+	for _, msg := range m.conversationHistory {
+		if msg.InputTokens > 0 || msg.OutputTokens > 0 {
+			turns++
+			input += msg.InputTokens
+			output += msg.OutputTokens
+		}
+	}
+	m.addAgentMessage(m.successStyle.Render("✓ Per-turn token usage shown after each reply"))
+	if turns > 0 {
+		m.addMessage(m.subtleStyle.Render(fmt.Sprintf("%d turns so far: ↑%d ↓%d tokens", turns, input, output)))
+	}
+}
+
+// trimConversation handles /trim <n>, keeping the last n turns of the conversation
+func (m *TestUIModel) trimConversation(args []string) {
+	defer m.addMessage("")
+
+	turns := m.maxTurns
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			m.addAgentMessage(m.subtleStyle.Render("Usage: /trim <turns>  (e.g. /trim 10)"))
+			return
+		}
+		turns = n
+	}
+	if turns <= 0 {
+		m.addAgentMessage(m.subtleStyle.Render("Usage: /trim <turns>  (or set max_turns in ~/.octrafic/config.json)"))
+		return
+	}
+
+	before := len(m.conversationHistory)
+	m.conversationHistory = agent.TrimHistory(m.conversationHistory, turns)
+	if len(m.conversationHistory) == before {
+		m.addAgentMessage(m.subtleStyle.Render(fmt.Sprintf("Conversation has no more than %d turns, nothing to trim", turns)))
+		return
+	}
+	m.addAgentMessage(m.successStyle.Render(fmt.Sprintf("✓ Trimmed conversation to the last %d turns (%d of %d messages kept)", turns, len(m.conversationHistory), before)))
+}
+
+// workingMessage describes what the agent is waiting on, so slow local model loads don't look like a hang
+func (m *TestUIModel) workingMessage() string {
+	switch m.agentState {
+	case StateRunningTests:
+		return "Running tests..."
+	case StateUsingTool:
+		return "Working..."
+	}
+
+	if config.IsLocalProvider(m.llmProvider) && !m.modelLoaded {
+		return "Loading model into memory..."
+	}
+	name := m.llmModel
+	if name == "" {
+		name = m.llmProvider
+	}
+	if name == "" {
+		return "Working..."
+	}
+	return fmt.Sprintf("Waiting for %s...", name)
+}
+
+// captureVariables stores values from a test's response as variables for later tests, noting them
+// under the result line and in testResult
+func (m *TestUIModel) captureVariables(result *tester.TestResult, captures map[string]string, testResult map[string]any) {
+	captured, err := m.testExecutor.Capture(result, captures)
+	if len(captured) > 0 {
+		names := make([]string, 0, len(captured))
+		for name := range captured {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		parts := make([]string, 0, len(names))
+		for _, name := range names {
+			parts = append(parts, fmt.Sprintf("%s=%v", name, captured[name]))
+		}
+		m.addMessage(m.subtleStyle.Render("    Captured: " + strings.Join(parts, ", ")))
+		testResult["captured"] = captured
+	}
+	if err != nil {
+		m.addMessage(lipgloss.NewStyle().Foreground(Theme.Warning).Render("    ⚠ Capture failed: " + err.Error()))
+		testResult["capture_error"] = err.Error()
+	}
+}
+
+// showDownload reports where a downloaded file was saved and whether it met the test's expectations
+func (m *TestUIModel) showDownload(download *tester.DownloadResult, testResult map[string]any) {
+	testResult["download_size"] = download.Size
+	testResult["download_sha256"] = download.SHA256
+	if download.Path != "" {
+		testResult["saved_to"] = download.Path
+		m.addMessage(m.subtleStyle.Render(fmt.Sprintf("    Saved %s to %s", tester.FormatBytes(download.Size), download.Path)))
+	}
+	testResult["download_verified"] = len(download.Problems) == 0
+	if len(download.Problems) == 0 {
+		return
+	}
+	testResult["download_problems"] = download.Problems
+	for _, problem := range download.Problems {
+		m.addMessage(m.errorStyle.Render("    Download check failed: " + problem))
+	}
+}
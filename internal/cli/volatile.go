@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"github.com/Octrafic/octrafic-cli/internal/core/tester"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
+	"slices"
+)
+
+// handleVolatileCommand handles /volatile [list | add <path> | remove <path>], managing the project's
+// volatile fields: JSONPaths whose values change on every call and are ignored when comparing responses
+func (m *TestUIModel) handleVolatileCommand(args []string) {
+	defer m.addMessage("")
+
+	if m.currentProject == nil {
+		m.addAgentMessage(m.subtleStyle.Render("No active project"))
+		return
+	}
+
+	usage := m.errorStyle.Render("Usage: /volatile [list | add <path> | remove <path>]") + " " +
+		m.subtleStyle.Render("(e.g. /volatile add $.meta.request_id or $..updated_at)")
+	if len(args) == 0 || (len(args) == 1 && args[0] == "list") {
+		m.listVolatileFields()
+		return
+	}
+	if len(args) != 2 {
+		m.addAgentMessage(usage)
+		return
+	}
+
+	fields := m.currentProject.VolatileFields
+	path := args[1]
+	switch args[0] {
+	case "add":
+		if err := tester.ValidateVolatilePath(path); err != nil {
+			m.addAgentMessage(m.errorStyle.Render(err.Error()))
+			return
+		}
+		if slices.Contains(fields, path) {
+			m.addAgentMessage(m.subtleStyle.Render(path + " is already volatile"))
+			return
+		}
+		fields = append(slices.Clone(fields), path)
+	case "remove":
+		i := slices.Index(fields, path)
+		if i < 0 {
+			m.addAgentMessage(m.subtleStyle.Render(path + " is not a volatile field"))
+			return
+		}
+		fields = slices.Delete(slices.Clone(fields), i, i+1)
+	default:
+		m.addAgentMessage(usage)
+		return
+	}
+
+	m.currentProject.VolatileFields = fields
+	m.testExecutor.SetVolatileFields(fields)
+	if err := storage.SaveProject(m.currentProject); err != nil {
+		m.addAgentMessage(m.errorStyle.Render("Failed to save volatile fields: " + err.Error()))
+		return
+	}
+	if args[0] == "add" {
+		m.addAgentMessage(m.successStyle.Render("✓ " + path + " is now ignored when comparing responses"))
+	} else {
+		m.addAgentMessage(m.successStyle.Render("✓ " + path + " is compared again"))
+	}
+}
+
+// listVolatileFields shows the project's volatile fields
+func (m *TestUIModel) listVolatileFields() {
+	if len(m.currentProject.VolatileFields) == 0 {
+		m.addAgentMessage(m.subtleStyle.Render("No volatile fields; add one with /volatile add $.meta.request_id"))
+		return
+	}
+	m.addAgentMessage(m.agentStyle.Render("Volatile fields (ignored when comparing responses):"))
+	for _, field := range m.currentProject.VolatileFields {
+		m.addMessage("  " + field)
+	}
+}
@@ -3,7 +3,9 @@ package cli
 import (
 	"fmt"
 	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
 	"strings"
+	"unicode"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -31,7 +33,7 @@ const (
 type WizardState struct {
 	Type          WizardType
 	Step          WizardStep
-	SelectedType  string   // Selected auth type: "bearer", "apikey", "basic"
+	SelectedType  string   // Selected auth type: "bearer", "apikey", "basic", "digest", "custom", "oauth2", "awssigv4"
 	MenuItems     []string // Menu options for selection
 	SelectedIndex int      // Currently selected menu item
 	FormFields    []FormField
@@ -55,7 +57,7 @@ func NewAuthWizard() *WizardState {
 	return &WizardState{
 		Type:          WizardAuth,
 		Step:          StepSelectType,
-		MenuItems:     []string{"Bearer Token", "API Key", "Basic Auth", "None (clear auth)"},
+		MenuItems:     []string{"Bearer Token", "API Key", "Basic Auth", "Digest Auth", "Custom Header", "OAuth2 Client Credentials", "AWS SigV4", "None (clear auth)"},
 		SelectedIndex: 0,
 	}
 }
@@ -123,6 +125,97 @@ func CreateAuthFormFields(authType string) []FormField {
 			},
 		}
 
+	case "digest":
+		fields := CreateAuthFormFields("basic")
+		fields[len(fields)-1].Placeholder = "dev-digest"
+		return fields
+
+	case "custom":
+		return []FormField{
+			{
+				Name:        "header",
+				Label:       "Header name:",
+				Placeholder: "Authorization",
+			},
+			{
+				Name:        "template",
+				Label:       "Value template ({value} is replaced):",
+				Placeholder: "Token {value}",
+			},
+			{
+				Name:        "value",
+				Label:       "Value:",
+				Placeholder: "your-secret-here",
+				IsPassword:  true,
+			},
+			{
+				Name:        "profile_name",
+				Label:       "Save as profile (optional):",
+				Placeholder: "custom-header",
+			},
+		}
+
+	case "oauth2":
+		return []FormField{
+			{
+				Name:        "token_url",
+				Label:       "Token URL:",
+				Placeholder: "https://auth.example.com/oauth/token",
+			},
+			{
+				Name:        "client_id",
+				Label:       "Client ID:",
+				Placeholder: "my-client",
+			},
+			{
+				Name:        "client_secret",
+				Label:       "Client secret:",
+				Placeholder: "••••••••",
+				IsPassword:  true,
+			},
+			{
+				Name:        "scopes",
+				Label:       "Scopes (optional, space-separated):",
+				Placeholder: "read:users write:users",
+			},
+			{
+				Name:        "profile_name",
+				Label:       "Save as profile (optional):",
+				Placeholder: "oauth-client",
+			},
+		}
+
+	case "awssigv4":
+		return []FormField{
+			{
+				Name:        "access_key",
+				Label:       "Access key ID:",
+				Placeholder: "AKIA...",
+			},
+			{
+				Name:        "secret_key",
+				Label:       "Secret access key:",
+				Placeholder: "••••••••",
+				IsPassword:  true,
+			},
+			{
+				Name:        "region",
+				Label:       "Region:",
+				Placeholder: "us-east-1",
+			},
+			{
+				Name:        "service",
+				Label:       "Service:",
+				Value:       "execute-api",
+				Placeholder: "execute-api",
+			},
+			{
+				Name:        "profile_name",
+				Label:       "Save as profile (optional):",
+				Placeholder: "aws-gateway",
+			},
+		}
+
 	default:
 		return []FormField{}
 	}
@@ -130,16 +223,7 @@ func CreateAuthFormFields(authType string) []FormField {
 
 // BuildAuthProviderFromForm creates an AuthProvider from form fields
 func BuildAuthProviderFromForm(authType string, fields []FormField) (auth.AuthProvider, string, error) {
-	fieldMap := make(map[string]string)
-	for _, field := range fields {
-		if field.IsRadio {
-			if field.RadioIndex < len(field.Options) {
-				fieldMap[field.Name] = field.Options[field.RadioIndex]
-			}
-		} else {
-			fieldMap[field.Name] = field.Value
-		}
-	}
+	fieldMap := formValues(fields)
 
 	profileName := fieldMap["profile_name"]
 
@@ -157,6 +241,18 @@ func BuildAuthProviderFromForm(authType string, fields []FormField) (auth.AuthPr
 	case "basic":
 		return auth.NewBasicAuth(fieldMap["username"], fieldMap["password"]), profileName, nil
 
+	case "digest":
+		return auth.NewDigestAuth(fieldMap["username"], fieldMap["password"]), profileName, nil
+
+	case "custom":
+		return auth.NewCustomHeaderAuth(fieldMap["header"], fieldMap["template"], fieldMap["value"]), profileName, nil
+
+	case "oauth2":
+		return auth.NewOAuth2ClientCredentials(fieldMap["token_url"], fieldMap["client_id"], fieldMap["client_secret"], strings.Fields(fieldMap["scopes"])), profileName, nil
+
+	case "awssigv4":
+		return auth.NewAWSSigV4(fieldMap["access_key"], fieldMap["secret_key"], fieldMap["region"], fieldMap["service"]), profileName, nil
+
 	case "none":
 		return &auth.NoAuth{}, "", nil
 
@@ -260,6 +356,14 @@ func handleWizardKeys(m TestUIModel, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				authType = "apikey"
 			case "Basic Auth":
 				authType = "basic"
+			case "Digest Auth":
+				authType = "digest"
+			case "Custom Header":
+				authType = "custom"
+			case "OAuth2 Client Credentials":
+				authType = "oauth2"
+			case "AWS SigV4":
+				authType = "awssigv4"
 			case "None (clear auth)":
 				authType = "none"
 			}
@@ -329,6 +433,51 @@ func handleWizardKeys(m TestUIModel, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// formValues returns the values of form fields by field name, radio buttons giving their selected option
+func formValues(fields []FormField) map[string]string {
+	values := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if field.IsRadio {
+			if field.RadioIndex < len(field.Options) {
+				values[field.Name] = field.Options[field.RadioIndex]
+			}
+		} else {
+			values[field.Name] = field.Value
+		}
+	}
+	return values
+}
+
+// AuthConfigFromForm converts auth form values, keyed by field name, to settings that can be saved
+func AuthConfigFromForm(authType string, values map[string]string) *storage.AuthConfig {
+	location := values["location"]
+	if authType == "apikey" && location == "" {
+		location = "header"
+	}
+	return &storage.AuthConfig{
+		Type:     authType,
+		Token:    values["token"],
+		KeyName:  values["key"],
+		KeyValue: values["value"],
+		Location: location,
+		Username: values["username"],
+		Password: values["password"],
+
+		HeaderName:     values["header"],
+		HeaderTemplate: values["template"],
+
+		TokenURL:     values["token_url"],
+		ClientID:     values["client_id"],
+		ClientSecret: values["client_secret"],
+		Scopes:       strings.FieldsFunc(values["scopes"], func(r rune) bool { return r == ',' || unicode.IsSpace(r) }),
+
+		AccessKey: values["access_key"],
+		SecretKey: values["secret_key"],
+		Region:    values["region"],
+		Service:   values["service"],
+	}
+}
+
 // submitAuthForm validates and applies the auth configuration
 func submitAuthForm(m TestUIModel) (tea.Model, tea.Cmd) {
 	authProvider, profileName, err := BuildAuthProviderFromForm(
@@ -354,8 +503,14 @@ func submitAuthForm(m TestUIModel) (tea.Model, tea.Cmd) {
 	m.testExecutor.UpdateAuthProvider(authProvider)
 
 	// Save as profile if name provided
-	if strings.TrimSpace(profileName) != "" {
-		m.addMessage(m.successStyle.Render(fmt.Sprintf("✓ Authentication configured and saved as '%s'", profileName)))
+	if name := strings.TrimSpace(profileName); name != "" {
+		authConfig := AuthConfigFromForm(m.wizardState.SelectedType, formValues(m.wizardState.FormFields))
+		if err := m.saveAuthProfile(name, authConfig); err != nil {
+			m.addMessage(m.errorStyle.Render("Authentication configured, but not saved: " + err.Error()))
+		} else {
+			m.addMessage(m.successStyle.Render(fmt.Sprintf("✓ Authentication configured and saved as '%s'", name)))
+			m.addMessage(m.subtleStyle.Render(fmt.Sprintf("  Switch back to it with: auth use %s", name)))
+		}
 	} else {
 		if stringer, ok := authProvider.(fmt.Stringer); ok {
 			m.addMessage(m.successStyle.Render("✓ " + stringer.String()))
@@ -470,6 +625,14 @@ func (m TestUIModel) renderAuthForm() string {
 		title = "API Key Authentication"
 	case "basic":
 		title = "Basic Authentication"
+	case "digest":
+		title = "Digest Authentication"
+	case "custom":
+		title = "Custom Header Authentication"
+	case "oauth2":
+		title = "OAuth2 Client Credentials"
+	case "awssigv4":
+		title = "AWS Signature Version 4"
 	}
 
 	b.WriteString(titleStyle.Render(title))
@@ -17,6 +17,37 @@ type Config struct {
 	Onboarded       bool      `json:"onboarded"`
 	LastUpdateCheck time.Time `json:"last_update_check,omitempty"`
 	LatestVersion   string    `json:"latest_version,omitempty"`
+	SaveAuth        *bool     `json:"save_auth,omitempty"` // nil = ask the first time
+	OpenReports     bool      `json:"open_reports,omitempty"`
+	ValidateSchemas bool      `json:"validate_schemas,omitempty"`     // Check responses against the spec's response schemas
+	UpgradeHinted   []string  `json:"upgrade_hinted_specs,omitempty"` // Absolute paths of Swagger 2.0 specs the upgrade hint was shown for
+	MaxTurns        int       `json:"max_turns,omitempty"`            // Turns kept in the conversation sent to the LLM (0 = unlimited)
+	ConversionModel string    `json:"conversion_model,omitempty"`     // Cheaper model for spec processing (empty = Model)
+
+	// Azure OpenAI deployment and API version; BaseURL holds the resource endpoint
+	Deployment string `json:"deployment,omitempty"`
+	APIVersion string `json:"api_version,omitempty"`
+
+	// DisableStreaming requests whole responses, for gateways and models that can't stream
+	DisableStreaming bool `json:"disable_streaming,omitempty"`
+
+	// LLM generation settings: max_tokens caps each response (0 = provider default),
+	// temperature is left to the model when unset
+	MaxTokens   int      `json:"max_tokens,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty"`
+
+	// BasePathFromSpec prefixes requests with the base path of the spec's servers (or basePath)
+	BasePathFromSpec bool `json:"base_path_from_spec,omitempty"`
+
+	// Response body limits in bytes: 0 uses the default, a negative value disables truncation
+	DisplayBodyLimit int `json:"display_body_limit,omitempty"` // Shown in the chat (default 200)
+	ModelBodyLimit   int `json:"model_body_limit,omitempty"`   // Sent to the model (default no limit)
+
+	// DestructiveMethods always ask before being sent, even in auto-execute mode (default DELETE)
+	DestructiveMethods []string `json:"destructive_methods,omitempty"`
+
+	// KeepTempProjects leaves the temporary project of a -u/-s run on disk after quitting
+	KeepTempProjects bool `json:"keep_temp_projects,omitempty"`
 }
 
 // ShouldCheckForUpdate returns true if more than 24 hours since last check
@@ -44,8 +75,19 @@ func configDir() (string, error) {
 	return configDir, nil
 }
 
-// configPath returns the full path to the config file
+// configPath returns the full path to the config file of the active profile
 func configPath() (string, error) {
+	if profile := ActiveProfile(); profile != "" {
+		dir, err := ProfileDir(profile)
+		if err != nil {
+			return "", err
+		}
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "config.json"), nil
+	}
+
 	dir, err := configDir()
 	if err != nil {
 		return "", err
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	profilesDirName   = "profiles"
+	activeProfileFile = "active_profile"
+
+	// DefaultProfile names the top-level ~/.octrafic config used when no profile is selected
+	DefaultProfile = "default"
+)
+
+// profileOverride is set by --profile and wins over OCTRAFIC_PROFILE and the saved active profile
+var profileOverride string
+
+var validProfileName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// SetProfile selects the profile for this process
+func SetProfile(name string) {
+	profileOverride = name
+}
+
+// ActiveProfile returns the profile in effect: --profile, then OCTRAFIC_PROFILE, then the one saved
+// by `octrafic profile use`. Empty means the default config.
+func ActiveProfile() string {
+	name := profileOverride
+	if name == "" {
+		name = GetEnv("PROFILE")
+	}
+	if name == "" {
+		if dir, err := configDir(); err == nil {
+			if data, err := os.ReadFile(filepath.Join(dir, activeProfileFile)); err == nil {
+				name = strings.TrimSpace(string(data))
+			}
+		}
+	}
+	if name == DefaultProfile {
+		return ""
+	}
+	return name
+}
+
+// ValidateProfileName checks that name is safe to use as a directory name
+func ValidateProfileName(name string) error {
+	if !validProfileName.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q (use letters, digits, - and _)", name)
+	}
+	return nil
+}
+
+// ProfileDir returns the directory holding a profile's config and default auth
+func ProfileDir(name string) (string, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, profilesDirName, name), nil
+}
+
+// ProfileExists reports whether a profile has been created
+func ProfileExists(name string) bool {
+	dir, err := ProfileDir(name)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+// CreateProfile creates an empty profile directory
+func CreateProfile(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("%q is reserved for the top-level config", DefaultProfile)
+	}
+	dir, err := ProfileDir(name)
+	if err != nil {
+		return err
+	}
+	if ProfileExists(name) {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create profile directory: %w", err)
+	}
+	return nil
+}
+
+// ListProfiles returns the names of all created profiles, sorted
+func ListProfiles() ([]string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, profilesDirName))
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() && validProfileName.MatchString(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// UseProfile saves name as the active profile for future runs. "default" switches back to the top-level config.
+func UseProfile(name string) error {
+	dir, err := configDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, activeProfileFile)
+
+	if name == DefaultProfile || name == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to reset active profile: %w", err)
+		}
+		return nil
+	}
+	if !ProfileExists(name) {
+		return fmt.Errorf("profile %q does not exist (create it with: octrafic profile create %s)", name, name)
+	}
+	if err := os.WriteFile(path, []byte(name+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to save active profile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("OCTRAFIC_PROFILE", "")
+	t.Cleanup(func() { SetProfile("") })
+
+	if got := ActiveProfile(); got != "" {
+		t.Fatalf("ActiveProfile() = %q, want default", got)
+	}
+	if err := CreateProfile("work"); err != nil {
+		t.Fatalf("CreateProfile() error = %v", err)
+	}
+	if err := CreateProfile("work"); err == nil {
+		t.Error("expected error creating a duplicate profile")
+	}
+	for _, name := range []string{"../evil", "default", ""} {
+		if err := CreateProfile(name); err == nil {
+			t.Errorf("CreateProfile(%q) should fail", name)
+		}
+	}
+	if err := UseProfile("missing"); err == nil {
+		t.Error("expected error using a missing profile")
+	}
+
+	// Each profile keeps its own config
+	cfg := &Config{Provider: "claude", Onboarded: true}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := UseProfile("work"); err != nil {
+		t.Fatalf("UseProfile() error = %v", err)
+	}
+	if got := ActiveProfile(); got != "work" {
+		t.Fatalf("ActiveProfile() = %q, want work", got)
+	}
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Onboarded {
+		t.Error("work profile should not inherit the default config")
+	}
+
+	// --profile and OCTRAFIC_PROFILE override the saved choice
+	t.Setenv("OCTRAFIC_PROFILE", "default")
+	if got := ActiveProfile(); got != "" {
+		t.Errorf("ActiveProfile() with OCTRAFIC_PROFILE=default = %q, want default", got)
+	}
+	SetProfile("work")
+	if got := ActiveProfile(); got != "work" {
+		t.Errorf("ActiveProfile() with --profile = %q, want work", got)
+	}
+
+	names, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles() error = %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"work"}) {
+		t.Errorf("ListProfiles() = %v, want [work]", names)
+	}
+}
@@ -0,0 +1,163 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+)
+
+// AnalyzeOffline builds the analysis from the parsed endpoints alone, without any model or network
+// call: endpoints are grouped by their first path segment, and purposes come from the spec's
+// descriptions or, without one, from the method and path.
+func AnalyzeOffline(baseURL string, spec *parser.Specification) *Analysis {
+	analysis := &Analysis{
+		BaseURL:       baseURL,
+		Specification: spec,
+		Timestamp:     time.Now(),
+		Insights:      []string{},
+		EndpointInfo:  make(map[string]EndpointAnalysis),
+	}
+
+	groups := make(map[string]int)
+	authRequired, deprecated := 0, 0
+	for _, endpoint := range spec.Endpoints {
+		key := fmt.Sprintf("%s %s", endpoint.Method, endpoint.Path)
+		analysis.EndpointInfo[key] = EndpointAnalysis{
+			Path:           endpoint.Path,
+			Method:         endpoint.Method,
+			Purpose:        endpointPurpose(endpoint),
+			ExpectedInputs: expectedInputs(endpoint),
+			ExpectedOutput: expectedOutput(endpoint),
+		}
+
+		groups[pathGroup(endpoint.Path)]++
+		if endpoint.RequiresAuth {
+			authRequired++
+		}
+		if endpoint.Deprecated {
+			deprecated++
+		}
+	}
+
+	if len(groups) > 0 {
+		names := make([]string, 0, len(groups))
+		for name := range groups {
+			names = append(names, name)
+		}
+		// Largest groups first, then by name
+		slices.SortFunc(names, func(a, b string) int {
+			if groups[a] != groups[b] {
+				return groups[b] - groups[a]
+			}
+			return strings.Compare(a, b)
+		})
+		parts := make([]string, len(names))
+		for i, name := range names {
+			parts[i] = fmt.Sprintf("%s (%d)", name, groups[name])
+		}
+		analysis.Insights = append(analysis.Insights, fmt.Sprintf("%d endpoints in %d groups: %s", len(spec.Endpoints), len(groups), strings.Join(parts, ", ")))
+	}
+	if authRequired > 0 {
+		analysis.Insights = append(analysis.Insights, fmt.Sprintf("%d of %d endpoints require authentication", authRequired, len(spec.Endpoints)))
+	}
+	if deprecated > 0 {
+		analysis.Insights = append(analysis.Insights, fmt.Sprintf("Deprecated endpoints: %d", deprecated))
+	}
+
+	return analysis
+}
+
+// pathGroup returns the group of a path: its first segment that isn't a parameter, e.g.
+// /users/{id}/posts -> /users. Paths without one belong to "/".
+func pathGroup(path string) string {
+	for segment := range strings.SplitSeq(path, "/") {
+		if segment != "" && !isPathParam(segment) {
+			return "/" + segment
+		}
+	}
+	return "/"
+}
+
+// endpointPurpose returns the first line of the endpoint's description, or a purpose derived
+// from its method and path such as "List users" or "Delete user by id"
+func endpointPurpose(endpoint parser.Endpoint) string {
+	if description := strings.TrimSpace(endpoint.Description); description != "" {
+		line, _, _ := strings.Cut(description, "\n")
+		return strings.TrimSpace(line)
+	}
+
+	segments := strings.FieldsFunc(endpoint.Path, func(r rune) bool { return r == '/' })
+	resource, param := "resource", ""
+	for i := len(segments) - 1; i >= 0; i-- {
+		if isPathParam(segments[i]) {
+			if param == "" && i == len(segments)-1 {
+				param = strings.Trim(segments[i], "{}:")
+			}
+			continue
+		}
+		resource = segments[i]
+		break
+	}
+
+	target := resource
+	if param != "" {
+		target = fmt.Sprintf("%s by %s", strings.TrimSuffix(resource, "s"), param)
+	}
+	switch strings.ToUpper(endpoint.Method) {
+	case "GET":
+		if param == "" {
+			return "List " + resource
+		}
+		return "Get " + target
+	case "POST":
+		return "Create " + target
+	case "PUT", "PATCH":
+		return "Update " + target
+	case "DELETE":
+		return "Delete " + target
+	default:
+		return fmt.Sprintf("%s %s", strings.ToUpper(endpoint.Method), target)
+	}
+}
+
+// expectedInputs lists the endpoint's parameters as "name (in)", with required ones marked, and its body
+func expectedInputs(endpoint parser.Endpoint) []string {
+	var inputs []string
+	for _, p := range endpoint.Parameters {
+		input := fmt.Sprintf("%s (%s)", p.Name, p.In)
+		if p.Required {
+			input += " required"
+		}
+		inputs = append(inputs, input)
+	}
+	if endpoint.RequestBody != "" {
+		inputs = append(inputs, "request body")
+	}
+	return inputs
+}
+
+// expectedOutput describes the endpoint's first successful response
+func expectedOutput(endpoint parser.Endpoint) string {
+	statuses := make([]string, 0, len(endpoint.Responses))
+	for status := range endpoint.Responses {
+		if strings.HasPrefix(status, "2") {
+			statuses = append(statuses, status)
+		}
+	}
+	if len(statuses) == 0 {
+		return ""
+	}
+	slices.Sort(statuses)
+	if description := endpoint.Responses[statuses[0]]; description != "" {
+		return statuses[0] + ": " + description
+	}
+	return statuses[0]
+}
+
+// isPathParam reports whether a path segment is a parameter, as {id} or :id
+func isPathParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") || strings.HasPrefix(segment, ":")
+}
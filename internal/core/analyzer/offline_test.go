@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+)
+
+// failingTransport fails and counts every request, to prove none are made
+type failingTransport struct{ calls int }
+
+func (f *failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	f.calls++
+	return nil, errors.New("network access during offline analysis")
+}
+
+func TestAnalyzeOffline(t *testing.T) {
+	transport := &failingTransport{}
+	original := http.DefaultTransport
+	http.DefaultTransport = transport
+	t.Cleanup(func() { http.DefaultTransport = original })
+
+	spec := &parser.Specification{Endpoints: []parser.Endpoint{
+		{Method: "GET", Path: "/users", Responses: map[string]string{"200": "A list of users", "401": "Unauthorized"}},
+		{Method: "GET", Path: "/users/{id}", RequiresAuth: true, Parameters: []parser.Parameter{{Name: "id", In: "path", Required: true}}},
+		{Method: "POST", Path: "/users", Description: "Register a user\nSends a welcome email.", RequestBody: `{"name":"string"}`, RequiresAuth: true},
+		{Method: "DELETE", Path: "/orders/{orderId}", Deprecated: true},
+		{Method: "GET", Path: "/", Description: "API root"},
+	}}
+
+	analysis := AnalyzeOffline("https://api.example.com", spec)
+
+	if transport.calls != 0 {
+		t.Errorf("made %d network calls, want none", transport.calls)
+	}
+	if len(analysis.EndpointInfo) != len(spec.Endpoints) {
+		t.Fatalf("EndpointInfo has %d endpoints, want %d", len(analysis.EndpointInfo), len(spec.Endpoints))
+	}
+
+	want := map[string]EndpointAnalysis{
+		"GET /users":               {Path: "/users", Method: "GET", Purpose: "List users", ExpectedOutput: "200: A list of users"},
+		"GET /users/{id}":          {Path: "/users/{id}", Method: "GET", Purpose: "Get user by id", ExpectedInputs: []string{"id (path) required"}},
+		"POST /users":              {Path: "/users", Method: "POST", Purpose: "Register a user", ExpectedInputs: []string{"request body"}},
+		"DELETE /orders/{orderId}": {Path: "/orders/{orderId}", Method: "DELETE", Purpose: "Delete order by orderId"},
+	}
+	for key, wantInfo := range want {
+		if got := analysis.EndpointInfo[key]; !reflect.DeepEqual(got, wantInfo) {
+			t.Errorf("EndpointInfo[%q] = %+v, want %+v", key, got, wantInfo)
+		}
+	}
+
+	wantInsights := []string{
+		"5 endpoints in 3 groups: /users (3), / (1), /orders (1)",
+		"2 of 5 endpoints require authentication",
+		"Deprecated endpoints: 1",
+	}
+	if !reflect.DeepEqual(analysis.Insights, wantInsights) {
+		t.Errorf("Insights = %q, want %q", analysis.Insights, wantInsights)
+	}
+}
+
+func TestPathGroup(t *testing.T) {
+	tests := map[string]string{
+		"/users":             "/users",
+		"/users/{id}/posts":  "/users",
+		"/{tenant}/invoices": "/invoices",
+		"/:version/health":   "/health",
+		"/":                  "/",
+		"":                   "/",
+	}
+	for path, want := range tests {
+		if got := pathGroup(path); got != want {
+			t.Errorf("pathGroup(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
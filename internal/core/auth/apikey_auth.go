@@ -3,14 +3,25 @@ package auth
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync/atomic"
 )
 
 // APIKeyAuth represents API Key authentication
 type APIKeyAuth struct {
-	Key      string `json:"key"`      // The key name (e.g., "X-API-Key")
-	Value    string `json:"value"`    // The key value
-	Location string `json:"location"` // "header" or "query"
+	Key      string   `json:"key"`              // The key name (e.g., "X-API-Key")
+	Value    string   `json:"value"`            // The key value
+	Values   []string `json:"values,omitempty"` // Multiple key values rotated round-robin
+	Location string   `json:"location"`         // "header" or "query"
+
+	next atomic.Uint64
+}
+
+// KeyRotator is implemented by providers that rotate across several credentials
+type KeyRotator interface {
+	// KeyCount returns the number of credentials in rotation
+	KeyCount() int
 }
 
 // NewAPIKeyAuth creates a new API Key authentication provider
@@ -25,19 +36,45 @@ func NewAPIKeyAuth(key, value, location string) *APIKeyAuth {
 	}
 }
 
+// NewAPIKeyAuthRotating creates an API Key provider that rotates across several values round-robin
+func NewAPIKeyAuthRotating(key string, values []string, location string) *APIKeyAuth {
+	if len(values) == 1 {
+		return NewAPIKeyAuth(key, values[0], location)
+	}
+	a := NewAPIKeyAuth(key, "", location)
+	a.Values = values
+	return a
+}
+
+// KeyCount returns the number of key values in rotation
+func (a *APIKeyAuth) KeyCount() int {
+	if len(a.Values) > 0 {
+		return len(a.Values)
+	}
+	return 1
+}
+
+// currentValue returns the value for the next request, advancing the rotation
+func (a *APIKeyAuth) currentValue() string {
+	if len(a.Values) == 0 {
+		return a.Value
+	}
+	idx := a.next.Add(1) - 1
+	return a.Values[idx%uint64(len(a.Values))]
+}
+
 // Apply adds the API key to the request
 func (a *APIKeyAuth) Apply(req *http.Request) error {
 	if err := a.Validate(); err != nil {
 		return err
 	}
 
+	value := a.currentValue()
 	switch strings.ToLower(a.Location) {
 	case "header":
-		req.Header.Set(a.Key, a.Value)
+		req.Header.Set(a.Key, value)
 	case "query":
-		q := req.URL.Query()
-		q.Set(a.Key, a.Value)
-		req.URL.RawQuery = q.Encode()
+		req.URL.RawQuery = setQueryParam(req.URL.RawQuery, a.Key, value)
 	default:
 		return fmt.Errorf("invalid location: %s (must be 'header' or 'query')", a.Location)
 	}
@@ -45,6 +82,24 @@ func (a *APIKeyAuth) Apply(req *http.Request) error {
 	return nil
 }
 
+// setQueryParam sets key=value in a raw query string, replacing any existing values of key and
+// leaving the other parameters exactly as they were (order and encoding)
+func setQueryParam(rawQuery, key, value string) string {
+	var params []string
+	for param := range strings.SplitSeq(rawQuery, "&") {
+		if param == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(name); err == nil && unescaped == key {
+			continue
+		}
+		params = append(params, param)
+	}
+	params = append(params, url.QueryEscape(key)+"="+url.QueryEscape(value))
+	return strings.Join(params, "&")
+}
+
 // Type returns the authentication type
 func (a *APIKeyAuth) Type() string {
 	return "apikey"
@@ -55,7 +110,13 @@ func (a *APIKeyAuth) Validate() error {
 	if strings.TrimSpace(a.Key) == "" {
 		return fmt.Errorf("API key name cannot be empty")
 	}
-	if strings.TrimSpace(a.Value) == "" {
+	if len(a.Values) > 0 {
+		for _, v := range a.Values {
+			if strings.TrimSpace(v) == "" {
+				return fmt.Errorf("API key value cannot be empty")
+			}
+		}
+	} else if strings.TrimSpace(a.Value) == "" {
 		return fmt.Errorf("API key value cannot be empty")
 	}
 	location := strings.ToLower(a.Location)
@@ -67,14 +128,21 @@ func (a *APIKeyAuth) Validate() error {
 
 // Redact returns a copy with the value redacted
 func (a *APIKeyAuth) Redact() AuthProvider {
-	return &APIKeyAuth{
+	redacted := &APIKeyAuth{
 		Key:      a.Key,
 		Value:    RedactString(a.Value),
 		Location: a.Location,
 	}
+	for _, v := range a.Values {
+		redacted.Values = append(redacted.Values, RedactString(v))
+	}
+	return redacted
 }
 
 // String returns a human-readable representation
 func (a *APIKeyAuth) String() string {
+	if len(a.Values) > 0 {
+		return fmt.Sprintf("API Key %s in %s (%d keys, rotating)", a.Key, a.Location, len(a.Values))
+	}
 	return fmt.Sprintf("API Key %s in %s (%s)", a.Key, a.Location, RedactString(a.Value))
 }
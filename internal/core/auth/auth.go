@@ -3,8 +3,13 @@ package auth
 import (
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 )
 
+// SupportedTypes lists the authentication types accepted by ParseAuthType
+var SupportedTypes = []string{"none", "bearer", "apikey", "basic", "custom", "oauth2", "awssigv4", "digest"}
+
 // AuthProvider applies authentication to HTTP requests
 type AuthProvider interface {
 	// Apply adds authentication to the request
@@ -20,6 +25,12 @@ type AuthProvider interface {
 	Redact() AuthProvider
 }
 
+// RequestSigner is implemented by providers that sign the whole request, body included.
+// Callers that have the body at hand use ApplyToRequest instead of Apply.
+type RequestSigner interface {
+	ApplyToRequest(req *http.Request, body []byte) error
+}
+
 // NoAuth represents no authentication
 type NoAuth struct{}
 
@@ -41,15 +52,8 @@ func (n *NoAuth) Redact() AuthProvider {
 
 // ParseAuthType converts a string to an auth type validator
 func ParseAuthType(authType string) (string, error) {
-	validTypes := map[string]bool{
-		"none":   true,
-		"bearer": true,
-		"apikey": true,
-		"basic":  true,
-	}
-
-	if !validTypes[authType] {
-		return "", fmt.Errorf("invalid auth type: %s (valid: none, bearer, apikey, basic)", authType)
+	if !slices.Contains(SupportedTypes, authType) {
+		return "", fmt.Errorf("invalid auth type: %s (valid: %s)", authType, strings.Join(SupportedTypes, ", "))
 	}
 
 	return authType, nil
@@ -2,6 +2,8 @@ package auth
 
 import (
 	"net/http"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -38,6 +40,31 @@ func TestBearerAuth(t *testing.T) {
 	}
 }
 
+func TestBearerAuthTokenCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	bearer := NewBearerAuth("expired")
+	bearer.RefreshFunc = CommandTokenSource("echo '  fresh-token  '")
+	if err := bearer.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	_ = bearer.Apply(req)
+	if got := req.Header.Get("Authorization"); got != "Bearer fresh-token" {
+		t.Errorf("Authorization = %q, want the token the command printed", got)
+	}
+
+	bearer.RefreshFunc = CommandTokenSource("echo 'login expired' >&2; exit 3")
+	err := bearer.Refresh()
+	if err == nil || !strings.Contains(err.Error(), "login expired") {
+		t.Errorf("Refresh() error = %v, want the command's stderr", err)
+	}
+	if bearer.Token != "fresh-token" {
+		t.Errorf("a failed refresh replaced the token with %q", bearer.Token)
+	}
+}
+
 func TestAPIKeyAuth(t *testing.T) {
 	auth := NewAPIKeyAuth("X-API-Key", "my-secret-key", "header")
 
@@ -68,6 +95,40 @@ func TestAPIKeyAuth(t *testing.T) {
 	}
 }
 
+func TestAPIKeyAuthRotating(t *testing.T) {
+	auth := NewAPIKeyAuthRotating("X-API-Key", []string{"key-one", "key-two"}, "header")
+
+	if err := auth.Validate(); err != nil {
+		t.Fatalf("validation failed: %v", err)
+	}
+	if auth.KeyCount() != 2 {
+		t.Errorf("expected 2 keys, got %d", auth.KeyCount())
+	}
+
+	want := []string{"key-one", "key-two", "key-one"}
+	for i, w := range want {
+		req, _ := http.NewRequest("GET", "http://example.com", nil)
+		if err := auth.Apply(req); err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+		if got := req.Header.Get("X-API-Key"); got != w {
+			t.Errorf("request %d: expected %q, got %q", i, w, got)
+		}
+	}
+
+	redacted := auth.Redact().(*APIKeyAuth)
+	for _, v := range redacted.Values {
+		if v == "key-one" || v == "key-two" {
+			t.Error("expected values to be redacted")
+		}
+	}
+
+	single := NewAPIKeyAuthRotating("X-API-Key", []string{"only"}, "header")
+	if single.Value != "only" || len(single.Values) != 0 {
+		t.Errorf("expected single value to use plain key, got %+v", single)
+	}
+}
+
 func TestBasicAuth(t *testing.T) {
 	auth := NewBasicAuth("user123", "pass456")
 
@@ -105,6 +166,34 @@ func TestBasicAuth(t *testing.T) {
 	}
 }
 
+func TestCustomHeaderAuth(t *testing.T) {
+	auth := NewCustomHeaderAuth("Authorization", "Token {value}", "secret-token-123")
+
+	if err := auth.Validate(); err != nil {
+		t.Fatalf("validation failed: %v", err)
+	}
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	if err := auth.Apply(req); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "Token secret-token-123" {
+		t.Errorf("expected 'Token secret-token-123', got %q", got)
+	}
+
+	redacted := auth.Redact().(*CustomHeaderAuth)
+	if redacted.Value == "secret-token-123" {
+		t.Error("value was not redacted")
+	}
+
+	if err := NewCustomHeaderAuth("X-Auth", "Signature", "v").Validate(); err == nil {
+		t.Error("template without {value} should fail validation")
+	}
+	if err := NewCustomHeaderAuth("", "{value}", "v").Validate(); err == nil {
+		t.Error("empty header name should fail validation")
+	}
+}
+
 func TestNoAuth(t *testing.T) {
 	auth := &NoAuth{}
 
@@ -143,3 +232,20 @@ func TestValidation(t *testing.T) {
 		t.Error("empty username should fail validation")
 	}
 }
+
+func TestSetQueryParam(t *testing.T) {
+	tests := []struct {
+		rawQuery string
+		want     string
+	}{
+		{"", "api_key=k"},
+		{"page=2", "page=2&api_key=k"},
+		{"api_key=old&page=2", "page=2&api_key=k"},
+		{"q=a%20b&api%5Fkey=old", "q=a%20b&api_key=k"},
+	}
+	for _, tt := range tests {
+		if got := setQueryParam(tt.rawQuery, "api_key", "k"); got != tt.want {
+			t.Errorf("setQueryParam(%q) = %q, want %q", tt.rawQuery, got, tt.want)
+		}
+	}
+}
@@ -1,14 +1,28 @@
 package auth
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"net/http"
+	"os/exec"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
 )
 
+// tokenCommandTimeout bounds how long a token refresh command may run
+const tokenCommandTimeout = 30 * time.Second
+
 // BearerAuth represents Bearer token authentication
 type BearerAuth struct {
 	Token string `json:"token"`
+
+	// RefreshFunc, when set, returns a new token after the API rejects the current one with a 401
+	RefreshFunc func() (string, error) `json:"-"`
+
+	mu sync.RWMutex
 }
 
 // NewBearerAuth creates a new Bearer authentication provider
@@ -21,10 +35,57 @@ func (b *BearerAuth) Apply(req *http.Request) error {
 	if err := b.Validate(); err != nil {
 		return err
 	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 	req.Header.Set("Authorization", "Bearer "+b.Token)
 	return nil
 }
 
+// Refresh replaces the token with one from RefreshFunc
+func (b *BearerAuth) Refresh() error {
+	if b.RefreshFunc == nil {
+		return ErrRefreshUnsupported
+	}
+	token, err := b.RefreshFunc()
+	if err != nil {
+		return fmt.Errorf("failed to refresh bearer token: %w", err)
+	}
+	if strings.TrimSpace(token) == "" {
+		return fmt.Errorf("failed to refresh bearer token: refresh returned an empty token")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.Token = token
+	return nil
+}
+
+// CommandTokenSource returns a RefreshFunc that runs command in the shell and uses what it prints
+// as the new token, e.g. a CLI login or a curl to the API's token endpoint
+func CommandTokenSource(command string) func() (string, error) {
+	return func() (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), tokenCommandTimeout)
+		defer cancel()
+
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+		} else {
+			cmd = exec.CommandContext(ctx, "sh", "-c", command)
+		}
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			if message := strings.TrimSpace(stderr.String()); message != "" {
+				return "", fmt.Errorf("token command failed: %w: %s", err, message)
+			}
+			return "", fmt.Errorf("token command failed: %w", err)
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+}
+
 // Type returns the authentication type
 func (b *BearerAuth) Type() string {
 	return "bearer"
@@ -32,6 +93,8 @@ func (b *BearerAuth) Type() string {
 
 // Validate checks if the token is present
 func (b *BearerAuth) Validate() error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 	if strings.TrimSpace(b.Token) == "" {
 		return fmt.Errorf("bearer token cannot be empty")
 	}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ValuePlaceholder is replaced with the secret value in custom header templates
+const ValuePlaceholder = "{value}"
+
+// CustomHeaderAuth sends a secret in an arbitrary header using a value template
+// (e.g., "Authorization: Token {value}" or "X-Auth: Signature keyId={value}")
+type CustomHeaderAuth struct {
+	Header   string `json:"header"`   // Header name (e.g., "Authorization")
+	Template string `json:"template"` // Value template containing {value}
+	Value    string `json:"value"`    // The secret value
+}
+
+// NewCustomHeaderAuth creates a new custom header authentication provider
+func NewCustomHeaderAuth(header, template, value string) *CustomHeaderAuth {
+	if template == "" {
+		template = ValuePlaceholder
+	}
+	return &CustomHeaderAuth{
+		Header:   header,
+		Template: template,
+		Value:    value,
+	}
+}
+
+// Apply sets the header with the rendered template
+func (c *CustomHeaderAuth) Apply(req *http.Request) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	req.Header.Set(c.Header, strings.ReplaceAll(c.Template, ValuePlaceholder, c.Value))
+	return nil
+}
+
+// Type returns the authentication type
+func (c *CustomHeaderAuth) Type() string {
+	return "custom"
+}
+
+// Validate checks if the configuration is valid
+func (c *CustomHeaderAuth) Validate() error {
+	if strings.TrimSpace(c.Header) == "" {
+		return fmt.Errorf("custom auth header name cannot be empty")
+	}
+	if !strings.Contains(c.Template, ValuePlaceholder) {
+		return fmt.Errorf("custom auth template must contain %s", ValuePlaceholder)
+	}
+	if strings.TrimSpace(c.Value) == "" {
+		return fmt.Errorf("custom auth value cannot be empty")
+	}
+	return nil
+}
+
+// Redact returns a copy with the value redacted
+func (c *CustomHeaderAuth) Redact() AuthProvider {
+	return &CustomHeaderAuth{
+		Header:   c.Header,
+		Template: c.Template,
+		Value:    RedactString(c.Value),
+	}
+}
+
+// String returns a human-readable representation
+func (c *CustomHeaderAuth) String() string {
+	return fmt.Sprintf("Custom Header %s: %s", c.Header, strings.ReplaceAll(c.Template, ValuePlaceholder, RedactString(c.Value)))
+}
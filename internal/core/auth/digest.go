@@ -0,0 +1,252 @@
+package auth
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ChallengeResponder is implemented by providers that can only authenticate after the server's
+// 401 challenge, such as HTTP Digest. The caller retries the request once after a handled challenge.
+type ChallengeResponder interface {
+	// HandleChallenge stores the challenge of a 401 response, reporting whether it is one the provider answers
+	HandleChallenge(resp *http.Response) bool
+}
+
+// digestChallenge holds the parameters of a WWW-Authenticate: Digest challenge
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	qop       string // the protection chosen from the server's list: auth, auth-int or empty (RFC 2069)
+}
+
+// DigestAuth represents HTTP Digest authentication (RFC 7616, RFC 2617)
+type DigestAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+
+	mu        sync.Mutex
+	challenge *digestChallenge
+	nc        int // requests sent with the current nonce
+	cnonce    func() string
+}
+
+// NewDigestAuth creates a new Digest authentication provider
+func NewDigestAuth(username, password string) *DigestAuth {
+	return &DigestAuth{
+		Username: username,
+		Password: password,
+	}
+}
+
+// Apply adds the Digest Authorization header, reading the body through req.GetBody for qop=auth-int
+func (d *DigestAuth) Apply(req *http.Request) error {
+	var body []byte
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return fmt.Errorf("failed to read request body for digest: %w", err)
+		}
+		defer func() { _ = rc.Close() }()
+		if body, err = io.ReadAll(rc); err != nil {
+			return fmt.Errorf("failed to read request body for digest: %w", err)
+		}
+	}
+	return d.ApplyToRequest(req, body)
+}
+
+// ApplyToRequest answers the last challenge received. Before any challenge the request is sent
+// without credentials, since the server's 401 supplies the nonce.
+func (d *DigestAuth) ApplyToRequest(req *http.Request, body []byte) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.challenge == nil {
+		return nil
+	}
+	c := d.challenge
+
+	newHash := md5.New
+	algorithm := strings.ToUpper(c.algorithm)
+	if strings.HasPrefix(algorithm, "SHA-256") {
+		newHash = sha256.New
+	}
+	h := func(s string) string {
+		sum := newHash()
+		sum.Write([]byte(s))
+		return hex.EncodeToString(sum.Sum(nil))
+	}
+
+	d.nc++
+	nc := fmt.Sprintf("%08x", d.nc)
+	cnonce := newCnonce()
+	if d.cnonce != nil {
+		cnonce = d.cnonce()
+	}
+	uri := req.URL.RequestURI()
+
+	ha1 := h(d.Username + ":" + c.realm + ":" + d.Password)
+	if strings.HasSuffix(algorithm, "-SESS") {
+		ha1 = h(ha1 + ":" + c.nonce + ":" + cnonce)
+	}
+	ha2 := h(req.Method + ":" + uri)
+	if c.qop == "auth-int" {
+		ha2 = h(req.Method + ":" + uri + ":" + h(string(body)))
+	}
+
+	var response string
+	if c.qop == "" {
+		response = h(ha1 + ":" + c.nonce + ":" + ha2)
+	} else {
+		response = h(strings.Join([]string{ha1, c.nonce, nc, cnonce, c.qop, ha2}, ":"))
+	}
+
+	params := []string{
+		"username=" + quoteParam(d.Username),
+		"realm=" + quoteParam(c.realm),
+		"nonce=" + quoteParam(c.nonce),
+		"uri=" + quoteParam(uri),
+	}
+	if c.algorithm != "" {
+		params = append(params, "algorithm="+c.algorithm)
+	}
+	params = append(params, "response="+quoteParam(response))
+	if c.opaque != "" {
+		params = append(params, "opaque="+quoteParam(c.opaque))
+	}
+	if c.qop != "" {
+		params = append(params, "qop="+c.qop, "nc="+nc, "cnonce="+quoteParam(cnonce))
+	}
+	req.Header.Set("Authorization", "Digest "+strings.Join(params, ", "))
+	return nil
+}
+
+// HandleChallenge stores a Digest challenge from a 401 response. A challenge with a new nonce
+// (e.g. after the old one went stale) restarts the nonce count.
+func (d *DigestAuth) HandleChallenge(resp *http.Response) bool {
+	for _, header := range resp.Header.Values("WWW-Authenticate") {
+		scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+		if !strings.EqualFold(scheme, "Digest") {
+			continue
+		}
+		params := parseAuthParams(rest)
+		if params["nonce"] == "" {
+			continue
+		}
+		algorithm := params["algorithm"]
+		if algorithm != "" && !slices.Contains([]string{"MD5", "MD5-SESS", "SHA-256", "SHA-256-SESS"}, strings.ToUpper(algorithm)) {
+			continue
+		}
+
+		challenge := &digestChallenge{
+			realm:     params["realm"],
+			nonce:     params["nonce"],
+			opaque:    params["opaque"],
+			algorithm: algorithm,
+		}
+		offered := strings.Split(params["qop"], ",")
+		for i := range offered {
+			offered[i] = strings.TrimSpace(offered[i])
+		}
+		switch {
+		case slices.Contains(offered, "auth"):
+			challenge.qop = "auth"
+		case slices.Contains(offered, "auth-int"):
+			challenge.qop = "auth-int"
+		}
+
+		d.mu.Lock()
+		d.challenge = challenge
+		d.nc = 0
+		d.mu.Unlock()
+		return true
+	}
+	return false
+}
+
+// parseAuthParams parses comma-separated auth-params (name=token or name="quoted, string")
+func parseAuthParams(s string) map[string]string {
+	params := map[string]string{}
+	for s != "" {
+		s = strings.TrimLeft(s, " \t,")
+		name, rest, ok := strings.Cut(s, "=")
+		if !ok {
+			break
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		rest = strings.TrimLeft(rest, " \t")
+
+		var value strings.Builder
+		if strings.HasPrefix(rest, `"`) {
+			i := 1
+			for ; i < len(rest) && rest[i] != '"'; i++ {
+				if rest[i] == '\\' && i+1 < len(rest) {
+					i++
+				}
+				value.WriteByte(rest[i])
+			}
+			s = rest[min(i+1, len(rest)):]
+		} else {
+			end := strings.IndexByte(rest, ',')
+			if end < 0 {
+				end = len(rest)
+			}
+			value.WriteString(strings.TrimSpace(rest[:end]))
+			s = rest[end:]
+		}
+		params[name] = value.String()
+	}
+	return params
+}
+
+// quoteParam renders an auth-param value as an HTTP quoted-string
+func quoteParam(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func newCnonce() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Type returns the authentication type
+func (d *DigestAuth) Type() string {
+	return "digest"
+}
+
+// Validate checks if username and password are present
+func (d *DigestAuth) Validate() error {
+	if strings.TrimSpace(d.Username) == "" {
+		return fmt.Errorf("username cannot be empty")
+	}
+	if strings.TrimSpace(d.Password) == "" {
+		return fmt.Errorf("password cannot be empty")
+	}
+	return nil
+}
+
+// Redact returns a copy with password redacted
+func (d *DigestAuth) Redact() AuthProvider {
+	return &DigestAuth{
+		Username: d.Username,
+		Password: "***",
+	}
+}
+
+// String returns a human-readable representation
+func (d *DigestAuth) String() string {
+	return fmt.Sprintf("Digest Auth (%s)", d.Username)
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func challengeResponse(headers ...string) *http.Response {
+	resp := &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}}
+	for _, h := range headers {
+		resp.Header.Add("WWW-Authenticate", h)
+	}
+	return resp
+}
+
+// The worked example from RFC 2617 section 3.5
+func TestDigestAuthRFC2617Example(t *testing.T) {
+	provider := NewDigestAuth("Mufasa", "Circle Of Life")
+	provider.cnonce = func() string { return "0a4f113b" }
+
+	handled := provider.HandleChallenge(challengeResponse(
+		`Basic realm="fallback"`,
+		`Digest realm="testrealm@host.com", qop="auth,auth-int", nonce="dcd98b7102dd2f0e8b11d0f600bfb0c093", opaque="5ccc069c403ebaf9f0171e9517f40e41"`,
+	))
+	if !handled {
+		t.Fatal("HandleChallenge() = false, want true")
+	}
+
+	req, _ := http.NewRequest("GET", "http://www.nowhere.org/dir/index.html", nil)
+	if err := provider.Apply(req); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	got := req.Header.Get("Authorization")
+	for _, want := range []string{
+		`Digest username="Mufasa"`,
+		`realm="testrealm@host.com"`,
+		`uri="/dir/index.html"`,
+		`qop=auth, nc=00000001, cnonce="0a4f113b"`,
+		`response="6629fae49393a05397450978507c4ef1"`,
+		`opaque="5ccc069c403ebaf9f0171e9517f40e41"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Authorization = %s\nmissing %s", got, want)
+		}
+	}
+
+	// The nonce count increases with each request using the same nonce
+	_ = provider.Apply(req)
+	if got := req.Header.Get("Authorization"); !strings.Contains(got, "nc=00000002") {
+		t.Errorf("second request: %s, want nc=00000002", got)
+	}
+}
+
+func TestDigestAuthWithoutChallenge(t *testing.T) {
+	provider := NewDigestAuth("user", "pass")
+	req, _ := http.NewRequest("GET", "http://example.com/", nil)
+	if err := provider.Apply(req); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "" {
+		t.Errorf("Authorization = %q before any challenge, want none", got)
+	}
+
+	for _, header := range []string{`Basic realm="x"`, `Digest realm="x"`, `Digest realm="x", nonce="n", algorithm=SHA-512-256`} {
+		if provider.HandleChallenge(challengeResponse(header)) {
+			t.Errorf("HandleChallenge(%q) = true, want false", header)
+		}
+	}
+}
+
+func TestParseAuthParams(t *testing.T) {
+	got := parseAuthParams(`realm="a, \"b\"", nonce=abc ,qop="auth,auth-int",stale=TRUE`)
+	want := map[string]string{"realm": `a, "b"`, "nonce": "abc", "qop": "auth,auth-int", "stale": "TRUE"}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("params[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("parseAuthParams() = %v", got)
+	}
+}
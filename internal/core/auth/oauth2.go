@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryLeeway refreshes tokens slightly before they expire so in-flight requests don't race the expiry
+const tokenExpiryLeeway = 30 * time.Second
+
+// TokenRefresher is implemented by providers whose credentials can be renewed after a 401
+type TokenRefresher interface {
+	// Refresh renews the credentials, returning ErrRefreshUnsupported when the provider has no way to
+	Refresh() error
+}
+
+// ErrRefreshUnsupported is returned by Refresh when a provider has nothing to refresh with
+var ErrRefreshUnsupported = errors.New("token refresh not configured")
+
+// OAuth2ClientCredentials fetches bearer tokens with the OAuth2 client-credentials grant
+type OAuth2ClientCredentials struct {
+	TokenURL        string   `json:"token_url"`
+	ClientID        string   `json:"client_id"`
+	ClientSecret    string   `json:"client_secret"`
+	RequestedScopes []string `json:"scopes,omitempty"` // Scopes requested with each token
+
+	mu      sync.Mutex
+	token   string
+	granted []string
+	expiry  time.Time // zero when the token server didn't send expires_in
+	client  *http.Client
+	scoped  map[string]*OAuth2ClientCredentials // Providers for endpoints needing more scopes, by scope set
+}
+
+// tokenResponse is the token endpoint's JSON reply (RFC 6749 section 5.1 and 5.2)
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Scope            string `json:"scope"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// NewOAuth2ClientCredentials creates a provider that lazily fetches and caches an access token
+func NewOAuth2ClientCredentials(tokenURL, clientID, clientSecret string, scopes []string) *OAuth2ClientCredentials {
+	return &OAuth2ClientCredentials{
+		TokenURL:        tokenURL,
+		ClientID:        clientID,
+		ClientSecret:    clientSecret,
+		RequestedScopes: scopes,
+		client:          &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Apply adds the current access token to the Authorization header, fetching one if needed
+func (o *OAuth2ClientCredentials) Apply(req *http.Request) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	token, err := o.Token()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Token returns a valid access token, fetching a new one when none is cached or it has expired
+func (o *OAuth2ClientCredentials) Token() (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.token != "" && (o.expiry.IsZero() || time.Now().Before(o.expiry)) {
+		return o.token, nil
+	}
+	if err := o.fetchToken(); err != nil {
+		return "", err
+	}
+	return o.token, nil
+}
+
+// Refresh fetches a new token even if the cached one hasn't expired, e.g. after it was revoked.
+// Tokens fetched for other scopes are dropped and fetched again when next needed.
+func (o *OAuth2ClientCredentials) Refresh() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.scoped = nil
+	return o.fetchToken()
+}
+
+// ForScopes returns a provider whose tokens are requested with scopes on top of the configured
+// ones, for endpoints that declare them. Providers are kept per scope set, so endpoints with
+// different requirements don't evict each other's tokens.
+func (o *OAuth2ClientCredentials) ForScopes(scopes []string) AuthProvider {
+	wanted := slices.Clone(o.RequestedScopes)
+	for _, scope := range scopes {
+		if !slices.Contains(wanted, scope) {
+			wanted = append(wanted, scope)
+		}
+	}
+	if len(wanted) == len(o.RequestedScopes) {
+		return o
+	}
+	key := strings.Join(slices.Sorted(slices.Values(wanted)), " ")
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if scoped, ok := o.scoped[key]; ok {
+		return scoped
+	}
+	scoped := NewOAuth2ClientCredentials(o.TokenURL, o.ClientID, o.ClientSecret, wanted)
+	if o.client != nil {
+		scoped.client = o.client
+	}
+	if o.scoped == nil {
+		o.scoped = make(map[string]*OAuth2ClientCredentials)
+	}
+	o.scoped[key] = scoped
+	return scoped
+}
+
+// Scopes returns the scopes granted with the current token, fetching one if needed.
+// When the token server omits "scope", the requested scopes were granted (RFC 6749 section 5.1).
+func (o *OAuth2ClientCredentials) Scopes() ([]string, bool) {
+	if _, err := o.Token(); err != nil {
+		return nil, false
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.granted, true
+}
+
+// fetchToken requests a token from the token endpoint; callers hold o.mu
+func (o *OAuth2ClientCredentials) fetchToken() error {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(o.RequestedScopes) > 0 {
+		form.Set("scope", strings.Join(o.RequestedScopes, " "))
+	}
+
+	req, err := http.NewRequest(http.MethodPost, o.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(o.ClientID), url.QueryEscape(o.ClientSecret))
+
+	client := o.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch OAuth2 token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read OAuth2 token response: %w", err)
+	}
+
+	// Error replies aren't always JSON, so the status is checked before a parse failure is reported
+	var tr tokenResponse
+	parseErr := json.Unmarshal(body, &tr)
+	if resp.StatusCode != http.StatusOK || tr.Error != "" {
+		message := tr.Error
+		if tr.ErrorDescription != "" {
+			message += ": " + tr.ErrorDescription
+		}
+		if message == "" {
+			message = "no error details"
+		}
+		return fmt.Errorf("OAuth2 token request failed with status %d (%s)", resp.StatusCode, message)
+	}
+	if parseErr != nil {
+		return fmt.Errorf("failed to parse OAuth2 token response: %w", parseErr)
+	}
+	if tr.AccessToken == "" {
+		return fmt.Errorf("OAuth2 token response has no access_token")
+	}
+	if tr.TokenType != "" && !strings.EqualFold(tr.TokenType, "bearer") {
+		return fmt.Errorf("unsupported OAuth2 token type: %s", tr.TokenType)
+	}
+
+	o.token = tr.AccessToken
+	o.granted = o.RequestedScopes
+	if tr.Scope != "" {
+		o.granted = strings.Fields(tr.Scope)
+	}
+	o.expiry = time.Time{}
+	if tr.ExpiresIn > 0 {
+		lifetime := time.Duration(tr.ExpiresIn) * time.Second
+		o.expiry = time.Now().Add(lifetime - min(tokenExpiryLeeway, lifetime/2))
+	}
+	return nil
+}
+
+// Type returns the authentication type
+func (o *OAuth2ClientCredentials) Type() string {
+	return "oauth2"
+}
+
+// Validate checks that the token URL and client credentials are present
+func (o *OAuth2ClientCredentials) Validate() error {
+	if strings.TrimSpace(o.TokenURL) == "" {
+		return fmt.Errorf("OAuth2 token URL cannot be empty")
+	}
+	if u, err := url.Parse(o.TokenURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid OAuth2 token URL: %s", o.TokenURL)
+	}
+	if strings.TrimSpace(o.ClientID) == "" {
+		return fmt.Errorf("OAuth2 client ID cannot be empty")
+	}
+	if strings.TrimSpace(o.ClientSecret) == "" {
+		return fmt.Errorf("OAuth2 client secret cannot be empty")
+	}
+	return nil
+}
+
+// CachedToken returns the current access token without fetching one, or "" if there is none
+func (o *OAuth2ClientCredentials) CachedToken() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.token
+}
+
+// Redact returns a copy with the client secret and any cached token redacted
+func (o *OAuth2ClientCredentials) Redact() AuthProvider {
+	redacted := &OAuth2ClientCredentials{
+		TokenURL:        o.TokenURL,
+		ClientID:        o.ClientID,
+		ClientSecret:    RedactString(o.ClientSecret),
+		RequestedScopes: o.RequestedScopes,
+	}
+	if token := o.CachedToken(); token != "" {
+		redacted.token = RedactString(token)
+	}
+	return redacted
+}
+
+// String returns a human-readable representation
+func (o *OAuth2ClientCredentials) String() string {
+	s := fmt.Sprintf("OAuth2 client credentials (%s via %s)", o.ClientID, o.TokenURL)
+	if len(o.RequestedScopes) > 0 {
+		s += ", scopes: " + strings.Join(o.RequestedScopes, " ")
+	}
+	return s
+}
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTokenServer issues "token-N" access tokens, counting requests
+func newTokenServer(t *testing.T, expiresIn int, scope string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "client" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"bad credentials"}`))
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "client_credentials" {
+			t.Errorf("unexpected token request form: %v", r.Form)
+		}
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d,"scope":%q}`, n, expiresIn, scope)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestOAuth2ClientCredentialsCachesToken(t *testing.T) {
+	server, calls := newTokenServer(t, 3600, "")
+	provider := NewOAuth2ClientCredentials(server.URL, "client", "secret", []string{"read"})
+
+	for range 3 {
+		req, _ := http.NewRequest("GET", "http://example.com", nil)
+		if err := provider.Apply(req); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if got := req.Header.Get("Authorization"); got != "Bearer token-1" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer token-1")
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("token endpoint called %d times, want 1", calls.Load())
+	}
+
+	// Without a "scope" in the reply, the requested scopes were granted
+	if scopes, ok := provider.Scopes(); !ok || !slices.Equal(scopes, []string{"read"}) {
+		t.Errorf("Scopes() = %v, %v; want [read], true", scopes, ok)
+	}
+
+	if err := provider.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if token, _ := provider.Token(); token != "token-2" {
+		t.Errorf("Token() after Refresh = %q, want token-2", token)
+	}
+}
+
+func TestOAuth2ClientCredentialsRefreshesExpiredToken(t *testing.T) {
+	server, calls := newTokenServer(t, 3600, "read write")
+	provider := NewOAuth2ClientCredentials(server.URL, "client", "secret", nil)
+
+	first, _ := provider.Token()
+	provider.expiry = time.Now().Add(-time.Second)
+	second, _ := provider.Token()
+	if first == second || calls.Load() != 2 {
+		t.Errorf("expected expired token to be refreshed, got %q then %q (%d calls)", first, second, calls.Load())
+	}
+	if scopes, _ := provider.Scopes(); !slices.Equal(scopes, []string{"read", "write"}) {
+		t.Errorf("Scopes() = %v, want granted scopes [read write]", scopes)
+	}
+}
+
+func TestOAuth2ClientCredentialsForScopes(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		requested = append(requested, r.Form.Get("scope"))
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer"}`, len(requested))
+	}))
+	defer server.Close()
+	provider := NewOAuth2ClientCredentials(server.URL, "client", "secret", []string{"read"})
+
+	if got := provider.ForScopes([]string{"read"}); got != provider {
+		t.Error("ForScopes() with configured scopes returned a new provider")
+	}
+
+	orders := provider.ForScopes([]string{"orders:write"})
+	if again := provider.ForScopes([]string{"orders:write", "read"}); again != orders {
+		t.Error("ForScopes() didn't reuse the provider for the same scope set")
+	}
+	if _, err := orders.(*OAuth2ClientCredentials).Token(); err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if _, err := provider.Token(); err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if !slices.Equal(requested, []string{"read orders:write", "read"}) {
+		t.Errorf("token requests asked for scopes %q, want the endpoint's scopes and then the configured ones", requested)
+	}
+	if scopes, _ := orders.(ScopedProvider).Scopes(); !slices.Equal(scopes, []string{"read", "orders:write"}) {
+		t.Errorf("Scopes() = %v, want [read orders:write]", scopes)
+	}
+}
+
+func TestOAuth2ClientCredentialsTokenError(t *testing.T) {
+	server, _ := newTokenServer(t, 3600, "")
+	provider := NewOAuth2ClientCredentials(server.URL, "client", "wrong", nil)
+
+	_, err := provider.Token()
+	if err == nil {
+		t.Fatal("expected error for rejected client credentials")
+	}
+	if want := "OAuth2 token request failed with status 401 (invalid_client: bad credentials)"; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
+
+func TestOAuth2ClientCredentialsValidateAndRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider *OAuth2ClientCredentials
+		wantErr  bool
+	}{
+		{"valid", NewOAuth2ClientCredentials("https://auth.example.com/token", "id", "secret", nil), false},
+		{"missing token URL", NewOAuth2ClientCredentials("", "id", "secret", nil), true},
+		{"relative token URL", NewOAuth2ClientCredentials("/token", "id", "secret", nil), true},
+		{"missing client ID", NewOAuth2ClientCredentials("https://auth.example.com/token", "", "secret", nil), true},
+		{"missing secret", NewOAuth2ClientCredentials("https://auth.example.com/token", "id", " ", nil), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.provider.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	redacted := NewOAuth2ClientCredentials("https://auth.example.com/token", "id", "super-secret-value", nil).Redact()
+	if secret := redacted.(*OAuth2ClientCredentials).ClientSecret; secret == "super-secret-value" {
+		t.Error("client secret was not redacted")
+	}
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// ScopedProvider is implemented by providers that know which OAuth scopes their credentials carry
+type ScopedProvider interface {
+	// Scopes returns the granted scopes; ok is false when they can't be determined
+	Scopes() (scopes []string, ok bool)
+}
+
+// ScopeRequester is implemented by providers that can ask for credentials carrying given scopes
+type ScopeRequester interface {
+	// ForScopes returns a provider for requests that need scopes
+	ForScopes(scopes []string) AuthProvider
+}
+
+// Scopes reads the scopes granted to a JWT bearer token from its "scope" (space-separated)
+// or "scp" (string or list) claim. Opaque tokens report ok=false.
+func (b *BearerAuth) Scopes() ([]string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return jwtScopes(b.Token)
+}
+
+// MissingScopes returns the required scopes not present in granted
+func MissingScopes(required, granted []string) []string {
+	var missing []string
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// jwtScopes decodes the payload of a JWT without verifying it and extracts its scope claims
+func jwtScopes(token string) ([]string, bool) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return nil, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, false
+	}
+
+	var claims struct {
+		Scope *string `json:"scope"`
+		Scp   any     `json:"scp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, false
+	}
+
+	switch {
+	case claims.Scope != nil:
+		return strings.Fields(*claims.Scope), true
+	case claims.Scp != nil:
+		switch scp := claims.Scp.(type) {
+		case string:
+			return strings.Fields(scp), true
+		case []any:
+			scopes := make([]string, 0, len(scp))
+			for _, s := range scp {
+				if s, ok := s.(string); ok {
+					scopes = append(scopes, s)
+				}
+			}
+			return scopes, true
+		}
+	}
+	return nil, false
+}
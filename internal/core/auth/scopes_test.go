@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"encoding/base64"
+	"slices"
+	"testing"
+)
+
+func testJWT(payload string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." + enc.EncodeToString([]byte(payload)) + ".sig"
+}
+
+func TestBearerAuthScopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		want   []string
+		wantOK bool
+	}{
+		{"scope claim", testJWT(`{"scope":"read:users write:users"}`), []string{"read:users", "write:users"}, true},
+		{"scp string", testJWT(`{"scp":"admin"}`), []string{"admin"}, true},
+		{"scp list", testJWT(`{"scp":["a","b"]}`), []string{"a", "b"}, true},
+		{"no scope claim", testJWT(`{"sub":"42"}`), nil, false},
+		{"opaque token", "abc123", nil, false},
+		{"bad payload", "a.!!!.c", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NewBearerAuth(tt.token).Scopes()
+			if ok != tt.wantOK || !slices.Equal(got, tt.want) {
+				t.Errorf("Scopes() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	got := MissingScopes([]string{"read", "write", "admin"}, []string{"read", "admin"})
+	if !slices.Equal(got, []string{"write"}) {
+		t.Errorf("MissingScopes() = %v, want [write]", got)
+	}
+	if got := MissingScopes([]string{"read"}, []string{"read"}); got != nil {
+		t.Errorf("MissingScopes() = %v, want nil", got)
+	}
+}
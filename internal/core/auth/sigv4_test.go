@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Vectors from the AWS Signature Version 4 test suite
+func TestAWSSigV4MatchesTestSuite(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		url       string
+		signature string
+	}{
+		{"get-vanilla", "GET", "https://example.amazonaws.com/", "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"},
+		{"get-vanilla-query-order-key-case", "GET", "https://example.amazonaws.com/?Param2=value2&Param1=value1", "b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500"},
+		{"post-vanilla", "POST", "https://example.amazonaws.com/", "5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewAWSSigV4("AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service")
+			provider.now = func() time.Time { return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC) }
+
+			req, _ := http.NewRequest(tt.method, tt.url, nil)
+			if err := provider.ApplyToRequest(req, nil); err != nil {
+				t.Fatalf("ApplyToRequest() error = %v", err)
+			}
+
+			want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=" + tt.signature
+			if got := req.Header.Get("Authorization"); got != want {
+				t.Errorf("Authorization = %q\nwant %q", got, want)
+			}
+			if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+				t.Errorf("X-Amz-Date = %q", got)
+			}
+		})
+	}
+}
+
+func TestSigV4CanonicalQuerySortsByKeyThenValue(t *testing.T) {
+	u, _ := url.Parse("https://example.amazonaws.com/?a-b=2&a=1&b=y&b=x&a_c=3")
+	// A key sorts before the longer keys it prefixes, and repeated keys by value
+	want := "a=1&a-b=2&a_c=3&b=x&b=y"
+	if got := sigV4CanonicalQuery(u); got != want {
+		t.Errorf("sigV4CanonicalQuery() = %q, want %q", got, want)
+	}
+}
+
+func TestAWSSigV4ApplyReadsBody(t *testing.T) {
+	provider := NewAWSSigV4("AKID", "secret", "eu-west-1", "execute-api")
+	provider.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+	body := []byte(`{"name":"widget"}`)
+
+	viaApply, _ := http.NewRequest("POST", "https://api.example.com/items", bytes.NewReader(body))
+	viaApply.Header.Set("Content-Type", "application/json")
+	if err := provider.Apply(viaApply); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	withBody, _ := http.NewRequest("POST", "https://api.example.com/items", bytes.NewReader(body))
+	withBody.Header.Set("Content-Type", "application/json")
+	if err := provider.ApplyToRequest(withBody, body); err != nil {
+		t.Fatalf("ApplyToRequest() error = %v", err)
+	}
+
+	got := viaApply.Header.Get("Authorization")
+	if got != withBody.Header.Get("Authorization") {
+		t.Errorf("Apply and ApplyToRequest signatures differ:\n%s\n%s", got, withBody.Header.Get("Authorization"))
+	}
+	if !strings.Contains(got, "SignedHeaders=content-type;host;x-amz-date,") {
+		t.Errorf("Authorization = %q, want content-type signed", got)
+	}
+
+	empty, _ := http.NewRequest("POST", "https://api.example.com/items", nil)
+	empty.Header.Set("Content-Type", "application/json")
+	_ = provider.ApplyToRequest(empty, nil)
+	if empty.Header.Get("Authorization") == got {
+		t.Error("signature does not cover the body")
+	}
+}
+
+func TestAWSSigV4Redact(t *testing.T) {
+	provider := NewAWSSigV4("AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "execute-api")
+	redacted := provider.Redact().(*AWSSigV4)
+	if redacted.SecretKey == provider.SecretKey || strings.Contains(redacted.SecretKey, "MDENG") {
+		t.Errorf("secret key not redacted: %q", redacted.SecretKey)
+	}
+	if redacted.AccessKey != provider.AccessKey || redacted.Region != "us-east-1" || redacted.Service != "execute-api" {
+		t.Errorf("Redact() changed non-secret fields: %+v", redacted)
+	}
+}
@@ -3,10 +3,13 @@ package converter
 import (
 	"fmt"
 	"github.com/Octrafic/octrafic-cli/internal/config"
+	"github.com/Octrafic/octrafic-cli/internal/infra/logger"
+	"github.com/Octrafic/octrafic-cli/internal/infra/storage"
 	"github.com/Octrafic/octrafic-cli/internal/llm"
 	"github.com/Octrafic/octrafic-cli/internal/llm/common"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -28,8 +31,90 @@ Requirements:
 
 Output the complete OpenAPI 3.0 JSON specification:`
 
-// ConvertToOpenAPI converts a non-OpenAPI spec file to OpenAPI format using LLM
-func ConvertToOpenAPI(specPath string, detectedFormat string) (string, error) {
+const upgradePrompt = `Upgrade the following Swagger 2.0 specification to OpenAPI 3.0 JSON format.
+
+Source file content:
+---
+%s
+---
+
+Requirements:
+1. Output ONLY valid JSON - no markdown, no code blocks, no explanations
+2. Set "openapi" to "3.0.3" and remove the "swagger" field
+3. Convert host, basePath and schemes into "servers"
+4. Move "definitions" to "components/schemas" and rewrite every $ref accordingly
+5. Move "securityDefinitions" to "components/securitySchemes"
+6. Replace "in: body" and "in: formData" parameters with "requestBody", using "consumes" for media types
+7. Wrap response schemas in "content", using "produces" for media types
+8. Preserve every path, method, parameter, description, deprecated flag and security requirement
+
+Output the complete OpenAPI 3.0 JSON specification:`
+
+// ConvertToOpenAPI converts a non-OpenAPI spec file to OpenAPI format using LLM.
+// An unchanged file is converted once; useCache false converts it again.
+func ConvertToOpenAPI(specPath string, detectedFormat string, useCache bool) (string, error) {
+	return convert(specPath, "convert-"+detectedFormat, useCache, func(content string) string {
+		return fmt.Sprintf(conversionPrompt, detectedFormat, content)
+	})
+}
+
+// UpgradeSwagger2 upgrades a Swagger 2.0 spec file to OpenAPI 3.0 using LLM.
+// An unchanged file is upgraded once; useCache false upgrades it again.
+func UpgradeSwagger2(specPath string, useCache bool) (string, error) {
+	return convert(specPath, "upgrade-swagger2", useCache, func(content string) string {
+		return fmt.Sprintf(upgradePrompt, content)
+	})
+}
+
+// convert sends the spec through the LLM with the prompt built by buildPrompt and
+// writes the resulting JSON next to the source file. Results are cached by the file's
+// hash and kind, so converting an unchanged file again costs nothing.
+func convert(specPath, kind string, useCache bool, buildPrompt func(content string) string) (string, error) {
+	hash, err := storage.ComputeFileHash(specPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read spec file: %w", err)
+	}
+	cacheKey := hash + "-" + cacheKeySuffix(kind)
+
+	jsonContent, cached := "", false
+	if useCache {
+		jsonContent, cached = storage.LoadConversion(cacheKey)
+	}
+	if !cached {
+		content, err := os.ReadFile(specPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read spec file: %w", err)
+		}
+		if jsonContent, err = convertWithLLM(buildPrompt(string(content))); err != nil {
+			return "", err
+		}
+		// The cache only saves credits; a failed write doesn't fail the conversion
+		if err := storage.SaveConversion(cacheKey, jsonContent); err != nil {
+			logger.Warn("Failed to cache converted spec", logger.Err(err))
+		}
+	}
+
+	// Generate output path
+	dir := filepath.Dir(specPath)
+	baseName := strings.TrimSuffix(filepath.Base(specPath), filepath.Ext(specPath))
+	outputPath := filepath.Join(dir, baseName+".openapi.json")
+
+	// Write converted file
+	if err := os.WriteFile(outputPath, []byte(jsonContent), 0644); err != nil {
+		return "", fmt.Errorf("failed to write converted spec: %w", err)
+	}
+
+	return outputPath, nil
+}
+
+// cacheKeySuffix turns a conversion kind such as "convert-API Blueprint" into a file name part
+func cacheKeySuffix(kind string) string {
+	return strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(kind), "-"), "-")
+}
+
+// convertWithLLM sends a conversion prompt to the configured LLM and returns the JSON it answers with.
+// Tests replace it to count conversions.
+var convertWithLLM = func(prompt string) (string, error) {
 	// Load app config
 	cfg, err := config.Load()
 	if err != nil {
@@ -40,12 +125,6 @@ func ConvertToOpenAPI(specPath string, detectedFormat string) (string, error) {
 		return "", fmt.Errorf("no API key configured - run octrafic to complete onboarding")
 	}
 
-	// Read source file
-	content, err := os.ReadFile(specPath)
-	if err != nil {
-		return "", fmt.Errorf("failed to read spec file: %w", err)
-	}
-
 	// Create LLM provider
 	providerConfig := common.ProviderConfig{
 		Provider: cfg.Provider,
@@ -60,9 +139,6 @@ func ConvertToOpenAPI(specPath string, detectedFormat string) (string, error) {
 	}
 	defer func() { _ = provider.Close() }()
 
-	// Prepare conversion prompt
-	prompt := fmt.Sprintf(conversionPrompt, detectedFormat, string(content))
-
 	messages := []common.Message{
 		{
 			Role:    "user",
@@ -75,24 +151,16 @@ func ConvertToOpenAPI(specPath string, detectedFormat string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("LLM conversion failed: %w", err)
 	}
+	if response.TokenUsage != nil {
+		common.RecordUsage(common.OperationSpecProcessing, cfg.Model, response.TokenUsage.InputTokens, response.TokenUsage.OutputTokens)
+	}
 
 	// Extract JSON from response
 	jsonContent := extractJSON(response.Message)
 	if jsonContent == "" {
 		return "", fmt.Errorf("LLM did not return valid JSON")
 	}
-
-	// Generate output path
-	dir := filepath.Dir(specPath)
-	baseName := strings.TrimSuffix(filepath.Base(specPath), filepath.Ext(specPath))
-	outputPath := filepath.Join(dir, baseName+".openapi.json")
-
-	// Write converted file
-	if err := os.WriteFile(outputPath, []byte(jsonContent), 0644); err != nil {
-		return "", fmt.Errorf("failed to write converted spec: %w", err)
-	}
-
-	return outputPath, nil
+	return jsonContent, nil
 }
 
 // extractJSON extracts JSON content from LLM response
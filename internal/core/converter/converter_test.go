@@ -1,6 +1,11 @@
 package converter
 
-import "testing"
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestExtractJSON(t *testing.T) {
 	tests := []struct {
@@ -59,3 +64,49 @@ func TestExtractJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertCachesUnchangedFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	calls := 0
+	original := convertWithLLM
+	convertWithLLM = func(prompt string) (string, error) {
+		calls++
+		return fmt.Sprintf(`{"openapi": "3.0.3", "info": {"title": "conversion %d"}}`, calls), nil
+	}
+	t.Cleanup(func() { convertWithLLM = original })
+
+	specPath := filepath.Join(t.TempDir(), "api.raml")
+	if err := os.WriteFile(specPath, []byte("#%RAML 1.0\ntitle: Users\n/users:\n  get:\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	convertAndRead := func(useCache bool) string {
+		t.Helper()
+		outputPath, err := ConvertToOpenAPI(specPath, "RAML", useCache)
+		if err != nil {
+			t.Fatalf("ConvertToOpenAPI() error = %v", err)
+		}
+		data, err := os.ReadFile(outputPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	first := convertAndRead(true)
+	if second := convertAndRead(true); calls != 1 || second != first {
+		t.Errorf("second conversion called the LLM (%d calls) or changed the output: %s", calls, second)
+	}
+
+	if convertAndRead(false); calls != 2 {
+		t.Errorf("LLM calls = %d, want a new conversion without the cache", calls)
+	}
+
+	if err := os.WriteFile(specPath, []byte("#%RAML 1.0\ntitle: Users v2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if convertAndRead(true); calls != 3 {
+		t.Errorf("LLM calls = %d, want a new conversion after the file changed", calls)
+	}
+}
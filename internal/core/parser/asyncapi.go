@@ -0,0 +1,155 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AsyncAPI operations have no HTTP method; they are listed under these pseudo-methods instead
+const (
+	asyncAPISubscribe = "SUB"
+	asyncAPIPublish   = "PUB"
+)
+
+// asyncAPIKey matches the top-level asyncapi version key of a YAML document
+var asyncAPIKey = regexp.MustCompile(`(?m)^["']?asyncapi["']?\s*:`)
+
+// isAsyncAPIYAML reports whether a YAML document is an AsyncAPI definition
+func isAsyncAPIYAML(content []byte) bool {
+	return asyncAPIKey.Match(content)
+}
+
+// parseAsyncAPI maps the operations of an AsyncAPI 2.x document (JSON or YAML) to endpoints: each
+// channel's subscribe and publish operation becomes a SUB or PUB endpoint on the channel name, or
+// uses the method of its HTTP binding. The message payload schema is the request body.
+func parseAsyncAPI(content []byte) (*Specification, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(content, &doc); err != nil {
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse AsyncAPI (tried JSON and YAML): %w", err)
+		}
+	}
+
+	version, _ := doc["asyncapi"].(string)
+	if !strings.HasPrefix(version, "2.") {
+		return nil, fmt.Errorf("unsupported AsyncAPI version %q (only 2.x is supported)", version)
+	}
+
+	spec := &Specification{
+		Format:     "asyncapi",
+		Version:    version,
+		RawContent: string(content),
+		Endpoints:  []Endpoint{},
+	}
+
+	channels, _ := doc["channels"].(map[string]any)
+	names := make([]string, 0, len(channels))
+	for name := range channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resolver := newRefResolver(doc)
+	for _, name := range names {
+		channel, ok := resolver.resolve(channels[name]).(map[string]any)
+		if !ok {
+			continue
+		}
+		params := parseAsyncAPIParameters(channel["parameters"])
+		channelDescription, _ := channel["description"].(string)
+
+		for _, op := range []struct{ key, method string }{
+			{"subscribe", asyncAPISubscribe},
+			{"publish", asyncAPIPublish},
+		} {
+			operation, ok := channel[op.key].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			endpoint := Endpoint{
+				Method:      op.method,
+				Path:        name,
+				Description: channelDescription,
+				Parameters:  params,
+				Responses:   make(map[string]string),
+			}
+			if method := asyncAPIHTTPMethod(operation); method != "" {
+				endpoint.Method = method
+			}
+			if summary, ok := operation["summary"].(string); ok && summary != "" {
+				endpoint.Description = summary
+			}
+			if description, ok := operation["description"].(string); ok && description != "" {
+				endpoint.Description = description
+			}
+			if payload := asyncAPIPayload(operation["message"]); payload != nil {
+				if encoded, err := json.Marshal(payload); err == nil {
+					endpoint.RequestBody = string(encoded)
+				}
+			}
+			spec.Endpoints = append(spec.Endpoints, endpoint)
+		}
+	}
+
+	return spec, nil
+}
+
+// asyncAPIHTTPMethod returns the method of an operation's HTTP binding, if it has one
+func asyncAPIHTTPMethod(operation map[string]any) string {
+	bindings, _ := operation["bindings"].(map[string]any)
+	binding, _ := bindings["http"].(map[string]any)
+	method, _ := binding["method"].(string)
+	if method = strings.ToUpper(method); isHTTPMethod(method) {
+		return method
+	}
+	return ""
+}
+
+// asyncAPIPayload returns a message's payload schema; for a oneOf list of messages, a oneOf of their payloads
+func asyncAPIPayload(message any) any {
+	messageMap, ok := message.(map[string]any)
+	if !ok {
+		return nil
+	}
+	if alternatives, ok := messageMap["oneOf"].([]any); ok {
+		var payloads []any
+		for _, alternative := range alternatives {
+			if payload := asyncAPIPayload(alternative); payload != nil {
+				payloads = append(payloads, payload)
+			}
+		}
+		if len(payloads) == 0 {
+			return nil
+		}
+		return map[string]any{"oneOf": payloads}
+	}
+	return messageMap["payload"]
+}
+
+// parseAsyncAPIParameters reads channel parameters (e.g. userId in user/{userId}/signedup), sorted by name
+func parseAsyncAPIParameters(parameters any) []Parameter {
+	paramMap, ok := parameters.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	params := make([]Parameter, 0, len(paramMap))
+	for name, value := range paramMap {
+		p := Parameter{Name: name, In: "path", Required: true}
+		if details, ok := value.(map[string]any); ok {
+			p.Description, _ = details["description"].(string)
+			if schema, ok := details["schema"].(map[string]any); ok {
+				p.Type, _ = schema["type"].(string)
+			}
+		}
+		params = append(params, p)
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+	return params
+}
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// jsonMediaSchema returns the schema of the JSON media type in an OpenAPI 3 content map, falling back
+// to another JSON media type (e.g. application/merge-patch+json)
+func jsonMediaSchema(content map[string]any) any {
+	if media, ok := content["application/json"].(map[string]any); ok {
+		return media["schema"]
+	}
+
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType := range content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+	for _, mediaType := range mediaTypes {
+		if media, ok := content[mediaType].(map[string]any); ok && strings.Contains(mediaType, "json") {
+			return media["schema"]
+		}
+	}
+	return nil
+}
+
+// requestBodySchema returns the schema of an operation's JSON request body: the JSON media type in
+// requestBody.content (OpenAPI 3) or the body parameter (Swagger 2)
+func requestBodySchema(details map[string]any) any {
+	if requestBody, ok := details["requestBody"].(map[string]any); ok {
+		content, _ := requestBody["content"].(map[string]any)
+		return jsonMediaSchema(content)
+	}
+
+	if params, ok := details["parameters"].([]any); ok {
+		for _, p := range params {
+			if param, ok := p.(map[string]any); ok && param["in"] == "body" {
+				return param["schema"]
+			}
+		}
+	}
+	return nil
+}
+
+// requestBodyJSON renders the operation's JSON request body schema as compact JSON
+func requestBodyJSON(details map[string]any) string {
+	schema := requestBodySchema(details)
+	if schema == nil {
+		return ""
+	}
+	encoded, err := json.Marshal(schema)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+// parseResponses maps each response status code to its description; a reference that couldn't be
+// resolved stands in for a missing description
+func parseResponses(details map[string]any) map[string]string {
+	responses := make(map[string]string)
+	list, ok := details["responses"].(map[string]any)
+	if !ok {
+		return responses
+	}
+	for status, response := range list {
+		responseMap, _ := response.(map[string]any)
+		description, _ := responseMap["description"].(string)
+		if ref, ok := responseMap["$ref"].(string); ok && description == "" {
+			description = ref
+		}
+		responses[status] = description
+	}
+	return responses
+}
+
+// responseSchemas maps each response status to the compact JSON of its body schema: the JSON media
+// type in content (OpenAPI 3) or the response schema (Swagger 2). Responses without one are left out.
+func responseSchemas(details map[string]any) map[string]string {
+	list, ok := details["responses"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	var schemas map[string]string
+	for status, response := range list {
+		responseMap, _ := response.(map[string]any)
+		schema := responseMap["schema"]
+		if content, ok := responseMap["content"].(map[string]any); ok {
+			schema = jsonMediaSchema(content)
+		}
+		if schema == nil {
+			continue
+		}
+		encoded, err := json.Marshal(schema)
+		if err != nil {
+			continue
+		}
+		if schemas == nil {
+			schemas = make(map[string]string)
+		}
+		schemas[status] = string(encoded)
+	}
+	return schemas
+}
@@ -0,0 +1,151 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// harIgnoredHeaders are headers browsers and HTTP clients add to every request; they say nothing
+// about the API and are left out of the recorded parameters
+var harIgnoredHeaders = map[string]bool{
+	"accept-encoding": true, "accept-language": true, "cache-control": true, "connection": true,
+	"content-length": true, "cookie": true, "host": true, "origin": true, "pragma": true,
+	"referer": true, "sec-ch-ua": true, "sec-ch-ua-mobile": true, "sec-ch-ua-platform": true,
+	"sec-fetch-dest": true, "sec-fetch-mode": true, "sec-fetch-site": true, "user-agent": true,
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *struct {
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+	} `json:"postData,omitempty"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// isHAR reports whether a JSON document has the log.entries shape of a HAR file
+func isHAR(data map[string]any) bool {
+	log, ok := data["log"].(map[string]any)
+	if !ok {
+		return false
+	}
+	_, ok = log["entries"].([]any)
+	return ok
+}
+
+// parseHAR extracts endpoints from the requests recorded in a HAR (HTTP Archive) file. Requests
+// with the same method and path become one endpoint: the first recorded body is kept as the
+// example RequestBody, and query parameters, headers and response statuses are merged.
+func parseHAR(content []byte) (*Specification, error) {
+	var har struct {
+		Log struct {
+			Version string `json:"version"`
+			Entries []struct {
+				Request  harRequest `json:"request"`
+				Response struct {
+					Status     int    `json:"status"`
+					StatusText string `json:"statusText"`
+				} `json:"response"`
+				Comment string `json:"comment"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	if err := json.Unmarshal(content, &har); err != nil {
+		return nil, fmt.Errorf("failed to parse HAR: %w", err)
+	}
+
+	spec := &Specification{
+		Format:     "har",
+		Version:    har.Log.Version,
+		RawContent: string(content),
+		Endpoints:  []Endpoint{},
+	}
+
+	index := map[string]int{}
+	for _, entry := range har.Log.Entries {
+		req := entry.Request
+		method := strings.ToUpper(req.Method)
+		if !isHTTPMethod(method) {
+			continue
+		}
+		path := "/"
+		if u, err := url.Parse(req.URL); err == nil && u.Path != "" {
+			path = u.Path
+		}
+
+		key := method + " " + path
+		i, seen := index[key]
+		if !seen {
+			i = len(spec.Endpoints)
+			index[key] = i
+			spec.Endpoints = append(spec.Endpoints, Endpoint{
+				Method:      method,
+				Path:        path,
+				Description: entry.Comment,
+				Responses:   make(map[string]string),
+			})
+		}
+		ep := &spec.Endpoints[i]
+
+		if ep.RequestBody == "" && req.PostData != nil && req.PostData.Text != "" {
+			ep.RequestBody = req.PostData.Text
+			if mimeType, _, _ := strings.Cut(req.PostData.MimeType, ";"); mimeType != "" {
+				ep.ContentTypes = []string{strings.TrimSpace(mimeType)}
+			}
+		}
+		if status := entry.Response.Status; status > 0 {
+			ep.Responses[strconv.Itoa(status)] = entry.Response.StatusText
+		}
+		for _, q := range req.QueryString {
+			addHARParameter(ep, q.Name, "query")
+		}
+		for _, h := range req.Headers {
+			name := strings.ToLower(h.Name)
+			switch {
+			case strings.HasPrefix(name, ":") || harIgnoredHeaders[name] || name == "content-type":
+			case name == "authorization":
+				ep.RequiresAuth = true
+				if scheme, _, _ := strings.Cut(strings.ToLower(h.Value), " "); scheme == "bearer" || scheme == "basic" {
+					ep.AuthType = scheme
+				}
+			case name == "x-api-key" || name == "api-key":
+				ep.RequiresAuth = true
+				ep.AuthType = "apikey"
+			default:
+				addHARParameter(ep, h.Name, "header")
+			}
+		}
+	}
+
+	for i := range spec.Endpoints {
+		params := spec.Endpoints[i].Parameters
+		sort.Slice(params, func(a, b int) bool {
+			if params[a].In != params[b].In {
+				return params[a].In < params[b].In
+			}
+			return params[a].Name < params[b].Name
+		})
+	}
+	return spec, nil
+}
+
+// addHARParameter records a query or header parameter seen on a request, once per endpoint
+func addHARParameter(ep *Endpoint, name, in string) {
+	for _, p := range ep.Parameters {
+		if p.In == in && strings.EqualFold(p.Name, name) {
+			return
+		}
+	}
+	ep.Parameters = append(ep.Parameters, Parameter{Name: name, In: in, Type: "string"})
+}
@@ -20,6 +20,7 @@ type JSONLEndpoint struct {
 	Tags         []string       `json:"tags,omitempty"`
 	RequiresAuth bool           `json:"requires_auth"`
 	AuthType     string         `json:"auth_type"` // "bearer", "basic", "apikey", "none"
+	Deprecated   bool           `json:"deprecated,omitempty"`
 }
 
 // IsJSONLFormat checks if the file is already in JSONL format
@@ -139,6 +140,10 @@ func ConvertOpenAPIToJSONL(specPath, outputPath string) error {
 				endpoint.Description = description
 			}
 
+			if deprecated, ok := methodObj["deprecated"].(bool); ok {
+				endpoint.Deprecated = deprecated
+			}
+
 			// Extract parameters
 			if params, ok := methodObj["parameters"].([]any); ok {
 				for _, p := range params {
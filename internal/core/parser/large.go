@@ -0,0 +1,242 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// LargeSpecThreshold is the size above which JSON specs are decoded incrementally
+	LargeSpecThreshold int64 = 50 << 20 // 50 MB
+
+	// MaxSpecSize caps specs that have to be loaded into memory in full (YAML, Markdown, GraphQL)
+	MaxSpecSize int64 = 512 << 20 // 512 MB
+)
+
+// parseLargeOpenAPIJSON extracts endpoints from a large OpenAPI JSON document path by path,
+// without loading the whole file. RawContent is left empty to keep memory bounded.
+func parseLargeOpenAPIJSON(path string) (*Specification, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	return parseOpenAPIStream(file)
+}
+
+// parseOpenAPIStream decodes an OpenAPI JSON document from r, visiting one path item at a time
+func parseOpenAPIStream(r io.Reader) (*Specification, error) {
+	dec := json.NewDecoder(r)
+
+	spec := &Specification{
+		Format:    "openapi",
+		Endpoints: []Endpoint{},
+	}
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	// Security and reference targets may be declared after paths, so they are collected into doc.
+	// Path items using $ref before the targets were read are kept raw and parsed at the end.
+	doc := map[string]any{}
+	resolver := newRefResolver(doc)
+	var deferred []rawPathItem
+
+	for dec.More() {
+		key, err := readKey(dec)
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "openapi", "swagger":
+			var version string
+			if err := dec.Decode(&version); err != nil {
+				return nil, fmt.Errorf("failed to decode %s version: %w", key, err)
+			}
+			spec.Version = version
+		case "info":
+			var info map[string]any
+			if err := dec.Decode(&info); err != nil {
+				return nil, fmt.Errorf("failed to decode info: %w", err)
+			}
+			if _, ok := info["_postman_id"]; ok {
+				return nil, fmt.Errorf("large Postman collections are not supported (max %d MB)", LargeSpecThreshold>>20)
+			}
+			if schema, ok := info["schema"].(string); ok && strings.Contains(schema, "postman") {
+				return nil, fmt.Errorf("large Postman collections are not supported (max %d MB)", LargeSpecThreshold>>20)
+			}
+		case "log":
+			return nil, fmt.Errorf("large HAR files are not supported (max %d MB)", LargeSpecThreshold>>20)
+		case "paths":
+			if err := expectDelim(dec, '{'); err != nil {
+				return nil, err
+			}
+			for dec.More() {
+				path, err := readKey(dec)
+				if err != nil {
+					return nil, err
+				}
+				var raw json.RawMessage
+				if err := dec.Decode(&raw); err != nil {
+					return nil, fmt.Errorf("failed to decode path %s: %w", path, err)
+				}
+				item := rawPathItem{path: path, raw: raw}
+				if !refTargetsRead(doc) && bytes.Contains(raw, []byte(`"$ref"`)) {
+					deferred = append(deferred, item)
+					continue
+				}
+				endpoints, err := item.parse(resolver)
+				if err != nil {
+					return nil, err
+				}
+				spec.Endpoints = append(spec.Endpoints, endpoints...)
+			}
+			if err := expectDelim(dec, '}'); err != nil {
+				return nil, err
+			}
+		case "security", "securityDefinitions", "definitions", "parameters", "responses":
+			var value any
+			if err := dec.Decode(&value); err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %w", key, err)
+			}
+			doc[key] = value
+		case "components":
+			components, err := decodeComponents(dec)
+			if err != nil {
+				return nil, err
+			}
+			doc[key] = components
+		default:
+			if err := skipValue(dec); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, item := range deferred {
+		endpoints, err := item.parse(resolver)
+		if err != nil {
+			return nil, err
+		}
+		spec.Endpoints = append(spec.Endpoints, endpoints...)
+	}
+
+	applySecurity(spec, doc["security"], securitySchemes(doc))
+	return spec, nil
+}
+
+// rawPathItem is a path item kept undecoded until its references can be resolved
+type rawPathItem struct {
+	path string
+	raw  json.RawMessage
+}
+
+func (p rawPathItem) parse(resolver *refResolver) ([]Endpoint, error) {
+	var item any
+	if err := json.Unmarshal(p.raw, &item); err != nil {
+		return nil, fmt.Errorf("failed to decode path %s: %w", p.path, err)
+	}
+	return parseOpenAPIPathItem(p.path, item, resolver), nil
+}
+
+// refTargetsRead reports whether the sections $ref usually points into (OpenAPI 3 components,
+// Swagger 2 definitions) have been read
+func refTargetsRead(doc map[string]any) bool {
+	_, components := doc["components"]
+	_, definitions := doc["definitions"]
+	return components || definitions
+}
+
+// decodedComponents are the parts of the components object the parser uses; the rest is skipped
+var decodedComponents = map[string]bool{
+	"securitySchemes": true,
+	"schemas":         true,
+	"parameters":      true,
+	"responses":       true,
+	"requestBodies":   true,
+	"examples":        true,
+}
+
+// decodeComponents reads the components object, keeping only security schemes and reference targets
+func decodeComponents(dec *json.Decoder) (map[string]any, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	components := map[string]any{}
+	for dec.More() {
+		key, err := readKey(dec)
+		if err != nil {
+			return nil, err
+		}
+		if !decodedComponents[key] {
+			if err := skipValue(dec); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var value map[string]any
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+		components[key] = value
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	return components, nil
+}
+
+// expectDelim reads the next token and checks it is the given delimiter
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to parse OpenAPI JSON: %w", err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("failed to parse OpenAPI JSON: expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
+// readKey reads an object key
+func readKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to parse OpenAPI JSON: %w", err)
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("failed to parse OpenAPI JSON: expected object key, got %v", tok)
+	}
+	return key, nil
+}
+
+// skipValue consumes the next value without retaining it
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to parse OpenAPI JSON: %w", err)
+		}
+		if d, ok := tok.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// methodOrder ranks methods within a path so listings read GET, POST, PUT, PATCH, DELETE, and
+// AsyncAPI channels subscribe before publish; anything else (HEAD, OPTIONS) follows alphabetically
+var methodOrder = map[string]int{"GET": 1, "POST": 2, "PUT": 3, "PATCH": 4, "DELETE": 5, asyncAPISubscribe: 6, asyncAPIPublish: 7}
+
+// NormalizeEndpoints drops repeated method and path pairs, keeping the first, and sorts the rest
+// by path and then method. Parsers walk maps and nested folders, so without it the same spec
+// could list its endpoints in a different order on every parse.
+func NormalizeEndpoints(endpoints []Endpoint) []Endpoint {
+	seen := make(map[string]bool, len(endpoints))
+	unique := make([]Endpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		key := strings.ToUpper(endpoint.Method) + " " + endpoint.Path
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, endpoint)
+	}
+
+	slices.SortStableFunc(unique, func(a, b Endpoint) int {
+		return cmp.Or(
+			cmp.Compare(a.Path, b.Path),
+			cmp.Compare(methodRank(a.Method), methodRank(b.Method)),
+			cmp.Compare(strings.ToUpper(a.Method), strings.ToUpper(b.Method)),
+		)
+	})
+	return unique
+}
+
+// methodRank returns the position of method in methodOrder, after the listed methods when absent
+func methodRank(method string) int {
+	if rank, ok := methodOrder[strings.ToUpper(method)]; ok {
+		return rank
+	}
+	return len(methodOrder) + 1
+}
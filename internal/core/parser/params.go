@@ -0,0 +1,65 @@
+package parser
+
+// parseParameters merges path-level and operation-level parameters (OpenAPI 3 and Swagger 2).
+// An operation parameter overrides a path-level one with the same name and location. Body parameters
+// are described by the request body instead, and unresolved $ref entries are skipped.
+func parseParameters(pathLevel, operation any) []Parameter {
+	var params []Parameter
+	index := map[string]int{}
+
+	for _, list := range []any{pathLevel, operation} {
+		items, ok := list.([]any)
+		if !ok {
+			continue
+		}
+		for _, item := range items {
+			p, ok := parseParameter(item)
+			if !ok {
+				continue
+			}
+			key := p.In + ":" + p.Name
+			if i, ok := index[key]; ok {
+				params[i] = p
+				continue
+			}
+			index[key] = len(params)
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// parseParameter reads a single parameter object; the type comes from schema (OpenAPI 3) or the
+// parameter itself (Swagger 2), with array item types written as array[string]
+func parseParameter(item any) (Parameter, bool) {
+	paramMap, ok := item.(map[string]any)
+	if !ok {
+		return Parameter{}, false
+	}
+	name, _ := paramMap["name"].(string)
+	in, _ := paramMap["in"].(string)
+	if name == "" || in == "" || in == "body" {
+		return Parameter{}, false
+	}
+
+	p := Parameter{Name: name, In: in}
+	p.Required, _ = paramMap["required"].(bool)
+	if in == "path" {
+		p.Required = true // path parameters are always required
+	}
+	p.Description, _ = paramMap["description"].(string)
+
+	typed := paramMap
+	if schema, ok := paramMap["schema"].(map[string]any); ok {
+		typed = schema
+	}
+	p.Type, _ = typed["type"].(string)
+	if p.Type == "array" {
+		if items, ok := typed["items"].(map[string]any); ok {
+			if itemType, ok := items["type"].(string); ok {
+				p.Type = "array[" + itemType + "]"
+			}
+		}
+	}
+	return p, true
+}
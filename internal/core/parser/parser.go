@@ -2,20 +2,48 @@ package parser
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	neturl "net/url"
 	"os"
 	"path/filepath"
 	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
+// SupportedFormats lists the specification formats ParseSpecification understands
+var SupportedFormats = []string{"openapi", "swagger", "asyncapi", "postman", "har", "graphql", "protobuf", "markdown"}
+
+// SupportedExtensions lists the spec file extensions ParseSpecification accepts
+var SupportedExtensions = []string{".json", ".har", ".yaml", ".yml", ".graphql", ".gql", ".proto", ".md", ".markdown"}
+
+// ErrNoEndpoints is returned when a specification parses but defines no endpoints
+var ErrNoEndpoints = errors.New("no endpoints found")
+
 type Specification struct {
-	Format     string     `json:"format"`
-	Version    string     `json:"version,omitempty"`
-	Endpoints  []Endpoint `json:"endpoints"`
-	RawContent string     `json:"raw_content"`
+	Format      string       `json:"format"`
+	Version     string       `json:"version,omitempty"`
+	Endpoints   []Endpoint   `json:"endpoints"`
+	AuthSchemes []AuthScheme `json:"auth_schemes,omitempty"` // Security schemes operations require, most used first
+	ServerURLs  []string     `json:"server_urls,omitempty"`  // Servers the spec declares, first is the default
+	RawContent  string       `json:"raw_content"`
+}
+
+// PrimaryAuthScheme returns the security scheme most operations require, or nil if the spec declares none
+func (s *Specification) PrimaryAuthScheme() *AuthScheme {
+	if len(s.AuthSchemes) == 0 {
+		return nil
+	}
+	return &s.AuthSchemes[0]
+}
+
+// IsSwagger2 reports whether the specification is a Swagger 2.0 document
+func (s *Specification) IsSwagger2() bool {
+	return s.Format == "openapi" && strings.HasPrefix(s.Version, "2.")
 }
 
 type Endpoint struct {
@@ -26,7 +54,61 @@ type Endpoint struct {
 	RequestBody  string            `json:"request_body,omitempty"`
 	Responses    map[string]string `json:"responses,omitempty"`
 	RequiresAuth bool              `json:"requires_auth"`
-	AuthType     string            `json:"auth_type"` // "bearer", "basic", "apikey", "none"
+	AuthType     string            `json:"auth_type"` // "bearer", "basic", "digest", "apikey", "oauth2", "none"
+	Deprecated   bool              `json:"deprecated,omitempty"`
+	Examples     []RequestExample  `json:"examples,omitempty"`
+	Scopes       []string          `json:"scopes,omitempty"`        // OAuth2 scopes the spec requires for this operation
+	ContentTypes []string          `json:"content_types,omitempty"` // Media types the request body accepts
+	XMLRoot      *XMLElement       `json:"xml_root,omitempty"`      // Root element of XML request bodies
+
+	// ResponseSchemas holds the JSON schema of each response body as compact JSON, by status
+	// ("200", "4XX", "default")
+	ResponseSchemas map[string]string `json:"response_schemas,omitempty"`
+
+	// security is the operation's raw security requirement list until applySecurity resolves it
+	security any
+}
+
+// RequestExample is a named request body example defined by the spec authors
+type RequestExample struct {
+	Name        string `json:"name"`
+	Summary     string `json:"summary,omitempty"`
+	ContentType string `json:"content_type"`
+	Value       any    `json:"value"`
+}
+
+// ResponseSchema returns the schema declared for a response status: the exact code first, then
+// its class (2XX) and finally the default response. It is empty when none is declared.
+func (e Endpoint) ResponseSchema(status int) string {
+	code := strconv.Itoa(status)
+	for _, key := range []string{code, code[:1] + "XX", code[:1] + "xx", "default"} {
+		if schema, ok := e.ResponseSchemas[key]; ok {
+			return schema
+		}
+	}
+	return ""
+}
+
+// MatchesPath reports whether a concrete request path matches this endpoint's path template
+// (e.g., /users/42 matches /users/{id})
+func (e Endpoint) MatchesPath(path string) bool {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	templateParts := strings.Split(strings.Trim(e.Path, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(templateParts) != len(pathParts) {
+		return false
+	}
+	for i, part := range templateParts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			continue
+		}
+		if part != pathParts[i] {
+			return false
+		}
+	}
+	return true
 }
 
 type Parameter struct {
@@ -37,14 +119,39 @@ type Parameter struct {
 	Description string `json:"description"`
 }
 
+// ParseSpecification parses a spec file in any supported format, with its endpoints deduplicated
+// and sorted so the same file always yields the same list
 func ParseSpecification(path string) (*Specification, error) {
-	content, err := os.ReadFile(path)
+	spec, err := parseSpecification(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, err
 	}
+	spec.Endpoints = NormalizeEndpoints(spec.Endpoints)
+	return spec, nil
+}
 
+// parseSpecification picks the parser for a spec file from its extension and content
+func parseSpecification(path string) (*Specification, error) {
 	ext := strings.ToLower(filepath.Ext(path))
 
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if info.Size() > LargeSpecThreshold {
+		if ext == ".json" {
+			return parseLargeOpenAPIJSON(path)
+		}
+		if info.Size() > MaxSpecSize {
+			return nil, fmt.Errorf("specification is too large (%d MB, max %d MB for %s files)", info.Size()>>20, MaxSpecSize>>20, ext)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
 	if ext == ".json" {
 		var data map[string]any
 		if err := json.Unmarshal(content, &data); err == nil {
@@ -62,17 +169,30 @@ func ParseSpecification(path string) (*Specification, error) {
 			if _, ok := data["swagger"]; ok {
 				return parseOpenAPI(content)
 			}
+			if _, ok := data["asyncapi"]; ok {
+				return parseAsyncAPI(content)
+			}
+			if isHAR(data) {
+				return parseHAR(content)
+			}
 		}
 		return parseOpenAPI(content)
 	}
 
 	switch ext {
+	case ".har":
+		return parseHAR(content)
 	case ".md", ".markdown":
 		return parseMarkdown(string(content))
 	case ".yaml", ".yml":
+		if isAsyncAPIYAML(content) {
+			return parseAsyncAPI(content)
+		}
 		return parseOpenAPI(content)
 	case ".graphql", ".gql":
 		return parseGraphQL(string(content))
+	case ".proto":
+		return parseProto(string(content))
 	default:
 		return nil, fmt.Errorf("unsupported file format: %s", ext)
 	}
@@ -137,36 +257,135 @@ func parseOpenAPI(content []byte) (*Specification, error) {
 
 	if version, ok := openapi["openapi"].(string); ok {
 		spec.Version = version
+	} else if version, ok := openapi["swagger"].(string); ok {
+		spec.Version = version
 	}
 
 	if paths, ok := openapi["paths"].(map[string]any); ok {
+		resolver := newRefResolver(openapi)
 		for path, methods := range paths {
-			if methodMap, ok := methods.(map[string]any); ok {
-				for method, details := range methodMap {
-					endpoint := Endpoint{
-						Method:    strings.ToUpper(method),
-						Path:      path,
-						Responses: make(map[string]string),
-					}
+			spec.Endpoints = append(spec.Endpoints, parseOpenAPIPathItem(path, methods, resolver)...)
+		}
+	}
+	applySecurity(spec, openapi["security"], securitySchemes(openapi))
+	spec.ServerURLs = serverURLs(openapi)
 
-					if detailsMap, ok := details.(map[string]any); ok {
-						if desc, ok := detailsMap["description"].(string); ok {
-							endpoint.Description = desc
-						}
-						if summary, ok := detailsMap["summary"].(string); ok {
-							if endpoint.Description == "" {
-								endpoint.Description = summary
-							}
-						}
-					}
+	return spec, nil
+}
+
+// parseOpenAPIPathItem extracts the endpoints defined by a single OpenAPI path item, inlining
+// the references it uses
+func parseOpenAPIPathItem(path string, methods any, resolver *refResolver) []Endpoint {
+	methodMap, ok := resolver.resolve(methods).(map[string]any)
+	if !ok {
+		return nil
+	}
+	rawMethods, _ := resolver.deref(methods).(map[string]any)
+
+	var endpoints []Endpoint
+	for method, details := range methodMap {
+		// Path items also hold shared fields such as parameters, summary and servers
+		if !isHTTPMethod(strings.ToUpper(method)) {
+			continue
+		}
+
+		endpoint := Endpoint{
+			Method:    strings.ToUpper(method),
+			Path:      path,
+			Responses: make(map[string]string),
+		}
 
-					spec.Endpoints = append(spec.Endpoints, endpoint)
+		if detailsMap, ok := details.(map[string]any); ok {
+			if desc, ok := detailsMap["description"].(string); ok {
+				endpoint.Description = desc
+			}
+			if summary, ok := detailsMap["summary"].(string); ok {
+				if endpoint.Description == "" {
+					endpoint.Description = summary
 				}
 			}
+			if deprecated, ok := detailsMap["deprecated"].(bool); ok {
+				endpoint.Deprecated = deprecated
+			}
+			if requestBody, ok := detailsMap["requestBody"].(map[string]any); ok {
+				endpoint.Examples = parseRequestExamples(requestBody)
+			}
+			// Media types are read before resolution: an XML root is named after the referenced schema
+			rawDetails, ok := resolver.deref(rawMethods[method]).(map[string]any)
+			if !ok {
+				rawDetails = detailsMap
+			}
+			endpoint.ContentTypes, endpoint.XMLRoot = requestMediaTypes(rawDetails, resolver)
+			endpoint.Parameters = parseParameters(methodMap["parameters"], detailsMap["parameters"])
+			endpoint.RequestBody = requestBodyJSON(detailsMap)
+			endpoint.Responses = parseResponses(detailsMap)
+			endpoint.ResponseSchemas = responseSchemas(detailsMap)
+			if security, ok := detailsMap["security"]; ok {
+				endpoint.security = security
+			}
 		}
+
+		endpoints = append(endpoints, endpoint)
 	}
+	return endpoints
+}
 
-	return spec, nil
+// parseRequestExamples collects named examples from requestBody.content[*].examples,
+// plus a single requestBody.content[*].example as "default"
+func parseRequestExamples(requestBody map[string]any) []RequestExample {
+	content, ok := requestBody["content"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	contentTypes := make([]string, 0, len(content))
+	for contentType := range content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+
+	var examples []RequestExample
+	for _, contentType := range contentTypes {
+		media, ok := content[contentType].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if named, ok := media["examples"].(map[string]any); ok {
+			names := make([]string, 0, len(named))
+			for name := range named {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				example, ok := named[name].(map[string]any)
+				if !ok {
+					continue
+				}
+				value, ok := example["value"]
+				if !ok {
+					continue
+				}
+				summary, _ := example["summary"].(string)
+				examples = append(examples, RequestExample{
+					Name:        name,
+					Summary:     summary,
+					ContentType: contentType,
+					Value:       value,
+				})
+			}
+		}
+
+		if value, ok := media["example"]; ok {
+			examples = append(examples, RequestExample{
+				Name:        "default",
+				ContentType: contentType,
+				Value:       value,
+			})
+		}
+	}
+	return examples
 }
 
 func isHTTPMethod(s string) bool {
@@ -240,6 +459,7 @@ func extractPostmanItems(items []postmanItem, endpoints *[]Endpoint) {
 				Method:      strings.ToUpper(item.Request.Method),
 				Path:        extractPostmanURL(item.Request.URL),
 				Description: item.Name,
+				Parameters:  postmanQueryParams(item.Request.URL),
 				Responses:   make(map[string]string),
 			}
 
@@ -295,7 +515,11 @@ func extractPostmanURL(url any) string {
 	return "/"
 }
 
+// extractPathFromURL returns the path of a Postman URL, without the host, {{baseUrl}}-style
+// variables before the path or the query string
 func extractPathFromURL(rawURL string) string {
+	rawURL, _, _ = strings.Cut(rawURL, "#")
+	rawURL, _, _ = strings.Cut(rawURL, "?")
 	if idx := strings.Index(rawURL, "}}"); idx != -1 {
 		rawURL = rawURL[idx+2:]
 	}
@@ -312,6 +536,75 @@ func extractPathFromURL(rawURL string) string {
 	return rawURL
 }
 
+// postmanQueryParams returns the query parameters of a Postman URL, from the URL object's query
+// array (skipping disabled entries) or from the query string of a raw URL
+func postmanQueryParams(url any) []Parameter {
+	var params []Parameter
+	add := func(name, description string) {
+		if name == "" {
+			return
+		}
+		for _, p := range params {
+			if p.Name == name {
+				return
+			}
+		}
+		params = append(params, Parameter{Name: name, In: "query", Type: "string", Description: description})
+	}
+
+	raw, _ := url.(string)
+	if v, ok := url.(map[string]any); ok {
+		if query, ok := v["query"].([]any); ok {
+			for _, q := range query {
+				entry, ok := q.(map[string]any)
+				if !ok {
+					continue
+				}
+				if disabled, _ := entry["disabled"].(bool); disabled {
+					continue
+				}
+				name, _ := entry["key"].(string)
+				add(name, postmanDescription(entry["description"], entry["value"]))
+			}
+			return params
+		}
+		raw, _ = v["raw"].(string)
+	}
+
+	_, query, found := strings.Cut(raw, "?")
+	if !found {
+		return nil
+	}
+	query, _, _ = strings.Cut(query, "#")
+	for pair := range strings.SplitSeq(query, "&") {
+		name, value, _ := strings.Cut(pair, "=")
+		if unescaped, err := neturl.QueryUnescape(name); err == nil {
+			name = unescaped
+		}
+		add(name, postmanDescription(nil, value))
+	}
+	return params
+}
+
+// postmanDescription returns a query parameter's description, which Postman stores as a string or
+// as {"content": ...}, falling back to its example value
+func postmanDescription(description, value any) string {
+	switch d := description.(type) {
+	case string:
+		if d != "" {
+			return d
+		}
+	case map[string]any:
+		if content, _ := d["content"].(string); content != "" {
+			return content
+		}
+	}
+	if v, _ := value.(string); v != "" {
+		return "Example: " + v
+	}
+	return ""
+}
+
 func parseGraphQL(content string) (*Specification, error) {
 	spec := &Specification{
 		Format:     "graphql",
@@ -2,6 +2,12 @@ package parser
 
 import (
 	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -37,6 +43,27 @@ func TestIsHTTPMethodLowercase(t *testing.T) {
 	}
 }
 
+func TestIsXMLMediaType(t *testing.T) {
+	tests := []struct {
+		mediaType string
+		want      bool
+	}{
+		{"application/xml", true},
+		{"text/xml; charset=utf-8", true},
+		{"application/soap+xml", true},
+		{"Application/XML", true},
+		{"application/json", false},
+		{"text/html", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsXMLMediaType(tt.mediaType); got != tt.want {
+			t.Errorf("IsXMLMediaType(%q) = %v, want %v", tt.mediaType, got, tt.want)
+		}
+	}
+}
+
 func TestExtractPathFromURL(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -361,6 +388,78 @@ func TestExtractPostmanURL(t *testing.T) {
 	}
 }
 
+func TestPostmanQueryParams(t *testing.T) {
+	collection := map[string]any{
+		"info": map[string]any{
+			"name":   "Search API",
+			"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json",
+		},
+		"item": []any{
+			map[string]any{
+				"name": "Search (raw string)",
+				"request": map[string]any{
+					"method": "GET",
+					"url":    "{{baseUrl}}/search?q=foo&page=2",
+				},
+			},
+			map[string]any{
+				"name": "Search (URL object)",
+				"request": map[string]any{
+					"method": "GET",
+					"url": map[string]any{
+						"raw":  "https://api.example.com/items?limit=10&sort=name&debug=1",
+						"host": []any{"api", "example", "com"},
+						"path": []any{"items"},
+						"query": []any{
+							map[string]any{"key": "limit", "value": "10", "description": "Page size"},
+							map[string]any{"key": "sort", "value": "name"},
+							map[string]any{"key": "debug", "value": "1", "disabled": true},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	content, _ := json.Marshal(collection)
+	spec, err := parsePostman(content)
+	if err != nil {
+		t.Fatalf("parsePostman failed: %v", err)
+	}
+	if len(spec.Endpoints) != 2 {
+		t.Fatalf("expected 2 endpoints, got %d", len(spec.Endpoints))
+	}
+
+	tests := []struct {
+		path   string
+		params []Parameter
+	}{
+		{
+			path: "/search",
+			params: []Parameter{
+				{Name: "q", In: "query", Type: "string", Description: "Example: foo"},
+				{Name: "page", In: "query", Type: "string", Description: "Example: 2"},
+			},
+		},
+		{
+			path: "/items",
+			params: []Parameter{
+				{Name: "limit", In: "query", Type: "string", Description: "Page size"},
+				{Name: "sort", In: "query", Type: "string", Description: "Example: name"},
+			},
+		},
+	}
+	for i, tt := range tests {
+		ep := spec.Endpoints[i]
+		if ep.Path != tt.path {
+			t.Errorf("endpoint %d: expected path %q, got %q", i, tt.path, ep.Path)
+		}
+		if !reflect.DeepEqual(ep.Parameters, tt.params) {
+			t.Errorf("endpoint %d: expected parameters %+v, got %+v", i, tt.params, ep.Parameters)
+		}
+	}
+}
+
 func TestParseGraphQL(t *testing.T) {
 	content := `
 type Query {
@@ -458,3 +557,1031 @@ func TestParseGraphQLField(t *testing.T) {
 		t.Error("expected nil for field with no name")
 	}
 }
+
+func TestComputeStats(t *testing.T) {
+	endpoints := []Endpoint{
+		{Method: "GET", Path: "/users", Responses: map[string]string{"200": "OK"}},
+		{Method: "POST", Path: "/users", RequestBody: "{}", RequiresAuth: true},
+		{Method: "get", Path: "/users/{id}", RequiresAuth: true},
+	}
+
+	stats := ComputeStats(endpoints)
+	if stats.Total != 3 {
+		t.Errorf("expected 3 endpoints, got %d", stats.Total)
+	}
+	if stats.ByMethod["GET"] != 2 || stats.ByMethod["POST"] != 1 {
+		t.Errorf("unexpected method counts: %v", stats.ByMethod)
+	}
+	if stats.RequiresAuth != 2 || stats.WithRequestBody != 1 || stats.WithResponses != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	want := "3 endpoints (GET 2, POST 1) · 2 require auth · 1 with request body · 1 with documented responses"
+	if got := stats.Summary(); got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
+
+func TestEndpointMatchesPath(t *testing.T) {
+	ep := Endpoint{Method: "GET", Path: "/users/{id}/posts"}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/users/42/posts", true},
+		{"/users/42/posts?limit=1", true},
+		{"/users/42", false},
+		{"/users/42/comments", false},
+	}
+	for _, tt := range tests {
+		if got := ep.MatchesPath(tt.path); got != tt.want {
+			t.Errorf("MatchesPath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParseOpenAPIDeprecated(t *testing.T) {
+	content := []byte(`{"openapi":"3.0.0","paths":{"/old":{"get":{"summary":"Old","deprecated":true}},"/new":{"get":{"summary":"New"}}}}`)
+	spec, err := parseOpenAPI(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, ep := range spec.Endpoints {
+		if want := ep.Path == "/old"; ep.Deprecated != want {
+			t.Errorf("%s: Deprecated = %v, want %v", ep.Path, ep.Deprecated, want)
+		}
+	}
+}
+
+func TestParseOpenAPIStream(t *testing.T) {
+	content := `{
+		"openapi": "3.0.0",
+		"info": {"title": "Big API"},
+		"components": {"schemas": {"User": {"type": "object", "properties": {"id": {"type": "integer"}}}}},
+		"paths": {
+			"/users": {"get": {"summary": "List users"}, "post": {"summary": "Create user"}},
+			"/old": {"get": {"summary": "Old", "deprecated": true}}
+		},
+		"tags": [{"name": "users"}]
+	}`
+
+	spec, err := parseOpenAPIStream(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.Version != "3.0.0" {
+		t.Errorf("expected version 3.0.0, got %q", spec.Version)
+	}
+	if len(spec.Endpoints) != 3 {
+		t.Fatalf("expected 3 endpoints, got %d", len(spec.Endpoints))
+	}
+
+	if _, err := parseOpenAPIStream(strings.NewReader(`{"info": {"_postman_id": "x"}, "item": []}`)); err == nil {
+		t.Error("expected error for large Postman collection")
+	}
+	if _, err := parseOpenAPIStream(strings.NewReader(`[]`)); err == nil {
+		t.Error("expected error for non-object document")
+	}
+}
+
+func TestSpecificationIsSwagger2(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"swagger 2.0", `{"swagger": "2.0", "paths": {}}`, true},
+		{"openapi 3", `{"openapi": "3.0.3", "paths": {}}`, false},
+		{"swagger yaml", "swagger: \"2.0\"\npaths: {}\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := parseOpenAPI([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("parseOpenAPI() error = %v", err)
+			}
+			if got := spec.IsSwagger2(); got != tt.want {
+				t.Errorf("IsSwagger2() = %v, want %v (version %q)", got, tt.want, spec.Version)
+			}
+		})
+	}
+}
+
+func TestParseOpenAPIRequestExamples(t *testing.T) {
+	content := `{
+		"openapi": "3.0.0",
+		"paths": {
+			"/users": {
+				"post": {
+					"requestBody": {
+						"content": {
+							"application/json": {
+								"examples": {
+									"regular": {"summary": "Regular user", "value": {"name": "alice", "role": "user"}},
+									"admin": {"summary": "Administrator", "value": {"name": "root", "role": "admin"}}
+								}
+							},
+							"application/xml": {
+								"example": "<user><name>bob</name></user>"
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	spec, err := parseOpenAPI([]byte(content))
+	if err != nil {
+		t.Fatalf("parseOpenAPI() error = %v", err)
+	}
+	if len(spec.Endpoints) != 1 {
+		t.Fatalf("expected 1 endpoint, got %d", len(spec.Endpoints))
+	}
+
+	examples := spec.Endpoints[0].Examples
+	if len(examples) != 3 {
+		t.Fatalf("expected 3 examples, got %d", len(examples))
+	}
+
+	wantNames := []string{"admin", "regular", "default"}
+	for i, want := range wantNames {
+		if examples[i].Name != want {
+			t.Errorf("examples[%d].Name = %q, want %q", i, examples[i].Name, want)
+		}
+	}
+	if examples[0].Summary != "Administrator" || examples[0].ContentType != "application/json" {
+		t.Errorf("unexpected admin example: %+v", examples[0])
+	}
+	if value, ok := examples[0].Value.(map[string]any); !ok || value["role"] != "admin" {
+		t.Errorf("unexpected admin example value: %v", examples[0].Value)
+	}
+	if examples[2].ContentType != "application/xml" {
+		t.Errorf("default example content type = %q", examples[2].ContentType)
+	}
+}
+
+func TestParseOpenAPIParameters(t *testing.T) {
+	spec, err := ParseSpecification("testdata/parameters.yaml")
+	if err != nil {
+		t.Fatalf("ParseSpecification() error = %v", err)
+	}
+	if len(spec.Endpoints) != 2 {
+		t.Fatalf("expected 2 endpoints (shared path item fields skipped), got %d", len(spec.Endpoints))
+	}
+
+	byMethod := map[string]Endpoint{}
+	for _, ep := range spec.Endpoints {
+		byMethod[ep.Method] = ep
+	}
+
+	userID := Parameter{Name: "userId", In: "path", Type: "integer", Required: true, Description: "ID of the user"}
+	wantGet := []Parameter{
+		userID,
+		{Name: "X-Tenant", In: "header", Type: "string", Required: true, Description: "Tenant override"},
+		{Name: "limit", In: "query", Type: "integer", Description: "Maximum number of posts"},
+		{Name: "tags", In: "query", Type: "array[string]", Required: true},
+	}
+	if got := byMethod["GET"].Parameters; !slices.Equal(got, wantGet) {
+		t.Errorf("GET parameters = %+v\nwant %+v", got, wantGet)
+	}
+
+	// Path-level parameters apply to operations that declare none of their own
+	wantPost := []Parameter{userID, {Name: "X-Tenant", In: "header", Type: "string"}}
+	if got := byMethod["POST"].Parameters; !slices.Equal(got, wantPost) {
+		t.Errorf("POST parameters = %+v\nwant %+v", got, wantPost)
+	}
+}
+
+func TestParseSwagger2Parameters(t *testing.T) {
+	content := `{"swagger": "2.0", "paths": {"/pets/{petId}": {"put": {"parameters": [
+		{"name": "petId", "in": "path", "type": "string"},
+		{"name": "dryRun", "in": "query", "type": "boolean"},
+		{"name": "body", "in": "body", "schema": {"$ref": "#/definitions/Pet"}},
+		{"$ref": "#/parameters/Trace"}
+	]}}}}`
+
+	spec, err := parseOpenAPI([]byte(content))
+	if err != nil {
+		t.Fatalf("parseOpenAPI() error = %v", err)
+	}
+	want := []Parameter{
+		{Name: "petId", In: "path", Type: "string", Required: true},
+		{Name: "dryRun", In: "query", Type: "boolean"},
+	}
+	if got := spec.Endpoints[0].Parameters; !slices.Equal(got, want) {
+		t.Errorf("parameters = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseRequestMediaTypes(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantTypes []string
+		wantRoot  *XMLElement
+	}{
+		{
+			name: "openapi 3 xml schema name",
+			content: `{"openapi": "3.0.0", "paths": {"/orders": {"post": {"requestBody": {"content": {
+				"application/json": {"schema": {"$ref": "#/components/schemas/Order"}},
+				"application/xml": {"schema": {"$ref": "#/components/schemas/Order", "xml": {"name": "order", "namespace": "urn:shop", "prefix": "s"}}}
+			}}}}}}`,
+			wantTypes: []string{"application/json", "application/xml"},
+			wantRoot:  &XMLElement{Name: "order", Namespace: "urn:shop", Prefix: "s"},
+		},
+		{
+			name: "openapi 3 root named after component",
+			content: `{"openapi": "3.0.0", "paths": {"/orders": {"post": {"requestBody": {"content": {
+				"text/xml": {"schema": {"$ref": "#/components/schemas/Order"}}
+			}}}}}}`,
+			wantTypes: []string{"text/xml"},
+			wantRoot:  &XMLElement{Name: "Order"},
+		},
+		{
+			name: "swagger 2 consumes",
+			content: `{"swagger": "2.0", "paths": {"/pets": {"post": {
+				"consumes": ["application/xml", "application/json"],
+				"parameters": [{"in": "body", "name": "body", "schema": {"$ref": "#/definitions/Pet"}}]
+			}}}}`,
+			wantTypes: []string{"application/json", "application/xml"},
+			wantRoot:  &XMLElement{Name: "Pet"},
+		},
+		{
+			name:      "json only",
+			content:   `{"openapi": "3.0.0", "paths": {"/users": {"post": {"requestBody": {"content": {"application/json": {}}}}}}}`,
+			wantTypes: []string{"application/json"},
+		},
+		{
+			name:    "no request body",
+			content: `{"openapi": "3.0.0", "paths": {"/users": {"get": {}}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := parseOpenAPI([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("parseOpenAPI() error = %v", err)
+			}
+			ep := spec.Endpoints[0]
+			if !slices.Equal(ep.ContentTypes, tt.wantTypes) {
+				t.Errorf("ContentTypes = %v, want %v", ep.ContentTypes, tt.wantTypes)
+			}
+			if !reflect.DeepEqual(ep.XMLRoot, tt.wantRoot) {
+				t.Errorf("XMLRoot = %+v, want %+v", ep.XMLRoot, tt.wantRoot)
+			}
+		})
+	}
+}
+
+func TestParseOpenAPISecurityScopes(t *testing.T) {
+	content := `{
+		"openapi": "3.0.0",
+		"security": [{"oauth": ["read:users"]}],
+		"components": {"securitySchemes": {
+			"oauth": {"type": "oauth2"},
+			"key": {"type": "apiKey", "in": "header", "name": "X-API-Key"}
+		}},
+		"paths": {
+			"/users": {
+				"get": {"summary": "List"},
+				"post": {"security": [{"key": []}, {"oauth": ["write:users", "read:users"]}]},
+				"delete": {"security": []}
+			}
+		}
+	}`
+
+	want := map[string][]string{
+		"GET":    {"read:users"},
+		"POST":   {"read:users", "write:users"},
+		"DELETE": nil,
+	}
+
+	for name, parse := range map[string]func() (*Specification, error){
+		"full":   func() (*Specification, error) { return parseOpenAPI([]byte(content)) },
+		"stream": func() (*Specification, error) { return parseOpenAPIStream(strings.NewReader(content)) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			spec, err := parse()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, ep := range spec.Endpoints {
+				if !slices.Equal(ep.Scopes, want[ep.Method]) {
+					t.Errorf("%s: Scopes = %v, want %v", ep.Method, ep.Scopes, want[ep.Method])
+				}
+			}
+		})
+	}
+}
+
+func TestParseSwagger2SecurityScopes(t *testing.T) {
+	content := `{
+		"swagger": "2.0",
+		"securityDefinitions": {"petstore_auth": {"type": "oauth2", "flow": "implicit"}},
+		"paths": {"/pets": {"get": {"security": [{"petstore_auth": ["read:pets"]}]}}}
+	}`
+	spec, err := parseOpenAPI([]byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := spec.Endpoints[0].Scopes; !slices.Equal(got, []string{"read:pets"}) {
+		t.Errorf("Scopes = %v, want [read:pets]", got)
+	}
+}
+
+func TestParseOpenAPISecuritySchemes(t *testing.T) {
+	content := `{
+		"openapi": "3.0.0",
+		"security": [{"bearerAuth": []}],
+		"components": {"securitySchemes": {
+			"bearerAuth": {"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			"basicAuth": {"type": "http", "scheme": "basic"},
+			"apiKey": {"type": "apiKey", "in": "header", "name": "X-API-Key"}
+		}},
+		"paths": {
+			"/health": {"get": {"security": []}},
+			"/users": {
+				"get": {"summary": "List"},
+				"post": {"security": [{"apiKey": []}, {"bearerAuth": []}]}
+			},
+			"/login": {"post": {"security": [{"basicAuth": []}]}},
+			"/feed": {"get": {"security": [{}, {"bearerAuth": []}]}}
+		}
+	}`
+
+	want := map[string]struct {
+		requiresAuth bool
+		authType     string
+	}{
+		"GET /health": {false, "none"},
+		"GET /users":  {true, "bearer"},
+		"POST /users": {true, "apikey"},
+		"POST /login": {true, "basic"},
+		"GET /feed":   {false, "none"},
+	}
+
+	for name, parse := range map[string]func() (*Specification, error){
+		"full":   func() (*Specification, error) { return parseOpenAPI([]byte(content)) },
+		"stream": func() (*Specification, error) { return parseOpenAPIStream(strings.NewReader(content)) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			spec, err := parse()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, ep := range spec.Endpoints {
+				key := ep.Method + " " + ep.Path
+				if ep.RequiresAuth != want[key].requiresAuth || ep.AuthType != want[key].authType {
+					t.Errorf("%s: RequiresAuth = %v, AuthType = %q; want %v, %q",
+						key, ep.RequiresAuth, ep.AuthType, want[key].requiresAuth, want[key].authType)
+				}
+			}
+
+			primary := spec.PrimaryAuthScheme()
+			if primary == nil || primary.Name != "bearerAuth" || primary.Type != "bearer" {
+				t.Fatalf("PrimaryAuthScheme() = %+v, want bearerAuth", primary)
+			}
+			var apiKey *AuthScheme
+			for i := range spec.AuthSchemes {
+				if spec.AuthSchemes[i].Name == "apiKey" {
+					apiKey = &spec.AuthSchemes[i]
+				}
+			}
+			if apiKey == nil || apiKey.KeyName != "X-API-Key" || apiKey.Location != "header" {
+				t.Errorf("apiKey scheme = %+v, want X-API-Key in header", apiKey)
+			}
+		})
+	}
+}
+
+func TestParseSwagger2SecurityDefinitions(t *testing.T) {
+	content := `{
+		"swagger": "2.0",
+		"securityDefinitions": {
+			"basic": {"type": "basic"},
+			"oauth": {"type": "oauth2", "flow": "application", "tokenUrl": "https://auth.example.com/token"}
+		},
+		"paths": {
+			"/pets": {"get": {"security": [{"oauth": ["read:pets"]}]}},
+			"/status": {"get": {}}
+		}
+	}`
+	spec, err := parseOpenAPI([]byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, ep := range spec.Endpoints {
+		switch ep.Path {
+		case "/pets":
+			if !ep.RequiresAuth || ep.AuthType != "oauth2" {
+				t.Errorf("/pets: RequiresAuth = %v, AuthType = %q; want true, oauth2", ep.RequiresAuth, ep.AuthType)
+			}
+		case "/status":
+			if ep.RequiresAuth || ep.AuthType != "" {
+				t.Errorf("/status: RequiresAuth = %v, AuthType = %q; want it undetermined", ep.RequiresAuth, ep.AuthType)
+			}
+		}
+	}
+	want := []AuthScheme{{Name: "oauth", Type: "oauth2", TokenURL: "https://auth.example.com/token", Scopes: []string{"read:pets"}}}
+	if !reflect.DeepEqual(spec.AuthSchemes, want) {
+		t.Errorf("AuthSchemes = %+v, want %+v", spec.AuthSchemes, want)
+	}
+}
+
+func TestParseOpenAPIRequestBodiesAndResponses(t *testing.T) {
+	content := `{
+		"openapi": "3.0.0",
+		"paths": {
+			"/users": {
+				"post": {
+					"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/NewUser"}}}},
+					"responses": {
+						"200": {"description": "User created"},
+						"400": {"description": "Invalid user"}
+					}
+				},
+				"put": {
+					"requestBody": {"content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/NewUser"}}}}},
+					"responses": {"204": {"description": "Users replaced"}}
+				}
+			},
+			"/notes": {
+				"post": {
+					"requestBody": {"content": {"application/json": {"schema": {"type": "object", "properties": {"text": {"type": "string"}}}}}},
+					"responses": {"default": {"$ref": "#/components/responses/Error"}}
+				}
+			}
+		},
+		"components": {"schemas": {"NewUser": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}}}}
+	}`
+
+	newUser := `{"properties":{"name":{"type":"string"}},"required":["name"],"type":"object"}`
+	want := map[string]struct {
+		body      string
+		responses map[string]string
+	}{
+		"POST /users": {newUser, map[string]string{"200": "User created", "400": "Invalid user"}},
+		"PUT /users":  {`{"items":` + newUser + `,"type":"array"}`, map[string]string{"204": "Users replaced"}},
+		"POST /notes": {`{"properties":{"text":{"type":"string"}},"type":"object"}`, map[string]string{"default": "#/components/responses/Error"}},
+	}
+
+	for name, parse := range map[string]func() (*Specification, error){
+		"full":   func() (*Specification, error) { return parseOpenAPI([]byte(content)) },
+		"stream": func() (*Specification, error) { return parseOpenAPIStream(strings.NewReader(content)) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			spec, err := parse()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(spec.Endpoints) != len(want) {
+				t.Fatalf("expected %d endpoints, got %d", len(want), len(spec.Endpoints))
+			}
+			for _, ep := range spec.Endpoints {
+				key := ep.Method + " " + ep.Path
+				if ep.RequestBody != want[key].body {
+					t.Errorf("%s: RequestBody = %s, want %s", key, ep.RequestBody, want[key].body)
+				}
+				if !reflect.DeepEqual(ep.Responses, want[key].responses) {
+					t.Errorf("%s: Responses = %v, want %v", key, ep.Responses, want[key].responses)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSwagger2RequestBody(t *testing.T) {
+	content := `{
+		"swagger": "2.0",
+		"definitions": {"Pet": {"type": "object", "properties": {"name": {"type": "string"}}}},
+		"paths": {"/pets": {"post": {
+			"parameters": [{"in": "body", "name": "pet", "schema": {"$ref": "#/definitions/Pet"}}],
+			"responses": {"201": {"description": "Created"}}
+		}}}
+	}`
+	spec, err := parseOpenAPI([]byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ep := spec.Endpoints[0]
+	if want := `{"properties":{"name":{"type":"string"}},"type":"object"}`; ep.RequestBody != want {
+		t.Errorf("RequestBody = %s, want %s", ep.RequestBody, want)
+	}
+	if ep.Responses["201"] != "Created" {
+		t.Errorf("Responses = %v, want 201: Created", ep.Responses)
+	}
+}
+
+func TestParseResponseSchemas(t *testing.T) {
+	content := `{
+		"openapi": "3.0.0",
+		"paths": {"/users/{id}": {"get": {"responses": {
+			"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}},
+			"4XX": {"description": "Client error", "content": {"application/problem+json": {"schema": {"type": "object"}}}},
+			"204": {"description": "No content"}
+		}}}},
+		"components": {"schemas": {"User": {"type": "object", "required": ["id"]}}}
+	}`
+	spec, err := parseOpenAPI([]byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ep := spec.Endpoints[0]
+	want := map[string]string{"200": `{"required":["id"],"type":"object"}`, "4XX": `{"type":"object"}`}
+	if !reflect.DeepEqual(ep.ResponseSchemas, want) {
+		t.Errorf("ResponseSchemas = %v, want %v", ep.ResponseSchemas, want)
+	}
+	for status, schema := range map[int]string{200: want["200"], 404: want["4XX"], 204: "", 500: ""} {
+		if got := ep.ResponseSchema(status); got != schema {
+			t.Errorf("ResponseSchema(%d) = %q, want %q", status, got, schema)
+		}
+	}
+
+	swagger := `{
+		"swagger": "2.0",
+		"paths": {"/pets": {"get": {"responses": {
+			"default": {"description": "Pets", "schema": {"type": "array", "items": {"type": "string"}}}
+		}}}}
+	}`
+	spec, err = parseOpenAPI([]byte(swagger))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := spec.Endpoints[0].ResponseSchema(200); got != `{"items":{"type":"string"},"type":"array"}` {
+		t.Errorf("Swagger 2 ResponseSchema(200) = %s", got)
+	}
+}
+
+func TestParseServerURLs(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		want     []string
+		basePath string
+	}{
+		{
+			name: "openapi servers with variables",
+			content: `{"openapi": "3.0.0", "paths": {}, "servers": [
+				{"url": "https://{region}.example.com/api/v1/", "variables": {"region": {"default": "eu"}}},
+				{"url": "http://localhost:8080"}
+			]}`,
+			want:     []string{"https://eu.example.com/api/v1/", "http://localhost:8080"},
+			basePath: "/api/v1",
+		},
+		{
+			name:     "relative server url",
+			content:  `{"openapi": "3.1.0", "paths": {}, "servers": [{"url": "/v2"}]}`,
+			want:     []string{"/v2"},
+			basePath: "/v2",
+		},
+		{
+			name:     "swagger host and basePath",
+			content:  `{"swagger": "2.0", "paths": {}, "host": "petstore.io", "basePath": "/v1", "schemes": ["http"]}`,
+			want:     []string{"http://petstore.io/v1"},
+			basePath: "/v1",
+		},
+		{
+			name:    "no servers",
+			content: `{"openapi": "3.0.0", "paths": {}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := parseOpenAPI([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(spec.ServerURLs, tt.want) {
+				t.Errorf("ServerURLs = %v, want %v", spec.ServerURLs, tt.want)
+			}
+			if got := spec.BasePath(); got != tt.basePath {
+				t.Errorf("BasePath() = %q, want %q", got, tt.basePath)
+			}
+		})
+	}
+}
+
+func TestApplyBasePath(t *testing.T) {
+	spec := &Specification{ServerURLs: []string{"https://api.example.com/api/v1"}}
+	tests := []struct {
+		baseURL  string
+		want     string
+		warnings int
+	}{
+		{"https://api.example.com", "https://api.example.com/api/v1", 0},
+		{"https://api.example.com/", "https://api.example.com/api/v1", 0},
+		{"https://api.example.com/api/v1", "https://api.example.com/api/v1", 0},
+		{"http://localhost:3000", "http://localhost:3000/api/v1", 1},
+		{"https://api.example.com/v2", "https://api.example.com/v2", 1},
+	}
+	for _, tt := range tests {
+		got, warnings := ApplyBasePath(tt.baseURL, spec)
+		if got != tt.want || len(warnings) != tt.warnings {
+			t.Errorf("ApplyBasePath(%q) = %q, %v; want %q with %d warning(s)", tt.baseURL, got, warnings, tt.want, tt.warnings)
+		}
+	}
+
+	if got, warnings := ApplyBasePath("http://localhost:3000", &Specification{}); got != "http://localhost:3000" || len(warnings) > 0 {
+		t.Errorf("ApplyBasePath() without servers = %q, %v", got, warnings)
+	}
+}
+
+func TestDetectServers(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "single server",
+			content: `{"openapi": "3.0.0", "paths": {}, "servers": [{"url": "https://api.example.com/v1/"}]}`,
+			want:    []string{"https://api.example.com/v1"},
+		},
+		{
+			name: "templated servers",
+			content: `{"openapi": "3.0.0", "paths": {}, "servers": [
+				{"url": "https://{tenant}.example.com", "variables": {"tenant": {}}},
+				{"url": "{scheme}://{region}.example.com:{port}/api", "variables": {
+					"scheme": {"default": "https"}, "region": {"default": "eu"}, "port": {"default": 8443}
+				}}
+			]}`,
+			want: []string{"https://eu.example.com:8443/api"},
+		},
+		{
+			name:    "relative server only",
+			content: `{"openapi": "3.0.0", "paths": {}, "servers": [{"url": "/v2"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "openapi.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := DetectServers(path)
+			if err != nil {
+				t.Fatalf("DetectServers failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DetectServers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefResolverCycles(t *testing.T) {
+	doc := map[string]any{
+		"components": map[string]any{"schemas": map[string]any{
+			"Node": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"children": map[string]any{"type": "array", "items": map[string]any{"$ref": "#/components/schemas/Node"}},
+					"owner":    map[string]any{"$ref": "#/components/schemas/User"},
+				},
+			},
+			"User": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"manager": map[string]any{"$ref": "#/components/schemas/User"}, "root": map[string]any{"$ref": "#/components/schemas/Node"}},
+			},
+			"Loop": map[string]any{"$ref": "#/components/schemas/Loop"},
+		}},
+	}
+
+	resolver := newRefResolver(doc)
+	for _, ref := range []string{"Node", "User", "Loop"} {
+		resolved := resolver.resolve(map[string]any{"$ref": "#/components/schemas/" + ref})
+		if _, err := json.Marshal(resolved); err != nil {
+			t.Errorf("%s: resolved schema isn't finite JSON: %v", ref, err)
+		}
+	}
+
+	node, _ := resolver.resolve(map[string]any{"$ref": "#/components/schemas/Node"}).(map[string]any)
+	properties, _ := node["properties"].(map[string]any)
+	children, _ := properties["children"].(map[string]any)
+	if items, _ := children["items"].(map[string]any); items["$ref"] != "#/components/schemas/Node" {
+		t.Errorf("recursive reference = %v, want it left as $ref", items)
+	}
+	if owner, _ := properties["owner"].(map[string]any); owner["type"] != "object" {
+		t.Errorf("owner = %v, want the User schema inlined", owner)
+	}
+}
+
+func TestParseOpenAPIResolvesRefs(t *testing.T) {
+	// components come last so the stream parser has to defer the path item
+	content := `{
+		"openapi": "3.0.0",
+		"paths": {
+			"/users/{id}": {
+				"parameters": [{"$ref": "#/components/parameters/UserID"}],
+				"put": {
+					"requestBody": {"$ref": "#/components/requestBodies/User"},
+					"responses": {
+						"200": {"$ref": "#/components/responses/User"},
+						"404": {"$ref": "#/components/responses/NotFound"}
+					}
+				}
+			}
+		},
+		"components": {
+			"parameters": {"UserID": {"name": "id", "in": "path", "schema": {"type": "integer"}}},
+			"requestBodies": {"User": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}},
+			"responses": {
+				"User": {"description": "The updated user"},
+				"NotFound": {"description": "No such user"}
+			},
+			"schemas": {"User": {"type": "object", "properties": {
+				"name": {"type": "string"},
+				"manager": {"$ref": "#/components/schemas/User"}
+			}}}
+		}
+	}`
+
+	wantBody := `{"properties":{"manager":{"$ref":"#/components/schemas/User"},"name":{"type":"string"}},"type":"object"}`
+	wantResponses := map[string]string{"200": "The updated user", "404": "No such user"}
+	wantParams := []Parameter{{Name: "id", In: "path", Type: "integer", Required: true}}
+
+	for name, parse := range map[string]func() (*Specification, error){
+		"full":   func() (*Specification, error) { return parseOpenAPI([]byte(content)) },
+		"stream": func() (*Specification, error) { return parseOpenAPIStream(strings.NewReader(content)) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			spec, err := parse()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(spec.Endpoints) != 1 {
+				t.Fatalf("expected 1 endpoint, got %d", len(spec.Endpoints))
+			}
+			ep := spec.Endpoints[0]
+			if ep.RequestBody != wantBody {
+				t.Errorf("RequestBody = %s, want %s", ep.RequestBody, wantBody)
+			}
+			if !reflect.DeepEqual(ep.Responses, wantResponses) {
+				t.Errorf("Responses = %v, want %v", ep.Responses, wantResponses)
+			}
+			if !reflect.DeepEqual(ep.Parameters, wantParams) {
+				t.Errorf("Parameters = %+v, want %+v", ep.Parameters, wantParams)
+			}
+		})
+	}
+}
+
+func TestParseOpenAPIXMLRootFromResolvedRef(t *testing.T) {
+	content := `{
+		"openapi": "3.0.0",
+		"components": {"schemas": {
+			"Order": {"type": "object"},
+			"Pet": {"type": "object", "xml": {"name": "pet", "namespace": "urn:pets"}}
+		}},
+		"paths": {
+			"/orders": {"post": {"requestBody": {"content": {"application/xml": {"schema": {"$ref": "#/components/schemas/Order"}}}}}},
+			"/pets": {"post": {"requestBody": {"content": {"application/xml": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}}
+		}
+	}`
+	spec, err := parseOpenAPI([]byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]*XMLElement{
+		"/orders": {Name: "Order"},
+		"/pets":   {Name: "pet", Namespace: "urn:pets"},
+	}
+	for _, ep := range spec.Endpoints {
+		if !reflect.DeepEqual(ep.XMLRoot, want[ep.Path]) {
+			t.Errorf("%s: XMLRoot = %+v, want %+v", ep.Path, ep.XMLRoot, want[ep.Path])
+		}
+	}
+}
+
+func TestParseAsyncAPI(t *testing.T) {
+	spec, err := ParseSpecification("testdata/asyncapi.yaml")
+	if err != nil {
+		t.Fatalf("ParseSpecification() error = %v", err)
+	}
+	if spec.Format != "asyncapi" || spec.Version != "2.6.0" {
+		t.Errorf("Format = %q, Version = %q, want asyncapi 2.6.0", spec.Format, spec.Version)
+	}
+
+	want := []struct {
+		method, path, description, body string
+	}{
+		{"POST", "/webhooks/users", "Deliver user events over HTTP", `{"type":"object"}`},
+		{"SUB", "user/{userId}/signedup", "A user signed up", `{"properties":{"id":{"type":"string"}},"type":"object"}`},
+		{"PUB", "user/{userId}/signedup", "Events about a user signing up", `{"oneOf":[{"properties":{"email":{"type":"string"}},"type":"object"},{"type":"string"}]}`},
+	}
+	if len(spec.Endpoints) != len(want) {
+		t.Fatalf("expected %d endpoints, got %d", len(want), len(spec.Endpoints))
+	}
+	for i, w := range want {
+		ep := spec.Endpoints[i]
+		if ep.Method != w.method || ep.Path != w.path || ep.Description != w.description || ep.RequestBody != w.body {
+			t.Errorf("endpoint %d = %s %s %q %s, want %s %s %q %s", i, ep.Method, ep.Path, ep.Description, ep.RequestBody,
+				w.method, w.path, w.description, w.body)
+		}
+	}
+
+	wantParams := []Parameter{{Name: "userId", In: "path", Type: "string", Required: true, Description: "ID of the user"}}
+	if !reflect.DeepEqual(spec.Endpoints[1].Parameters, wantParams) {
+		t.Errorf("Parameters = %+v, want %+v", spec.Endpoints[1].Parameters, wantParams)
+	}
+
+	if _, err := parseAsyncAPI([]byte(`{"asyncapi": "3.0.0", "channels": {}}`)); err == nil {
+		t.Error("expected error for AsyncAPI 3")
+	}
+}
+
+func TestParseHAR(t *testing.T) {
+	spec, err := ParseSpecification("testdata/recording.har")
+	if err != nil {
+		t.Fatalf("ParseSpecification() error = %v", err)
+	}
+	if spec.Format != "har" || spec.Version != "1.2" {
+		t.Errorf("Format = %q, Version = %q, want har 1.2", spec.Format, spec.Version)
+	}
+	if len(spec.Endpoints) != 2 {
+		t.Fatalf("expected 2 endpoints (the two GETs deduplicated), got %d", len(spec.Endpoints))
+	}
+
+	list := spec.Endpoints[0]
+	if list.Method != "GET" || list.Path != "/users" || !list.RequiresAuth || list.AuthType != "bearer" {
+		t.Errorf("endpoint 0 = %s %s auth %v %q, want GET /users with bearer auth", list.Method, list.Path, list.RequiresAuth, list.AuthType)
+	}
+	wantParams := []Parameter{
+		{Name: "Accept", In: "header", Type: "string"},
+		{Name: "limit", In: "query", Type: "string"},
+		{Name: "page", In: "query", Type: "string"},
+	}
+	if !reflect.DeepEqual(list.Parameters, wantParams) {
+		t.Errorf("Parameters = %+v, want %+v", list.Parameters, wantParams)
+	}
+
+	create := spec.Endpoints[1]
+	if create.Method != "POST" || create.RequestBody != `{"name":"Ada"}` {
+		t.Errorf("endpoint 1 = %s body %q, want POST with the recorded body", create.Method, create.RequestBody)
+	}
+	if !slices.Equal(create.ContentTypes, []string{"application/json"}) || create.Responses["201"] != "Created" {
+		t.Errorf("ContentTypes = %v, Responses = %v", create.ContentTypes, create.Responses)
+	}
+}
+
+func TestParseProto(t *testing.T) {
+	content := `syntax = "proto3";
+
+package shop.v1;
+
+import "google/protobuf/timestamp.proto";
+
+option go_package = "example.com/shop/v1";
+
+// Orders manages customer orders.
+service OrderService {
+  // Fetches a single order by ID.
+  rpc GetOrder(GetOrderRequest) returns (Order);
+  rpc WatchOrders(stream Order.Filter) returns (stream Order) {
+    option (google.api.http) = { get: "/v1/orders:watch" };
+  }
+}
+
+message GetOrderRequest {
+  string id = 1; // trailing comment
+  repeated string fields = 2;
+}
+
+message Order {
+  message Filter {
+    string status = 1;
+  }
+  enum Status {
+    PENDING = 0;
+    SHIPPED = 1;
+  }
+  string id = 1;
+  map<string, int32> items = 2;
+  oneof payment {
+    string card = 3;
+    string invoice = 4;
+  }
+}
+`
+
+	spec, err := parseProto(content)
+	if err != nil {
+		t.Fatalf("parseProto failed: %v", err)
+	}
+	if spec.Format != "protobuf" || spec.Version != "proto3" {
+		t.Errorf("format %q version %q, want protobuf proto3", spec.Format, spec.Version)
+	}
+	if len(spec.Endpoints) != 2 {
+		t.Fatalf("expected 2 endpoints, got %d", len(spec.Endpoints))
+	}
+
+	get := spec.Endpoints[0]
+	if get.Method != "POST" || get.Path != "/shop.v1.OrderService/GetOrder" {
+		t.Errorf("first endpoint %s %s, want POST /shop.v1.OrderService/GetOrder", get.Method, get.Path)
+	}
+	if get.RequestBody != "GetOrderRequest" || get.Responses["200"] != "Order" {
+		t.Errorf("GetOrder request %q response %q", get.RequestBody, get.Responses["200"])
+	}
+	if get.Description != "Fetches a single order by ID." {
+		t.Errorf("GetOrder description %q", get.Description)
+	}
+	if len(get.Parameters) != 2 || get.Parameters[0].Name != "id" || get.Parameters[1].Type != "repeated string" {
+		t.Errorf("GetOrder parameters %+v", get.Parameters)
+	}
+	if get.Parameters[0].Description != "" {
+		t.Errorf("trailing comment became a description: %q", get.Parameters[0].Description)
+	}
+
+	watch := spec.Endpoints[1]
+	if watch.Path != "/shop.v1.OrderService/WatchOrders" || watch.RequestBody != "Order.Filter" {
+		t.Errorf("second endpoint %s with body %q", watch.Path, watch.RequestBody)
+	}
+	if !strings.Contains(watch.Description, "client and server streaming") {
+		t.Errorf("WatchOrders description %q doesn't mention streaming", watch.Description)
+	}
+	if len(watch.Parameters) != 1 || watch.Parameters[0].Name != "status" {
+		t.Errorf("nested Order.Filter parameters %+v", watch.Parameters)
+	}
+}
+
+func TestParseProtoWithoutServices(t *testing.T) {
+	if _, err := parseProto(`syntax = "proto3"; message Empty {}`); !errors.Is(err, ErrNoEndpoints) {
+		t.Errorf("parseProto without services: err = %v, want ErrNoEndpoints", err)
+	}
+}
+
+func TestParseSpecificationNormalizesEndpoints(t *testing.T) {
+	// Postman collections can hold the same request in several folders
+	collection := `{
+		"info": {"_postman_id": "1", "name": "Dupes", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item": [
+			{"name": "Users", "item": [
+				{"name": "Delete user", "request": {"method": "DELETE", "url": "{{baseUrl}}/users/1"}},
+				{"name": "List users", "request": {"method": "GET", "url": "{{baseUrl}}/users"}}
+			]},
+			{"name": "Smoke", "item": [
+				{"name": "List users again", "request": {"method": "GET", "url": "{{baseUrl}}/users"}},
+				{"name": "Create user", "request": {"method": "POST", "url": "{{baseUrl}}/users"}}
+			]},
+			{"name": "Health", "request": {"method": "GET", "url": "{{baseUrl}}/health"}}
+		]
+	}`
+	path := filepath.Join(t.TempDir(), "collection.json")
+	if err := os.WriteFile(path, []byte(collection), 0644); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := ParseSpecification(path)
+	if err != nil {
+		t.Fatalf("ParseSpecification failed: %v", err)
+	}
+	var got []string
+	for _, ep := range spec.Endpoints {
+		got = append(got, ep.Method+" "+ep.Path)
+	}
+	want := []string{"GET /health", "GET /users", "POST /users", "DELETE /users/1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("endpoints = %v, want %v", got, want)
+	}
+	if spec.Endpoints[1].Description != "List users" {
+		t.Errorf("duplicate kept %q, want the first occurrence", spec.Endpoints[1].Description)
+	}
+
+	// OpenAPI paths come from a map, so they must not depend on iteration order
+	openapi := `{"openapi": "3.0.0", "paths": {
+		"/orders": {"post": {}, "get": {}},
+		"/accounts/{id}": {"delete": {}, "patch": {}, "get": {}},
+		"/accounts": {"get": {}},
+		"/zones": {"options": {}, "head": {}, "get": {}}
+	}}`
+	path = filepath.Join(t.TempDir(), "openapi.json")
+	if err := os.WriteFile(path, []byte(openapi), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var first []string
+	for i := range 20 {
+		spec, err := ParseSpecification(path)
+		if err != nil {
+			t.Fatalf("ParseSpecification failed: %v", err)
+		}
+		var order []string
+		for _, ep := range spec.Endpoints {
+			order = append(order, ep.Method+" "+ep.Path)
+		}
+		if i == 0 {
+			first = order
+			continue
+		}
+		if !reflect.DeepEqual(order, first) {
+			t.Fatalf("parse %d ordered endpoints %v, first parse %v", i, order, first)
+		}
+	}
+	want = []string{"GET /accounts", "GET /accounts/{id}", "PATCH /accounts/{id}", "DELETE /accounts/{id}", "GET /orders", "POST /orders", "GET /zones", "HEAD /zones", "OPTIONS /zones"}
+	if !reflect.DeepEqual(first, want) {
+		t.Errorf("endpoints = %v, want %v", first, want)
+	}
+}
@@ -0,0 +1,353 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// protoToken is a token of a .proto file with the comment written right before it
+type protoToken struct {
+	text    string
+	comment string
+}
+
+// protoMessage is a message definition: its fields as body parameters
+type protoMessage struct {
+	fields []Parameter
+}
+
+// protoParser walks the tokens of a .proto file
+type protoParser struct {
+	tokens   []protoToken
+	pos      int
+	pkg      string
+	messages map[string]*protoMessage // By name, nested messages as Outer.Inner
+}
+
+// parseProto maps the services of a Protocol Buffers definition to endpoints: each rpc becomes a
+// POST to /package.Service/Method, the path gRPC and gRPC-Web gateways use, with the request
+// message's name as the request body and its fields as body parameters.
+func parseProto(content string) (*Specification, error) {
+	p := &protoParser{tokens: tokenizeProto(content), messages: map[string]*protoMessage{}}
+	spec := &Specification{
+		Format:     "protobuf",
+		Version:    "proto2",
+		RawContent: content,
+		Endpoints:  []Endpoint{},
+	}
+
+	type rpc struct {
+		service  string
+		name     string
+		request  string
+		response string
+		comment  string
+		streams  []string
+	}
+	var rpcs []rpc
+
+	for !p.done() {
+		switch tok := p.next(); tok.text {
+		case "syntax", "edition":
+			p.expect("=")
+			spec.Version = strings.Trim(p.next().text, `"'`)
+			p.expect(";")
+		case "package":
+			p.pkg = p.next().text
+			p.expect(";")
+		case "message":
+			p.parseMessage("")
+		case "service":
+			service := p.next().text
+			if !p.expect("{") {
+				return nil, fmt.Errorf("failed to parse proto: expected { after service %s", service)
+			}
+			for !p.done() && p.peek() != "}" {
+				tok := p.next()
+				if tok.text != "rpc" {
+					p.skipStatement()
+					continue
+				}
+				r := rpc{service: service, name: p.next().text, comment: tok.comment}
+				var stream bool
+				r.request, stream = p.parseRPCType()
+				if stream {
+					r.streams = append(r.streams, "client")
+				}
+				if p.next().text != "returns" {
+					return nil, fmt.Errorf("failed to parse proto: expected returns in rpc %s.%s", service, r.name)
+				}
+				r.response, stream = p.parseRPCType()
+				if stream {
+					r.streams = append(r.streams, "server")
+				}
+				// Either ";" or a block of options
+				if p.peek() == "{" {
+					p.next()
+					p.skipBlock()
+				} else {
+					p.expect(";")
+				}
+				rpcs = append(rpcs, r)
+			}
+			p.expect("}")
+		default:
+			// enum, extend, option, import and anything unknown
+			p.skipStatement()
+		}
+	}
+
+	for _, r := range rpcs {
+		service := r.service
+		if p.pkg != "" {
+			service = p.pkg + "." + service
+		}
+		endpoint := Endpoint{
+			Method:      "POST",
+			Path:        "/" + service + "/" + r.name,
+			Description: r.comment,
+			RequestBody: r.request,
+			Responses:   map[string]string{"200": r.response},
+			AuthType:    "none",
+		}
+		if endpoint.Description == "" {
+			endpoint.Description = fmt.Sprintf("gRPC %s.%s", r.service, r.name)
+		}
+		if len(r.streams) > 0 {
+			endpoint.Description += fmt.Sprintf(" (%s streaming)", strings.Join(r.streams, " and "))
+		}
+		if message := p.lookupMessage(r.request); message != nil {
+			endpoint.Parameters = message.fields
+		}
+		spec.Endpoints = append(spec.Endpoints, endpoint)
+	}
+
+	if len(spec.Endpoints) == 0 {
+		return nil, fmt.Errorf("%w: the proto file defines no services", ErrNoEndpoints)
+	}
+	return spec, nil
+}
+
+// parseMessage reads a message body after the "message" keyword, registering it and its nested
+// messages under their dotted names
+func (p *protoParser) parseMessage(parent string) {
+	name := p.next().text
+	if parent != "" {
+		name = parent + "." + name
+	}
+	message := &protoMessage{}
+	p.messages[name] = message
+	if !p.expect("{") {
+		return
+	}
+	p.parseFields(name, message)
+}
+
+// parseFields reads the fields of a message or oneof block up to its closing brace
+func (p *protoParser) parseFields(name string, message *protoMessage) {
+	for !p.done() {
+		tok := p.next()
+		switch tok.text {
+		case "}":
+			return
+		case ";":
+		case "message":
+			p.parseMessage(name)
+		case "oneof":
+			p.next()
+			if p.expect("{") {
+				p.parseFields(name, message)
+			}
+		case "enum", "extend", "extensions", "reserved", "option":
+			p.skipStatement()
+		default:
+			// [repeated|optional|required] type name = number [options];
+			label, fieldType := "", tok.text
+			if fieldType == "repeated" || fieldType == "optional" || fieldType == "required" {
+				label, fieldType = fieldType, p.next().text
+			}
+			if fieldType == "map" {
+				fieldType = p.collectUntil(">")
+			}
+			fieldName := p.next().text
+			p.skipStatement()
+			if label == "repeated" {
+				fieldType = "repeated " + fieldType
+			}
+			message.fields = append(message.fields, Parameter{
+				Name:        fieldName,
+				In:          "body",
+				Type:        fieldType,
+				Required:    label == "required",
+				Description: tok.comment,
+			})
+		}
+	}
+}
+
+// parseRPCType reads "(Type)" or "(stream Type)" and returns the type and whether it streams
+func (p *protoParser) parseRPCType() (string, bool) {
+	p.expect("(")
+	name, stream := p.next().text, false
+	if name == "stream" && p.peek() != ")" {
+		name, stream = p.next().text, true
+	}
+	p.expect(")")
+	return strings.TrimPrefix(name, "."), stream
+}
+
+// lookupMessage finds a message by the name an rpc refers to it with, with or without the package
+func (p *protoParser) lookupMessage(name string) *protoMessage {
+	if message, ok := p.messages[name]; ok {
+		return message
+	}
+	if p.pkg != "" {
+		return p.messages[strings.TrimPrefix(name, p.pkg+".")]
+	}
+	return nil
+}
+
+// collectUntil joins the tokens up to and including end, e.g. "map<string, int32>"
+func (p *protoParser) collectUntil(end string) string {
+	var b strings.Builder
+	b.WriteString("map")
+	for !p.done() {
+		text := p.next().text
+		b.WriteString(text)
+		if text == "," {
+			b.WriteString(" ")
+		}
+		if text == end {
+			break
+		}
+	}
+	return b.String()
+}
+
+// skipStatement skips to the end of a statement: its ";" or its balanced block
+func (p *protoParser) skipStatement() {
+	for !p.done() {
+		switch p.next().text {
+		case ";":
+			return
+		case "{":
+			p.skipBlock()
+			return
+		}
+	}
+}
+
+// skipBlock skips past the "}" closing a block whose "{" was just read
+func (p *protoParser) skipBlock() {
+	for depth := 1; depth > 0 && !p.done(); {
+		switch p.next().text {
+		case "{":
+			depth++
+		case "}":
+			depth--
+		}
+	}
+}
+
+func (p *protoParser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *protoParser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos].text
+}
+
+func (p *protoParser) next() protoToken {
+	if p.done() {
+		return protoToken{}
+	}
+	p.pos++
+	return p.tokens[p.pos-1]
+}
+
+// expect consumes the next token if it is text and reports whether it was
+func (p *protoParser) expect(text string) bool {
+	if p.peek() != text {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+// tokenizeProto splits a .proto file into identifiers, literals and punctuation. Comments are
+// dropped, except that the lines of a comment are attached to the token that follows it.
+func tokenizeProto(content string) []protoToken {
+	var tokens []protoToken
+	var comment []string
+	lineHasToken := false
+	emit := func(text string) {
+		tokens = append(tokens, protoToken{text: text, comment: strings.Join(comment, " ")})
+		comment = nil
+		lineHasToken = true
+	}
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == '\n':
+			// A blank line detaches a comment from what follows
+			if line, _, _ := strings.Cut(content[i+1:], "\n"); strings.TrimSpace(line) == "" {
+				comment = nil
+			}
+			lineHasToken = false
+			i++
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.HasPrefix(content[i:], "//"):
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				end = len(content) - i
+			}
+			// Comments trailing a statement describe it, not the next one, and are dropped
+			if text := strings.TrimSpace(strings.TrimLeft(content[i:i+end], "/")); text != "" && !lineHasToken {
+				comment = append(comment, text)
+			}
+			i += end
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				end = len(content) - i - 2
+			}
+			for _, line := range strings.Split(content[i+2:i+2+end], "\n") {
+				if text := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "*")); text != "" {
+					comment = append(comment, text)
+				}
+			}
+			i += min(end+4, len(content)-i)
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(content) && content[j] != c {
+				if content[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			j = min(j+1, len(content))
+			emit(content[i:j])
+			i = j
+		case isProtoIdent(c):
+			j := i
+			for j < len(content) && (isProtoIdent(content[j]) || content[j] == '.') {
+				j++
+			}
+			emit(content[i:j])
+			i = j
+		default:
+			emit(string(c))
+			i++
+		}
+	}
+	return tokens
+}
+
+// isProtoIdent reports whether c can appear in an identifier or number
+func isProtoIdent(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || c == '+' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
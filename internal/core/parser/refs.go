@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// refResolver inlines local $ref references (e.g. #/components/schemas/User) using the document
+// they point into. A reference back to one that is still being resolved (a recursive schema) is
+// left as a $ref, and each reference is resolved once, so shared definitions don't multiply.
+type refResolver struct {
+	doc       map[string]any
+	resolving map[string]bool
+	resolved  map[string]any
+}
+
+func newRefResolver(doc map[string]any) *refResolver {
+	return &refResolver{
+		doc:       doc,
+		resolving: map[string]bool{},
+		resolved:  map[string]any{},
+	}
+}
+
+// resolve returns node with every resolvable local reference replaced by its target. The input is
+// not modified; resolved values may be shared between results and must be treated as read-only.
+func (r *refResolver) resolve(node any) any {
+	switch v := node.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			return r.resolveRef(ref, v)
+		}
+		out := make(map[string]any, len(v))
+		for key, child := range v {
+			out[key] = r.resolve(child)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, child := range v {
+			out[i] = r.resolve(child)
+		}
+		return out
+	}
+	return node
+}
+
+// resolveRef returns the resolved target of ref, or node itself for cycles and unknown references
+func (r *refResolver) resolveRef(ref string, node map[string]any) any {
+	if target, ok := r.resolved[ref]; ok {
+		return target
+	}
+	if r.resolving[ref] {
+		return node
+	}
+	target, ok := r.lookup(ref)
+	if !ok {
+		return node
+	}
+
+	r.resolving[ref] = true
+	resolved := r.resolve(target)
+	delete(r.resolving, ref)
+	r.resolved[ref] = resolved
+	return resolved
+}
+
+// deref follows references at the top of node only, leaving nested ones in place
+func (r *refResolver) deref(node any) any {
+	seen := map[string]bool{}
+	for {
+		nodeMap, ok := node.(map[string]any)
+		if !ok {
+			return node
+		}
+		ref, ok := nodeMap["$ref"].(string)
+		if !ok || seen[ref] {
+			return node
+		}
+		seen[ref] = true
+		target, ok := r.lookup(ref)
+		if !ok {
+			return node
+		}
+		node = target
+	}
+}
+
+// lookup follows a local JSON pointer reference (RFC 6901) from the document root
+func (r *refResolver) lookup(ref string) (any, bool) {
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil, false // external files and URLs aren't fetched
+	}
+
+	var node any = r.doc
+	for _, token := range strings.Split(pointer, "/") {
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		switch v := node.(type) {
+		case map[string]any:
+			if node, ok = v[token]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			node = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
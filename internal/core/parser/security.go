@@ -0,0 +1,207 @@
+package parser
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// scopedSchemeTypes are the security scheme types whose requirements list OAuth scopes
+var scopedSchemeTypes = map[string]bool{"oauth2": true, "openIdConnect": true}
+
+// securitySchemes returns the scheme definitions of an OpenAPI 3 (components.securitySchemes)
+// or Swagger 2 (securityDefinitions) document
+func securitySchemes(doc map[string]any) map[string]any {
+	if components, ok := doc["components"].(map[string]any); ok {
+		if schemes, ok := components["securitySchemes"].(map[string]any); ok {
+			return schemes
+		}
+	}
+	if schemes, ok := doc["securityDefinitions"].(map[string]any); ok {
+		return schemes
+	}
+	return nil
+}
+
+// AuthScheme is a security scheme declared by the spec, in the terms of the project auth config
+type AuthScheme struct {
+	Name     string   `json:"name"`                // Key under components.securitySchemes or securityDefinitions
+	Type     string   `json:"type"`                // "bearer", "basic", "digest", "apikey", "oauth2"
+	KeyName  string   `json:"key_name,omitempty"`  // API key header, query or cookie parameter name
+	Location string   `json:"location,omitempty"`  // API key location: header, query or cookie
+	TokenURL string   `json:"token_url,omitempty"` // OAuth2 client credentials token endpoint
+	Scopes   []string `json:"scopes,omitempty"`    // OAuth2 scopes required across the spec
+}
+
+// applySecurity resolves each operation's security requirements, falling back to the document-level
+// ones: it sets RequiresAuth and AuthType from the first requirement, fills Scopes, and lists the
+// schemes in use on spec.AuthSchemes, most required first. An empty requirement list (security: [])
+// or an empty requirement ({}) makes an operation public. Operations with no requirements at all are
+// left undetermined.
+func applySecurity(spec *Specification, global any, schemes map[string]any) {
+	uses := map[string]int{}
+	scopes := map[string][]string{}
+
+	for i := range spec.Endpoints {
+		ep := &spec.Endpoints[i]
+		requirements := ep.security
+		if requirements == nil {
+			requirements = global
+		}
+		ep.security = nil
+		ep.Scopes = requiredScopes(requirements, schemes)
+
+		list, ok := requirements.([]any)
+		if !ok {
+			continue
+		}
+		for _, requirement := range list {
+			reqMap, _ := requirement.(map[string]any)
+			for name, values := range reqMap {
+				uses[name]++
+				if values, ok := values.([]any); ok {
+					for _, v := range values {
+						if scope, ok := v.(string); ok && scope != "" {
+							scopes[name] = append(scopes[name], scope)
+						}
+					}
+				}
+			}
+		}
+
+		if len(list) == 0 || slices.ContainsFunc(list, isEmptyRequirement) {
+			ep.RequiresAuth, ep.AuthType = false, "none"
+			continue
+		}
+		ep.RequiresAuth = true
+		ep.AuthType = requirementAuthType(list[0], schemes)
+	}
+
+	for name := range uses {
+		scheme, ok := authScheme(name, schemes[name])
+		if !ok {
+			continue
+		}
+		sort.Strings(scopes[name])
+		scheme.Scopes = slices.Compact(scopes[name])
+		spec.AuthSchemes = append(spec.AuthSchemes, scheme)
+	}
+	sort.Slice(spec.AuthSchemes, func(i, j int) bool {
+		a, b := spec.AuthSchemes[i], spec.AuthSchemes[j]
+		if uses[a.Name] != uses[b.Name] {
+			return uses[a.Name] > uses[b.Name]
+		}
+		return a.Name < b.Name
+	})
+}
+
+// requirementAuthType returns the auth type of the first known scheme in a requirement, by name
+func requirementAuthType(requirement any, schemes map[string]any) string {
+	reqMap, _ := requirement.(map[string]any)
+	names := make([]string, 0, len(reqMap))
+	for name := range reqMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if authType := authType(schemes[name]); authType != "" {
+			return authType
+		}
+	}
+	return ""
+}
+
+// isEmptyRequirement reports whether a security requirement is {}, which makes auth optional
+func isEmptyRequirement(requirement any) bool {
+	reqMap, ok := requirement.(map[string]any)
+	return ok && len(reqMap) == 0
+}
+
+// authType maps a scheme definition to an auth type: http bearer and basic, apiKey, and OAuth2.
+// OpenID Connect tokens are sent as bearer tokens. Unknown schemes give an empty type.
+func authType(definition any) string {
+	scheme, _ := definition.(map[string]any)
+	schemeType, _ := scheme["type"].(string)
+	switch schemeType {
+	case "http":
+		httpScheme, _ := scheme["scheme"].(string)
+		switch strings.ToLower(httpScheme) {
+		case "bearer":
+			return "bearer"
+		case "basic":
+			return "basic"
+		case "digest":
+			return "digest"
+		}
+	case "basic": // Swagger 2
+		return "basic"
+	case "apiKey":
+		return "apikey"
+	case "oauth2":
+		return "oauth2"
+	case "openIdConnect":
+		return "bearer"
+	}
+	return ""
+}
+
+// authScheme describes a named scheme definition, reporting false for unknown scheme types
+func authScheme(name string, definition any) (AuthScheme, bool) {
+	scheme := AuthScheme{Name: name, Type: authType(definition)}
+	if scheme.Type == "" {
+		return scheme, false
+	}
+
+	details, _ := definition.(map[string]any)
+	switch scheme.Type {
+	case "apikey":
+		scheme.KeyName, _ = details["name"].(string)
+		scheme.Location, _ = details["in"].(string)
+	case "oauth2":
+		scheme.TokenURL, _ = details["tokenUrl"].(string) // Swagger 2 application flow
+		if flows, ok := details["flows"].(map[string]any); ok {
+			if flow, ok := flows["clientCredentials"].(map[string]any); ok {
+				scheme.TokenURL, _ = flow["tokenUrl"].(string)
+			}
+		}
+	}
+	return scheme, true
+}
+
+// requiredScopes returns the sorted scopes of the first security requirement that uses a scoped scheme
+func requiredScopes(requirements any, schemes map[string]any) []string {
+	list, ok := requirements.([]any)
+	if !ok {
+		return nil
+	}
+
+	for _, requirement := range list {
+		reqMap, ok := requirement.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		var scopes []string
+		scoped := false
+		for name, values := range reqMap {
+			scheme, _ := schemes[name].(map[string]any)
+			if schemeType, _ := scheme["type"].(string); !scopedSchemeTypes[schemeType] {
+				continue
+			}
+			scoped = true
+			if values, ok := values.([]any); ok {
+				for _, v := range values {
+					if s, ok := v.(string); ok && s != "" {
+						scopes = append(scopes, s)
+					}
+				}
+			}
+		}
+		if scoped {
+			sort.Strings(scopes)
+			return slices.Compact(scopes)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// serverURLs extracts the server URLs of an OpenAPI 3 document (servers, with variables set to
+// their defaults) or a Swagger 2.0 one (schemes, host and basePath)
+func serverURLs(openapi map[string]any) []string {
+	if servers, ok := openapi["servers"].([]any); ok {
+		var urls []string
+		for _, s := range servers {
+			server, _ := s.(map[string]any)
+			raw, _ := server["url"].(string)
+			if raw == "" {
+				continue
+			}
+			variables, _ := server["variables"].(map[string]any)
+			for name, v := range variables {
+				variable, _ := v.(map[string]any)
+				if def, ok := variable["default"]; ok {
+					raw = strings.ReplaceAll(raw, "{"+name+"}", fmt.Sprint(def))
+				}
+			}
+			urls = append(urls, raw)
+		}
+		return urls
+	}
+
+	host, _ := openapi["host"].(string)
+	basePath, _ := openapi["basePath"].(string)
+	if host == "" && basePath == "" {
+		return nil
+	}
+	if host == "" {
+		return []string{basePath}
+	}
+	scheme := "https"
+	if schemes, ok := openapi["schemes"].([]any); ok && len(schemes) > 0 {
+		if s, ok := schemes[0].(string); ok {
+			scheme = s
+		}
+	}
+	return []string{scheme + "://" + host + basePath}
+}
+
+// BasePath returns the path prefix of the spec's first server, e.g. /api/v1, or "" when paths
+// are relative to the root
+func (s *Specification) BasePath() string {
+	if len(s.ServerURLs) == 0 {
+		return ""
+	}
+	u, err := url.Parse(s.ServerURLs[0])
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(u.Path, "/")
+}
+
+// ApplyBasePath appends the spec's base path to baseURL so the spec's relative paths resolve
+// against the live API, unless baseURL already includes it. It also returns warnings about
+// conflicts between baseURL and the spec's servers.
+func ApplyBasePath(baseURL string, spec *Specification) (string, []string) {
+	var warnings []string
+	base, err := url.Parse(baseURL)
+	if err != nil || spec == nil {
+		return baseURL, nil
+	}
+
+	var hosts []string
+	for _, server := range spec.ServerURLs {
+		if u, err := url.Parse(server); err == nil && u.Host != "" {
+			if strings.EqualFold(u.Host, base.Host) {
+				hosts = nil
+				break
+			}
+			hosts = append(hosts, u.Host)
+		}
+	}
+	if len(hosts) > 0 && base.Host != "" {
+		warnings = append(warnings, fmt.Sprintf("base URL host %s isn't one of the spec's servers (%s)", base.Host, strings.Join(hosts, ", ")))
+	}
+
+	basePath := spec.BasePath()
+	current := strings.TrimSuffix(base.Path, "/")
+	switch {
+	case basePath == "", current == basePath, strings.HasSuffix(current, basePath):
+		return baseURL, warnings
+	case current != "":
+		warnings = append(warnings, fmt.Sprintf("base URL path %s differs from the spec's base path %s; leaving the base URL unchanged", current, basePath))
+		return baseURL, warnings
+	}
+	return strings.TrimSuffix(baseURL, "/") + basePath, warnings
+}
+
+// AbsoluteServerURLs returns the declared servers that can serve as a base URL: absolute URLs
+// with a host, in the spec's order. Servers still holding a variable without a default are skipped.
+func (s *Specification) AbsoluteServerURLs() []string {
+	var urls []string
+	for _, server := range s.ServerURLs {
+		if strings.ContainsAny(server, "{}") {
+			continue
+		}
+		u, err := url.Parse(server)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		urls = append(urls, strings.TrimSuffix(server, "/"))
+	}
+	return urls
+}
+
+// DetectServers parses the spec at path and returns the base URLs its servers declare, so the
+// API URL can be left out when the spec already names it
+func DetectServers(path string) ([]string, error) {
+	spec, err := ParseSpecification(path)
+	if err != nil {
+		return nil, err
+	}
+	return spec.AbsoluteServerURLs(), nil
+}
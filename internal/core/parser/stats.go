@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SpecStats summarizes the shape of a parsed specification
+type SpecStats struct {
+	Total           int
+	ByMethod        map[string]int
+	RequiresAuth    int
+	WithRequestBody int
+	WithResponses   int
+}
+
+// ComputeStats computes summary statistics from parsed endpoints
+func ComputeStats(endpoints []Endpoint) SpecStats {
+	stats := SpecStats{
+		Total:    len(endpoints),
+		ByMethod: make(map[string]int),
+	}
+	for _, ep := range endpoints {
+		stats.ByMethod[strings.ToUpper(ep.Method)]++
+		if ep.RequiresAuth {
+			stats.RequiresAuth++
+		}
+		if ep.RequestBody != "" {
+			stats.WithRequestBody++
+		}
+		if len(ep.Responses) > 0 {
+			stats.WithResponses++
+		}
+	}
+	return stats
+}
+
+// Summary returns a short human-readable description of the stats
+func (s SpecStats) Summary() string {
+	methods := make([]string, 0, len(s.ByMethod))
+	for method := range s.ByMethod {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	parts := make([]string, 0, len(methods))
+	for _, method := range methods {
+		parts = append(parts, fmt.Sprintf("%s %d", method, s.ByMethod[method]))
+	}
+
+	summary := fmt.Sprintf("%d endpoints", s.Total)
+	if len(parts) > 0 {
+		summary += " (" + strings.Join(parts, ", ") + ")"
+	}
+	return fmt.Sprintf("%s · %d require auth · %d with request body · %d with documented responses",
+		summary, s.RequiresAuth, s.WithRequestBody, s.WithResponses)
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"mime"
+	"path"
+	"sort"
+	"strings"
+)
+
+// XMLElement describes the root element of an XML request body, from the schema's xml object
+type XMLElement struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+}
+
+// IsXMLMediaType reports whether a media type or Content-Type header carries XML
+// (application/xml, text/xml, *+xml)
+func IsXMLMediaType(mediaType string) bool {
+	if parsed, _, err := mime.ParseMediaType(mediaType); err == nil {
+		mediaType = parsed
+	}
+	mediaType = strings.ToLower(mediaType)
+	return mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
+
+// requestMediaTypes returns the sorted media types an operation's request body accepts, from
+// requestBody.content (OpenAPI 3) or consumes (Swagger 2), and the XML root element when one is XML.
+// details is the unresolved operation, so schema references still carry the component name.
+func requestMediaTypes(details map[string]any, resolver *refResolver) ([]string, *XMLElement) {
+	schemas := map[string]any{}
+
+	if requestBody, ok := resolver.deref(details["requestBody"]).(map[string]any); ok {
+		if content, ok := requestBody["content"].(map[string]any); ok {
+			for mediaType, media := range content {
+				mediaMap, _ := resolver.deref(media).(map[string]any)
+				schemas[mediaType] = mediaMap["schema"]
+			}
+		}
+	}
+
+	if consumes, ok := details["consumes"].([]any); ok {
+		var bodySchema any
+		if params, ok := details["parameters"].([]any); ok {
+			for _, p := range params {
+				if param, ok := resolver.deref(p).(map[string]any); ok && param["in"] == "body" {
+					bodySchema = param["schema"]
+				}
+			}
+		}
+		for _, c := range consumes {
+			if mediaType, ok := c.(string); ok && mediaType != "" {
+				schemas[mediaType] = bodySchema
+			}
+		}
+	}
+
+	if len(schemas) == 0 {
+		return nil, nil
+	}
+
+	mediaTypes := make([]string, 0, len(schemas))
+	for mediaType := range schemas {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+
+	for _, mediaType := range mediaTypes {
+		if !IsXMLMediaType(mediaType) {
+			continue
+		}
+		if root := xmlRoot(schemas[mediaType], resolver); root != nil {
+			return mediaTypes, root
+		}
+	}
+	return mediaTypes, nil
+}
+
+// xmlRoot reads a schema's xml object. Without an explicit name, the element is named
+// after the referenced component, as OpenAPI specifies.
+func xmlRoot(schema any, resolver *refResolver) *XMLElement {
+	schemaMap, ok := schema.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	root := &XMLElement{}
+	if ref, ok := schemaMap["$ref"].(string); ok {
+		root.Name = path.Base(ref)
+		// An xml object beside the $ref wins; otherwise the referenced schema's own applies
+		if _, ok := schemaMap["xml"]; !ok {
+			if target, ok := resolver.deref(schemaMap).(map[string]any); ok {
+				schemaMap = target
+			}
+		}
+	}
+	if xmlObj, ok := schemaMap["xml"].(map[string]any); ok {
+		if name, ok := xmlObj["name"].(string); ok && name != "" {
+			root.Name = name
+		}
+		root.Namespace, _ = xmlObj["namespace"].(string)
+		root.Prefix, _ = xmlObj["prefix"].(string)
+	}
+
+	if root.Name == "" {
+		return nil
+	}
+	return root
+}
@@ -0,0 +1,82 @@
+package reporter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Octrafic/octrafic-cli/internal/llm/common"
+)
+
+// CostSection renders a Markdown "Cost" section summarizing LLM token usage and its estimated
+// spend by operation. Models missing from the price table count towards tokens but not cost.
+func CostSection(usage []common.Usage) string {
+	if len(usage) == 0 {
+		return ""
+	}
+
+	type row struct {
+		operation     string
+		calls         int
+		input, output int64
+		cost          float64
+		priced        bool
+	}
+	var rows []*row
+	byOperation := map[string]*row{}
+	total := &row{operation: "**Total**", priced: true}
+	var unpriced []string
+
+	for _, u := range usage {
+		r, ok := byOperation[u.Operation]
+		if !ok {
+			r = &row{operation: u.Operation, priced: true}
+			byOperation[u.Operation] = r
+			rows = append(rows, r)
+		}
+		for _, target := range []*row{r, total} {
+			target.calls += u.Calls
+			target.input += u.InputTokens
+			target.output += u.OutputTokens
+		}
+
+		price, ok := common.LookupPrice(u.Model)
+		if !ok {
+			r.priced, total.priced = false, false
+			if !slices.Contains(unpriced, u.Model) {
+				unpriced = append(unpriced, u.Model)
+			}
+			continue
+		}
+		cost := price.Cost(u.InputTokens, u.OutputTokens)
+		r.cost += cost
+		total.cost += cost
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\n## Cost\n\n")
+	b.WriteString("| Operation | Calls | Input tokens | Output tokens | Estimated cost |\n")
+	b.WriteString("|---|---:|---:|---:|---:|\n")
+	for _, r := range append(rows, total) {
+		cost := fmt.Sprintf("$%.4f", r.cost)
+		if !r.priced {
+			cost = "n/a"
+			if r.cost > 0 {
+				cost = fmt.Sprintf("≥ $%.4f", r.cost)
+			}
+		}
+		fmt.Fprintf(&b, "| %s | %d | %d | %d | %s |\n", r.operation, r.calls, r.input, r.output, cost)
+	}
+
+	b.WriteString("\nEstimates use list prices per million tokens and exclude discounts, caching and taxes.")
+	if len(unpriced) > 0 {
+		for i, model := range unpriced {
+			if model == "" {
+				unpriced[i] = "default model"
+			}
+		}
+		fmt.Fprintf(&b, " No price is known for: %s.", strings.Join(unpriced, ", "))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
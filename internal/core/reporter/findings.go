@@ -0,0 +1,63 @@
+package reporter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Severities a finding can have, most severe first
+var Severities = []string{"critical", "high", "medium", "low", "info"}
+
+// Finding is an issue the agent recorded while testing
+type Finding struct {
+	Severity    string
+	Endpoint    string // e.g. "POST /users"; empty for API-wide findings
+	Description string
+}
+
+// SeverityRank orders severities from most (0) to least severe; unknown ones sort last
+func SeverityRank(severity string) int {
+	if i := slices.Index(Severities, severity); i >= 0 {
+		return i
+	}
+	return len(Severities)
+}
+
+// SortFindings orders findings by severity, keeping the recorded order within a severity
+func SortFindings(findings []Finding) []Finding {
+	sorted := slices.Clone(findings)
+	slices.SortStableFunc(sorted, func(a, b Finding) int {
+		return SeverityRank(a.Severity) - SeverityRank(b.Severity)
+	})
+	return sorted
+}
+
+// FindingsSection renders a Markdown "Issues" section listing findings by severity
+func FindingsSection(findings []Finding) string {
+	if len(findings) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\n## Issues\n\n")
+	b.WriteString("| Severity | Endpoint | Description |\n")
+	b.WriteString("|---|---|---|\n")
+	for _, f := range SortFindings(findings) {
+		endpoint := f.Endpoint
+		if endpoint == "" {
+			endpoint = "—"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", f.Severity, tableCell(endpoint), tableCell(f.Description))
+	}
+	return b.String()
+}
+
+// cellEscaper escapes a table cell's pipes, and its angle brackets so markup shows as text
+var cellEscaper = strings.NewReplacer("|", `\|`, "<", "&lt;", ">", "&gt;")
+
+// tableCell keeps text on one line inside a Markdown table cell
+func tableCell(s string) string {
+	s = cellEscaper.Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
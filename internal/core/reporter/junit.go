@@ -0,0 +1,131 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// TestResult is an executed test as exported to CI formats
+type TestResult struct {
+	Name           string // Optional test name; "METHOD endpoint" is used without one
+	Method         string
+	Endpoint       string
+	StatusCode     int
+	ExpectedStatus int // Status the test expects; 0 accepts any status below 400
+	Duration       time.Duration
+	Error          string   // Why the request itself failed, e.g. a timeout
+	Failures       []string // Failed assertions and checks
+	Skipped        string   // Why the test didn't run; empty when it ran
+}
+
+// Failed reports whether a test that ran got an unexpected status, failed a check or didn't complete
+func (r TestResult) Failed() bool {
+	return r.Skipped == "" && (r.Error != "" || r.StatusFailure() != "" || len(r.Failures) > 0)
+}
+
+// StatusFailure describes why the response status fails the test, or returns "" when it doesn't
+func (r TestResult) StatusFailure() string {
+	switch {
+	case r.ExpectedStatus != 0 && r.StatusCode != r.ExpectedStatus:
+		return fmt.Sprintf("status %d, expected %d", r.StatusCode, r.ExpectedStatus)
+	case r.ExpectedStatus == 0 && r.StatusCode >= 400:
+		return fmt.Sprintf("status %d", r.StatusCode)
+	}
+	return ""
+}
+
+type junitSuite struct {
+	XMLName   xml.Name    `xml:"testsuite"`
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Errors    int         `xml:"errors,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	Cases     []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnitXML renders results as a JUnit <testsuite>: one <testcase> per test, classed by endpoint.
+// Request errors are <error>s; unexpected statuses and failed checks are <failure>s.
+func JUnitXML(results []TestResult) ([]byte, error) {
+	suite := junitSuite{
+		Name:      "octrafic",
+		Tests:     len(results),
+		Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05"),
+	}
+
+	var total time.Duration
+	for _, r := range results {
+		total += r.Duration
+		name := r.Name
+		if name == "" {
+			name = strings.TrimSpace(r.Method + " " + r.Endpoint)
+		}
+		tc := junitCase{Name: name, Classname: r.Endpoint, Time: junitSeconds(r.Duration)}
+
+		switch {
+		case r.Skipped != "":
+			suite.Skipped++
+			tc.Skipped = &junitMessage{Message: r.Skipped}
+		case r.Error != "":
+			suite.Errors++
+			tc.Error = &junitMessage{Message: r.Error, Type: "RequestError"}
+		case r.Failed():
+			suite.Failures++
+			failures := r.Failures
+			if status := r.StatusFailure(); status != "" {
+				failures = append([]string{status}, failures...)
+			}
+			tc.Failure = &junitMessage{Message: failures[0], Type: "AssertionError", Text: strings.Join(failures, "\n")}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	suite.Time = junitSeconds(total)
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// GenerateJUnit writes results as a JUnit XML file for CI systems.
+// It returns the absolute path to the generated file.
+func GenerateJUnit(results []TestResult, outputPath string) (string, error) {
+	data, err := JUnitXML(results)
+	if err != nil {
+		return "", err
+	}
+	absPath, err := reportPath(outputPath, "xml")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(absPath, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return absPath, nil
+}
+
+// junitSeconds formats a duration as JUnit's decimal seconds
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
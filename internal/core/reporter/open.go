@@ -0,0 +1,49 @@
+package reporter
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// ErrNoViewer is returned when there is no desktop viewer available (headless or CI environments)
+var ErrNoViewer = errors.New("no viewer available in this environment")
+
+// OpenFile opens a file in the default viewer for the current OS
+func OpenFile(path string) error {
+	if isHeadless() {
+		return ErrNoViewer
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", path)
+	case "windows":
+		cmd = exec.Command("cmd", "/c", "start", "", path)
+	default:
+		if _, err := exec.LookPath("xdg-open"); err != nil {
+			return ErrNoViewer
+		}
+		cmd = exec.Command("xdg-open", path)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Reap the launcher process without blocking the caller
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// isHeadless reports whether we are running in CI or without a graphical session
+func isHeadless() bool {
+	if os.Getenv("CI") != "" {
+		return true
+	}
+	if runtime.GOOS == "linux" || runtime.GOOS == "freebsd" {
+		return os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == ""
+	}
+	return false
+}
@@ -6,11 +6,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
-	"github.com/yuin/goldmark/renderer/html"
 )
 
 const htmlTemplate = `<!DOCTYPE html>
@@ -157,6 +157,9 @@ const htmlTemplate = `<!DOCTYPE html>
 </body>
 </html>`
 
+// Formats lists the report formats this build can generate
+var Formats = []string{"pdf", "html", "junit"}
+
 // CheckWeasyPrint checks if weasyprint is installed and available in PATH.
 func CheckWeasyPrint() error {
 	_, err := exec.LookPath("weasyprint")
@@ -173,34 +176,15 @@ func GeneratePDF(markdownContent string, outputPath string) (string, error) {
 		return "", err
 	}
 
-	// Convert markdown to HTML using goldmark
-	md := goldmark.New(
-		goldmark.WithExtensions(extension.GFM),
-		goldmark.WithRendererOptions(html.WithUnsafe()),
-	)
-
-	var htmlBody bytes.Buffer
-	if err := md.Convert([]byte(markdownContent), &htmlBody); err != nil {
-		return "", fmt.Errorf("failed to convert markdown to HTML: %w", err)
-	}
-
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	fullHTML := fmt.Sprintf(htmlTemplate, htmlBody.String(), timestamp)
-
-	// Always save reports to ~/Documents/octrafic/
-	homeDir, err := os.UserHomeDir()
+	fullHTML, err := renderHTML(markdownContent)
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
-	}
-	docsDir := filepath.Join(homeDir, "Documents", "octrafic")
-	if err := os.MkdirAll(docsDir, 0o755); err != nil {
-		return "", fmt.Errorf("failed to create output directory: %w", err)
+		return "", err
 	}
 
-	if outputPath == "" {
-		outputPath = fmt.Sprintf("octrafic-report-%s.pdf", time.Now().Format("2006-01-02_150405"))
+	absPath, err := reportPath(outputPath, "pdf")
+	if err != nil {
+		return "", err
 	}
-	absPath := filepath.Join(docsDir, filepath.Base(outputPath))
 
 	// Write HTML to temp file
 	tmpFile, err := os.CreateTemp("", "octrafic-report-*.html")
@@ -223,3 +207,56 @@ func GeneratePDF(markdownContent string, outputPath string) (string, error) {
 
 	return absPath, nil
 }
+
+// GenerateHTML converts markdown content to a standalone HTML file styled like the PDF report.
+// Unlike GeneratePDF it needs no external tools. It returns the absolute path to the file.
+func GenerateHTML(markdownContent string, outputPath string) (string, error) {
+	fullHTML, err := renderHTML(markdownContent)
+	if err != nil {
+		return "", err
+	}
+
+	absPath, err := reportPath(outputPath, "html")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(absPath, []byte(fullHTML), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	return absPath, nil
+}
+
+// renderHTML converts markdown content to the full report HTML document
+func renderHTML(markdownContent string) (string, error) {
+	// Raw HTML in the Markdown is left out: the content comes from the LLM and from API responses,
+	// and the report is opened in a browser
+	md := goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	var htmlBody bytes.Buffer
+	if err := md.Convert([]byte(markdownContent), &htmlBody); err != nil {
+		return "", fmt.Errorf("failed to convert markdown to HTML: %w", err)
+	}
+
+	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	return fmt.Sprintf(htmlTemplate, htmlBody.String(), timestamp), nil
+}
+
+// reportPath returns where a report named outputPath is saved: always in ~/Documents/octrafic/,
+// with the extension of the format. An empty name gets a timestamped one.
+func reportPath(outputPath, format string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	docsDir := filepath.Join(homeDir, "Documents", "octrafic")
+	if err := os.MkdirAll(docsDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	name := filepath.Base(outputPath)
+	if outputPath == "" {
+		name = "octrafic-report-" + time.Now().Format("2006-01-02_150405")
+	}
+	name = strings.TrimSuffix(name, filepath.Ext(name)) + "." + format
+	return filepath.Join(docsDir, name), nil
+}
@@ -0,0 +1,172 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Octrafic/octrafic-cli/internal/llm/common"
+)
+
+func TestGenerateHTML(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	report := `# Users API Report
+
+| Method | Endpoint | Status |
+|--------|----------|--------|
+| GET | /users | 200 |
+| POST | /users | 201 |
+`
+	path, err := GenerateHTML(report, "users-report.pdf")
+	if err != nil {
+		t.Fatalf("GenerateHTML() error = %v", err)
+	}
+	if want := filepath.Join(home, "Documents", "octrafic", "users-report.html"); path != want {
+		t.Errorf("GenerateHTML() path = %s, want %s", path, want)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	html := string(data)
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<h1>Users API Report</h1>",
+		"<td>GET</td>\n<td>/users</td>\n<td>200</td>",
+		"<td>POST</td>\n<td>/users</td>\n<td>201</td>",
+		"Generated by <strong>Octrafic</strong>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML report is missing %q", want)
+		}
+	}
+}
+
+func TestGenerateJUnit(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	path, err := GenerateJUnit([]TestResult{
+		{Method: "GET", Endpoint: "/users", StatusCode: 200, Duration: 120 * time.Millisecond},
+		{Name: "create user", Method: "POST", Endpoint: "/users", StatusCode: 422, Duration: 80 * time.Millisecond,
+			Failures: []string{"$.id: expected 1, got <missing>"}},
+	}, "ci-results")
+	if err != nil {
+		t.Fatalf("GenerateJUnit() error = %v", err)
+	}
+	if filepath.Ext(path) != ".xml" {
+		t.Errorf("GenerateJUnit() path = %s, want an .xml file", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	var suite struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Cases    []struct {
+			Name      string `xml:"name,attr"`
+			Classname string `xml:"classname,attr"`
+			Time      string `xml:"time,attr"`
+			Failure   *struct {
+				Message string `xml:"message,attr"`
+				Text    string `xml:",chardata"`
+			} `xml:"failure"`
+		} `xml:"testcase"`
+	}
+	if err := xml.Unmarshal(data, &suite); err != nil {
+		t.Fatalf("report isn't valid XML: %v\n%s", err, data)
+	}
+	if suite.Tests != 2 || suite.Failures != 1 || len(suite.Cases) != 2 {
+		t.Fatalf("suite = %d tests, %d failures, %d cases; want 2, 1, 2", suite.Tests, suite.Failures, len(suite.Cases))
+	}
+
+	pass, fail := suite.Cases[0], suite.Cases[1]
+	if pass.Name != "GET /users" || pass.Classname != "/users" || pass.Time != "0.120" || pass.Failure != nil {
+		t.Errorf("passing case = %+v", pass)
+	}
+	if fail.Name != "create user" || fail.Failure == nil || fail.Failure.Message != "status 422" ||
+		!strings.Contains(fail.Failure.Text, "$.id: expected 1") {
+		t.Errorf("failing case = %+v", fail)
+	}
+}
+
+func TestCostSection(t *testing.T) {
+	if got := CostSection(nil); got != "" {
+		t.Errorf("CostSection(nil) = %q, want no section", got)
+	}
+
+	got := CostSection([]common.Usage{
+		{Operation: "test_generation", Model: "gpt-4o", Calls: 2, InputTokens: 1_000_000, OutputTokens: 100_000},
+		{Operation: "test_generation", Model: "gpt-4o-2024-08-06", Calls: 1, InputTokens: 200_000},
+		{Operation: "spec_processing", Model: "llama3", Calls: 1, InputTokens: 1000, OutputTokens: 500},
+		{Operation: "spec_processing", Model: "anthropic/claude-sonnet-4-20250514", Calls: 1, InputTokens: 1_000_000},
+		{Operation: "chat", Calls: 1, InputTokens: 10, OutputTokens: 10},
+	})
+
+	for _, want := range []string{
+		"## Cost",
+		"| Operation | Calls | Input tokens | Output tokens | Estimated cost |",
+		"| test_generation | 3 | 1200000 | 100000 | $4.0000 |",
+		// Partly priced rows give a lower bound, rows without any price none at all
+		"| spec_processing | 2 | 1001000 | 500 | ≥ $3.0000 |",
+		"| chat | 1 | 10 | 10 | n/a |",
+		"| **Total** | 6 | 2201010 | 100510 | ≥ $7.0000 |",
+		"No price is known for: llama3, default model.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("cost section is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestFindingsSection(t *testing.T) {
+	if got := FindingsSection(nil); got != "" {
+		t.Errorf("FindingsSection(nil) = %q, want no section", got)
+	}
+
+	got := FindingsSection([]Finding{
+		{Severity: "low", Endpoint: "GET /users", Description: "No pagination"},
+		{Severity: "critical", Description: "TLS 1.0 is\naccepted"},
+		{Severity: "low", Endpoint: "GET /a|b", Description: "Second low"},
+	})
+	want := "\n\n## Issues\n\n" +
+		"| Severity | Endpoint | Description |\n" +
+		"|---|---|---|\n" +
+		"| critical | — | TLS 1.0 is accepted |\n" +
+		"| low | GET /users | No pagination |\n" +
+		"| low | GET /a\\|b | Second low |\n"
+	if got != want {
+		t.Errorf("FindingsSection() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGenerateHTMLEscapesMarkup(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	report := "# Report\n\n<img src=x onerror=alert(1)>\n\nBody: <script>alert(2)</script>" +
+		FindingsSection([]Finding{{Severity: "high", Endpoint: "GET /search", Description: "Reflects <script>alert(3)</script> unescaped"}})
+	path, err := GenerateHTML(report, "xss")
+	if err != nil {
+		t.Fatalf("GenerateHTML() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(data)
+	for _, unwanted := range []string{"<script", "<img"} {
+		if strings.Contains(html, unwanted) {
+			t.Errorf("HTML report contains raw %s markup", unwanted)
+		}
+	}
+	if !strings.Contains(html, "Reflects &lt;script&gt;alert(3)&lt;/script&gt; unescaped") {
+		t.Error("the finding's markup isn't shown as text")
+	}
+}
@@ -0,0 +1,130 @@
+package tester
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// Assertion checks that the value at a JSONPath in the response body equals an expected value
+type Assertion struct {
+	Path   string `json:"path" yaml:"path"`
+	Equals any    `json:"equals" yaml:"equals"`
+}
+
+// AssertionFailure describes an assertion that did not hold
+type AssertionFailure struct {
+	Assertion Assertion
+	Actual    string
+	Message   string
+}
+
+// ParseAssertions converts a decoded "expect" value (JSON/YAML) into assertions
+func ParseAssertions(raw any) []Assertion {
+	switch v := raw.(type) {
+	case []Assertion:
+		return v
+	case []any:
+		assertions := make([]Assertion, 0, len(v))
+		for _, item := range v {
+			m, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			path, _ := m["path"].(string)
+			if path == "" {
+				continue
+			}
+			assertions = append(assertions, Assertion{Path: path, Equals: m["equals"]})
+		}
+		return assertions
+	case []map[string]any:
+		items := make([]any, len(v))
+		for i, m := range v {
+			items[i] = m
+		}
+		return ParseAssertions(items)
+	}
+	return nil
+}
+
+// EvaluateAssertions checks every assertion against a JSON response body and returns the failures
+func EvaluateAssertions(body string, assertions []Assertion) []AssertionFailure {
+	var failures []AssertionFailure
+	if len(assertions) == 0 {
+		return nil
+	}
+
+	if !gjson.Valid(body) {
+		for _, a := range assertions {
+			failures = append(failures, AssertionFailure{
+				Assertion: a,
+				Message:   fmt.Sprintf("%s: response body is not valid JSON", a.Path),
+			})
+		}
+		return failures
+	}
+
+	for _, a := range assertions {
+		result := gjson.Get(body, jsonPathToGJSON(a.Path))
+		if !result.Exists() {
+			failures = append(failures, AssertionFailure{
+				Assertion: a,
+				Message:   fmt.Sprintf("%s: path not found", a.Path),
+			})
+			continue
+		}
+
+		expected, err := json.Marshal(a.Equals)
+		if err != nil {
+			failures = append(failures, AssertionFailure{
+				Assertion: a,
+				Actual:    result.Raw,
+				Message:   fmt.Sprintf("%s: invalid expected value: %v", a.Path, err),
+			})
+			continue
+		}
+		actual, err := json.Marshal(result.Value())
+		if err != nil || string(actual) != string(expected) {
+			failures = append(failures, AssertionFailure{
+				Assertion: a,
+				Actual:    result.Raw,
+				Message:   fmt.Sprintf("%s: expected %s, got %s", a.Path, expected, result.Raw),
+			})
+		}
+	}
+	return failures
+}
+
+// jsonPathToGJSON translates a simple JSONPath ($.a.b[0].c) into gjson syntax (a.b.0.c)
+func jsonPathToGJSON(path string) string {
+	p := strings.TrimSpace(path)
+	p = strings.TrimPrefix(p, "$")
+	p = strings.TrimPrefix(p, ".")
+	if p == "" {
+		return "@this"
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		switch p[i] {
+		case '[':
+			end := strings.IndexByte(p[i:], ']')
+			if end < 0 {
+				b.WriteString(p[i:])
+				return b.String()
+			}
+			key := strings.Trim(p[i+1:i+end], `'"`)
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(key)
+			i += end
+		default:
+			b.WriteByte(p[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package tester
+
+import "testing"
+
+func TestJSONPathToGJSON(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"$.status", "status"},
+		{"$.data[0].id", "data.0.id"},
+		{"$['user'].name", "user.name"},
+		{"status", "status"},
+		{"$", "@this"},
+	}
+	for _, tt := range tests {
+		if got := jsonPathToGJSON(tt.in); got != tt.want {
+			t.Errorf("jsonPathToGJSON(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateAssertions(t *testing.T) {
+	body := `{"status":"ok","count":3,"active":true,"items":[{"id":7}]}`
+
+	passing := []Assertion{
+		{Path: "$.status", Equals: "ok"},
+		{Path: "$.count", Equals: 3},
+		{Path: "$.active", Equals: true},
+		{Path: "$.items[0].id", Equals: float64(7)},
+	}
+	if failures := EvaluateAssertions(body, passing); len(failures) != 0 {
+		t.Fatalf("expected no failures, got %+v", failures)
+	}
+
+	failing := []Assertion{
+		{Path: "$.status", Equals: "error"},
+		{Path: "$.missing", Equals: "x"},
+	}
+	failures := EvaluateAssertions(body, failing)
+	if len(failures) != 2 {
+		t.Fatalf("expected 2 failures, got %d", len(failures))
+	}
+	if failures[0].Actual != `"ok"` {
+		t.Errorf("expected actual %q, got %q", `"ok"`, failures[0].Actual)
+	}
+
+	if failures := EvaluateAssertions("not json", passing[:1]); len(failures) != 1 {
+		t.Errorf("expected failure for non-JSON body, got %d", len(failures))
+	}
+}
+
+func TestParseAssertions(t *testing.T) {
+	raw := []any{
+		map[string]any{"path": "$.status", "equals": "ok"},
+		map[string]any{"equals": "no path"},
+		"invalid",
+	}
+	got := ParseAssertions(raw)
+	if len(got) != 1 || got[0].Path != "$.status" || got[0].Equals != "ok" {
+		t.Errorf("unexpected assertions: %+v", got)
+	}
+	if ParseAssertions(nil) != nil {
+		t.Error("expected nil for nil input")
+	}
+}
+
+func TestTestResultPassed(t *testing.T) {
+	if !(&TestResult{StatusCode: 200}).Passed() {
+		t.Error("expected 200 without assertions to pass")
+	}
+	if (&TestResult{StatusCode: 500}).Passed() {
+		t.Error("expected 500 to fail")
+	}
+	r := &TestResult{StatusCode: 200, FailedAssertions: []AssertionFailure{{Message: "x"}}}
+	if r.Passed() {
+		t.Error("expected failed assertion to fail the test")
+	}
+}
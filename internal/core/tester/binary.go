@@ -0,0 +1,102 @@
+package tester
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// binarySniffLen is how many leading bytes are inspected when the Content-Type is inconclusive
+const binarySniffLen = 512
+
+// binaryMediaPrefixes are media types whose bodies are never useful as text
+var binaryMediaPrefixes = []string{
+	"image/", "audio/", "video/", "font/",
+	"application/octet-stream", "application/pdf", "application/zip", "application/gzip",
+	"application/x-", "application/vnd.ms-", "application/vnd.openxmlformats", "application/msword",
+	"application/protobuf", "application/x-protobuf", "application/grpc",
+}
+
+// IsBinaryResponse reports whether a response body is binary, judged by its Content-Type and,
+// when that is missing or generic, by its first bytes
+func IsBinaryResponse(contentType string, body []byte) bool {
+	mediaType := strings.ToLower(contentType)
+	if parsed, _, err := mime.ParseMediaType(contentType); err == nil {
+		mediaType = parsed
+	}
+
+	switch {
+	case mediaType == "image/svg+xml":
+		return false
+	case strings.HasPrefix(mediaType, "text/"), strings.Contains(mediaType, "json"),
+		strings.Contains(mediaType, "xml"), strings.Contains(mediaType, "javascript"),
+		mediaType == "application/x-www-form-urlencoded", mediaType == "application/x-ndjson":
+		return false
+	}
+	for _, prefix := range binaryMediaPrefixes {
+		if strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+
+	sample := body[:min(len(body), binarySniffLen)]
+	if bytes.IndexByte(sample, 0) >= 0 {
+		return true
+	}
+	for i := 0; i < len(sample); {
+		r, size := utf8.DecodeRune(sample[i:])
+		if r == utf8.RuneError && size == 1 {
+			// A multi-byte rune cut at the sample boundary is not a sign of binary data
+			return len(sample)-i >= utf8.UTFMax || len(sample) == len(body)
+		}
+		i += size
+	}
+	return false
+}
+
+// BinarySummary describes a binary body in place of its content, e.g. "binary response, image/png, 48.0 KB"
+func BinarySummary(contentType string, size int, savedPath string) string {
+	if contentType == "" {
+		contentType = "unknown type"
+	}
+	summary := fmt.Sprintf("binary response, %s, %s", contentType, FormatBytes(size))
+	if savedPath != "" {
+		summary += ", saved to " + savedPath
+	}
+	return summary
+}
+
+// saveBinaryBody writes a binary body to dir, named after the request and a timestamp
+func saveBinaryBody(dir, method, endpoint, contentType string, body []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create binary output directory: %w", err)
+	}
+
+	path := filepath.Join(dir, binaryBodyName(method, endpoint, contentType, body))
+	if err := os.WriteFile(path, body, 0644); err != nil {
+		return "", fmt.Errorf("failed to save binary response: %w", err)
+	}
+	return path, nil
+}
+
+// binaryBodyName names a saved binary body after the request, a timestamp and its media type
+func binaryBodyName(method, endpoint, contentType string, body []byte) string {
+	name := strings.TrimSuffix(FixtureName(method, endpoint, ""), ".json")
+	name += "_" + time.Now().Format("20060102-150405")
+
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+			name += exts[0]
+		}
+	}
+	return name
+}
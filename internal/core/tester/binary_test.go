@@ -0,0 +1,77 @@
+package tester
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestIsBinaryResponse(t *testing.T) {
+	pngHeader := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	longText := []byte(strings.Repeat("a", binarySniffLen-1) + "é and more")
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		want        bool
+	}{
+		{"json", "application/json; charset=utf-8", []byte(`{"ok":true}`), false},
+		{"problem json", "application/problem+json", []byte(`{}`), false},
+		{"html", "text/html", []byte("<p>hi</p>"), false},
+		{"svg is text", "image/svg+xml", []byte("<svg/>"), false},
+		{"png", "image/png", pngHeader, true},
+		{"pdf", "application/pdf", []byte("%PDF-1.7"), true},
+		{"octet stream", "application/octet-stream", []byte("plain"), true},
+		{"no content type, text", "", []byte("hello world"), false},
+		{"no content type, binary", "", pngHeader, true},
+		{"invalid utf-8", "", []byte{0xff, 0xfe, 'a', 'b', 'c', 'd', 'e'}, true},
+		{"rune cut at sample boundary", "", longText, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBinaryResponse(tt.contentType, tt.body); got != tt.want {
+				t.Errorf("IsBinaryResponse(%q) = %v, want %v", tt.contentType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteTestSummarizesBinary(t *testing.T) {
+	image := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 2048)...)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(image)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	executor := NewExecutor(server.URL, nil)
+	executor.SetBinaryDir(dir)
+
+	result, err := executor.ExecuteTest("GET", "/avatar", nil, nil)
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if !result.Binary || !strings.HasPrefix(result.ResponseBody, "binary response, image/png, 2.0 KB") {
+		t.Errorf("ResponseBody = %q, want a binary summary", result.ResponseBody)
+	}
+	if result.ResponseBytes != len(image) {
+		t.Errorf("ResponseBytes = %d, want %d", result.ResponseBytes, len(image))
+	}
+
+	saved, err := os.ReadFile(result.BinaryPath)
+	if err != nil {
+		t.Fatalf("binary body not saved: %v", err)
+	}
+	if !bytes.Equal(saved, image) {
+		t.Error("saved body differs from the response")
+	}
+	if !strings.HasSuffix(result.BinaryPath, ".png") {
+		t.Errorf("BinaryPath = %q, want a .png extension", result.BinaryPath)
+	}
+}
@@ -0,0 +1,146 @@
+package tester
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// variableRef matches a {{name}} reference to a captured value; dotted references such as
+// {{login.status_code}} point at test results and are left to skip_unless conditions
+var variableRef = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
+// ParseCaptures converts a decoded "capture" value (variable name → JSONPath) into a map
+func ParseCaptures(raw any) map[string]string {
+	switch v := raw.(type) {
+	case map[string]string:
+		return v
+	case map[string]any:
+		captures := make(map[string]string, len(v))
+		for name, path := range v {
+			if p, ok := path.(string); ok && p != "" {
+				captures[name] = p
+			}
+		}
+		return captures
+	}
+	return nil
+}
+
+// SetVariable stores a value that later requests can reference as {{name}} in their path,
+// headers or body
+func (e *Executor) SetVariable(name string, value any) {
+	if e.variables == nil {
+		e.variables = map[string]any{}
+	}
+	e.variables[name] = value
+}
+
+// Capture stores the values at the given JSONPaths of a response body as variables and returns
+// them. Paths missing from the body are reported in the error; the others are still captured.
+func (e *Executor) Capture(result *TestResult, captures map[string]string) (map[string]any, error) {
+	names := make([]string, 0, len(captures))
+	for name := range captures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	captured := make(map[string]any, len(captures))
+	var missing []string
+	for _, name := range names {
+		value := gjson.Get(result.ResponseBody, jsonPathToGJSON(captures[name]))
+		if !value.Exists() {
+			missing = append(missing, fmt.Sprintf("%s (%s)", name, captures[name]))
+			continue
+		}
+		captured[name] = value.Value()
+		e.SetVariable(name, value.Value())
+	}
+	if len(missing) > 0 {
+		return captured, fmt.Errorf("not found in response body: %s", strings.Join(missing, ", "))
+	}
+	return captured, nil
+}
+
+// expandVariables substitutes {{name}} references in a request's endpoint, header values and body.
+// Values in the endpoint are escaped; references to names never captured are left as they are,
+// since literal braces are legitimate in headers and bodies.
+func (e *Executor) expandVariables(endpoint string, headers map[string]string, body any) (string, map[string]string, any) {
+	lookup := func(name string) (string, bool) {
+		value, ok := e.variables[name]
+		return variableString(value), ok
+	}
+	expand := func(s string) string {
+		return variableRef.ReplaceAllStringFunc(s, func(match string) string {
+			if value, ok := lookup(variableRef.FindStringSubmatch(match)[1]); ok {
+				return value
+			}
+			return match
+		})
+	}
+
+	endpoint, _ = fillEndpoint(endpoint, true, lookup)
+	if len(headers) > 0 {
+		expanded := make(map[string]string, len(headers))
+		for name, value := range headers {
+			expanded[name] = expand(value)
+		}
+		headers = expanded
+	}
+	return endpoint, headers, e.expandBody(body, expand)
+}
+
+// expandBody substitutes references in the strings of a decoded JSON body. A string that is only a
+// reference takes the captured value with its type, so "{{id}}" becomes 42 rather than "42".
+func (e *Executor) expandBody(body any, expand func(string) string) any {
+	switch v := body.(type) {
+	case string:
+		if match := variableRef.FindStringSubmatch(v); match != nil && match[0] == v {
+			if value, ok := e.variables[match[1]]; ok {
+				return value
+			}
+		}
+		return expand(v)
+	case map[string]any:
+		expanded := make(map[string]any, len(v))
+		for key, child := range v {
+			expanded[key] = e.expandBody(child, expand)
+		}
+		return expanded
+	case []any:
+		expanded := make([]any, len(v))
+		for i, child := range v {
+			expanded[i] = e.expandBody(child, expand)
+		}
+		return expanded
+	case *Multipart:
+		expanded := &Multipart{Files: v.Files}
+		if v.Fields != nil {
+			expanded.Fields = make(map[string]string, len(v.Fields))
+			for name, value := range v.Fields {
+				expanded.Fields[name] = expand(value)
+			}
+		}
+		return expanded
+	}
+	return body
+}
+
+// variableString renders a captured value for a path, header or string body
+func variableString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
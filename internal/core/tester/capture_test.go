@@ -0,0 +1,127 @@
+package tester
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCaptureAndReuse(t *testing.T) {
+	items := map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/items":
+			var item map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&item)
+			items["42"] = item
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{"id":42,"token":"t-1"}}`))
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/items/"):
+			if r.Header.Get("X-Token") != "t-1" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			item, ok := items[strings.TrimPrefix(r.URL.Path, "/items/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(item)
+		case r.Method == "PUT":
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(body)
+		}
+	}))
+	defer server.Close()
+
+	executor := NewExecutor(server.URL, nil)
+	created, err := executor.ExecuteTest("POST", "/items", nil, map[string]any{"name": "widget"})
+	if err != nil || created.StatusCode != http.StatusCreated {
+		t.Fatalf("create: status %d, err %v", created.StatusCode, err)
+	}
+
+	captured, err := executor.Capture(created, map[string]string{"id": "$.data.id", "token": "$.data.token"})
+	if err != nil {
+		t.Fatalf("Capture() error = %v", err)
+	}
+	if !reflect.DeepEqual(captured, map[string]any{"id": float64(42), "token": "t-1"}) {
+		t.Errorf("captured = %v", captured)
+	}
+
+	fetched, err := executor.ExecuteTest("GET", "/items/{{id}}", map[string]string{"X-Token": "{{ token }}"}, nil)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if fetched.StatusCode != http.StatusOK || !strings.Contains(fetched.ResponseBody, `"widget"`) {
+		t.Errorf("read: status %d, body %s", fetched.StatusCode, fetched.ResponseBody)
+	}
+
+	// A body value that is only a reference keeps the captured type
+	echoed, _ := executor.ExecuteTest("PUT", "/items/{{id}}", nil, map[string]any{"id": "{{id}}", "label": "item {{id}}"})
+	if echoed.ResponseBody != `{"id":42,"label":"item 42"}` {
+		t.Errorf("PUT body = %s", echoed.ResponseBody)
+	}
+}
+
+func TestCaptureMissingPath(t *testing.T) {
+	executor := NewExecutor("http://localhost", nil)
+	captured, err := executor.Capture(&TestResult{ResponseBody: `{"id":1}`}, map[string]string{"id": "$.id", "etag": "$.etag"})
+	if err == nil || !strings.Contains(err.Error(), "etag") {
+		t.Errorf("Capture() error = %v, want etag reported missing", err)
+	}
+	if captured["id"] != float64(1) {
+		t.Errorf("captured = %v, want id still captured", captured)
+	}
+}
+
+func TestExecuteTestUnknownVariable(t *testing.T) {
+	var path, header, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, header = r.URL.EscapedPath(), r.Header.Get("X-Template")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	// Literal braces are legitimate, so references to names never captured are sent as they are
+	executor := NewExecutor(server.URL, nil)
+	_, err := executor.ExecuteTest("POST", "/items/{{id}}", map[string]string{"X-Template": "Hi {{name}}"}, map[string]any{"text": "{{ greeting }}"})
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if path != "/items/%7B%7Bid%7D%7D" || header != "Hi {{name}}" || body != `{"text":"{{ greeting }}"}` {
+		t.Errorf("sent path %s, header %q, body %s; want the references unchanged", path, header, body)
+	}
+}
+
+func TestExecuteTestEscapesEndpointVariables(t *testing.T) {
+	var uri string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.RequestURI
+	}))
+	defer server.Close()
+
+	executor := NewExecutor(server.URL, nil)
+	executor.SetVariable("key", "a/b c")
+	executor.SetVariable("q", "x&y=z")
+	if _, err := executor.ExecuteTest("GET", "/files/{{key}}?search={{q}}&path={{key}}", nil, nil); err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if want := "/files/a%2Fb%20c?search=x%26y%3Dz&path=a%2Fb+c"; uri != want {
+		t.Errorf("request URI = %s, want %s", uri, want)
+	}
+}
+
+func TestParseCaptures(t *testing.T) {
+	got := ParseCaptures(map[string]any{"id": "$.id", "bad": 3, "empty": ""})
+	if !reflect.DeepEqual(got, map[string]string{"id": "$.id"}) {
+		t.Errorf("ParseCaptures() = %v", got)
+	}
+	if ParseCaptures(nil) != nil {
+		t.Error("ParseCaptures(nil) should be nil")
+	}
+}
@@ -0,0 +1,181 @@
+package tester
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// Variables holds the results of earlier named tests in a run, for use in skip_unless conditions
+type Variables map[string]*TestResult
+
+// templateRef matches {{test.field}} references in a condition
+var templateRef = regexp.MustCompile(`\{\{\s*([^}]*?)\s*\}\}`)
+
+// comparisonOps are checked longest first so ">=" isn't read as ">"
+var comparisonOps = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+// Capture stores a test's outcome under name so later conditions can reference it
+func (v Variables) Capture(name string, result *TestResult, err error) {
+	if result == nil {
+		result = &TestResult{Error: err}
+	}
+	v[name] = result
+}
+
+// Resolve returns the value of a reference such as login.status_code or login.body.data.token.
+// Fields: status_code, passed, duration_ms, error, body and body.<JSONPath>.
+func (v Variables) Resolve(ref string) (string, error) {
+	name, field, ok := strings.Cut(ref, ".")
+	if !ok {
+		return "", fmt.Errorf("invalid reference %q (use <test>.<field>)", ref)
+	}
+	result, ok := v[name]
+	if !ok {
+		return "", fmt.Errorf("test %q has no result", name)
+	}
+
+	switch field {
+	case "status_code":
+		return strconv.Itoa(result.StatusCode), nil
+	case "passed":
+		return strconv.FormatBool(result.Passed()), nil
+	case "duration_ms":
+		return strconv.FormatInt(result.Duration.Milliseconds(), 10), nil
+	case "error":
+		if result.Error != nil {
+			return result.Error.Error(), nil
+		}
+		return "", nil
+	case "body":
+		return result.ResponseBody, nil
+	}
+
+	if path, ok := strings.CutPrefix(field, "body."); ok {
+		value := gjson.Get(result.ResponseBody, jsonPathToGJSON(path))
+		if !value.Exists() {
+			return "", fmt.Errorf("%s not found in response body", ref)
+		}
+		return value.String(), nil
+	}
+	return "", fmt.Errorf("unknown field %q in %q", field, ref)
+}
+
+// EvaluateCondition resolves {{test.field}} references in cond and evaluates it. Comparisons
+// (==, !=, <, <=, >, >=) can be joined with &&; a bare value is true unless empty, "false", "0" or "null".
+// An error means the condition could not be evaluated and should be treated as not met.
+func (v Variables) EvaluateCondition(cond string) (bool, error) {
+	for clause := range strings.SplitSeq(cond, "&&") {
+		met, err := v.evaluateClause(strings.TrimSpace(clause))
+		if err != nil || !met {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (v Variables) evaluateClause(clause string) (bool, error) {
+	lhs, op, rhs, isComparison := splitComparison(clause)
+	if !isComparison {
+		value, err := v.expand(clause)
+		if err != nil {
+			return false, err
+		}
+		switch unquote(value) {
+		case "", "false", "0", "null":
+			return false, nil
+		}
+		return true, nil
+	}
+
+	left, err := v.expand(lhs)
+	if err != nil {
+		return false, err
+	}
+	right, err := v.expand(rhs)
+	if err != nil {
+		return false, err
+	}
+	return compareValues(unquote(left), op, unquote(right))
+}
+
+// expand substitutes every {{...}} reference in s
+func (v Variables) expand(s string) (string, error) {
+	var resolveErr error
+	expanded := templateRef.ReplaceAllStringFunc(s, func(match string) string {
+		value, err := v.Resolve(templateRef.FindStringSubmatch(match)[1])
+		if err != nil && resolveErr == nil {
+			resolveErr = err
+		}
+		return value
+	})
+	return expanded, resolveErr
+}
+
+// splitComparison finds the first comparison operator outside {{...}} references
+func splitComparison(clause string) (string, string, string, bool) {
+	depth := 0
+	for i := 0; i < len(clause); i++ {
+		switch {
+		case strings.HasPrefix(clause[i:], "{{"):
+			depth++
+			i++
+			continue
+		case strings.HasPrefix(clause[i:], "}}") && depth > 0:
+			depth--
+			i++
+			continue
+		}
+		if depth > 0 {
+			continue
+		}
+		for _, op := range comparisonOps {
+			if strings.HasPrefix(clause[i:], op) {
+				return strings.TrimSpace(clause[:i]), op, strings.TrimSpace(clause[i+len(op):]), true
+			}
+		}
+	}
+	return "", "", "", false
+}
+
+// compareValues compares numerically when both sides are numbers, otherwise as strings
+func compareValues(left, op, right string) (bool, error) {
+	l, lErr := strconv.ParseFloat(left, 64)
+	r, rErr := strconv.ParseFloat(right, 64)
+	if lErr == nil && rErr == nil {
+		switch op {
+		case "==":
+			return l == r, nil
+		case "!=":
+			return l != r, nil
+		case ">=":
+			return l >= r, nil
+		case "<=":
+			return l <= r, nil
+		case ">":
+			return l > r, nil
+		case "<":
+			return l < r, nil
+		}
+	}
+
+	switch op {
+	case "==":
+		return left == right, nil
+	case "!=":
+		return left != right, nil
+	}
+	return false, fmt.Errorf("cannot compare %q %s %q: values are not numbers", left, op, right)
+}
+
+// unquote trims whitespace and one pair of surrounding quotes
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
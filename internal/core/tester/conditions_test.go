@@ -0,0 +1,43 @@
+package tester
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	vars := Variables{}
+	vars.Capture("login", &TestResult{StatusCode: 200, ResponseBody: `{"token":"abc","user":{"id":7}}`}, nil)
+	vars.Capture("broken", nil, errors.New("connection refused"))
+
+	tests := []struct {
+		name    string
+		cond    string
+		want    bool
+		wantErr bool
+	}{
+		{"status equals", "{{login.status_code}} == 200", true, false},
+		{"status not equals", "{{login.status_code}} != 200", false, false},
+		{"numeric comparison", "{{login.status_code}} < 300", true, false},
+		{"body field", `{{login.body.token}} == "abc"`, true, false},
+		{"nested JSONPath", "{{ login.body.$.user.id }} >= 7", true, false},
+		{"passed is truthy", "{{login.passed}}", true, false},
+		{"failed request", "{{broken.passed}}", false, false},
+		{"conjunction", "{{login.status_code}} == 200 && {{broken.status_code}} == 200", false, false},
+		{"unknown test", "{{signup.status_code}} == 201", false, true},
+		{"missing body field", "{{login.body.missing}} == 1", false, true},
+		{"non-numeric ordering", "{{login.body.token}} > 1", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := vars.EvaluateCondition(tt.cond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EvaluateCondition(%q) error = %v, wantErr %v", tt.cond, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("EvaluateCondition(%q) = %v, want %v", tt.cond, got, tt.want)
+			}
+		})
+	}
+}
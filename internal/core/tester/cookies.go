@@ -0,0 +1,14 @@
+package tester
+
+import "net/http/cookiejar"
+
+// EnableCookies keeps the cookies responses set and sends them with later requests to the same
+// site, as a browser would, so a login carries over to the tests after it. Off by default, so
+// each test runs in isolation. The jar lasts as long as the executor, i.e. the whole interactive
+// session or octrafic run: it isn't reset between test groups, so a group can rely on a login
+// made by an earlier one.
+func (e *Executor) EnableCookies() {
+	// cookiejar.New only fails on invalid options
+	jar, _ := cookiejar.New(nil)
+	e.client.Jar = jar
+}
@@ -0,0 +1,65 @@
+package tester
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+)
+
+func newSessionServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123", Path: "/"})
+		case "/me":
+			if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "abc123" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestExecutorCarriesCookies(t *testing.T) {
+	server := newSessionServer(t)
+
+	executor := NewExecutor(server.URL, &auth.BearerAuth{Token: "token"})
+	executor.EnableCookies()
+	results := executor.ExecuteParallel([]Request{
+		{Method: "POST", Endpoint: "/login"},
+		// Tests without auth carry their own provider; the jar is the executor's either way
+		{Method: "GET", Endpoint: "/me", Auth: &auth.NoAuth{}},
+	}, 1)
+
+	for i, outcome := range results {
+		if outcome.Err != nil {
+			t.Fatalf("request %d error = %v", i, outcome.Err)
+		}
+	}
+	if status := results[1].Result.StatusCode; status != http.StatusOK {
+		t.Errorf("GET /me status = %d, want 200 with the session cookie", status)
+	}
+}
+
+func TestExecutorCookiesOffByDefault(t *testing.T) {
+	server := newSessionServer(t)
+
+	executor := NewExecutor(server.URL, nil)
+	if executor.client.Jar != nil {
+		t.Fatal("cookies are enabled by default")
+	}
+	if _, err := executor.ExecuteTest("POST", "/login", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	result, err := executor.ExecuteTest("GET", "/me", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET /me status = %d, want 401 without a cookie jar", result.StatusCode)
+	}
+}
@@ -0,0 +1,395 @@
+package tester
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+)
+
+// CurlRequest is a request parsed from a curl command line
+type CurlRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    any               `json:"body,omitempty"` // Decoded JSON, or the raw string for other payloads
+}
+
+// curlIgnoredFlags take no value and don't affect the request
+var curlIgnoredFlags = map[string]bool{
+	"-s": true, "--silent": true, "-S": true, "--show-error": true, "-v": true, "--verbose": true,
+	"-i": true, "--include": true, "-L": true, "--location": true, "-k": true, "--insecure": true,
+	"--compressed": true, "-f": true, "--fail": true, "-g": true, "--globoff": true,
+}
+
+// curlIgnoredValueFlags take a value that doesn't affect the request
+var curlIgnoredValueFlags = map[string]bool{
+	"-o": true, "--output": true, "-m": true, "--max-time": true, "--connect-timeout": true,
+	"-w": true, "--write-out": true, "--retry": true,
+}
+
+// ParseCurl parses a curl command into a request. Supports -X, -H, -d/--data*, --json, -u, -A, -e, -b and -G.
+func ParseCurl(command string) (*CurlRequest, error) {
+	args, err := splitShellArgs(command)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 0 && (args[0] == "curl" || strings.HasSuffix(args[0], "/curl")) {
+		args = args[1:]
+	}
+
+	req := &CurlRequest{Headers: make(map[string]string)}
+	var data []string
+	getWithData := false
+	isJSON := false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		value := func() (string, error) {
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("missing value for %s", arg)
+			}
+			i++
+			return args[i], nil
+		}
+
+		// Support --flag=value
+		if strings.HasPrefix(arg, "--") {
+			if name, v, ok := strings.Cut(arg, "="); ok {
+				arg = name
+				args = append(args[:i+1], append([]string{v}, args[i+1:]...)...)
+			}
+		}
+
+		switch arg {
+		case "-X", "--request":
+			v, err := value()
+			if err != nil {
+				return nil, err
+			}
+			req.Method = strings.ToUpper(v)
+		case "-H", "--header":
+			v, err := value()
+			if err != nil {
+				return nil, err
+			}
+			name, headerValue, ok := strings.Cut(v, ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid header: %s", v)
+			}
+			req.Headers[strings.TrimSpace(name)] = strings.TrimSpace(headerValue)
+		case "-d", "--data", "--data-raw", "--data-binary", "--data-ascii":
+			v, err := value()
+			if err != nil {
+				return nil, err
+			}
+			if strings.HasPrefix(v, "@") && arg != "--data-raw" {
+				return nil, fmt.Errorf("reading request data from files (%s) is not supported", v)
+			}
+			data = append(data, v)
+		case "--data-urlencode":
+			v, err := value()
+			if err != nil {
+				return nil, err
+			}
+			encoded, err := urlEncodeData(v)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, encoded)
+		case "--json":
+			v, err := value()
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, v)
+			isJSON = true
+		case "-u", "--user":
+			v, err := value()
+			if err != nil {
+				return nil, err
+			}
+			req.Headers["Authorization"] = "Basic " + basicCredentials(v)
+		case "-A", "--user-agent":
+			v, err := value()
+			if err != nil {
+				return nil, err
+			}
+			req.Headers["User-Agent"] = v
+		case "-e", "--referer":
+			v, err := value()
+			if err != nil {
+				return nil, err
+			}
+			req.Headers["Referer"] = v
+		case "-b", "--cookie":
+			v, err := value()
+			if err != nil {
+				return nil, err
+			}
+			req.Headers["Cookie"] = v
+		case "-G", "--get":
+			getWithData = true
+		case "--url":
+			v, err := value()
+			if err != nil {
+				return nil, err
+			}
+			req.URL = v
+		default:
+			switch {
+			case curlIgnoredFlags[arg]:
+			case curlIgnoredValueFlags[arg]:
+				if _, err := value(); err != nil {
+					return nil, err
+				}
+			case strings.HasPrefix(arg, "-"):
+				return nil, fmt.Errorf("unsupported curl option: %s", arg)
+			case req.URL == "":
+				req.URL = arg
+			default:
+				return nil, fmt.Errorf("unexpected argument: %s", arg)
+			}
+		}
+	}
+
+	if req.URL == "" {
+		return nil, fmt.Errorf("no URL found in curl command")
+	}
+
+	payload := strings.Join(data, "&")
+	switch {
+	case getWithData && payload != "":
+		sep := "?"
+		if strings.Contains(req.URL, "?") {
+			sep = "&"
+		}
+		req.URL += sep + payload
+	case payload != "":
+		var decoded any
+		if err := json.Unmarshal([]byte(payload), &decoded); err == nil {
+			req.Body = decoded
+			isJSON = true
+		} else {
+			req.Body = payload
+		}
+		if !hasHeader(req.Headers, "Content-Type") {
+			if isJSON {
+				req.Headers["Content-Type"] = "application/json"
+			} else {
+				req.Headers["Content-Type"] = "application/x-www-form-urlencoded"
+			}
+		}
+	}
+
+	if req.Method == "" {
+		req.Method = "GET"
+		if req.Body != nil {
+			req.Method = "POST"
+		}
+	}
+
+	if len(req.Headers) == 0 {
+		req.Headers = nil
+	}
+	return req, nil
+}
+
+// ToCurl builds a curl command equivalent to a request, including the credentials authProvider adds.
+// Pass authProvider.Redact() to hide them. Headers are sorted by name so the output is stable.
+func ToCurl(method, rawURL string, headers map[string]string, body any, authProvider auth.AuthProvider) string {
+	method = strings.ToUpper(method)
+	form, multipart := body.(*Multipart)
+	var data []byte
+	var contentType string
+	if !multipart {
+		var err error
+		if data, contentType, err = encodeBody(body, headers, nil); err != nil {
+			data = nil
+		}
+	}
+
+	header := http.Header{}
+	if data != nil {
+		header.Set("Content-Type", contentType)
+	}
+	for name, value := range headers {
+		// curl -F sets the multipart Content-Type with its boundary
+		if multipart && strings.EqualFold(name, "Content-Type") {
+			continue
+		}
+		header.Set(name, value)
+	}
+
+	// Schemes curl implements itself are passed as options; the rest are applied to a request to
+	// see what they add. Signing and challenges depend on the exact request, so they can't be copied.
+	var authArgs []string
+	switch p := authProvider.(type) {
+	case nil, *auth.NoAuth:
+	case *auth.DigestAuth:
+		authArgs = []string{"--digest", "-u", shellQuote(p.Username + ":" + p.Password)}
+	case *auth.AWSSigV4:
+		authArgs = []string{"--aws-sigv4", shellQuote("aws:amz:" + p.Region + ":" + p.Service), "-u", shellQuote(p.AccessKey + ":" + p.SecretKey)}
+	case *auth.OAuth2ClientCredentials:
+		// Only a token already fetched is used; fetching one here would mean a network call
+		token := p.CachedToken()
+		if token == "" {
+			token = "<token>"
+		}
+		header.Set("Authorization", "Bearer "+token)
+	default:
+		if req, err := http.NewRequest(method, rawURL, nil); err == nil {
+			req.Header = header
+			if p.Apply(req) == nil {
+				rawURL = req.URL.String()
+			}
+		}
+	}
+
+	args := []string{"curl"}
+	if method != http.MethodGet || data != nil || multipart {
+		args = append(args, "-X", method)
+	}
+	args = append(args, shellQuote(rawURL))
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			args = append(args, "-H", shellQuote(name+": "+value))
+		}
+	}
+	args = append(args, authArgs...)
+	if data != nil {
+		args = append(args, "--data-raw", shellQuote(string(data)))
+	}
+	if multipart {
+		args = append(args, form.curlArgs()...)
+	}
+	return strings.Join(args, " ")
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// SplitURL splits the request URL into a base URL (scheme and host) and the endpoint path with query
+func (r *CurlRequest) SplitURL() (string, string, error) {
+	raw := r.URL
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid URL: %w", err)
+	}
+	endpoint := u.EscapedPath()
+	if endpoint == "" {
+		endpoint = "/"
+	}
+	if u.RawQuery != "" {
+		endpoint += "?" + u.RawQuery
+	}
+	return u.Scheme + "://" + u.Host, endpoint, nil
+}
+
+// splitShellArgs splits a command line into arguments, honoring single and double quotes,
+// backslash escapes and line continuations
+func splitShellArgs(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(c)
+			}
+		case quote == '"':
+			switch {
+			case c == '"':
+				quote = 0
+			case c == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`+"`", runes[i+1]):
+				i++
+				current.WriteRune(runes[i])
+			default:
+				current.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inArg = true
+		case c == '\\':
+			if i+1 < len(runes) {
+				i++
+				if runes[i] == '\n' || runes[i] == '\r' {
+					continue // line continuation
+				}
+				current.WriteRune(runes[i])
+				inArg = true
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(c)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in curl command", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// urlEncodeData encodes a --data-urlencode value the way curl does: "content" and "=content"
+// encode the whole content, "name=content" encodes the content and keeps the name as given.
+// The @file forms are rejected.
+func urlEncodeData(v string) (string, error) {
+	name, content, hasName := strings.Cut(v, "=")
+	if !hasName {
+		if strings.Contains(v, "@") {
+			return "", fmt.Errorf("reading request data from files (%s) is not supported", v)
+		}
+		return url.QueryEscape(v), nil
+	}
+	if name == "" {
+		return url.QueryEscape(content), nil
+	}
+	return name + "=" + url.QueryEscape(content), nil
+}
+
+// basicCredentials encodes curl's user[:password] for a Basic Authorization header
+func basicCredentials(userPass string) string {
+	return base64.StdEncoding.EncodeToString([]byte(userPass))
+}
+
+func hasHeader(headers map[string]string, name string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
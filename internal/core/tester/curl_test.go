@@ -0,0 +1,194 @@
+package tester
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+)
+
+func TestParseCurl(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    *CurlRequest
+		wantErr bool
+	}{
+		{
+			name:    "simple GET",
+			command: "curl https://api.example.com/users",
+			want:    &CurlRequest{Method: "GET", URL: "https://api.example.com/users"},
+		},
+		{
+			name:    "POST with JSON and headers",
+			command: `curl -X POST 'https://api.example.com/users' -H "Content-Type: application/json" -H 'X-Trace: abc' -d '{"name":"alice"}'`,
+			want: &CurlRequest{
+				Method:  "POST",
+				URL:     "https://api.example.com/users",
+				Headers: map[string]string{"Content-Type": "application/json", "X-Trace": "abc"},
+				Body:    map[string]any{"name": "alice"},
+			},
+		},
+		{
+			name:    "data implies POST and form content type",
+			command: "curl https://api.example.com/login --data 'user=a&pass=b'",
+			want: &CurlRequest{
+				Method:  "POST",
+				URL:     "https://api.example.com/login",
+				Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+				Body:    "user=a&pass=b",
+			},
+		},
+		{
+			name:    "line continuations, long flags and ignored options",
+			command: "curl -s -L \\\n  --request=PUT \\\n  --header 'Authorization: Bearer xyz' \\\n  https://api.example.com/items/1",
+			want: &CurlRequest{
+				Method:  "PUT",
+				URL:     "https://api.example.com/items/1",
+				Headers: map[string]string{"Authorization": "Bearer xyz"},
+			},
+		},
+		{
+			name:    "basic auth",
+			command: "curl -u admin:secret https://api.example.com/me",
+			want: &CurlRequest{
+				Method:  "GET",
+				URL:     "https://api.example.com/me",
+				Headers: map[string]string{"Authorization": "Basic YWRtaW46c2VjcmV0"},
+			},
+		},
+		{
+			name:    "GET with data moves it to the query",
+			command: "curl -G https://api.example.com/search -d q=test",
+			want:    &CurlRequest{Method: "GET", URL: "https://api.example.com/search?q=test"},
+		},
+		{
+			name:    "data-urlencode encodes the content and keeps the name",
+			command: `curl -G https://api.example.com/search --data-urlencode 'q=a b&c' --data-urlencode '=x/y' --data-urlencode 'plain text'`,
+			want:    &CurlRequest{Method: "GET", URL: "https://api.example.com/search?q=a+b%26c&x%2Fy&plain+text"},
+		},
+		{
+			name:    "data-urlencode from a file",
+			command: "curl https://api.example.com/upload --data-urlencode name@notes.txt",
+			wantErr: true,
+		},
+		{
+			name:    "missing URL",
+			command: "curl -X GET",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated quote",
+			command: "curl 'https://api.example.com",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported option",
+			command: "curl --upload-file x.bin https://api.example.com",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCurl(tt.command)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCurl() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCurl() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurlRequestSplitURL(t *testing.T) {
+	req := &CurlRequest{URL: "https://api.example.com:8443/v1/users?limit=5"}
+	base, endpoint, err := req.SplitURL()
+	if err != nil {
+		t.Fatalf("SplitURL() error = %v", err)
+	}
+	if base != "https://api.example.com:8443" || endpoint != "/v1/users?limit=5" {
+		t.Errorf("SplitURL() = %q, %q", base, endpoint)
+	}
+}
+
+func TestToCurl(t *testing.T) {
+	bearer := auth.NewBearerAuth("secret-token-123456")
+	headers := map[string]string{"X-Trace": "it's-1"}
+	body := map[string]any{"name": "alice"}
+
+	got := ToCurl("post", "https://api.example.com/users", headers, body, bearer)
+	want := `curl -X POST 'https://api.example.com/users' -H 'Authorization: Bearer secret-token-123456' -H 'Content-Type: application/json' -H 'X-Trace: it'\''s-1' --data-raw '{"name":"alice"}'`
+	if got != want {
+		t.Errorf("ToCurl() =\n%s\nwant\n%s", got, want)
+	}
+
+	// The command parses back into the same request
+	parsed, err := ParseCurl(got)
+	if err != nil {
+		t.Fatalf("ParseCurl() error = %v", err)
+	}
+	wantParsed := &CurlRequest{
+		Method:  "POST",
+		URL:     "https://api.example.com/users",
+		Headers: map[string]string{"Authorization": "Bearer secret-token-123456", "Content-Type": "application/json", "X-Trace": "it's-1"},
+		Body:    body,
+	}
+	if !reflect.DeepEqual(parsed, wantParsed) {
+		t.Errorf("ParseCurl(ToCurl()) = %+v, want %+v", parsed, wantParsed)
+	}
+
+	if redacted := ToCurl("POST", "https://api.example.com/users", headers, body, bearer.Redact()); strings.Contains(redacted, "secret-token-123456") {
+		t.Errorf("redacted command contains the token: %s", redacted)
+	}
+}
+
+func TestToCurlAuthSchemes(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider auth.AuthProvider
+		want     string
+	}{
+		{"no auth", nil, `curl 'https://api.example.com/users'`},
+		{"api key in query", auth.NewAPIKeyAuth("api_key", "k1", "query"), `curl 'https://api.example.com/users?api_key=k1'`},
+		{"digest", auth.NewDigestAuth("alice", "pw"), `curl 'https://api.example.com/users' --digest -u 'alice:pw'`},
+		{"sigv4", auth.NewAWSSigV4("AKID", "SECRET", "eu-west-1", "execute-api"), `curl 'https://api.example.com/users' --aws-sigv4 'aws:amz:eu-west-1:execute-api' -u 'AKID:SECRET'`},
+		{"oauth2 without a token", auth.NewOAuth2ClientCredentials("https://auth.example.com/token", "id", "secret", nil), `curl 'https://api.example.com/users' -H 'Authorization: Bearer <token>'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToCurl("GET", "https://api.example.com/users", nil, nil, tt.provider); got != tt.want {
+				t.Errorf("ToCurl() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutorLastCurl(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	executor := NewExecutor(server.URL, auth.NewBearerAuth("secret-token-123456"))
+	if got := executor.LastCurl(false); got != "" {
+		t.Errorf("LastCurl() = %q before any request, want empty", got)
+	}
+	executor.SetVariable("id", 42)
+	if _, err := executor.ExecuteTest("DELETE", "/users/{{id}}", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "curl -X DELETE '" + server.URL + "/users/42' -H 'Authorization: Bearer secret-token-123456'"
+	if got := executor.LastCurl(false); got != want {
+		t.Errorf("LastCurl(false) = %s, want %s", got, want)
+	}
+	if got := executor.LastCurl(true); strings.Contains(got, "secret-token-123456") {
+		t.Errorf("LastCurl(true) = %s, want the token redacted", got)
+	}
+}
@@ -0,0 +1,205 @@
+package tester
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultDownloadDir holds downloads when no --save-binary directory is set
+const defaultDownloadDir = "downloads"
+
+// Download asks for a file response to be saved and verified instead of read as text
+type Download struct {
+	SaveTo            string `json:"save_to"`                       // Path inside the download directory, or a directory (ending in /) to save under a generated name
+	Overwrite         bool   `json:"overwrite,omitempty"`           // Replace an existing file at SaveTo instead of failing
+	ExpectContentType string `json:"expect_content_type,omitempty"` // Media type such as application/pdf or image/*
+	ExpectSize        int    `json:"expect_size,omitempty"`         // Exact size in bytes
+	ExpectChecksum    string `json:"expect_checksum,omitempty"`     // sha256:<hex>, sha1:<hex> or md5:<hex>; bare hex is judged by length
+}
+
+// DownloadResult records where a downloaded file was saved and how it compared to the expectations
+type DownloadResult struct {
+	Path        string
+	Size        int
+	ContentType string
+	SHA256      string
+	Problems    []string // Failed expectations; empty when the file is as expected
+}
+
+// ParseDownload converts a decoded "download" value into a Download, or nil when absent
+func ParseDownload(raw any) *Download {
+	switch v := raw.(type) {
+	case *Download:
+		return v
+	case Download:
+		return &v
+	case map[string]any:
+		d := &Download{}
+		d.SaveTo, _ = v["save_to"].(string)
+		d.ExpectContentType, _ = v["expect_content_type"].(string)
+		d.ExpectChecksum, _ = v["expect_checksum"].(string)
+		d.Overwrite, _ = v["overwrite"].(bool)
+		if size, ok := v["expect_size"].(float64); ok {
+			d.ExpectSize = int(size)
+		} else if size, ok := v["expect_size"].(int); ok {
+			d.ExpectSize = size
+		}
+		if d.SaveTo == "" && d.ExpectContentType == "" && d.ExpectChecksum == "" && d.ExpectSize == 0 {
+			return nil
+		}
+		return d
+	}
+	return nil
+}
+
+// saveDownload writes a successful response body to the download's path under dir and checks it
+// against the expectations. Responses with an error status aren't saved, so their body stays readable.
+func saveDownload(d *Download, dir, method, endpoint string, statusCode int, contentType string, body []byte) *DownloadResult {
+	result := &DownloadResult{Size: len(body), ContentType: contentType}
+	sum := sha256.Sum256(body)
+	result.SHA256 = hex.EncodeToString(sum[:])
+
+	if statusCode < 200 || statusCode >= 300 {
+		result.Problems = append(result.Problems, fmt.Sprintf("not downloaded: status %d", statusCode))
+		return result
+	}
+
+	if d.SaveTo != "" {
+		path, err := writeDownload(d, dir, method, endpoint, contentType, body)
+		if err != nil {
+			result.Problems = append(result.Problems, err.Error())
+		}
+		result.Path = path
+	}
+
+	if d.ExpectContentType != "" && !mediaTypeMatches(contentType, d.ExpectContentType) {
+		result.Problems = append(result.Problems, fmt.Sprintf("content type is %q, expected %s", contentType, d.ExpectContentType))
+	}
+	if d.ExpectSize > 0 && len(body) != d.ExpectSize {
+		result.Problems = append(result.Problems, fmt.Sprintf("size is %d bytes, expected %d", len(body), d.ExpectSize))
+	}
+	if d.ExpectChecksum != "" {
+		if err := verifyChecksum(body, d.ExpectChecksum); err != nil {
+			result.Problems = append(result.Problems, err.Error())
+		}
+	}
+	return result
+}
+
+// writeDownload saves body to the download's path under dir, or under a generated name when the
+// path is a directory. An existing file is only replaced when the download asks for it. Writes go
+// through an os.Root so symlinks inside dir can't lead outside it.
+func writeDownload(d *Download, dir, method, endpoint, contentType string, body []byte) (string, error) {
+	rel, isDir, err := downloadPath(dir, d.SaveTo)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create download directory: %w", err)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to open download directory: %w", err)
+	}
+	defer func() { _ = root.Close() }()
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if d.Overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	if info, err := root.Stat(rel); isDir || (err == nil && info.IsDir()) {
+		rel = filepath.Join(rel, binaryBodyName(method, endpoint, contentType, body))
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	if err := root.MkdirAll(filepath.Dir(rel), 0755); err != nil {
+		return "", fmt.Errorf("failed to create download directory: %w", err)
+	}
+
+	path := filepath.Join(dir, rel)
+	f, err := root.OpenFile(rel, flags, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return "", fmt.Errorf("not saved: %s already exists (set overwrite to replace it)", path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to save download: %w", err)
+	}
+	if _, err := f.Write(body); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("failed to save download: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to save download: %w", err)
+	}
+	return path, nil
+}
+
+// downloadPath resolves a save_to path to a name relative to dir and reports whether it names a
+// directory. Absolute paths and paths climbing out of dir are rejected, since the path comes from the model.
+func downloadPath(dir, saveTo string) (string, bool, error) {
+	isDir := strings.HasSuffix(saveTo, "/") || strings.HasSuffix(saveTo, string(os.PathSeparator))
+	rel := filepath.Clean(filepath.FromSlash(saveTo))
+	if filepath.IsAbs(rel) || strings.HasPrefix(saveTo, "/") || (rel != "." && !filepath.IsLocal(rel)) {
+		return "", false, fmt.Errorf("not saved: save_to %q must be a relative path inside %s", saveTo, dir)
+	}
+	return rel, isDir || rel == ".", nil
+}
+
+// mediaTypeMatches reports whether a Content-Type header matches an expected media type,
+// ignoring parameters and case; "image/*" matches any image
+func mediaTypeMatches(contentType, expected string) bool {
+	actual := strings.ToLower(contentType)
+	if parsed, _, err := mime.ParseMediaType(contentType); err == nil {
+		actual = parsed
+	}
+	expected = strings.ToLower(strings.TrimSpace(expected))
+	if parsed, _, err := mime.ParseMediaType(expected); err == nil {
+		expected = parsed
+	}
+	if prefix, ok := strings.CutSuffix(expected, "/*"); ok {
+		return strings.HasPrefix(actual, prefix+"/")
+	}
+	return actual == expected
+}
+
+// verifyChecksum compares body's digest with an expected "algorithm:hex" checksum
+func verifyChecksum(body []byte, expected string) error {
+	algorithm, want, ok := strings.Cut(strings.ToLower(strings.TrimSpace(expected)), ":")
+	if !ok {
+		want = algorithm
+		switch len(want) {
+		case md5.Size * 2:
+			algorithm = "md5"
+		case sha1.Size * 2:
+			algorithm = "sha1"
+		default:
+			algorithm = "sha256"
+		}
+	}
+
+	var h hash.Hash
+	switch algorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha1":
+		h = sha1.New()
+	case "md5":
+		h = md5.New()
+	default:
+		return fmt.Errorf("unsupported checksum algorithm %q (use sha256, sha1 or md5)", algorithm)
+	}
+	h.Write(body)
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		return fmt.Errorf("%s checksum is %s, expected %s", algorithm, got, want)
+	}
+	return nil
+}
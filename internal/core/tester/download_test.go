@@ -0,0 +1,200 @@
+package tester
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExecuteRequestDownload(t *testing.T) {
+	pdf := []byte("%PDF-1.7\n" + strings.Repeat("x", 1000))
+	sum := sha256.Sum256(pdf)
+	checksum := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"no such report"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write(pdf)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	executor := NewExecutor(server.URL, nil)
+	executor.SetBinaryDir(dir)
+
+	t.Run("saved and verified", func(t *testing.T) {
+		saveTo := filepath.Join(dir, "reports", "monthly.pdf")
+		result, err := executor.ExecuteRequest(Request{Method: "GET", Endpoint: "/reports/1", Download: &Download{
+			SaveTo:            "reports/monthly.pdf",
+			ExpectContentType: "application/pdf",
+			ExpectSize:        len(pdf),
+			ExpectChecksum:    "sha256:" + checksum,
+		}})
+		if err != nil {
+			t.Fatalf("ExecuteRequest() error = %v", err)
+		}
+		if !result.Passed() || len(result.Download.Problems) > 0 {
+			t.Errorf("download problems = %v, want none", result.Download.Problems)
+		}
+		if result.Download.Path != saveTo || result.BinaryPath != saveTo {
+			t.Errorf("saved to %q, want %q", result.Download.Path, saveTo)
+		}
+		saved, err := os.ReadFile(saveTo)
+		if err != nil || string(saved) != string(pdf) {
+			t.Errorf("saved file differs from the response (err %v)", err)
+		}
+		if strings.Contains(result.ResponseBody, "%PDF") {
+			t.Errorf("ResponseBody = %q, want a summary instead of the file content", result.ResponseBody)
+		}
+	})
+
+	t.Run("expectations not met", func(t *testing.T) {
+		result, err := executor.ExecuteRequest(Request{Method: "GET", Endpoint: "/reports/1", Download: &Download{
+			SaveTo:            "generated/",
+			ExpectContentType: "image/*",
+			ExpectSize:        10,
+			ExpectChecksum:    strings.Repeat("0", 32),
+		}})
+		if err != nil {
+			t.Fatalf("ExecuteRequest() error = %v", err)
+		}
+		if result.Passed() || len(result.Download.Problems) != 3 {
+			t.Errorf("download problems = %v, want content type, size and checksum", result.Download.Problems)
+		}
+		if filepath.Dir(result.Download.Path) != filepath.Join(dir, "generated") || !strings.HasSuffix(result.Download.Path, ".pdf") {
+			t.Errorf("saved to %q, want a generated .pdf name in %s/generated", result.Download.Path, dir)
+		}
+	})
+
+	t.Run("error status not saved", func(t *testing.T) {
+		saveTo := filepath.Join(dir, "missing.pdf")
+		result, err := executor.ExecuteRequest(Request{Method: "GET", Endpoint: "/missing", Download: &Download{SaveTo: "missing.pdf"}})
+		if err != nil {
+			t.Fatalf("ExecuteRequest() error = %v", err)
+		}
+		if _, err := os.Stat(saveTo); !os.IsNotExist(err) {
+			t.Errorf("error response was saved to %s", saveTo)
+		}
+		if result.ResponseBody != `{"error":"no such report"}` || len(result.Download.Problems) != 1 {
+			t.Errorf("ResponseBody = %q, problems = %v", result.ResponseBody, result.Download.Problems)
+		}
+	})
+}
+
+func TestDownloadSaveTo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte("id\n" + strings.TrimPrefix(r.URL.Path, "/")))
+	}))
+	defer server.Close()
+
+	download := func(executor *Executor, endpoint string, d *Download) *DownloadResult {
+		t.Helper()
+		result, err := executor.ExecuteRequest(Request{Method: "GET", Endpoint: endpoint, Download: d})
+		if err != nil {
+			t.Fatalf("ExecuteRequest() error = %v", err)
+		}
+		return result.Download
+	}
+
+	t.Run("defaults to downloads/", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		got := download(NewExecutor(server.URL, nil), "/1", &Download{SaveTo: "export.csv"})
+		if want := filepath.Join("downloads", "export.csv"); got.Path != want || len(got.Problems) > 0 {
+			t.Errorf("saved to %q with problems %v, want %s", got.Path, got.Problems, want)
+		}
+	})
+
+	dir := t.TempDir()
+	executor := NewExecutor(server.URL, nil)
+	executor.SetBinaryDir(dir)
+
+	for _, saveTo := range []string{"/etc/passwd", "../outside.csv", "exports/../../outside.csv", filepath.Join(dir, "abs.csv")} {
+		t.Run("rejects "+saveTo, func(t *testing.T) {
+			got := download(executor, "/1", &Download{SaveTo: saveTo})
+			if got.Path != "" || len(got.Problems) != 1 || !strings.Contains(got.Problems[0], "must be a relative path") {
+				t.Errorf("saved to %q with problems %v, want it rejected", got.Path, got.Problems)
+			}
+		})
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "outside.csv")); !os.IsNotExist(err) {
+		t.Error("a download escaped the download directory")
+	}
+
+	t.Run("overwrite only when asked", func(t *testing.T) {
+		path := filepath.Join(dir, "export.csv")
+		download(executor, "/1", &Download{SaveTo: "export.csv"})
+
+		got := download(executor, "/2", &Download{SaveTo: "export.csv"})
+		if len(got.Problems) != 1 || !strings.Contains(got.Problems[0], "already exists") {
+			t.Errorf("problems = %v, want the existing file reported", got.Problems)
+		}
+		if data, _ := os.ReadFile(path); string(data) != "id\n1" {
+			t.Errorf("existing file = %q, want it kept", data)
+		}
+
+		got = download(executor, "/3", &Download{SaveTo: "export.csv", Overwrite: true})
+		if len(got.Problems) > 0 {
+			t.Errorf("problems = %v, want none with overwrite", got.Problems)
+		}
+		if data, _ := os.ReadFile(path); string(data) != "id\n3" {
+			t.Errorf("file = %q, want it replaced", data)
+		}
+	})
+
+	t.Run("symlinks can't lead outside", func(t *testing.T) {
+		outside := t.TempDir()
+		if err := os.Symlink(outside, filepath.Join(dir, "linked")); err != nil {
+			t.Skipf("symlinks unavailable: %v", err)
+		}
+		if err := os.Symlink(filepath.Join(outside, "target.csv"), filepath.Join(dir, "target.csv")); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, d := range []*Download{{SaveTo: "linked/export.csv"}, {SaveTo: "linked/"}, {SaveTo: "target.csv", Overwrite: true}} {
+			if got := download(executor, "/1", d); len(got.Problems) == 0 {
+				t.Errorf("save_to %q saved to %q, want it refused", d.SaveTo, got.Path)
+			}
+		}
+		if entries, _ := os.ReadDir(outside); len(entries) > 0 {
+			t.Errorf("downloads escaped through a symlink: %v", entries)
+		}
+	})
+}
+
+func TestMediaTypeMatches(t *testing.T) {
+	tests := []struct {
+		contentType, expected string
+		want                  bool
+	}{
+		{"application/pdf", "application/pdf", true},
+		{"text/csv; charset=utf-8", "TEXT/CSV", true},
+		{"image/png", "image/*", true},
+		{"application/json", "image/*", false},
+		{"application/zip", "application/pdf", false},
+	}
+	for _, tt := range tests {
+		if got := mediaTypeMatches(tt.contentType, tt.expected); got != tt.want {
+			t.Errorf("mediaTypeMatches(%q, %q) = %v, want %v", tt.contentType, tt.expected, got, tt.want)
+		}
+	}
+}
+
+func TestParseDownload(t *testing.T) {
+	if ParseDownload(nil) != nil || ParseDownload(map[string]any{"save_to": nil}) != nil {
+		t.Error("ParseDownload() of an empty value should be nil")
+	}
+	d := ParseDownload(map[string]any{"save_to": "out.csv", "overwrite": true, "expect_content_type": "text/csv", "expect_size": float64(42)})
+	if d == nil || d.SaveTo != "out.csv" || !d.Overwrite || d.ExpectContentType != "text/csv" || d.ExpectSize != 42 {
+		t.Errorf("ParseDownload() = %+v", d)
+	}
+}
@@ -3,33 +3,96 @@ package tester
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
 	"io"
 	"net/http"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 )
 
 type TestResult struct {
-	StatusCode   int
-	ResponseBody string
-	Duration     time.Duration
-	Error        error
+	StatusCode       int
+	ResponseBody     string
+	Duration         time.Duration
+	RequestBytes     int    // Size of the encoded request body
+	ResponseBytes    int    // Size of the response body as received
+	ContentType      string // Response Content-Type header
+	Binary           bool   // ResponseBody holds a summary because the real body is binary
+	BinaryPath       string // Where the binary body was saved, if saving is enabled
+	XMLError         error  // Why an XML response isn't well-formed; such bodies are left as received
+	Error            error
+	FailedAssertions []AssertionFailure
+	Attempts         int             // Times the request was sent under the retry policy; above 1 means it was retried
+	Download         *DownloadResult // Saved file and its verification, for requests with a Download
 }
 
+// Passed reports whether the request succeeded with a non-error status and all assertions held
+func (r *TestResult) Passed() bool {
+	return r.Error == nil && r.StatusCode < 400 && len(r.FailedAssertions) == 0 &&
+		(r.Download == nil || len(r.Download.Problems) == 0)
+}
+
+// FormatBytes renders a byte count with a binary unit, e.g. 512 B, 1.5 KB, 3.2 MB
+func FormatBytes(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := unit, 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
+
+// DefaultTimeout bounds each request, including reading the response, unless SetTimeout changes it
+const DefaultTimeout = 30 * time.Second
+
+// ErrTimeout is wrapped by the error of a request that didn't complete within the executor's timeout
+var ErrTimeout = errors.New("request timed out")
+
 type Executor struct {
 	baseURL      string
 	client       *http.Client
 	authProvider auth.AuthProvider
+	replayer     *Replayer
+	recorder     *Recorder
+	binaryDir    string
+	uploadDirs   []string // Where multipart uploads are read from; the working directory first
+	retry        RetryPolicy
+	proxy        string // Set with SetProxy; empty uses the proxy environment variables
+	tls          TLSOptions
+
+	volatileFields []string
+	variables      map[string]any // Values captured from earlier responses, referenced as {{name}}
+
+	lastMu      sync.Mutex
+	lastRequest *sentRequest // Most recent request, for LastCurl
+}
+
+// sentRequest is a request as the executor sent it, after variable expansion
+type sentRequest struct {
+	method       string
+	url          string
+	headers      map[string]string
+	body         any
+	authProvider auth.AuthProvider
 }
 
 func NewExecutor(baseURL string, authProvider auth.AuthProvider) *Executor {
 	return &Executor{
 		baseURL:      baseURL,
 		authProvider: authProvider,
+		uploadDirs:   []string{"."},
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Transport: newTransport(),
+			Timeout:   DefaultTimeout,
 		},
 	}
 }
@@ -39,70 +102,339 @@ func (e *Executor) UpdateAuthProvider(authProvider auth.AuthProvider) {
 	e.authProvider = authProvider
 }
 
+// SetTimeout bounds how long each request may take; zero waits indefinitely
+func (e *Executor) SetTimeout(timeout time.Duration) {
+	e.client.Timeout = timeout
+}
+
+// Timeout returns the per-request timeout
+func (e *Executor) Timeout() time.Duration {
+	return e.client.Timeout
+}
+
+// timeoutError wraps ErrTimeout when err means the request ran out of time
+func (e *Executor) timeoutError(err error) (error, bool) {
+	if isTimeout(err) {
+		return fmt.Errorf("%w after %s", ErrTimeout, e.client.Timeout), true
+	}
+	return err, false
+}
+
+// SetRetryPolicy resends requests that fail the way p describes
+func (e *Executor) SetRetryPolicy(p RetryPolicy) {
+	e.retry = p
+}
+
+// RetryPolicy returns the policy failed requests are retried under
+func (e *Executor) RetryPolicy() RetryPolicy {
+	return e.retry
+}
+
+// SetVolatileFields sets the JSONPaths whose values are ignored when response bodies are compared
+func (e *Executor) SetVolatileFields(patterns []string) {
+	e.volatileFields = patterns
+}
+
+// SetReplayer serves saved responses from r instead of sending requests
+func (e *Executor) SetReplayer(r *Replayer) {
+	e.replayer = r
+}
+
+// SetRecorder saves every real request/response pair through r
+func (e *Executor) SetRecorder(r *Recorder) {
+	e.recorder = r
+}
+
+// LastCurl returns a curl command repeating the most recent request, or "" before the first one.
+// With redact the credentials are hidden.
+func (e *Executor) LastCurl(redact bool) string {
+	e.lastMu.Lock()
+	last := e.lastRequest
+	e.lastMu.Unlock()
+	if last == nil {
+		return ""
+	}
+
+	authProvider := last.authProvider
+	if redact && authProvider != nil {
+		authProvider = authProvider.Redact()
+	}
+	return ToCurl(last.method, last.url, last.headers, last.body, authProvider)
+}
+
+// SetSpecPath lets multipart uploads also read files from the directory of the spec at specPath.
+// Remote specs add nothing.
+func (e *Executor) SetSpecPath(specPath string) {
+	e.uploadDirs = []string{"."}
+	if specPath != "" && !strings.Contains(specPath, "://") {
+		if dir := filepath.Dir(specPath); dir != "." {
+			e.uploadDirs = append(e.uploadDirs, dir)
+		}
+	}
+}
+
+// SetBinaryDir saves binary response bodies to dir; they are otherwise only summarized
+func (e *Executor) SetBinaryDir(dir string) {
+	e.binaryDir = dir
+}
+
 func (e *Executor) ExecuteTest(method, endpoint string, headers map[string]string, body any) (*TestResult, error) {
+	return e.execute(e.authProvider, method, endpoint, headers, body, nil)
+}
+
+// execute sends a request authenticated by authProvider rather than the executor's own provider,
+// so concurrent requests can use different credentials. A non-nil download saves and verifies the body.
+func (e *Executor) execute(authProvider auth.AuthProvider, method, endpoint string, headers map[string]string, body any, download *Download) (*TestResult, error) {
 	startTime := time.Now()
 
+	endpoint, headers, body = e.expandVariables(endpoint, headers, body)
+
 	// Build full URL
 	fullURL := e.baseURL + endpoint
 	if !strings.HasPrefix(fullURL, "http://") && !strings.HasPrefix(fullURL, "https://") {
 		fullURL = "http://" + fullURL
 	}
 
+	e.lastMu.Lock()
+	e.lastRequest = &sentRequest{method: method, url: fullURL, headers: headers, body: body, authProvider: authProvider}
+	e.lastMu.Unlock()
+
 	// Prepare request body
-	var reqBody io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
+	jsonBody, contentType, err := encodeBody(body, headers, e.uploadDirs)
+	if err != nil {
+		return &TestResult{Error: fmt.Errorf("failed to marshal body: %w", err)}, err
+	}
+
+	if e.replayer != nil {
+		fixture, err := e.replayer.Lookup(method, endpoint, jsonBody)
 		if err != nil {
-			return &TestResult{Error: fmt.Errorf("failed to marshal body: %w", err)}, err
+			return &TestResult{Error: err}, err
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
+		return &TestResult{
+			StatusCode:    fixture.StatusCode,
+			ResponseBody:  fixture.Body,
+			Duration:      time.Since(startTime),
+			RequestBytes:  len(jsonBody),
+			ResponseBytes: len(fixture.Body),
+			Attempts:      1,
+		}, nil
 	}
 
-	// Create request
-	req, err := http.NewRequest(method, fullURL, reqBody)
-	if err != nil {
-		return &TestResult{Error: fmt.Errorf("failed to create request: %w", err)}, err
+	// Providers rotating several keys get one attempt per key when rate limited. Rotations are
+	// counted apart from challenge, refresh and transient retries, which don't switch keys on purpose.
+	keys, rotations := 1, 1
+	if rotator, ok := authProvider.(auth.KeyRotator); ok {
+		keys = rotator.KeyCount()
 	}
 
-	// Add headers
-	req.Header.Set("Content-Type", "application/json")
-	for key, value := range headers {
-		req.Header.Set(key, value)
-	}
+	refresher, _ := authProvider.(auth.TokenRefresher)
+	refreshed := false
+	responder, _ := authProvider.(auth.ChallengeResponder)
+	challenged := false
 
-	// Apply authentication
-	if e.authProvider != nil {
-		if err := e.authProvider.Apply(req); err != nil {
-			return &TestResult{Error: fmt.Errorf("failed to apply auth: %w", err)}, err
+	retries := 0
+
+	var resp *http.Response
+	for {
+		var reqBody io.Reader
+		if jsonBody != nil {
+			reqBody = bytes.NewReader(jsonBody)
 		}
-	}
 
-	// Execute request
-	resp, err := e.client.Do(req)
-	duration := time.Since(startTime)
+		// Create request
+		req, err := http.NewRequest(method, fullURL, reqBody)
+		if err != nil {
+			return &TestResult{Error: fmt.Errorf("failed to create request: %w", err)}, err
+		}
 
-	if err != nil {
-		return &TestResult{
-			Duration: duration,
-			Error:    fmt.Errorf("request failed: %w", err),
-		}, err
+		// Add headers; a multipart form keeps its own Content-Type, which carries the part boundary
+		req.Header.Set("Content-Type", contentType)
+		_, multipart := body.(*Multipart)
+		for key, value := range headers {
+			if multipart && strings.EqualFold(key, "Content-Type") {
+				continue
+			}
+			req.Header.Set(key, value)
+		}
+
+		// Apply authentication
+		if authProvider != nil {
+			if signer, ok := authProvider.(auth.RequestSigner); ok {
+				err = signer.ApplyToRequest(req, jsonBody)
+			} else {
+				err = authProvider.Apply(req)
+			}
+			if err != nil {
+				return &TestResult{Error: fmt.Errorf("failed to apply auth: %w", err)}, err
+			}
+		}
+
+		// Execute request
+		resp, err = e.client.Do(req)
+		if err != nil {
+			if retries < e.retry.Retries && e.retry.retryError(err) {
+				retries++
+				e.retry.wait(retries)
+				continue
+			}
+			if timeoutErr, ok := e.timeoutError(err); ok {
+				return &TestResult{Duration: time.Since(startTime), RequestBytes: len(jsonBody), Error: timeoutErr, Attempts: retries + 1}, timeoutErr
+			}
+			return &TestResult{
+				Duration:     time.Since(startTime),
+				RequestBytes: len(jsonBody),
+				Error:        fmt.Errorf("request failed: %w", err),
+				Attempts:     retries + 1,
+			}, err
+		}
+
+		// Challenge-based schemes (Digest) answer the server's 401 and retry once
+		if resp.StatusCode == http.StatusUnauthorized && responder != nil && !challenged {
+			challenged = true
+			if responder.HandleChallenge(resp) {
+				_ = resp.Body.Close()
+				continue
+			}
+		}
+
+		// The token may have expired or been revoked mid-session: renew it and retry once
+		if resp.StatusCode == http.StatusUnauthorized && refresher != nil && !refreshed {
+			refreshed = true
+			if refresher.Refresh() == nil {
+				_ = resp.Body.Close()
+				continue
+			}
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests && rotations < keys {
+			_ = resp.Body.Close()
+			rotations++
+			continue
+		}
+
+		if retries < e.retry.Retries && e.retry.retryStatus(resp.StatusCode) {
+			_ = resp.Body.Close()
+			retries++
+			e.retry.wait(retries)
+			continue
+		}
+		break
 	}
+	duration := time.Since(startTime)
 	defer func() { _ = resp.Body.Close() }()
 
 	// Read response
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
+		if timeoutErr, ok := e.timeoutError(err); ok {
+			err = timeoutErr
+		}
 		return &TestResult{
-			StatusCode: resp.StatusCode,
-			Duration:   duration,
-			Error:      fmt.Errorf("failed to read response: %w", err),
+			StatusCode:   resp.StatusCode,
+			Duration:     duration,
+			RequestBytes: len(jsonBody),
+			Error:        fmt.Errorf("failed to read response: %w", err),
+			Attempts:     retries + 1,
 		}, err
 	}
 
-	return &TestResult{
-		StatusCode:   resp.StatusCode,
-		ResponseBody: string(respBody),
-		Duration:     duration,
-		Error:        nil,
-	}, nil
+	if e.recorder != nil {
+		// Recording is best effort; a failed write must not fail the test
+		_ = e.recorder.Record(Fixture{
+			Method:         strings.ToUpper(method),
+			Path:           endpoint,
+			BodyHash:       BodyHash(jsonBody),
+			RequestHeaders: headers,
+			RequestBody:    string(jsonBody),
+			StatusCode:     resp.StatusCode,
+			Body:           string(respBody),
+			DurationMs:     duration.Milliseconds(),
+			RecordedAt:     time.Now().UTC(),
+		})
+	}
+
+	result := &TestResult{
+		StatusCode:    resp.StatusCode,
+		ResponseBody:  string(respBody),
+		Duration:      duration,
+		RequestBytes:  len(jsonBody),
+		ResponseBytes: len(respBody),
+		ContentType:   resp.Header.Get("Content-Type"),
+		Error:         nil,
+		Attempts:      retries + 1,
+	}
+
+	if download != nil {
+		dir := e.binaryDir
+		if dir == "" {
+			dir = defaultDownloadDir
+		}
+		result.Download = saveDownload(download, dir, method, endpoint, result.StatusCode, result.ContentType, respBody)
+		if result.StatusCode >= 200 && result.StatusCode < 300 {
+			// The file is what's being tested; its content is never shown as text
+			result.Binary = true
+			result.BinaryPath = result.Download.Path
+			result.ResponseBody = BinarySummary(result.ContentType, len(respBody), result.BinaryPath)
+			return result, nil
+		}
+	}
+
+	// Binary bodies are useless (and costly) as text, so callers and the model only see a summary
+	if IsBinaryResponse(result.ContentType, respBody) {
+		result.Binary = true
+		if e.binaryDir != "" {
+			// Saving is best effort; the summary just omits the path when it fails
+			path, err := saveBinaryBody(e.binaryDir, method, endpoint, result.ContentType, respBody)
+			if err == nil {
+				result.BinaryPath = path
+			}
+		}
+		result.ResponseBody = BinarySummary(result.ContentType, len(respBody), result.BinaryPath)
+	} else if parser.IsXMLMediaType(result.ContentType) && len(bytes.TrimSpace(respBody)) > 0 {
+		if formatted, err := FormatXML(result.ResponseBody); err != nil {
+			result.XMLError = err
+		} else {
+			result.ResponseBody = formatted
+		}
+	}
+
+	return result, nil
+}
+
+// encodeBody serializes a request body as JSON and returns the default Content-Type for it.
+// String bodies sent with an explicit non-JSON Content-Type (e.g. form data) are sent as-is,
+// as are XML documents, which default to application/xml. Multipart files are read from uploadDirs.
+func encodeBody(body any, headers map[string]string, uploadDirs []string) ([]byte, string, error) {
+	if body == nil {
+		return nil, "application/json", nil
+	}
+	if form, ok := body.(*Multipart); ok {
+		return form.encode(uploadDirs)
+	}
+	if s, ok := body.(string); ok {
+		explicit := false
+		for name, value := range headers {
+			if strings.EqualFold(name, "Content-Type") {
+				explicit = true
+				if !strings.Contains(strings.ToLower(value), "json") {
+					return []byte(s), value, nil
+				}
+			}
+		}
+		if !explicit && looksLikeXML(s) {
+			return []byte(s), "application/xml", nil
+		}
+	}
+	data, err := json.Marshal(body)
+	return data, "application/json", err
+}
+
+// ExecuteTestWithAssertions executes a test and evaluates the given assertions against the response body
+func (e *Executor) ExecuteTestWithAssertions(method, endpoint string, headers map[string]string, body any, expect []Assertion) (*TestResult, error) {
+	result, err := e.ExecuteTest(method, endpoint, headers, body)
+	if err != nil {
+		return result, err
+	}
+	result.FailedAssertions = EvaluateAssertions(result.ResponseBody, expect)
+	return result, nil
 }
@@ -0,0 +1,334 @@
+package tester
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecuteTestRecordsSizes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("x", 2048)))
+	}))
+	defer server.Close()
+
+	result, err := NewExecutor(server.URL, nil).ExecuteTest("POST", "/items", nil, map[string]any{"id": 1})
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if result.RequestBytes != len(`{"id":1}`) {
+		t.Errorf("RequestBytes = %d, want %d", result.RequestBytes, len(`{"id":1}`))
+	}
+	if result.ResponseBytes != 2048 {
+		t.Errorf("ResponseBytes = %d, want 2048", result.ResponseBytes)
+	}
+}
+
+func TestExecuteTestAPIKeyInQuery(t *testing.T) {
+	var gotQuery, gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		gotHeader = r.Header.Get("api_key")
+	}))
+	defer server.Close()
+
+	executor := NewExecutor(server.URL, auth.NewAPIKeyAuth("api_key", "s3cret value", "query"))
+	if _, err := executor.ExecuteTest("GET", "/items?limit=5&sort=name", nil, nil); err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if want := "limit=5&sort=name&api_key=s3cret+value"; gotQuery != want {
+		t.Errorf("query = %q, want %q", gotQuery, want)
+	}
+	if gotHeader != "" {
+		t.Errorf("API key was also sent as a header: %q", gotHeader)
+	}
+}
+
+func TestExecuteTestRefreshesOAuth2TokenOn401(t *testing.T) {
+	var issued int
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issued++
+		_, _ = w.Write([]byte(`{"access_token":"token-` + strconv.Itoa(issued) + `","token_type":"bearer"}`))
+	}))
+	defer tokenServer.Close()
+
+	// The API has revoked the first token and only accepts the second
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer api.Close()
+
+	provider := auth.NewOAuth2ClientCredentials(tokenServer.URL, "client", "secret", nil)
+	result, err := NewExecutor(api.URL, provider).ExecuteTest("GET", "/me", nil, nil)
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if result.StatusCode != http.StatusOK || issued != 2 {
+		t.Errorf("status = %d after %d token fetches, want 200 after 2", result.StatusCode, issued)
+	}
+}
+
+func TestExecuteTestRefreshesBearerTokenOn401(t *testing.T) {
+	tests := []struct {
+		name         string
+		refresh      func() (string, error)
+		wantStatus   int
+		wantRequests int
+	}{
+		{"retries once with refreshed token", func() (string, error) { return "fresh", nil }, http.StatusOK, 2},
+		{"no refresh func", nil, http.StatusUnauthorized, 1},
+		{"refresh fails", func() (string, error) { return "", errors.New("boom") }, http.StatusUnauthorized, 1},
+		{"refreshed token also rejected", func() (string, error) { return "still-stale", nil }, http.StatusUnauthorized, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Header.Get("Authorization") != "Bearer fresh" {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+			}))
+			defer server.Close()
+
+			provider := auth.NewBearerAuth("stale")
+			provider.RefreshFunc = tt.refresh
+			result, err := NewExecutor(server.URL, provider).ExecuteTest("GET", "/me", nil, nil)
+			if err != nil {
+				t.Fatalf("ExecuteTest() error = %v", err)
+			}
+			if result.StatusCode != tt.wantStatus || requests != tt.wantRequests {
+				t.Errorf("status = %d after %d requests, want %d after %d", result.StatusCode, requests, tt.wantStatus, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestExecuteTestAnswersDigestChallenge(t *testing.T) {
+	md5hex := func(s string) string {
+		sum := md5.Sum([]byte(s))
+		return hex.EncodeToString(sum[:])
+	}
+
+	// The stub challenges unauthenticated requests and checks the digest itself
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		authz := r.Header.Get("Authorization")
+		params := map[string]string{}
+		for _, part := range strings.Split(strings.TrimPrefix(authz, "Digest "), ", ") {
+			if k, v, ok := strings.Cut(part, "="); ok {
+				params[k] = strings.Trim(v, `"`)
+			}
+		}
+		ha1 := md5hex("alice:api:s3cret")
+		ha2 := md5hex(r.Method + ":" + r.URL.RequestURI())
+		want := md5hex(strings.Join([]string{ha1, "n0nce", params["nc"], params["cnonce"], "auth", ha2}, ":"))
+		if !strings.HasPrefix(authz, "Digest ") || params["response"] != want {
+			w.Header().Set("WWW-Authenticate", `Digest realm="api", qop="auth", nonce="n0nce", opaque="op"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		password     string
+		wantStatus   int
+		wantRequests int
+	}{
+		{"correct password", "s3cret", http.StatusOK, 2},
+		{"wrong password", "wrong", http.StatusUnauthorized, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = 0
+			result, err := NewExecutor(server.URL, auth.NewDigestAuth("alice", tt.password)).ExecuteTest("GET", "/items?page=2", nil, nil)
+			if err != nil {
+				t.Fatalf("ExecuteTest() error = %v", err)
+			}
+			if result.StatusCode != tt.wantStatus || requests != tt.wantRequests {
+				t.Errorf("status = %d after %d requests, want %d after %d", result.StatusCode, requests, tt.wantStatus, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{5 * 1024 * 1024, "5.0 MB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.n); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestExecuteTestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	executor := NewExecutor(server.URL, nil)
+	if executor.Timeout() != DefaultTimeout {
+		t.Errorf("Timeout() = %s, want %s", executor.Timeout(), DefaultTimeout)
+	}
+	executor.SetTimeout(50 * time.Millisecond)
+	// The per-test auth swap must keep the configured timeout
+	executor.UpdateAuthProvider(&auth.NoAuth{})
+
+	result, err := executor.ExecuteTest("GET", "/slow", nil, nil)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("ExecuteTest() error = %v, want ErrTimeout", err)
+	}
+	if !errors.Is(result.Error, ErrTimeout) || result.Duration >= time.Second {
+		t.Errorf("result error = %v after %s, want ErrTimeout within the timeout", result.Error, result.Duration)
+	}
+}
+
+func TestExecuteTestRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	executor := NewExecutor(server.URL, nil)
+	result, err := executor.ExecuteTest("GET", "/flaky", nil, nil)
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if result.StatusCode != http.StatusServiceUnavailable || result.Attempts != 1 {
+		t.Errorf("without retries got status %d after %d attempts, want 503 after 1", result.StatusCode, result.Attempts)
+	}
+
+	calls = 0
+	policy, err := ParseRetryOn(3, DefaultRetryOn)
+	if err != nil {
+		t.Fatalf("ParseRetryOn() error = %v", err)
+	}
+	policy.BaseDelay = time.Millisecond
+	executor.SetRetryPolicy(policy)
+
+	result, err = executor.ExecuteTest("GET", "/flaky", nil, nil)
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if result.StatusCode != http.StatusOK || result.Attempts != 3 || calls != 3 {
+		t.Errorf("got status %d after %d attempts (%d calls), want 200 after 3", result.StatusCode, result.Attempts, calls)
+	}
+}
+
+func TestExecuteTestRotationAfterRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case calls <= 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.Header.Get("X-API-Key") == "c":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	defer server.Close()
+
+	// Two transient retries use keys a and b; c is rate limited and must still rotate on to a
+	executor := NewExecutor(server.URL, auth.NewAPIKeyAuthRotating("X-API-Key", []string{"a", "b", "c"}, "header"))
+	executor.SetRetryPolicy(RetryPolicy{Retries: 2, Statuses: []string{"503"}, BaseDelay: time.Millisecond})
+
+	result, err := executor.ExecuteTest("GET", "/limited", nil, nil)
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if result.StatusCode != http.StatusOK || calls != 4 {
+		t.Errorf("got status %d after %d calls, want 200 after 4", result.StatusCode, calls)
+	}
+}
+
+func TestExecuteTestRetriesExhausted(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	executor := NewExecutor(server.URL, nil)
+	executor.SetRetryPolicy(RetryPolicy{Retries: 2, Statuses: []string{"502"}, BaseDelay: time.Millisecond})
+
+	result, _ := executor.ExecuteTest("GET", "/down", nil, nil)
+	if result.StatusCode != http.StatusBadGateway || result.Attempts != 3 || calls != 3 {
+		t.Errorf("got status %d after %d attempts (%d calls), want 502 after 3", result.StatusCode, result.Attempts, calls)
+	}
+}
+
+func TestExecuteTestRetriesConnectionErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	executor := NewExecutor(url, nil)
+	executor.SetRetryPolicy(RetryPolicy{Retries: 2, Connection: true, BaseDelay: time.Millisecond})
+
+	result, err := executor.ExecuteTest("GET", "/", nil, nil)
+	if err == nil {
+		t.Fatal("ExecuteTest() error = nil, want connection error")
+	}
+	if result.Attempts != 3 {
+		t.Errorf("Attempts = %d, want 3", result.Attempts)
+	}
+}
+
+func TestParseRetryOn(t *testing.T) {
+	policy, err := ParseRetryOn(2, "connection, 429,5xx")
+	if err != nil {
+		t.Fatalf("ParseRetryOn() error = %v", err)
+	}
+	if !policy.Connection || policy.Timeout {
+		t.Errorf("Connection = %v, Timeout = %v, want true, false", policy.Connection, policy.Timeout)
+	}
+	for status, want := range map[int]bool{429: true, 500: true, 503: true, 404: false, 200: false} {
+		if got := policy.retryStatus(status); got != want {
+			t.Errorf("retryStatus(%d) = %v, want %v", status, got, want)
+		}
+	}
+
+	for _, on := range []string{"6xx", "abc", "42"} {
+		if _, err := ParseRetryOn(1, on); err == nil {
+			t.Errorf("ParseRetryOn(%q) error = nil, want error", on)
+		}
+	}
+	if _, err := ParseRetryOn(-1, ""); err == nil {
+		t.Error("ParseRetryOn(-1) error = nil, want error")
+	}
+}
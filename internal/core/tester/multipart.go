@@ -0,0 +1,225 @@
+package tester
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// quoteEscaper escapes quoted Content-Disposition parameters, as mime/multipart does
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// Multipart is a multipart/form-data request body, for endpoints that take file uploads.
+// Passed as the body of a request, it is sent with a Content-Type carrying the part boundary.
+type Multipart struct {
+	Fields map[string]string        `json:"fields,omitempty"` // Text fields, name → value
+	Files  map[string]MultipartFile `json:"files,omitempty"`  // File fields, name → file
+}
+
+// MultipartFile is a file sent in a multipart form, read from Path or given inline as Content
+type MultipartFile struct {
+	Path        string `json:"path,omitempty"`         // Relative to the working directory or the spec's directory
+	Content     string `json:"content,omitempty"`      // Used instead of reading Path
+	Filename    string `json:"filename,omitempty"`     // Defaults to the base name of Path
+	ContentType string `json:"content_type,omitempty"` // Defaults from the file name's extension
+}
+
+// ParseMultipart converts a decoded "multipart" value into a Multipart, or nil when absent.
+// A file can be given as a path string or as an object with path, content, filename and content_type.
+func ParseMultipart(raw any) *Multipart {
+	switch v := raw.(type) {
+	case *Multipart:
+		return v
+	case Multipart:
+		return &v
+	case map[string]any:
+		form := &Multipart{}
+		if fields, ok := v["fields"].(map[string]any); ok {
+			form.Fields = make(map[string]string, len(fields))
+			for name, value := range fields {
+				if value != nil {
+					form.Fields[name] = variableString(value)
+				}
+			}
+		}
+		if files, ok := v["files"].(map[string]any); ok {
+			form.Files = make(map[string]MultipartFile, len(files))
+			for name, value := range files {
+				switch f := value.(type) {
+				case string:
+					form.Files[name] = MultipartFile{Path: f}
+				case map[string]any:
+					file := MultipartFile{}
+					file.Path, _ = f["path"].(string)
+					file.Content, _ = f["content"].(string)
+					file.Filename, _ = f["filename"].(string)
+					file.ContentType, _ = f["content_type"].(string)
+					form.Files[name] = file
+				}
+			}
+		}
+		if len(form.Fields) == 0 && len(form.Files) == 0 {
+			return nil
+		}
+		return form
+	}
+	return nil
+}
+
+// UnmarshalJSON accepts a file given as just its path, as well as the object form
+func (f *MultipartFile) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		*f = MultipartFile{Path: path}
+		return nil
+	}
+	type plain MultipartFile
+	return json.Unmarshal(data, (*plain)(f))
+}
+
+// filename returns the name the file is sent under
+func (f MultipartFile) filename() string {
+	if f.Filename != "" {
+		return f.Filename
+	}
+	if f.Path != "" {
+		return filepath.Base(f.Path)
+	}
+	return "file"
+}
+
+// contentType returns the file's media type, guessed from its name when not set
+func (f MultipartFile) contentType() string {
+	if f.ContentType != "" {
+		return f.ContentType
+	}
+	if guessed := mime.TypeByExtension(filepath.Ext(f.filename())); guessed != "" {
+		return guessed
+	}
+	return "application/octet-stream"
+}
+
+// FilePaths returns the paths of the local files the form uploads, sorted, for showing them
+// before the request is sent
+func (m *Multipart) FilePaths() []string {
+	var paths []string
+	for _, file := range m.Files {
+		if file.Content == "" && file.Path != "" {
+			paths = append(paths, file.Path)
+		}
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// read returns the file's content. Path is looked up in dirs in order and, like save_to, must be a
+// relative path that stays inside them, so a plan can't upload arbitrary files such as ~/.ssh keys.
+func (f MultipartFile) read(dirs []string) ([]byte, error) {
+	if f.Content != "" || f.Path == "" {
+		return []byte(f.Content), nil
+	}
+	rel := filepath.Clean(filepath.FromSlash(f.Path))
+	if filepath.IsAbs(rel) || strings.HasPrefix(f.Path, "/") || !filepath.IsLocal(rel) {
+		return nil, fmt.Errorf("upload path %q must be a relative path inside the working directory or the spec's directory", f.Path)
+	}
+
+	var err error
+	for _, dir := range dirs {
+		var data []byte
+		if data, err = readInDir(dir, rel); err == nil {
+			return data, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+	}
+	return nil, fmt.Errorf("failed to read upload: %w", err)
+}
+
+// readInDir reads name inside dir, refusing symlinks that lead out of it
+func readInDir(dir, name string) ([]byte, error) {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = root.Close() }()
+	return root.ReadFile(name)
+}
+
+// encode builds the form body and its Content-Type, reading files from dirs. Parts are written in
+// name order under a boundary derived from their content, so the same form always encodes the
+// same way and replay fixtures keep matching.
+func (m *Multipart) encode(dirs []string) ([]byte, string, error) {
+	contents := make(map[string][]byte, len(m.Files))
+	digest := sha256.New()
+	for _, name := range slices.Sorted(maps.Keys(m.Fields)) {
+		fmt.Fprintf(digest, "%s=%s\n", name, m.Fields[name])
+	}
+	for _, name := range slices.Sorted(maps.Keys(m.Files)) {
+		data, err := m.Files[name].read(dirs)
+		if err != nil {
+			return nil, "", err
+		}
+		contents[name] = data
+		fmt.Fprintf(digest, "%s@%s\n", name, m.Files[name].filename())
+		digest.Write(data)
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	if err := writer.SetBoundary("octrafic-" + hex.EncodeToString(digest.Sum(nil))[:32]); err != nil {
+		return nil, "", err
+	}
+	for _, name := range slices.Sorted(maps.Keys(m.Fields)) {
+		if err := writer.WriteField(name, m.Fields[name]); err != nil {
+			return nil, "", err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(m.Files)) {
+		file := m.Files[name]
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(name), quoteEscaper.Replace(file.filename())))
+		header.Set("Content-Type", file.contentType())
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(contents[name]); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
+
+// curlArgs returns the -F options that send the form with curl
+func (m *Multipart) curlArgs() []string {
+	var args []string
+	for _, name := range slices.Sorted(maps.Keys(m.Fields)) {
+		args = append(args, "-F", shellQuote(name+"="+m.Fields[name]))
+	}
+	for _, name := range slices.Sorted(maps.Keys(m.Files)) {
+		file := m.Files[name]
+		value := name + "=@" + file.Path
+		if file.Content != "" || file.Path == "" {
+			value = name + "=" + file.Content
+		}
+		value += ";filename=" + file.filename() + ";type=" + file.contentType()
+		args = append(args, "-F", shellQuote(value))
+	}
+	return args
+}
@@ -0,0 +1,170 @@
+package tester
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExecuteTestMultipartUpload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("avatar")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer func() { _ = file.Close() }()
+		content, _ := io.ReadAll(file)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"filename":     header.Filename,
+			"content_type": header.Header.Get("Content-Type"),
+			"content":      string(content),
+			"title":        r.FormValue("title"),
+		})
+	}))
+	defer server.Close()
+
+	form := &Multipart{
+		Fields: map[string]string{"title": "Profile picture"},
+		Files:  map[string]MultipartFile{"avatar": {Filename: "avatar.png", Content: "fake png bytes"}},
+	}
+	// A Content-Type header without the boundary must not replace the form's own
+	headers := map[string]string{"Content-Type": "multipart/form-data"}
+	result, err := NewExecutor(server.URL, nil).ExecuteTest("POST", "/avatar", headers, form)
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if result.StatusCode != http.StatusOK {
+		t.Fatalf("StatusCode = %d, body %s", result.StatusCode, result.ResponseBody)
+	}
+
+	var echoed map[string]string
+	if err := json.Unmarshal([]byte(result.ResponseBody), &echoed); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := map[string]string{"filename": "avatar.png", "content_type": "image/png", "content": "fake png bytes", "title": "Profile picture"}
+	for key, value := range want {
+		if echoed[key] != value {
+			t.Errorf("%s = %q, want %q", key, echoed[key], value)
+		}
+	}
+}
+
+func TestMultipartEncodeIsStable(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "report.csv"), []byte("a,b\n1,2\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	form := &Multipart{Fields: map[string]string{"b": "2", "a": "1"}, Files: map[string]MultipartFile{"report": {Path: "report.csv"}}}
+
+	first, contentType, err := form.encode([]string{dir})
+	if err != nil {
+		t.Fatalf("encode() error = %v", err)
+	}
+	second, _, _ := form.encode([]string{dir})
+	if string(first) != string(second) {
+		t.Error("encoding the same form twice gave different bodies")
+	}
+	if !strings.HasPrefix(contentType, "multipart/form-data; boundary=") {
+		t.Errorf("Content-Type = %q", contentType)
+	}
+	if !strings.Contains(string(first), `filename="report.csv"`) || strings.Index(string(first), `name="a"`) > strings.Index(string(first), `name="b"`) {
+		t.Errorf("unexpected body:\n%s", first)
+	}
+
+	form.Files["report"] = MultipartFile{Path: "missing.csv"}
+	if _, _, err := form.encode([]string{dir}); err == nil {
+		t.Error("encode() with a missing file succeeded, want an error")
+	}
+}
+
+func TestMultipartUploadPaths(t *testing.T) {
+	workDir, specDir, outside := t.TempDir(), t.TempDir(), t.TempDir()
+	t.Chdir(workDir)
+	for dir, name := range map[string]string{workDir: "local.txt", specDir: "fixture.txt", outside: "secret.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(outside, "secret.txt"), filepath.Join(workDir, "link.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer func() { _ = file.Close() }()
+		_, _ = io.Copy(w, file)
+	}))
+	defer server.Close()
+	executor := NewExecutor(server.URL, nil)
+	executor.SetSpecPath(filepath.Join(specDir, "openapi.yaml"))
+
+	// Files are found in the working directory, then next to the spec
+	for _, path := range []string{"local.txt", "fixture.txt"} {
+		form := &Multipart{Files: map[string]MultipartFile{"file": {Path: path}}}
+		result, err := executor.ExecuteTest("POST", "/upload", nil, form)
+		if err != nil || result.ResponseBody != path {
+			t.Errorf("upload of %s: body %q, error %v", path, result.ResponseBody, err)
+		}
+	}
+
+	// Nothing outside them, whether by absolute path, .. or a symlink
+	for _, path := range []string{filepath.Join(outside, "secret.txt"), "../" + filepath.Base(outside) + "/secret.txt", "link.txt"} {
+		form := &Multipart{Files: map[string]MultipartFile{"file": {Path: path}}}
+		if _, err := executor.ExecuteTest("POST", "/upload", nil, form); err == nil {
+			t.Errorf("upload of %s succeeded, want it refused", path)
+		}
+	}
+
+	form := &Multipart{Files: map[string]MultipartFile{"b": {Path: "b.txt"}, "a": {Path: "a.txt"}, "inline": {Content: "x"}}}
+	if got := form.FilePaths(); strings.Join(got, ",") != "a.txt,b.txt" {
+		t.Errorf("FilePaths() = %v, want a.txt and b.txt", got)
+	}
+}
+
+func TestParseMultipart(t *testing.T) {
+	var decoded map[string]any
+	_ = json.Unmarshal([]byte(`{"fields": {"title": "x", "count": 2}, "files": {"doc": "docs/a.pdf", "img": {"content": "abc", "filename": "a.png"}}}`), &decoded)
+
+	form := ParseMultipart(decoded)
+	if form == nil {
+		t.Fatal("ParseMultipart() = nil")
+	}
+	if form.Fields["title"] != "x" || form.Fields["count"] != "2" {
+		t.Errorf("Fields = %v", form.Fields)
+	}
+	if form.Files["doc"].Path != "docs/a.pdf" || form.Files["img"].Content != "abc" || form.Files["img"].Filename != "a.png" {
+		t.Errorf("Files = %+v", form.Files)
+	}
+
+	if ParseMultipart(nil) != nil || ParseMultipart(map[string]any{}) != nil {
+		t.Error("ParseMultipart() of an empty value should be nil")
+	}
+
+	// Saved test plans use the same short form for files
+	var fromJSON Multipart
+	if err := json.Unmarshal([]byte(`{"files": {"doc": "docs/a.pdf"}}`), &fromJSON); err != nil || fromJSON.Files["doc"].Path != "docs/a.pdf" {
+		t.Errorf("json.Unmarshal() = %+v, %v", fromJSON, err)
+	}
+}
+
+func TestToCurlMultipart(t *testing.T) {
+	form := &Multipart{
+		Fields: map[string]string{"title": "x"},
+		Files:  map[string]MultipartFile{"doc": {Path: "docs/a.pdf"}},
+	}
+	got := ToCurl("POST", "https://api.example.com/upload", map[string]string{"Content-Type": "multipart/form-data"}, form, nil)
+	want := `curl -X POST 'https://api.example.com/upload' -F 'title=x' -F 'doc=@docs/a.pdf;filename=a.pdf;type=application/pdf'`
+	if got != want {
+		t.Errorf("ToCurl() =\n%s\nwant\n%s", got, want)
+	}
+}
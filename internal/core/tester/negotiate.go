@@ -0,0 +1,101 @@
+package tester
+
+import (
+	"mime"
+	"slices"
+	"strings"
+)
+
+// Representation is one response of a content negotiation comparison
+type Representation struct {
+	Accept        string
+	StatusCode    int
+	ContentType   string
+	Body          string
+	MatchesAccept bool // The response's Content-Type is one the Accept header asked for
+	Error         error
+}
+
+// Negotiation compares the representations an endpoint returns for different Accept values
+type Negotiation struct {
+	Representations []Representation
+	ContentTypes    []string // Distinct media types returned, in request order
+	BodiesDiffer    bool     // Successful responses didn't all carry the same body, ignoring volatile fields
+}
+
+// CompareRepresentations sends the same request once per Accept value. Other headers, such as
+// Prefer, are sent with every request; an Accept among them is overridden.
+func (e *Executor) CompareRepresentations(method, endpoint string, headers map[string]string, body any, accepts []string) *Negotiation {
+	negotiation := &Negotiation{}
+	var firstBody *string
+	for _, accept := range accepts {
+		requestHeaders := make(map[string]string, len(headers)+1)
+		for name, value := range headers {
+			if !strings.EqualFold(name, "Accept") {
+				requestHeaders[name] = value
+			}
+		}
+		requestHeaders["Accept"] = accept
+
+		representation := Representation{Accept: accept}
+		result, err := e.ExecuteTest(method, endpoint, requestHeaders, body)
+		if err != nil {
+			representation.Error = err
+			negotiation.Representations = append(negotiation.Representations, representation)
+			continue
+		}
+
+		representation.StatusCode = result.StatusCode
+		representation.ContentType = result.ContentType
+		representation.Body = result.ResponseBody
+		representation.MatchesAccept = AcceptMatches(accept, result.ContentType)
+		negotiation.Representations = append(negotiation.Representations, representation)
+
+		if mediaType := baseMediaType(result.ContentType); mediaType != "" && !slices.Contains(negotiation.ContentTypes, mediaType) {
+			negotiation.ContentTypes = append(negotiation.ContentTypes, mediaType)
+		}
+		if result.StatusCode >= 200 && result.StatusCode < 300 {
+			normalized := NormalizeVolatile(representation.Body, e.volatileFields)
+			if firstBody == nil {
+				firstBody = &normalized
+			} else if *firstBody != normalized {
+				negotiation.BodiesDiffer = true
+			}
+		}
+	}
+	return negotiation
+}
+
+// AcceptMatches reports whether contentType satisfies one of the media ranges in an Accept header
+// (e.g. application/json, text/*, */*). A 406 Not Acceptable has no content type to match.
+func AcceptMatches(accept, contentType string) bool {
+	mediaType := baseMediaType(contentType)
+	if mediaType == "" {
+		return false
+	}
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		rangeType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+		wantType, wantSubtype, _ := strings.Cut(rangeType, "/")
+		if (wantType == "*" || wantType == typ) && (wantSubtype == "*" || wantSubtype == subtype) {
+			return true
+		}
+	}
+	return false
+}
+
+// baseMediaType returns the lowercase media type of a Content-Type header without its parameters
+func baseMediaType(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
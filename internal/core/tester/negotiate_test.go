@@ -0,0 +1,76 @@
+package tester
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestAcceptMatches(t *testing.T) {
+	tests := []struct {
+		accept      string
+		contentType string
+		want        bool
+	}{
+		{"application/json", "application/json; charset=utf-8", true},
+		{"application/xml", "application/json", false},
+		{"text/*", "text/csv", true},
+		{"*/*", "application/xml", true},
+		{"application/xml, application/json;q=0.5", "application/json", true},
+		{"application/json;q=0", "application/json", false},
+		{"application/json", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := AcceptMatches(tt.accept, tt.contentType); got != tt.want {
+			t.Errorf("AcceptMatches(%q, %q) = %v, want %v", tt.accept, tt.contentType, got, tt.want)
+		}
+	}
+}
+
+func TestCompareRepresentations(t *testing.T) {
+	var prefers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefers = append(prefers, r.Header.Get("Prefer"))
+		switch r.Header.Get("Accept") {
+		case "application/json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":1}`))
+		case "application/xml":
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<user><id>1</id></user>`))
+		default:
+			w.WriteHeader(http.StatusNotAcceptable)
+		}
+	}))
+	defer server.Close()
+
+	headers := map[string]string{"Prefer": "return=representation", "accept": "text/html"}
+	negotiation := NewExecutor(server.URL, nil).CompareRepresentations("GET", "/users/1", headers, nil,
+		[]string{"application/json", "application/xml", "text/csv"})
+
+	if len(negotiation.Representations) != 3 {
+		t.Fatalf("got %d representations, want 3", len(negotiation.Representations))
+	}
+	for i, want := range []struct {
+		status  int
+		matches bool
+	}{{200, true}, {200, true}, {406, false}} {
+		got := negotiation.Representations[i]
+		if got.StatusCode != want.status || got.MatchesAccept != want.matches {
+			t.Errorf("%s: status %d, matches %v; want %d, %v", got.Accept, got.StatusCode, got.MatchesAccept, want.status, want.matches)
+		}
+	}
+	if want := []string{"application/json", "application/xml"}; !slices.Equal(negotiation.ContentTypes, want) {
+		t.Errorf("ContentTypes = %v, want %v", negotiation.ContentTypes, want)
+	}
+	if !negotiation.BodiesDiffer {
+		t.Error("BodiesDiffer = false, want true")
+	}
+	for _, prefer := range prefers {
+		if prefer != "return=representation" {
+			t.Errorf("Prefer = %q, want it sent with every request", prefer)
+		}
+	}
+}
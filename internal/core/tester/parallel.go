@@ -0,0 +1,65 @@
+package tester
+
+import (
+	"sync"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+)
+
+// Request is one test to send with ExecuteRequest or ExecuteParallel
+type Request struct {
+	Method   string
+	Endpoint string
+	Headers  map[string]string
+	Body     any
+	Expect   []Assertion
+	Auth     auth.AuthProvider // Credentials for this request; nil uses the executor's provider
+	Download *Download         // Save the response body as a file and verify it
+}
+
+// Outcome is the result of a Request, as ExecuteTestWithAssertions would return it
+type Outcome struct {
+	Result *TestResult
+	Err    error
+}
+
+// ExecuteRequest sends req and evaluates its assertions. Unlike switching providers with
+// UpdateAuthProvider, req.Auth only applies to this request, so it is safe to call concurrently.
+func (e *Executor) ExecuteRequest(req Request) (*TestResult, error) {
+	authProvider := req.Auth
+	if authProvider == nil {
+		authProvider = e.authProvider
+	}
+	result, err := e.execute(authProvider, req.Method, req.Endpoint, req.Headers, req.Body, req.Download)
+	if err != nil {
+		return result, err
+	}
+	result.FailedAssertions = EvaluateAssertions(result.ResponseBody, req.Expect)
+	return result, nil
+}
+
+// ExecuteParallel sends requests with up to concurrency of them in flight at once and returns their
+// outcomes in the order of requests. A concurrency below 2 sends them one after another.
+func (e *Executor) ExecuteParallel(requests []Request, concurrency int) []Outcome {
+	outcomes := make([]Outcome, len(requests))
+	concurrency = max(1, min(concurrency, len(requests)))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result, err := e.ExecuteRequest(requests[i])
+				outcomes[i] = Outcome{Result: result, Err: err}
+			}
+		}()
+	}
+	for i := range requests {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return outcomes
+}
@@ -0,0 +1,90 @@
+package tester
+
+import (
+	"fmt"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExecuteParallelSpeedup(t *testing.T) {
+	const (
+		requests = 8
+		latency  = 100 * time.Millisecond
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(latency)
+		_, _ = fmt.Fprintf(w, `{"path":%q}`, r.URL.Path)
+	}))
+	defer server.Close()
+
+	batch := make([]Request, requests)
+	for i := range batch {
+		batch[i] = Request{Method: "GET", Endpoint: fmt.Sprintf("/items/%d", i)}
+	}
+
+	executor := NewExecutor(server.URL, nil)
+	start := time.Now()
+	outcomes := executor.ExecuteParallel(batch, 4)
+	elapsed := time.Since(start)
+
+	// Sequentially this takes requests×latency; four workers should need about a quarter of it
+	if sequential := requests * latency; elapsed >= sequential/2 {
+		t.Errorf("ExecuteParallel() took %s, want well under the sequential %s", elapsed, sequential)
+	}
+	for i, outcome := range outcomes {
+		if outcome.Err != nil {
+			t.Fatalf("outcome %d error = %v", i, outcome.Err)
+		}
+		if want := fmt.Sprintf(`{"path":"/items/%d"}`, i); outcome.Result.ResponseBody != want {
+			t.Errorf("outcome %d body = %s, want %s (results out of order)", i, outcome.Result.ResponseBody, want)
+		}
+	}
+}
+
+func TestExecuteParallelPerRequestAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	executor := NewExecutor(server.URL, auth.NewBearerAuth("secret"))
+	batch := make([]Request, 20)
+	for i := range batch {
+		batch[i] = Request{Method: "GET", Endpoint: "/me"}
+		if i%2 == 1 {
+			batch[i].Auth = &auth.NoAuth{}
+		}
+	}
+
+	for i, outcome := range executor.ExecuteParallel(batch, 5) {
+		want := "Bearer secret"
+		if i%2 == 1 {
+			want = ""
+		}
+		if outcome.Err != nil || outcome.Result.ResponseBody != want {
+			t.Errorf("request %d sent Authorization %q (err %v), want %q", i, outcome.Result.ResponseBody, outcome.Err, want)
+		}
+	}
+}
+
+func TestExecuteParallelEvaluatesAssertions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	outcomes := NewExecutor(server.URL, nil).ExecuteParallel([]Request{
+		{Method: "GET", Endpoint: "/health", Expect: []Assertion{{Path: "$.status", Equals: "ok"}}},
+		{Method: "GET", Endpoint: "/health", Expect: []Assertion{{Path: "$.status", Equals: "down"}}},
+	}, 0)
+
+	if len(outcomes[0].Result.FailedAssertions) != 0 {
+		t.Errorf("first request failed assertions: %v", outcomes[0].Result.FailedAssertions)
+	}
+	if len(outcomes[1].Result.FailedAssertions) != 1 {
+		t.Errorf("second request failed %d assertions, want 1", len(outcomes[1].Result.FailedAssertions))
+	}
+}
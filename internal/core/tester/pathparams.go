@@ -0,0 +1,63 @@
+package tester
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// pathPlaceholder matches {name} path template segments such as /users/{id}, and {{name}} variable
+// references so they aren't mistaken for one
+var pathPlaceholder = regexp.MustCompile(`\{\{[^{}]*\}\}|\{([^{}/]+)\}`)
+
+// FillPath substitutes path placeholders with escaped values, returning the names that have no value
+func FillPath(path string, values map[string]string) (string, []string) {
+	return fillEndpoint(path, false, func(name string) (string, bool) {
+		value := values[name]
+		return value, value != ""
+	})
+}
+
+// fillEndpoint is the one substitution behind FillPath and {{name}} variables. It replaces the
+// {param} placeholders of an endpoint's path, or with variables set its {{name}} references, by
+// the escaped value lookup returns: path-escaped in the path, query-escaped in the query string
+// ({param} placeholders only occur in the path). Names without a value are left as they are and
+// returned.
+func fillEndpoint(endpoint string, variables bool, lookup func(name string) (string, bool)) (string, []string) {
+	var missing []string
+	fill := func(s string, escape func(string) string) string {
+		return pathPlaceholder.ReplaceAllStringFunc(s, func(match string) string {
+			var name string
+			if ref := variableRef.FindStringSubmatch(match); ref != nil && ref[0] == match {
+				if !variables {
+					return match
+				}
+				name = ref[1]
+			} else if variables || strings.HasPrefix(match, "{{") {
+				return match
+			} else {
+				name = strings.TrimSpace(match[1 : len(match)-1])
+			}
+
+			value, ok := lookup(name)
+			if !ok {
+				if !slices.Contains(missing, name) {
+					missing = append(missing, name)
+				}
+				return match
+			}
+			return escape(value)
+		})
+	}
+
+	path, query, hasQuery := strings.Cut(endpoint, "?")
+	filled := fill(path, url.PathEscape)
+	if hasQuery {
+		if variables {
+			query = fill(query, url.QueryEscape)
+		}
+		filled += "?" + query
+	}
+	return filled, missing
+}
@@ -0,0 +1,28 @@
+package tester
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFillPath(t *testing.T) {
+	tests := []struct {
+		path        string
+		values      map[string]string
+		want        string
+		wantMissing []string
+	}{
+		{"/users/{id}", map[string]string{"id": "42"}, "/users/42", nil},
+		{"/users/{id}/posts/{postId}?limit={n}", map[string]string{"id": "a b"}, "/users/a%20b/posts/{postId}?limit={n}", []string{"postId"}},
+		{"/orgs/{org}/repos/{org}", map[string]string{"org": ""}, "/orgs/{org}/repos/{org}", []string{"org"}},
+		{"/health", nil, "/health", nil},
+		{"/users/{{id}}/posts/{postId}", map[string]string{"postId": "7"}, "/users/{{id}}/posts/7", nil},
+	}
+
+	for _, tt := range tests {
+		got, missing := FillPath(tt.path, tt.values)
+		if got != tt.want || !slices.Equal(missing, tt.wantMissing) {
+			t.Errorf("FillPath(%q) = %q, %v; want %q, %v", tt.path, got, missing, tt.want, tt.wantMissing)
+		}
+	}
+}
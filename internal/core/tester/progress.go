@@ -0,0 +1,108 @@
+package tester
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Progress writes one line per finished test, e.g. "[3/20] GET /users → 200 (45ms)", followed by a
+// summary line. Headless runs point it at stderr so stdout stays free for machine-readable output
+// such as --json; a nil writer (--quiet) disables printing but still counts results.
+type Progress struct {
+	mu      sync.Mutex
+	w       io.Writer
+	total   int
+	done    int
+	passed  int
+	failed  int
+	skipped int
+	start   time.Time
+}
+
+// NewProgress creates a progress printer for a run of total tests
+func NewProgress(w io.Writer, total int) *Progress {
+	return &Progress{w: w, total: total, start: time.Now()}
+}
+
+// Result records a finished test, judged by TestResult.Passed. err is a request error; result may
+// be nil when it is set.
+func (p *Progress) Result(method, endpoint string, result *TestResult, err error) {
+	var failures []string
+	passed := result != nil && result.Passed()
+	if result != nil {
+		for _, f := range result.FailedAssertions {
+			failures = append(failures, f.Message)
+		}
+	}
+	p.Checked(method, endpoint, result, err, passed, failures)
+}
+
+// Checked records a finished test whose verdict the caller made, e.g. against an expected status.
+// failures explain why a test failed.
+func (p *Progress) Checked(method, endpoint string, result *TestResult, err error, passed bool, failures []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+
+	var outcome string
+	switch {
+	case err != nil:
+		p.failed++
+		outcome = "error: " + err.Error()
+	case result == nil:
+		p.failed++
+		outcome = "error: no result"
+	default:
+		outcome = fmt.Sprintf("%d (%dms)", result.StatusCode, result.Duration.Milliseconds())
+		if passed {
+			p.passed++
+		} else {
+			p.failed++
+			outcome += " FAIL"
+			if len(failures) > 0 {
+				outcome += ": " + strings.Join(failures, "; ")
+			}
+		}
+	}
+	p.printf("%s %s %s → %s\n", p.counter(), strings.ToUpper(method), endpoint, outcome)
+}
+
+// Skipped records a test that was not run
+func (p *Progress) Skipped(method, endpoint, reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	p.skipped++
+	p.printf("%s %s %s → skipped (%s)\n", p.counter(), strings.ToUpper(method), endpoint, reason)
+}
+
+// Failed returns how many tests failed or errored so far
+func (p *Progress) Failed() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.failed
+}
+
+// Summary writes the final line, e.g. "20 tests: 17 passed, 2 failed, 1 skipped in 3.2s"
+func (p *Progress) Summary() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.printf("%d tests: %d passed, %d failed, %d skipped in %s\n",
+		p.done, p.passed, p.failed, p.skipped, time.Since(p.start).Round(100*time.Millisecond))
+}
+
+// counter renders the "[done/total]" prefix, padded so lines stay aligned
+func (p *Progress) counter() string {
+	width := len(fmt.Sprint(p.total))
+	return fmt.Sprintf("[%*d/%d]", width, p.done, p.total)
+}
+
+func (p *Progress) printf(format string, args ...any) {
+	if p.w == nil {
+		return
+	}
+	_, _ = fmt.Fprintf(p.w, format, args...)
+}
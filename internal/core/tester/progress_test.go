@@ -0,0 +1,72 @@
+package tester
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgress(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewProgress(&buf, 12)
+
+	p.Result("get", "/users", &TestResult{StatusCode: 200, Duration: 45 * time.Millisecond}, nil)
+	p.Result("POST", "/users", &TestResult{
+		StatusCode:       201,
+		Duration:         12 * time.Millisecond,
+		FailedAssertions: []AssertionFailure{{Message: "$.id: expected exists"}},
+	}, nil)
+	p.Result("GET", "/down", nil, errors.New("connection refused"))
+	p.Skipped("DELETE", "/users/1", "precondition not met")
+	p.Summary()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{
+		"[ 1/12] GET /users → 200 (45ms)",
+		"[ 2/12] POST /users → 201 (12ms) FAIL: $.id: expected exists",
+		"[ 3/12] GET /down → error: connection refused",
+		"[ 4/12] DELETE /users/1 → skipped (precondition not met)",
+	}
+	if len(lines) != len(want)+1 {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want)+1, buf.String())
+	}
+	for i, w := range want {
+		if lines[i] != w {
+			t.Errorf("line %d = %q, want %q", i, lines[i], w)
+		}
+	}
+	if !strings.HasPrefix(lines[4], "4 tests: 1 passed, 2 failed, 1 skipped in ") {
+		t.Errorf("unexpected summary %q", lines[4])
+	}
+	if p.Failed() != 2 {
+		t.Errorf("Failed() = %d, want 2", p.Failed())
+	}
+}
+
+func TestProgressQuiet(t *testing.T) {
+	p := NewProgress(nil, 1)
+	p.Result("GET", "/", &TestResult{StatusCode: 500}, nil)
+	p.Summary()
+	if p.Failed() != 1 {
+		t.Errorf("Failed() = %d, want 1", p.Failed())
+	}
+}
+
+func TestProgressChecked(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewProgress(&buf, 2)
+
+	// A 404 the test expected passes, a 200 it didn't expect fails
+	p.Checked("GET", "/gone", &TestResult{StatusCode: 404}, nil, true, nil)
+	p.Checked("GET", "/users", &TestResult{StatusCode: 200}, nil, false, []string{"status 200, expected 201"})
+
+	want := "[1/2] GET /gone → 404 (0ms)\n[2/2] GET /users → 200 (0ms) FAIL: status 200, expected 201\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+	if p.Failed() != 1 {
+		t.Errorf("Failed() = %d, want 1", p.Failed())
+	}
+}
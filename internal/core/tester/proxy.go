@@ -0,0 +1,60 @@
+package tester
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// newTransport returns the default transport, sending requests through the proxy set in
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	return transport
+}
+
+// SetProxy sends every request through the proxy at rawURL, ignoring the proxy environment variables.
+// An empty rawURL goes back to the environment.
+func (e *Executor) SetProxy(rawURL string) error {
+	transport, ok := e.client.Transport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("the executor's transport doesn't support proxies")
+	}
+	if rawURL == "" {
+		transport.Proxy = http.ProxyFromEnvironment
+		e.proxy = ""
+		return nil
+	}
+
+	proxyURL, err := ParseProxyURL(rawURL)
+	if err != nil {
+		return err
+	}
+	transport.Proxy = http.ProxyURL(proxyURL)
+	e.proxy = rawURL
+	return nil
+}
+
+// Proxy returns the proxy set with SetProxy, or "" when the environment decides
+func (e *Executor) Proxy() string {
+	return e.proxy
+}
+
+// ParseProxyURL parses a proxy address such as http://proxy:3128; a bare host:port is taken as http
+func ParseProxyURL(rawURL string) (*url.URL, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil || proxyURL.Hostname() == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", rawURL)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return proxyURL, nil
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (use http, https or socks5)", proxyURL.Scheme)
+	}
+}
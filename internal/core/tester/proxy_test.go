@@ -0,0 +1,56 @@
+package tester
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExecutorSendsThroughProxy(t *testing.T) {
+	var gotURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute URL of the target
+		gotURL = r.URL.String()
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer proxy.Close()
+
+	executor := NewExecutor("http://api.example.test", nil)
+	if err := executor.SetProxy(proxy.URL); err != nil {
+		t.Fatalf("SetProxy() error = %v", err)
+	}
+	result, err := executor.ExecuteTest("GET", "/users", nil, nil)
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if result.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", result.StatusCode)
+	}
+	if gotURL != "http://api.example.test/users" {
+		t.Errorf("proxy got %q, want http://api.example.test/users", gotURL)
+	}
+}
+
+func TestParseProxyURL(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: "http://proxy:3128", want: "http://proxy:3128"},
+		{raw: "proxy.corp:8080", want: "http://proxy.corp:8080"},
+		{raw: "socks5://127.0.0.1:1080", want: "socks5://127.0.0.1:1080"},
+		{raw: "ftp://proxy:21", wantErr: true},
+		{raw: "http://", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseProxyURL(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseProxyURL(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("ParseProxyURL(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package tester
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+)
+
+// sensitiveHeaderHints mark request headers whose values are redacted in recordings
+var sensitiveHeaderHints = []string{"authorization", "cookie", "token", "key", "secret", "password", "signature"}
+
+// Recorder saves real request/response pairs as replay fixtures
+type Recorder struct {
+	dir string
+}
+
+// NewRecorder creates a recorder writing fixtures to dir, creating it if needed
+func NewRecorder(dir string) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create record directory: %w", err)
+	}
+	return &Recorder{dir: dir}, nil
+}
+
+// Record writes a fixture keyed by method, path and body hash, redacting credentials
+func (r *Recorder) Record(fixture Fixture) error {
+	fixture.RequestHeaders = redactHeaders(fixture.RequestHeaders)
+
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fixture: %w", err)
+	}
+
+	path := filepath.Join(r.dir, FixtureName(fixture.Method, fixture.Path, fixture.BodyHash))
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// redactHeaders returns a copy of headers with credential-like values redacted
+func redactHeaders(headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	redacted := make(map[string]string, len(headers))
+	for name, value := range headers {
+		redacted[name] = value
+		lower := strings.ToLower(name)
+		for _, hint := range sensitiveHeaderHints {
+			if strings.Contains(lower, hint) {
+				redacted[name] = auth.RedactString(value)
+				break
+			}
+		}
+	}
+	return redacted
+}
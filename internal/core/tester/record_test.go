@@ -0,0 +1,79 @@
+package tester
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+)
+
+func TestRecordThenReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(dir)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+
+	executor := NewExecutor(server.URL, auth.NewBearerAuth("secret-token-value"))
+	executor.SetRecorder(recorder)
+
+	headers := map[string]string{"X-Api-Key": "super-secret-key", "X-Trace": "abc"}
+	body := map[string]any{"name": "alice"}
+	if _, err := executor.ExecuteTest("POST", "/users", headers, body); err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+
+	jsonBody, _ := json.Marshal(body)
+	data, err := os.ReadFile(filepath.Join(dir, FixtureName("POST", "/users", BodyHash(jsonBody))))
+	if err != nil {
+		t.Fatalf("fixture not written: %v", err)
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatalf("invalid fixture: %v", err)
+	}
+	if fixture.StatusCode != http.StatusCreated || fixture.RecordedAt.IsZero() {
+		t.Errorf("unexpected fixture metadata: %+v", fixture)
+	}
+	if fixture.RequestHeaders["X-Api-Key"] == "super-secret-key" {
+		t.Error("credential header was not redacted")
+	}
+	if fixture.RequestHeaders["X-Trace"] != "abc" {
+		t.Errorf("X-Trace = %q, want abc", fixture.RequestHeaders["X-Trace"])
+	}
+	if _, ok := fixture.RequestHeaders["Authorization"]; ok {
+		t.Error("auth provider header should not be recorded")
+	}
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("NewReplayer() error = %v", err)
+	}
+	offline := NewExecutor("http://example.invalid", nil)
+	offline.SetReplayer(replayer)
+
+	result, err := offline.ExecuteTest("POST", "/users", nil, body)
+	if err != nil {
+		t.Fatalf("replay error = %v", err)
+	}
+	if result.StatusCode != http.StatusCreated || result.ResponseBody != `{"name":"alice"}` {
+		t.Errorf("replayed %d %q", result.StatusCode, result.ResponseBody)
+	}
+
+	// A different body has no recording
+	if _, err := offline.ExecuteTest("POST", "/users", nil, map[string]any{"name": "bob"}); err == nil {
+		t.Error("expected error for unrecorded body")
+	}
+}
@@ -0,0 +1,120 @@
+package tester
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Fixture is a saved request/response pair served by replay mode
+type Fixture struct {
+	Method         string            `json:"method"`
+	Path           string            `json:"path"`
+	BodyHash       string            `json:"body_hash,omitempty"`
+	RequestHeaders map[string]string `json:"request_headers,omitempty"`
+	RequestBody    string            `json:"request_body,omitempty"`
+	StatusCode     int               `json:"status_code"`
+	Body           string            `json:"body"`
+	DurationMs     int64             `json:"duration_ms,omitempty"`
+	RecordedAt     time.Time         `json:"recorded_at,omitzero"`
+}
+
+// Replayer serves saved responses from a fixture directory instead of hitting the network
+type Replayer struct {
+	dir      string
+	fallback *Fixture
+}
+
+// NewReplayer creates a replayer reading fixtures from dir
+func NewReplayer(dir string) (*Replayer, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replay directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("replay path is not a directory: %s", dir)
+	}
+	return &Replayer{dir: dir}, nil
+}
+
+// SetFallback sets the response served when no recording exists. A status of 0 makes missing recordings an error.
+func (r *Replayer) SetFallback(statusCode int, body string) {
+	if statusCode == 0 {
+		r.fallback = nil
+		return
+	}
+	r.fallback = &Fixture{StatusCode: statusCode, Body: body}
+}
+
+// Lookup returns the recorded response for method, path and request body, or the fallback.
+// A recording matching the body hash wins over one keyed by method and path only.
+func (r *Replayer) Lookup(method, path string, body []byte) (*Fixture, error) {
+	names := []string{FixtureName(method, path, "")}
+	if hash := BodyHash(body); hash != "" {
+		names = append([]string{FixtureName(method, path, hash)}, names...)
+	}
+
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(r.dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture for %s %s: %w", method, path, err)
+		}
+		var fixture Fixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture for %s %s: %w", method, path, err)
+		}
+		return &fixture, nil
+	}
+
+	if r.fallback == nil {
+		return nil, fmt.Errorf("no recording for %s %s", method, path)
+	}
+	return &Fixture{
+		Method:     method,
+		Path:       path,
+		StatusCode: r.fallback.StatusCode,
+		Body:       r.fallback.Body,
+	}, nil
+}
+
+// BodyHash returns a short hash identifying a request body, or "" when there is no body
+func BodyHash(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:6])
+}
+
+// FixtureName returns the file name a fixture is stored under, keyed by method, path (query excluded)
+// and an optional body hash
+func FixtureName(method, path, bodyHash string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	var b strings.Builder
+	b.WriteString(strings.ToUpper(method))
+	b.WriteByte('_')
+	for _, c := range strings.Trim(path, "/") {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '.':
+			b.WriteRune(c)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	if bodyHash != "" {
+		b.WriteByte('_')
+		b.WriteString(bodyHash)
+	}
+	b.WriteString(".json")
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package tester
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFixtureName(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{"get", "/users", "GET_users.json"},
+		{"GET", "/users/1/posts", "GET_users_1_posts.json"},
+		{"POST", "/users?limit=10", "POST_users.json"},
+		{"GET", "/", "GET_.json"},
+		{"DELETE", "/items/{id}", "DELETE_items__id_.json"},
+	}
+
+	if got := FixtureName("POST", "/users", "abc123"); got != "POST_users_abc123.json" {
+		t.Errorf("FixtureName() with hash = %q", got)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			if got := FixtureName(tt.method, tt.path, ""); got != tt.want {
+				t.Errorf("FixtureName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayerServesFixtures(t *testing.T) {
+	dir := t.TempDir()
+	fixture := `{"method":"GET","path":"/users/1","status_code":200,"body":"{\"id\":1}"}`
+	if err := os.WriteFile(filepath.Join(dir, "GET_users_1.json"), []byte(fixture), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("NewReplayer() error = %v", err)
+	}
+	executor := NewExecutor("http://example.invalid", nil)
+	executor.SetReplayer(replayer)
+
+	result, err := executor.ExecuteTestWithAssertions("GET", "/users/1", nil, nil, []Assertion{{Path: "$.id", Equals: 1}})
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if result.StatusCode != 200 || !result.Passed() {
+		t.Errorf("got status %d, failures %v", result.StatusCode, result.FailedAssertions)
+	}
+
+	// No recording and no fallback
+	if _, err := executor.ExecuteTest("GET", "/missing", nil, nil); err == nil {
+		t.Error("expected error for missing recording")
+	}
+
+	// No recording with fallback
+	replayer.SetFallback(404, `{"error":"not recorded"}`)
+	result, err = executor.ExecuteTest("GET", "/missing", nil, nil)
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if result.StatusCode != 404 {
+		t.Errorf("fallback status = %d, want 404", result.StatusCode)
+	}
+}
+
+func TestNewReplayerMissingDir(t *testing.T) {
+	if _, err := NewReplayer(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
@@ -0,0 +1,93 @@
+package tester
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRetryOn is what a retry policy retries when no conditions are given
+const DefaultRetryOn = "connection,5xx"
+
+// defaultRetryDelay is the wait before the first retry; each further retry doubles it
+const defaultRetryDelay = 500 * time.Millisecond
+
+// RetryPolicy decides which failed requests are sent again and how long to wait in between.
+// The zero value never retries.
+type RetryPolicy struct {
+	Retries    int           // Extra attempts after the first
+	Connection bool          // Retry requests that failed without a response (refused, reset, DNS)
+	Timeout    bool          // Retry requests that ran out of time
+	Statuses   []string      // Retried statuses: exact codes like "503" or classes like "5xx"
+	BaseDelay  time.Duration // Wait before the first retry; zero uses the default
+}
+
+// ParseRetryOn builds a policy retrying up to retries times on a comma-separated list of
+// conditions: "connection", "timeout", status codes ("503") and status classes ("5xx")
+func ParseRetryOn(retries int, on string) (RetryPolicy, error) {
+	policy := RetryPolicy{Retries: retries}
+	if retries < 0 {
+		return policy, fmt.Errorf("retries must not be negative")
+	}
+	for _, cond := range strings.Split(on, ",") {
+		cond = strings.ToLower(strings.TrimSpace(cond))
+		switch {
+		case cond == "":
+		case cond == "connection":
+			policy.Connection = true
+		case cond == "timeout":
+			policy.Timeout = true
+		case len(cond) == 3 && cond[0] >= '1' && cond[0] <= '5' && cond[1:] == "xx":
+			policy.Statuses = append(policy.Statuses, cond)
+		default:
+			code, err := strconv.Atoi(cond)
+			if err != nil || code < 100 || code > 599 {
+				return policy, fmt.Errorf("invalid retry condition %q (use connection, timeout, a status like 503 or a class like 5xx)", cond)
+			}
+			policy.Statuses = append(policy.Statuses, cond)
+		}
+	}
+	return policy, nil
+}
+
+// retryStatus reports whether a response with this status should be retried
+func (p RetryPolicy) retryStatus(status int) bool {
+	code := strconv.Itoa(status)
+	for _, s := range p.Statuses {
+		if s == code || (strings.HasSuffix(s, "xx") && s[0] == code[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryError reports whether a request that failed with err should be retried
+func (p RetryPolicy) retryError(err error) bool {
+	if isTimeout(err) {
+		return p.Timeout
+	}
+	return p.Connection
+}
+
+// wait sleeps before the given retry (1 for the first): the base delay doubled for each earlier
+// retry, plus up to half of that again at random so clients don't retry in lockstep
+func (p RetryPolicy) wait(retry int) {
+	delay := p.BaseDelay
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+	delay <<= retry - 1
+	delay += time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	time.Sleep(delay)
+}
+
+// isTimeout reports whether err means a request ran out of time
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
@@ -0,0 +1,69 @@
+package tester
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const (
+	// sampleMaxItems is how many elements of each array a sample keeps
+	sampleMaxItems = 3
+	// sampleMaxString caps individual string values in a sample
+	sampleMaxString = 200
+	// sampleMaxBytes caps the whole sample, including non-JSON bodies
+	sampleMaxBytes = 4096
+)
+
+// SampleBody trims a response body to a small representative sample: arrays keep their first few
+// elements and long strings are shortened, so the data's shape survives at a fraction of the size.
+// truncated reports whether anything was removed.
+func SampleBody(body string) (sample string, truncated bool) {
+	var data any
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		if len(body) > sampleMaxBytes {
+			return body[:sampleMaxBytes] + "...", true
+		}
+		return body, false
+	}
+
+	trimmed, truncated := trimSample(data)
+	encoded, err := json.Marshal(trimmed)
+	if err != nil {
+		return body, false
+	}
+	sample = string(encoded)
+	if len(sample) > sampleMaxBytes {
+		return sample[:sampleMaxBytes] + "...", true
+	}
+	return sample, truncated
+}
+
+// trimSample shortens arrays and strings throughout a decoded JSON value
+func trimSample(v any) (any, bool) {
+	switch value := v.(type) {
+	case []any:
+		truncated := len(value) > sampleMaxItems
+		items := value[:min(len(value), sampleMaxItems)]
+		out := make([]any, len(items))
+		for i, item := range items {
+			var t bool
+			out[i], t = trimSample(item)
+			truncated = truncated || t
+		}
+		return out, truncated
+	case map[string]any:
+		truncated := false
+		out := make(map[string]any, len(value))
+		for k, item := range value {
+			var t bool
+			out[k], t = trimSample(item)
+			truncated = truncated || t
+		}
+		return out, truncated
+	case string:
+		if len(value) > sampleMaxString {
+			return strings.ToValidUTF8(value[:sampleMaxString], "") + "...", true
+		}
+	}
+	return v, false
+}
@@ -0,0 +1,36 @@
+package tester
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSampleBody(t *testing.T) {
+	long := strings.Repeat("x", sampleMaxString+50)
+
+	tests := []struct {
+		name          string
+		body          string
+		want          string
+		wantTruncated bool
+	}{
+		{"small object", `{"id":1,"name":"a"}`, `{"id":1,"name":"a"}`, false},
+		{"long array", `[1,2,3,4,5]`, `[1,2,3]`, true},
+		{"nested array", `{"data":[{"id":1},{"id":2},{"id":3},{"id":4}],"total":4}`, `{"data":[{"id":1},{"id":2},{"id":3}],"total":4}`, true},
+		{"long string", `{"bio":"` + long + `"}`, `{"bio":"` + long[:sampleMaxString] + `..."}`, true},
+		{"not json", "plain text", "plain text", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated := SampleBody(tt.body)
+			if got != tt.want || truncated != tt.wantTruncated {
+				t.Errorf("SampleBody() = %q, %v; want %q, %v", got, truncated, tt.want, tt.wantTruncated)
+			}
+		})
+	}
+
+	if got, truncated := SampleBody(strings.Repeat("y", sampleMaxBytes+10)); !truncated || len(got) != sampleMaxBytes+3 {
+		t.Errorf("expected large text body to be cut to %d bytes, got %d (truncated=%v)", sampleMaxBytes, len(got)-3, truncated)
+	}
+}
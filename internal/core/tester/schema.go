@@ -0,0 +1,161 @@
+package tester
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// SchemaViolation describes where a response body departs from its declared schema
+type SchemaViolation struct {
+	Path    string // JSONPath of the offending value, e.g. $.items[0].id
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	return v.Path + ": " + v.Message
+}
+
+// ValidateSchema checks a JSON body against a JSON schema as declared in an OpenAPI spec, reporting
+// missing required properties and type mismatches. Only type, nullable, required, properties, items,
+// allOf, anyOf and oneOf are checked; unresolved (recursive) $refs accept any value.
+func ValidateSchema(body, schema string) ([]SchemaViolation, error) {
+	var schemaNode map[string]any
+	if err := json.Unmarshal([]byte(schema), &schemaNode); err != nil {
+		return nil, fmt.Errorf("invalid response schema: %w", err)
+	}
+	var value any
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return nil, fmt.Errorf("response body is not valid JSON: %w", err)
+	}
+	return validateValue(value, schemaNode, "$"), nil
+}
+
+// validateValue checks value against schema, reporting violations under path
+func validateValue(value any, schema map[string]any, path string) []SchemaViolation {
+	if schema == nil || schema["$ref"] != nil {
+		return nil
+	}
+
+	var violations []SchemaViolation
+	for _, sub := range subschemas(schema["allOf"]) {
+		violations = append(violations, validateValue(value, sub, path)...)
+	}
+	for _, key := range []string{"anyOf", "oneOf"} {
+		alternatives := subschemas(schema[key])
+		if len(alternatives) > 0 && !slices.ContainsFunc(alternatives, func(alt map[string]any) bool {
+			return len(validateValue(value, alt, path)) == 0
+		}) {
+			violations = append(violations, SchemaViolation{path, fmt.Sprintf("matches none of the %d %s alternatives", len(alternatives), key)})
+		}
+	}
+
+	actual := jsonType(value)
+	if types := schemaTypes(schema); len(types) > 0 && !slices.ContainsFunc(types, func(t string) bool { return typeMatches(t, value) }) {
+		return append(violations, SchemaViolation{path, fmt.Sprintf("expected %s, got %s", joinTypes(types), actual)})
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		required, _ := schema["required"].([]any)
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, present := v[name]; !present {
+					violations = append(violations, SchemaViolation{path, fmt.Sprintf("missing required property %q", name)})
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			propSchema, _ := properties[name].(map[string]any)
+			if propValue, present := v[name]; present {
+				violations = append(violations, validateValue(propValue, propSchema, path+"."+name)...)
+			}
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				violations = append(violations, validateValue(item, items, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return violations
+}
+
+// subschemas returns the schemas of an allOf/anyOf/oneOf list
+func subschemas(list any) []map[string]any {
+	items, _ := list.([]any)
+	schemas := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		if schema, ok := item.(map[string]any); ok {
+			schemas = append(schemas, schema)
+		}
+	}
+	return schemas
+}
+
+// schemaTypes returns the types a schema allows: a single type or a list (OpenAPI 3.1), plus null
+// when it is nullable (OpenAPI 3.0)
+func schemaTypes(schema map[string]any) []string {
+	var types []string
+	switch t := schema["type"].(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+	}
+	if nullable, _ := schema["nullable"].(bool); nullable && len(types) > 0 {
+		types = append(types, "null")
+	}
+	return types
+}
+
+// typeMatches reports whether value is of the given JSON schema type
+func typeMatches(schemaType string, value any) bool {
+	if schemaType == "integer" {
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	}
+	return jsonType(value) == schemaType
+}
+
+// jsonType names the JSON schema type of a decoded value
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// joinTypes renders allowed types for a message, e.g. "string or null"
+func joinTypes(types []string) string {
+	switch len(types) {
+	case 1:
+		return types[0]
+	case 2:
+		return types[0] + " or " + types[1]
+	}
+	return fmt.Sprintf("one of %v", types)
+}
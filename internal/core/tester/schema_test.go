@@ -0,0 +1,78 @@
+package tester
+
+import (
+	"reflect"
+	"testing"
+)
+
+const userSchema = `{
+	"type": "object",
+	"required": ["id", "name"],
+	"properties": {
+		"id": {"type": "integer"},
+		"name": {"type": "string"},
+		"email": {"type": "string", "nullable": true},
+		"tags": {"type": "array", "items": {"type": "string"}},
+		"manager": {"$ref": "#/components/schemas/User"}
+	}
+}`
+
+func TestValidateSchemaConforming(t *testing.T) {
+	for _, body := range []string{
+		`{"id": 1, "name": "Ada"}`,
+		`{"id": 2, "name": "Grace", "email": null, "tags": ["admin"], "manager": {"anything": true}}`,
+	} {
+		violations, err := ValidateSchema(body, userSchema)
+		if err != nil {
+			t.Fatalf("ValidateSchema(%s) error = %v", body, err)
+		}
+		if len(violations) != 0 {
+			t.Errorf("ValidateSchema(%s) = %v, want no violations", body, violations)
+		}
+	}
+}
+
+func TestValidateSchemaNonConforming(t *testing.T) {
+	body := `{"id": 1.5, "email": 42, "tags": ["ok", 7]}`
+	violations, err := ValidateSchema(body, userSchema)
+	if err != nil {
+		t.Fatalf("ValidateSchema() error = %v", err)
+	}
+
+	want := []SchemaViolation{
+		{"$", `missing required property "name"`},
+		{"$.email", "expected string or null, got number"},
+		{"$.id", "expected integer, got number"},
+		{"$.tags[1]", "expected string, got number"},
+	}
+	if !reflect.DeepEqual(violations, want) {
+		t.Errorf("violations = %v, want %v", violations, want)
+	}
+}
+
+func TestValidateSchemaComposition(t *testing.T) {
+	schema := `{"type": "array", "items": {"oneOf": [
+		{"type": "object", "required": ["id"]},
+		{"type": "string"}
+	]}}`
+
+	violations, _ := ValidateSchema(`[{"id": 1}, "ref"]`, schema)
+	if len(violations) != 0 {
+		t.Errorf("violations = %v, want none", violations)
+	}
+	violations, _ = ValidateSchema(`[{"name": "x"}]`, schema)
+	if len(violations) != 1 || violations[0].Path != "$[0]" {
+		t.Errorf("violations = %v, want one at $[0]", violations)
+	}
+
+	violations, _ = ValidateSchema(`{"id": 1}`, `{"allOf": [{"required": ["id"]}, {"required": ["name"]}]}`)
+	if len(violations) != 1 || violations[0].Message != `missing required property "name"` {
+		t.Errorf("violations = %v, want missing name", violations)
+	}
+}
+
+func TestValidateSchemaInvalidBody(t *testing.T) {
+	if _, err := ValidateSchema("<html>", userSchema); err == nil {
+		t.Error("ValidateSchema() error = nil, want error for a non-JSON body")
+	}
+}
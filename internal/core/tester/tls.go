@@ -0,0 +1,57 @@
+package tester
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// TLSOptions controls how the executor verifies the API's certificate
+type TLSOptions struct {
+	Insecure bool   // Skip certificate verification entirely
+	CAFile   string // PEM bundle of extra CAs to trust, e.g. for a self-signed internal API
+}
+
+// SetTLS configures certificate verification for requests. The zero value verifies against the system roots.
+func (e *Executor) SetTLS(opts TLSOptions) error {
+	transport, ok := e.client.Transport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("the executor's transport doesn't support TLS options")
+	}
+
+	config := &tls.Config{InsecureSkipVerify: opts.Insecure}
+	if opts.CAFile != "" {
+		pool, err := loadCertPool(opts.CAFile)
+		if err != nil {
+			return err
+		}
+		config.RootCAs = pool
+	}
+	transport.TLSClientConfig = config
+	e.tls = opts
+	return nil
+}
+
+// TLS returns the options set with SetTLS
+func (e *Executor) TLS() TLSOptions {
+	return e.tls
+}
+
+// loadCertPool returns the system roots plus the certificates in the PEM file at path
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,80 @@
+package tester
+
+import (
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTLSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	// Rejected handshakes are expected; keep them out of the test output
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestExecutorRejectsUnknownCertificateByDefault(t *testing.T) {
+	server := newTLSServer(t)
+
+	if _, err := NewExecutor(server.URL, nil).ExecuteTest("GET", "/", nil, nil); err == nil {
+		t.Fatal("ExecuteTest() succeeded against a self-signed certificate, want a verification error")
+	}
+}
+
+func TestExecutorTrustsCAFile(t *testing.T) {
+	server := newTLSServer(t)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	executor := NewExecutor(server.URL, nil)
+	if err := executor.SetTLS(TLSOptions{CAFile: caFile}); err != nil {
+		t.Fatalf("SetTLS() error = %v", err)
+	}
+	result, err := executor.ExecuteTest("GET", "/", nil, nil)
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if result.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", result.StatusCode)
+	}
+}
+
+func TestExecutorInsecureSkipsVerification(t *testing.T) {
+	server := newTLSServer(t)
+
+	executor := NewExecutor(server.URL, nil)
+	if err := executor.SetTLS(TLSOptions{Insecure: true}); err != nil {
+		t.Fatalf("SetTLS() error = %v", err)
+	}
+	if _, err := executor.ExecuteTest("GET", "/", nil, nil); err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+}
+
+func TestSetTLSRejectsInvalidCAFile(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewExecutor("", nil).SetTLS(TLSOptions{CAFile: caFile}); err == nil {
+		t.Error("SetTLS() with a file without certificates succeeded, want an error")
+	}
+	if err := NewExecutor("", nil).SetTLS(TLSOptions{CAFile: filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+		t.Error("SetTLS() with a missing file succeeded, want an error")
+	}
+}
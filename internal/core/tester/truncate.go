@@ -0,0 +1,19 @@
+package tester
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// TruncateBody shortens body to at most limit bytes (cut at a rune boundary) and appends a marker
+// with the full size, so readers know something is missing. limit <= 0 leaves the body whole.
+func TruncateBody(body string, limit int) (string, bool) {
+	if limit <= 0 || len(body) <= limit {
+		return body, false
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s… [truncated, %s total]", body[:cut], FormatBytes(len(body))), true
+}
@@ -0,0 +1,29 @@
+package tester
+
+import "testing"
+
+func TestTruncateBody(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		limit         int
+		want          string
+		wantTruncated bool
+	}{
+		{"under limit", "hello", 10, "hello", false},
+		{"exact limit", "hello", 5, "hello", false},
+		{"no limit", "hello world", 0, "hello world", false},
+		{"negative limit", "hello world", -1, "hello world", false},
+		{"truncated", "hello world", 5, "hello… [truncated, 11 B total]", true},
+		{"rune boundary", "héllo", 2, "h… [truncated, 6 B total]", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated := TruncateBody(tt.body, tt.limit)
+			if got != tt.want || truncated != tt.wantTruncated {
+				t.Errorf("TruncateBody(%q, %d) = %q, %v; want %q, %v", tt.body, tt.limit, got, truncated, tt.want, tt.wantTruncated)
+			}
+		})
+	}
+}
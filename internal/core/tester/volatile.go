@@ -0,0 +1,144 @@
+package tester
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VolatilePlaceholder stands in for volatile values in normalized bodies
+const VolatilePlaceholder = "<volatile>"
+
+// pathSegment is one step of a volatile field path
+type pathSegment struct {
+	key       string // Property name, or "*" for any property or element
+	index     int    // Array index when isIndex is set
+	isIndex   bool
+	recursive bool // ..key matches the property at any depth
+}
+
+// ValidateVolatilePath checks that pattern is a supported JSONPath: $.a.b, $['a'], $.items[0],
+// $.items[*].id or $..updated_at
+func ValidateVolatilePath(pattern string) error {
+	_, err := parseVolatilePath(pattern)
+	return err
+}
+
+func parseVolatilePath(pattern string) ([]pathSegment, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(pattern), "$")
+	if !ok {
+		return nil, fmt.Errorf("path %q must start with $", pattern)
+	}
+
+	var segments []pathSegment
+	for rest != "" {
+		var seg pathSegment
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			seg.recursive = true
+			seg.key, rest = cutPathName(rest[2:])
+		case rest[0] == '.':
+			seg.key, rest = cutPathName(rest[1:])
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("path %q has an unclosed [", pattern)
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+			if unquoted, err := strconv.Unquote(strings.ReplaceAll(inner, "'", `"`)); err == nil {
+				seg.key = unquoted
+			} else if inner == "*" {
+				seg.key = "*"
+			} else if n, err := strconv.Atoi(inner); err == nil && n >= 0 {
+				seg.index, seg.isIndex = n, true
+			} else {
+				return nil, fmt.Errorf("path %q has an invalid selector [%s]", pattern, inner)
+			}
+		default:
+			return nil, fmt.Errorf("path %q is not a JSONPath like $.meta.request_id", pattern)
+		}
+		if seg.key == "" && !seg.isIndex {
+			return nil, fmt.Errorf("path %q has an empty property name", pattern)
+		}
+		segments = append(segments, seg)
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("path %q must name a field", pattern)
+	}
+	return segments, nil
+}
+
+// cutPathName splits a property name off the front of a path, up to the next . or [
+func cutPathName(path string) (string, string) {
+	if i := strings.IndexAny(path, ".["); i >= 0 {
+		return path[:i], path[i:]
+	}
+	return path, ""
+}
+
+// NormalizeVolatile replaces the values at the given paths of a JSON body with VolatilePlaceholder,
+// so bodies that only differ in timestamps, request IDs or cursors compare equal. Bodies that
+// aren't JSON and invalid patterns are left alone; JSON bodies are re-encoded with sorted keys.
+func NormalizeVolatile(body string, patterns []string) string {
+	if len(patterns) == 0 {
+		return body
+	}
+	var value any
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return body
+	}
+	for _, pattern := range patterns {
+		if segments, err := parseVolatilePath(pattern); err == nil {
+			value = blankPath(value, segments)
+		}
+	}
+	var normalized bytes.Buffer
+	encoder := json.NewEncoder(&normalized)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return body
+	}
+	return strings.TrimSuffix(normalized.String(), "\n")
+}
+
+// blankPath replaces every value of node matched by segments, returning the updated node
+func blankPath(node any, segments []pathSegment) any {
+	if len(segments) == 0 {
+		return VolatilePlaceholder
+	}
+	seg := segments[0]
+
+	if seg.recursive {
+		node = blankPath(node, append([]pathSegment{{key: seg.key}}, segments[1:]...))
+		switch v := node.(type) {
+		case map[string]any:
+			for key, child := range v {
+				v[key] = blankPath(child, segments)
+			}
+		case []any:
+			for i, child := range v {
+				v[i] = blankPath(child, segments)
+			}
+		}
+		return node
+	}
+
+	switch v := node.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if !seg.isIndex && (seg.key == "*" || seg.key == key) {
+				v[key] = blankPath(child, segments[1:])
+			}
+		}
+	case []any:
+		for i, child := range v {
+			if seg.key == "*" || (seg.isIndex && seg.index == i) {
+				v[i] = blankPath(child, segments[1:])
+			}
+		}
+	}
+	return node
+}
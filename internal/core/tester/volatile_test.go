@@ -0,0 +1,66 @@
+package tester
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNormalizeVolatile(t *testing.T) {
+	body := `{"meta":{"request_id":"r-1","took":3},"items":[{"id":1,"updated_at":"x"},{"id":2,"updated_at":"y"}],"cursor":"abc"}`
+
+	tests := []struct {
+		patterns []string
+		want     string
+	}{
+		{nil, body},
+		{[]string{"$.meta.request_id"}, `{"cursor":"abc","items":[{"id":1,"updated_at":"x"},{"id":2,"updated_at":"y"}],"meta":{"request_id":"<volatile>","took":3}}`},
+		{[]string{"$.items[*].updated_at", "$['cursor']"}, `{"cursor":"<volatile>","items":[{"id":1,"updated_at":"<volatile>"},{"id":2,"updated_at":"<volatile>"}],"meta":{"request_id":"r-1","took":3}}`},
+		{[]string{"$..updated_at", "$.items[0].id"}, `{"cursor":"abc","items":[{"id":"<volatile>","updated_at":"<volatile>"},{"id":2,"updated_at":"<volatile>"}],"meta":{"request_id":"r-1","took":3}}`},
+		{[]string{"$.missing.field"}, `{"cursor":"abc","items":[{"id":1,"updated_at":"x"},{"id":2,"updated_at":"y"}],"meta":{"request_id":"r-1","took":3}}`},
+	}
+	for _, tt := range tests {
+		if got := NormalizeVolatile(body, tt.patterns); got != tt.want {
+			t.Errorf("NormalizeVolatile(%v) =\n%s\nwant\n%s", tt.patterns, got, tt.want)
+		}
+	}
+
+	if got := NormalizeVolatile("<xml/>", []string{"$.id"}); got != "<xml/>" {
+		t.Errorf("non-JSON body changed: %s", got)
+	}
+}
+
+func TestValidateVolatilePath(t *testing.T) {
+	for _, valid := range []string{"$.meta.request_id", "$..timestamp", "$.items[*].id", "$['x-id']", "$[0].id"} {
+		if err := ValidateVolatilePath(valid); err != nil {
+			t.Errorf("ValidateVolatilePath(%q) error = %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"meta.id", "$", "$.", "$.items[", "$.items[-1]", "$x"} {
+		if err := ValidateVolatilePath(invalid); err == nil {
+			t.Errorf("ValidateVolatilePath(%q) error = nil, want error", invalid)
+		}
+	}
+}
+
+func TestCompareRepresentationsIgnoresVolatileFields(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"id":1,"request_id":"req-%d"}`, calls)
+	}))
+	defer server.Close()
+
+	executor := NewExecutor(server.URL, nil)
+	accepts := []string{"application/json", "*/*"}
+	if !executor.CompareRepresentations("GET", "/users/1", nil, nil, accepts).BodiesDiffer {
+		t.Error("BodiesDiffer = false, want true before marking request_id volatile")
+	}
+
+	executor.SetVolatileFields([]string{"$.request_id"})
+	if executor.CompareRepresentations("GET", "/users/1", nil, nil, accepts).BodiesDiffer {
+		t.Error("BodiesDiffer = true, want false when only a volatile field differs")
+	}
+}
@@ -0,0 +1,115 @@
+package tester
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// looksLikeXML reports whether a request body string is an XML document rather than JSON
+func looksLikeXML(s string) bool {
+	return strings.HasPrefix(strings.TrimSpace(s), "<")
+}
+
+// ValidateXML checks that body is a well-formed XML document with a root element
+func ValidateXML(body string) error {
+	dec := xml.NewDecoder(strings.NewReader(body))
+	hasRoot := false
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := tok.(xml.StartElement); ok {
+			hasRoot = true
+		}
+	}
+	if !hasRoot {
+		return errors.New("XML document has no root element")
+	}
+	return nil
+}
+
+// FormatXML validates body and re-indents it with two spaces per level.
+// Elements holding only text stay on one line; namespace prefixes are kept as written.
+func FormatXML(body string) (string, error) {
+	if err := ValidateXML(body); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	newline := func(depth int) {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(strings.Repeat("  ", depth))
+	}
+
+	// RawToken keeps prefixes as written instead of resolving them to namespace URLs
+	dec := xml.NewDecoder(strings.NewReader(body))
+	depth := 0
+	openTag := false // the last token opened an element that has no content yet
+	inline := false  // the current element's text was written on its start tag's line
+	for {
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			newline(depth)
+			b.WriteString("<" + xmlName(t.Name))
+			for _, attr := range t.Attr {
+				b.WriteString(" " + xmlName(attr.Name) + `="`)
+				_ = xml.EscapeText(&b, []byte(attr.Value))
+				b.WriteString(`"`)
+			}
+			b.WriteString(">")
+			depth++
+			openTag, inline = true, false
+		case xml.EndElement:
+			depth--
+			if !openTag && !inline {
+				newline(depth)
+			}
+			b.WriteString("</" + xmlName(t.Name) + ">")
+			openTag, inline = false, false
+		case xml.CharData:
+			text := strings.TrimSpace(string(t))
+			if text == "" {
+				continue
+			}
+			if !openTag {
+				newline(depth)
+			}
+			_ = xml.EscapeText(&b, []byte(text))
+			inline, openTag = openTag, false
+		case xml.Comment:
+			newline(depth)
+			b.WriteString("<!--" + string(t) + "-->")
+			openTag, inline = false, false
+		case xml.ProcInst:
+			newline(depth)
+			b.WriteString("<?" + t.Target + " " + string(t.Inst) + "?>")
+		case xml.Directive:
+			newline(depth)
+			b.WriteString("<!" + string(t) + ">")
+		}
+	}
+	return b.String(), nil
+}
+
+func xmlName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
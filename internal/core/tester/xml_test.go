@@ -0,0 +1,87 @@
+package tester
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFormatXML(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "nested elements",
+			body: `<?xml version="1.0"?><users><user id="1"><name>Ann &amp; Bo</name></user><user id="2"/></users>`,
+			want: "<?xml version=\"1.0\"?>\n<users>\n  <user id=\"1\">\n    <name>Ann &amp; Bo</name>\n  </user>\n  <user id=\"2\"></user>\n</users>",
+		},
+		{
+			name: "keeps namespace prefixes",
+			body: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body/></soap:Envelope>`,
+			want: "<soap:Envelope xmlns:soap=\"http://schemas.xmlsoap.org/soap/envelope/\">\n  <soap:Body></soap:Body>\n</soap:Envelope>",
+		},
+		{name: "mismatched tags", body: `<a><b></a>`, wantErr: true},
+		{name: "no root element", body: `just text`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatXML(tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormatXML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FormatXML() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteTestXMLBodies(t *testing.T) {
+	var gotContentType, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+
+		w.Header().Set("Content-Type", "application/xml")
+		if r.URL.Path == "/broken" {
+			_, _ = w.Write([]byte(`<order><id>1</order>`))
+			return
+		}
+		_, _ = w.Write([]byte(`<order><id>1</id></order>`))
+	}))
+	defer server.Close()
+
+	executor := NewExecutor(server.URL, nil)
+	result, err := executor.ExecuteTest("POST", "/orders", nil, `<order><item>book</item></order>`)
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if gotContentType != "application/xml" || gotBody != `<order><item>book</item></order>` {
+		t.Errorf("request sent as %q: %s", gotContentType, gotBody)
+	}
+	if want := "<order>\n  <id>1</id>\n</order>"; result.ResponseBody != want || result.XMLError != nil {
+		t.Errorf("ResponseBody = %q (XMLError %v), want %q", result.ResponseBody, result.XMLError, want)
+	}
+
+	// An explicit Content-Type wins over the XML default
+	if _, err := executor.ExecuteTest("POST", "/orders", map[string]string{"Content-Type": "text/xml"}, `<order/>`); err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if gotContentType != "text/xml" {
+		t.Errorf("Content-Type = %q, want text/xml", gotContentType)
+	}
+
+	result, err = executor.ExecuteTest("GET", "/broken", nil, nil)
+	if err != nil {
+		t.Fatalf("ExecuteTest() error = %v", err)
+	}
+	if result.XMLError == nil || result.ResponseBody != `<order><id>1</order>` {
+		t.Errorf("malformed XML: XMLError = %v, ResponseBody = %q", result.XMLError, result.ResponseBody)
+	}
+}
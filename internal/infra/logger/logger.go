@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"fmt"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -9,14 +11,39 @@ var (
 	logger *zap.Logger
 )
 
-// Init initializes the logger
-func Init(debugEnabled bool, logFilePath string) error {
-	var err error
+// Log formats accepted by Config.Format
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+// Config selects where logs go, how they are encoded and which levels are kept
+type Config struct {
+	Path   string // File to write to; empty writes to stderr
+	Format string // FormatConsole or FormatJSON; empty is JSON for a file and console for stderr
+	Level  string // debug, info, warn or error; empty is info
+}
 
-	// Parse log level
+// Init initializes the logger
+func Init(cfg Config) error {
 	level := zapcore.InfoLevel
-	if debugEnabled {
-		level = zapcore.DebugLevel
+	if cfg.Level != "" {
+		parsed, err := zapcore.ParseLevel(cfg.Level)
+		if err != nil {
+			return fmt.Errorf("invalid log level %q (use debug, info, warn or error)", cfg.Level)
+		}
+		level = parsed
+	}
+
+	format := cfg.Format
+	if format == "" {
+		format = FormatConsole
+		if cfg.Path != "" {
+			format = FormatJSON
+		}
+	}
+	if format != FormatConsole && format != FormatJSON {
+		return fmt.Errorf("invalid log format %q (use %s or %s)", cfg.Format, FormatConsole, FormatJSON)
 	}
 
 	// Configure encoder config
@@ -24,33 +51,24 @@ func Init(debugEnabled bool, logFilePath string) error {
 	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
 
-	// Build config
-	var config zap.Config
-	if logFilePath != "" {
-		// File logging only (no console output)
-		config = zap.Config{
-			Level:            zap.NewAtomicLevelAt(level),
-			Encoding:         "json",
-			EncoderConfig:    encoderConfig,
-			OutputPaths:      []string{logFilePath},
-			ErrorOutputPaths: []string{logFilePath + ".err"},
-		}
-	} else {
-		// No logging - use nop (discard all output)
-		config = zap.Config{
-			Level:            zap.NewAtomicLevelAt(level),
-			Encoding:         "json",
-			EncoderConfig:    encoderConfig,
-			OutputPaths:      []string{"stdout"},
-			ErrorOutputPaths: []string{"stderr"},
-		}
+	// Build config; stdout is left to the interface
+	config := zap.Config{
+		Level:            zap.NewAtomicLevelAt(level),
+		Encoding:         format,
+		EncoderConfig:    encoderConfig,
+		OutputPaths:      []string{"stderr"},
+		ErrorOutputPaths: []string{"stderr"},
+	}
+	if cfg.Path != "" {
+		config.OutputPaths = []string{cfg.Path}
+		config.ErrorOutputPaths = []string{cfg.Path + ".err"}
 	}
 
-	logger, err = config.Build()
+	built, err := config.Build()
 	if err != nil {
 		return err
 	}
-
+	logger = built
 	return nil
 }
 
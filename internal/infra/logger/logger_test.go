@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInitJSONFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "octrafic.log")
+	if err := Init(Config{Path: path, Format: FormatJSON, Level: "debug"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	t.Cleanup(func() { logger = nil })
+
+	Debug("loading spec", String("path", "openapi.yaml"))
+	Warn("request failed", Err(errors.New("timeout")), Bool("retried", true))
+	Close()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var entries []map[string]any
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d log lines, want 2", len(entries))
+	}
+	if entries[0]["msg"] != "loading spec" || entries[0]["level"] != "DEBUG" || entries[0]["path"] != "openapi.yaml" {
+		t.Errorf("first entry = %v", entries[0])
+	}
+	if entries[1]["error"] != "timeout" || entries[1]["retried"] != true {
+		t.Errorf("second entry = %v", entries[1])
+	}
+}
+
+func TestInitLevelFilters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "octrafic.log")
+	if err := Init(Config{Path: path, Level: "warn"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	t.Cleanup(func() { logger = nil })
+
+	Info("dropped")
+	Warn("kept")
+	Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(data, &entry); err != nil || entry["msg"] != "kept" {
+		t.Errorf("log = %q, want only the warning as JSON", data)
+	}
+}
+
+func TestInitRejectsInvalidSettings(t *testing.T) {
+	for _, cfg := range []Config{{Format: "xml"}, {Level: "verbose"}} {
+		if err := Init(cfg); err == nil {
+			t.Errorf("Init(%+v) succeeded, want an error", cfg)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+)
+
+// AuthProvider creates the auth provider for saved settings. A nil config or an unknown type gives no auth.
+func (a *AuthConfig) AuthProvider() auth.AuthProvider {
+	if a == nil {
+		return &auth.NoAuth{}
+	}
+
+	switch a.Type {
+	case "bearer":
+		bearer := auth.NewBearerAuth(a.Token)
+		if a.TokenCommand != "" {
+			bearer.RefreshFunc = auth.CommandTokenSource(a.TokenCommand)
+		}
+		return bearer
+	case "apikey":
+		if len(a.KeyValues) > 0 {
+			return auth.NewAPIKeyAuthRotating(a.KeyName, a.KeyValues, a.Location)
+		}
+		return auth.NewAPIKeyAuth(a.KeyName, a.KeyValue, a.Location)
+	case "basic":
+		return auth.NewBasicAuth(a.Username, a.Password)
+	case "digest":
+		return auth.NewDigestAuth(a.Username, a.Password)
+	case "custom":
+		return auth.NewCustomHeaderAuth(a.HeaderName, a.HeaderTemplate, a.KeyValue)
+	case "oauth2":
+		return auth.NewOAuth2ClientCredentials(a.TokenURL, a.ClientID, a.ClientSecret, a.Scopes)
+	case "awssigv4":
+		return auth.NewAWSSigV4(a.AccessKey, a.SecretKey, a.Region, a.Service)
+	default:
+		return &auth.NoAuth{}
+	}
+}
+
+// AuthProfileNames returns the names of the project's saved auth profiles, sorted
+func (p *Project) AuthProfileNames() []string {
+	return slices.Sorted(maps.Keys(p.AuthProfiles))
+}
+
+// SetAuthProfile saves credentials under a name, replacing any profile with that name
+func (p *Project) SetAuthProfile(name string, authConfig *AuthConfig) {
+	if p.AuthProfiles == nil {
+		p.AuthProfiles = make(map[string]*AuthConfig)
+	}
+	p.AuthProfiles[name] = authConfig
+}
+
+// sealAuth returns the credentials of a project as they are written to disk: encrypted
+// when OCTRAFIC_SECRET is set. The project itself is left unchanged.
+func sealAuth(p *Project) (Project, error) {
+	sealed := *p
+	var err error
+	if sealed.AuthConfig, err = p.AuthConfig.forSaving(); err != nil {
+		return sealed, err
+	}
+	if len(p.AuthProfiles) > 0 {
+		sealed.AuthProfiles = make(map[string]*AuthConfig, len(p.AuthProfiles))
+		for name, profile := range p.AuthProfiles {
+			if sealed.AuthProfiles[name], err = profile.forSaving(); err != nil {
+				return sealed, err
+			}
+		}
+	}
+	return sealed, nil
+}
+
+// unlockAuth decrypts the credentials of a project read from disk, see AuthConfig.unlock
+func unlockAuth(p *Project) {
+	p.AuthConfig.unlock()
+	for _, profile := range p.AuthProfiles {
+		profile.unlock()
+	}
+}
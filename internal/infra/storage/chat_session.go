@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Octrafic/octrafic-cli/internal/agents"
+)
+
+const (
+	chatSessionsDir = "sessions"
+
+	// maxChatSessions is how many conversations a project keeps; saving a new one deletes the oldest
+	maxChatSessions = 10
+)
+
+// ErrNoChatSession is returned when a project has no saved conversation to resume
+var ErrNoChatSession = errors.New("no saved session")
+
+// ChatSession is a project's conversation, saved so it can be resumed after the TUI is closed
+type ChatSession struct {
+	ID           string              `json:"id"`
+	SavedAt      time.Time           `json:"saved_at"`
+	Messages     []agent.ChatMessage `json:"messages"`
+	InputTokens  int64               `json:"input_tokens"`
+	OutputTokens int64               `json:"output_tokens"`
+}
+
+// NewChatSessionID returns the ID of a new conversation. IDs sort in the order they were created.
+func NewChatSessionID() string {
+	return time.Now().UTC().Format("20060102-150405.000000000")
+}
+
+// SaveChatSession saves a conversation in its own file, replacing the earlier saves of the same
+// session only. Only saved projects have sessions; temporary projects are skipped by the caller.
+func SaveChatSession(projectID string, session *ChatSession) error {
+	if session.ID == "" || !validProjectID(session.ID) {
+		return fmt.Errorf("invalid session ID %q", session.ID)
+	}
+	dir, err := chatSessionsPath(projectID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create sessions directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+	// Owner-only, as tool results may contain response bodies with tokens
+	if err := os.WriteFile(filepath.Join(dir, session.ID+".json"), data, 0600); err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+
+	names, err := chatSessionFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range names[:max(len(names)-maxChatSessions, 0)] {
+		if name != session.ID+".json" {
+			_ = os.Remove(filepath.Join(dir, name))
+		}
+	}
+	return nil
+}
+
+// LoadChatSession loads the project's most recent conversation other than excludeID, so a running
+// session can resume the one before it, or returns ErrNoChatSession
+func LoadChatSession(projectID, excludeID string) (*ChatSession, error) {
+	dir, err := chatSessionsPath(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := chatSessionFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	names = slices.DeleteFunc(names, func(name string) bool { return excludeID != "" && name == excludeID+".json" })
+	if len(names) == 0 {
+		return nil, ErrNoChatSession
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, names[len(names)-1]))
+	if os.IsNotExist(err) {
+		return nil, ErrNoChatSession
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+
+	var session ChatSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+	return &session, nil
+}
+
+// chatSessionFiles returns the names of the saved conversations in dir, oldest first
+func chatSessionFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sessions directory: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func chatSessionsPath(projectID string) (string, error) {
+	projectPath, err := GetProjectPathByType(projectID, false)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(projectPath, chatSessionsDir), nil
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Octrafic/octrafic-cli/internal/agents"
+)
+
+func TestChatSessionRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := LoadChatSession("session-id", ""); !errors.Is(err, ErrNoChatSession) {
+		t.Fatalf("LoadChatSession() error = %v, want ErrNoChatSession before saving", err)
+	}
+
+	session := &ChatSession{
+		ID:      NewChatSessionID(),
+		SavedAt: time.Now().UTC().Truncate(time.Second),
+		Messages: []agent.ChatMessage{
+			{Role: "user", Content: "Test GET /users"},
+			{Role: "assistant", Content: "Running it.", InputTokens: 120, OutputTokens: 30, FunctionCalls: []agent.ToolCall{
+				{ID: "call-1", Name: "ExecuteTestGroup", Arguments: map[string]any{"method": "GET", "endpoint": "/users"}, ThoughtSignature: "c2ln"},
+			}},
+			{Role: "user", FunctionResponse: &agent.FunctionResponseData{
+				ID: "call-1", Name: "ExecuteTestGroup", Response: map[string]any{"status_code": float64(200), "passed": true},
+			}},
+		},
+		InputTokens:  120,
+		OutputTokens: 30,
+	}
+	if err := SaveChatSession("session-id", session); err != nil {
+		t.Fatalf("SaveChatSession() error = %v", err)
+	}
+
+	loaded, err := LoadChatSession("session-id", "")
+	if err != nil {
+		t.Fatalf("LoadChatSession() error = %v", err)
+	}
+	if !loaded.SavedAt.Equal(session.SavedAt) {
+		t.Errorf("SavedAt = %v, want %v", loaded.SavedAt, session.SavedAt)
+	}
+	loaded.SavedAt = session.SavedAt
+	if !reflect.DeepEqual(loaded, session) {
+		t.Errorf("loaded session = %+v, want %+v", loaded, session)
+	}
+}
+
+func TestChatSessionsAreKeptPerSession(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	save := func(id, content string) {
+		t.Helper()
+		err := SaveChatSession("project-id", &ChatSession{ID: id, Messages: []agent.ChatMessage{{Role: "user", Content: content}}})
+		if err != nil {
+			t.Fatalf("SaveChatSession(%s) error = %v", id, err)
+		}
+	}
+	latest := func() string {
+		t.Helper()
+		session, err := LoadChatSession("project-id", "")
+		if err != nil {
+			t.Fatalf("LoadChatSession() error = %v", err)
+		}
+		return session.Messages[0].Content
+	}
+
+	// A new session doesn't replace the previous one, and saving a session again updates it
+	save("20260101-100000.000000", "first")
+	save("20260102-100000.000000", "second")
+	if got := latest(); got != "second" {
+		t.Errorf("latest session = %q, want second", got)
+	}
+	save("20260102-100000.000000", "second, continued")
+	if got := latest(); got != "second, continued" {
+		t.Errorf("latest session = %q, want the updated second", got)
+	}
+	if session, err := LoadChatSession("project-id", "20260102-100000.000000"); err != nil || session.Messages[0].Content != "first" {
+		t.Errorf("LoadChatSession() excluding the second = %+v, %v; want the first", session, err)
+	}
+	if _, err := LoadChatSession("project-id", "20260101-100000.000000"); err != nil {
+		t.Errorf("LoadChatSession() excluding an older session error = %v", err)
+	}
+
+	for i := range maxChatSessions {
+		save(fmt.Sprintf("20260103-1000%02d.000000", i), "later")
+	}
+	projectPath, _ := GetProjectPathByType("project-id", false)
+	names, _ := chatSessionFiles(filepath.Join(projectPath, chatSessionsDir))
+	if len(names) != maxChatSessions || names[0] != "20260103-100000.000000.json" {
+		t.Errorf("kept sessions = %v, want the %d most recent", names, maxChatSessions)
+	}
+
+	if err := SaveChatSession("project-id", &ChatSession{ID: "../escape"}); err == nil {
+		t.Error("SaveChatSession() with a path as ID: expected an error")
+	}
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// conversionsDir caches specs converted to OpenAPI by the LLM. Conversion runs before the
+// project exists, so the cache is shared by all projects and keyed by the source file's hash.
+const conversionsDir = "conversions"
+
+// LoadConversion returns the cached conversion stored under key, and whether there is one
+func LoadConversion(key string) (string, bool) {
+	path, err := conversionPath(key)
+	if err != nil {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// SaveConversion caches a converted spec under key
+func SaveConversion(key, content string) error {
+	path, err := conversionPath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create conversions directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write cached conversion: %w", err)
+	}
+	return nil
+}
+
+func conversionPath(key string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, storageDir, conversionsDir, key+".json"), nil
+}
@@ -53,7 +53,7 @@ func LoadEndpoints(projectID string, isTemporary bool) ([]parser.Endpoint, error
 }
 
 // GetEndpointsList returns comma-separated list of endpoints for system prompt
-// Format: "GET /users, POST /users, GET /users/{id}, PUT /users/{id}, DELETE /users/{id}, GET /health"
+// Format: "GET /users, POST /users, GET /users/{id}, PUT /users/{id}, DELETE /users/{id} [deprecated], GET /health"
 func GetEndpointsList(endpoints []parser.Endpoint) string {
 	if len(endpoints) == 0 {
 		return "No endpoints available"
@@ -65,6 +65,9 @@ func GetEndpointsList(endpoints []parser.Endpoint) string {
 			result += ", "
 		}
 		result += fmt.Sprintf("%s %s", ep.Method, ep.Path)
+		if ep.Deprecated {
+			result += " [deprecated]"
+		}
 	}
 
 	return result
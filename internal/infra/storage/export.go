@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+)
+
+// exportVersion is the version of the export file format
+const exportVersion = 1
+
+// ErrProjectExists is returned when importing a project whose ID or name is already taken
+var ErrProjectExists = errors.New("project already exists")
+
+// ProjectExport is a project bundled with its cached endpoints, for sharing it or moving it between machines
+type ProjectExport struct {
+	Version    int               `json:"version"`
+	ExportedAt time.Time         `json:"exported_at"`
+	Project    *Project          `json:"project"`
+	Endpoints  []parser.Endpoint `json:"endpoints,omitempty"`
+	SpecHash   string            `json:"spec_hash,omitempty"` // Hash of the spec the endpoints were parsed from
+}
+
+// ExportProject writes a project, its endpoints and spec hash to a single JSON file.
+// With stripAuth the saved credentials and auth profiles are left out; otherwise they are written as in
+// project.json, i.e. encrypted when OCTRAFIC_SECRET is set.
+func ExportProject(project *Project, path string, stripAuth bool) error {
+	exported, err := sealAuth(project)
+	if err != nil {
+		return err
+	}
+	if stripAuth {
+		exported.AuthConfig = nil
+		exported.AuthProfiles = nil
+	}
+
+	bundle := ProjectExport{
+		Version:    exportVersion,
+		ExportedAt: time.Now(),
+		Project:    &exported,
+	}
+	if HasEndpoints(project.ID, project.IsTemporary) {
+		endpoints, err := LoadEndpoints(project.ID, project.IsTemporary)
+		if err != nil {
+			return err
+		}
+		bundle.Endpoints = endpoints
+	}
+	if hash, err := getStoredHash(project.ID, project.IsTemporary); err == nil {
+		bundle.SpecHash = hash
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal project export: %w", err)
+	}
+	// Owner-only, as the file may hold credentials
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write project export: %w", err)
+	}
+	return nil
+}
+
+// ImportProject reads a file written by ExportProject and saves its project, endpoints and spec hash.
+// It fails with ErrProjectExists if a project with the same ID or name is already stored.
+func ImportProject(path string) (*Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project export: %w", err)
+	}
+
+	var bundle ProjectExport
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse project export: %w", err)
+	}
+	if bundle.Version > exportVersion {
+		return nil, fmt.Errorf("project export version %d is newer than supported (%d); upgrade octrafic", bundle.Version, exportVersion)
+	}
+	if bundle.Project == nil || bundle.Project.ID == "" {
+		return nil, fmt.Errorf("%s is not a project export", path)
+	}
+	// The ID names the project's directory, so it must not reach outside the projects directory
+	if !validProjectID(bundle.Project.ID) {
+		return nil, fmt.Errorf("%s has an invalid project ID %q", path, bundle.Project.ID)
+	}
+
+	project := bundle.Project
+	if _, err := LoadProject(project.ID); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProjectExists, project.ID)
+	}
+	if conflict, err := CheckNameConflict(project.Name, project.ID); err != nil {
+		return nil, err
+	} else if conflict != nil {
+		return nil, fmt.Errorf("%w: %s", ErrProjectExists, project.Name)
+	}
+
+	unlockAuth(project)
+	project.IsTemporary = false
+	project.LastAccessedAt = time.Time{}
+
+	if len(bundle.Endpoints) > 0 {
+		if err := saveParsedEndpoints(project.ID, bundle.SpecHash, bundle.Endpoints, false); err != nil {
+			return nil, err
+		}
+	}
+	if err := SaveProject(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// validProjectID reports whether id can name a directory inside the projects directory:
+// a single path element other than "." and "..".
+func validProjectID(id string) bool {
+	return filepath.IsLocal(id) && id != "." && !strings.ContainsAny(id, `/\`)
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+)
+
+func TestExportImportProject(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(SecretEnvVar, "")
+
+	project := &Project{
+		ID:             "export-test-id",
+		Name:           "Export API",
+		BaseURL:        "https://api.example.com",
+		SpecPath:       "/specs/openapi.yaml",
+		SpecHash:       "abc123",
+		AuthConfig:     &AuthConfig{Type: "bearer", Token: "export-token"},
+		VolatileFields: []string{"$.created_at"},
+		CreatedAt:      time.Now().Add(-time.Hour).Round(time.Second),
+	}
+	if err := SaveProject(project); err != nil {
+		t.Fatalf("SaveProject() error = %v", err)
+	}
+	endpoints := []parser.Endpoint{
+		{Method: "GET", Path: "/users", Description: "List users"},
+		{Method: "POST", Path: "/users", RequiresAuth: true},
+	}
+	if err := saveParsedEndpoints(project.ID, "abc123", endpoints, false); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	withAuth := filepath.Join(dir, "with-auth.json")
+	withoutAuth := filepath.Join(dir, "without-auth.json")
+	if err := ExportProject(project, withAuth, false); err != nil {
+		t.Fatalf("ExportProject() error = %v", err)
+	}
+	if err := ExportProject(project, withoutAuth, true); err != nil {
+		t.Fatalf("ExportProject(stripAuth) error = %v", err)
+	}
+	if data, _ := os.ReadFile(withoutAuth); strings.Contains(string(data), "export-token") {
+		t.Errorf("export with stripped auth contains the token:\n%s", data)
+	}
+
+	// Import on a "new machine"
+	t.Setenv("HOME", t.TempDir())
+	imported, err := ImportProject(withAuth)
+	if err != nil {
+		t.Fatalf("ImportProject() error = %v", err)
+	}
+
+	loaded, err := LoadProject(project.ID)
+	if err != nil {
+		t.Fatalf("LoadProject() after import error = %v", err)
+	}
+	for _, p := range []*Project{imported, loaded} {
+		if p.Name != project.Name || p.BaseURL != project.BaseURL || p.SpecPath != project.SpecPath ||
+			!p.CreatedAt.Equal(project.CreatedAt) || len(p.VolatileFields) != 1 {
+			t.Errorf("imported project = %+v, want the metadata of %+v", p, project)
+		}
+		if p.AuthConfig == nil || p.AuthConfig.Token != "export-token" {
+			t.Errorf("imported auth = %+v, want the bearer token", p.AuthConfig)
+		}
+	}
+
+	gotEndpoints, err := LoadEndpoints(project.ID, false)
+	if err != nil {
+		t.Fatalf("LoadEndpoints() error = %v", err)
+	}
+	if len(gotEndpoints) != 2 || gotEndpoints[0].Description != "List users" || !gotEndpoints[1].RequiresAuth {
+		t.Errorf("imported endpoints = %+v, want %+v", gotEndpoints, endpoints)
+	}
+	if hash, _ := getStoredHash(project.ID, false); hash != "abc123" {
+		t.Errorf("imported spec hash = %q, want abc123", hash)
+	}
+
+	// A second import would overwrite the project, so it is refused
+	if _, err := ImportProject(withoutAuth); !errors.Is(err, ErrProjectExists) {
+		t.Errorf("second ImportProject() error = %v, want ErrProjectExists", err)
+	}
+}
+
+func TestImportProjectRejectsOtherFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "endpoints.json")
+	if err := os.WriteFile(path, []byte(`[{"method":"GET","path":"/"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ImportProject(path); err == nil {
+		t.Error("ImportProject() of a file that isn't an export: expected an error")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version":99,"project":{"id":"x"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ImportProject(path); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("ImportProject() of a newer export: error = %v, want a version error", err)
+	}
+}
+
+func TestImportProjectRejectsUnsafeIDs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(t.TempDir(), "export.json")
+
+	for _, id := range []string{"../../escaped", "..", ".", "a/b", `a\b`, "/tmp/abs"} {
+		data, _ := json.Marshal(ProjectExport{Version: exportVersion, Project: &Project{ID: id, Name: "Evil " + id}})
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ImportProject(path); err == nil || !strings.Contains(err.Error(), "invalid project ID") {
+			t.Errorf("ImportProject() with ID %q: error = %v, want an invalid ID error", id, err)
+		}
+	}
+	for _, dir := range []string{filepath.Join(home, "escaped"), filepath.Join(home, ".octrafic", "escaped")} {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("a malicious ID wrote outside the projects directory: %s", dir)
+		}
+	}
+}
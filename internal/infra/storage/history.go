@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	historyDir = "history"
+
+	// MaxHistoryEntries is the number of most recent runs kept per endpoint
+	MaxHistoryEntries = 50
+)
+
+// HistoryEntry records the outcome of a single test run against an endpoint
+type HistoryEntry struct {
+	Timestamp  time.Time `json:"timestamp"`
+	StatusCode int       `json:"status_code,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	Passed     bool      `json:"passed"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// AppendHistory adds a run to the endpoint's rolling history, keeping the last MaxHistoryEntries
+func AppendHistory(projectID string, isTemporary bool, method, path string, entry HistoryEntry) error {
+	entries, err := LoadHistory(projectID, isTemporary, method, path)
+	if err != nil {
+		return err
+	}
+
+	entries = append(entries, entry)
+	if len(entries) > MaxHistoryEntries {
+		entries = entries[len(entries)-MaxHistoryEntries:]
+	}
+
+	filePath, err := historyFilePath(projectID, isTemporary, method, path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal history: %w", err)
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	return nil
+}
+
+// LoadHistory loads the endpoint's run history, oldest first. Returns an empty slice when there is none.
+func LoadHistory(projectID string, isTemporary bool, method, path string) ([]HistoryEntry, error) {
+	filePath, err := historyFilePath(projectID, isTemporary, method, path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return []HistoryEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	var entries []HistoryEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal history: %w", err)
+	}
+	return entries, nil
+}
+
+// historyFilePath returns the history file for an endpoint, e.g. history/GET_users_{id}.json
+func historyFilePath(projectID string, isTemporary bool, method, path string) (string, error) {
+	projectPath, err := GetProjectPathByType(projectID, isTemporary)
+	if err != nil {
+		return "", fmt.Errorf("failed to get project path: %w", err)
+	}
+
+	name := strings.ToUpper(method) + "_" + strings.Trim(path, "/")
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '_'
+		}
+		return r
+	}, name)
+
+	return filepath.Join(projectPath, historyDir, name+".json"), nil
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Octrafic/octrafic-cli/internal/config"
+)
+
+const profileAuthFile = "auth.json"
+
+// LoadProfileAuth returns the default authentication of the active profile, or nil when it has none
+func LoadProfileAuth() (*AuthConfig, error) {
+	profile := config.ActiveProfile()
+	if profile == "" {
+		return nil, nil
+	}
+	dir, err := config.ProfileDir(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, profileAuthFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile auth: %w", err)
+	}
+
+	var authConfig AuthConfig
+	if err := json.Unmarshal(data, &authConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse profile auth: %w", err)
+	}
+	authConfig.unlock()
+	return &authConfig, nil
+}
+
+// SaveProfileAuth stores default authentication in a profile, encrypted when OCTRAFIC_SECRET is set
+func SaveProfileAuth(profile string, authConfig *AuthConfig) error {
+	dir, err := config.ProfileDir(profile)
+	if err != nil {
+		return err
+	}
+	if authConfig, err = authConfig.forSaving(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(authConfig, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal profile auth: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, profileAuthFile), data, 0600); err != nil {
+		return fmt.Errorf("failed to write profile auth: %w", err)
+	}
+	return nil
+}
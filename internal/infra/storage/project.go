@@ -4,10 +4,12 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,28 +22,72 @@ const tempProjectsDir = "/tmp/octrafic-projects"
 
 // Project represents a single API testing project
 type Project struct {
-	ID             string      `json:"id"`
-	Name           string      `json:"name"`
-	BaseURL        string      `json:"base_url"`
-	SpecPath       string      `json:"spec_path,omitempty"`
-	SpecHash       string      `json:"spec_hash,omitempty"`
-	IsTemporary    bool        `json:"is_temporary"`
-	AuthConfig     *AuthConfig `json:"auth_config,omitempty"`
-	CreatedAt      time.Time   `json:"created_at"`
-	UpdatedAt      time.Time   `json:"updated_at"`
-	LastAccessedAt time.Time   `json:"last_accessed_at"`
+	ID             string                 `json:"id"`
+	Name           string                 `json:"name"`
+	BaseURL        string                 `json:"base_url"`
+	SpecPath       string                 `json:"spec_path,omitempty"`
+	SpecHash       string                 `json:"spec_hash,omitempty"`
+	IsTemporary    bool                   `json:"is_temporary"`
+	AuthConfig     *AuthConfig            `json:"auth_config,omitempty"`
+	AuthProfiles   map[string]*AuthConfig `json:"auth_profiles,omitempty"` // Named credentials, e.g. dev and prod
+	Preferences    *Preferences           `json:"preferences,omitempty"`
+	VolatileFields []string               `json:"volatile_fields,omitempty"` // JSONPaths ignored when comparing responses
+	LLM            *LLMSettings           `json:"llm,omitempty"`             // Overrides the global LLM settings
+	SaveAuth       *bool                  `json:"save_auth,omitempty"`       // Whether credentials may be saved; nil = ask
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	LastAccessedAt time.Time              `json:"last_accessed_at"`
 }
 
-// AuthConfig stores authentication configuration for a project
-// WARNING: Credentials are stored in plain text
+// Preferences stores per-project UI preferences restored when the project loads
+type Preferences struct {
+	ThinkingEnabled *bool  `json:"thinking_enabled,omitempty"`
+	ExecutionMode   string `json:"execution_mode,omitempty"` // ask or auto
+	Concurrency     int    `json:"concurrency,omitempty"`    // Tests of a group sent at once (/parallel)
+}
+
+// LLMSettings overrides the global LLM provider settings for a project, e.g. to use a cheaper
+// model for a simple API. Empty fields keep the global value.
+type LLMSettings struct {
+	Provider string `json:"provider,omitempty"`
+	Model    string `json:"model,omitempty"`
+	BaseURL  string `json:"base_url,omitempty"`
+}
+
+// ModelOverride returns the project's LLM settings as an agent override; a nil project has none
+func (p *Project) ModelOverride() agent.ModelOverride {
+	if p == nil || p.LLM == nil {
+		return agent.ModelOverride{}
+	}
+	return agent.ModelOverride{Provider: p.LLM.Provider, Model: p.LLM.Model, BaseURL: p.LLM.BaseURL}
+}
+
+// AuthConfig stores authentication configuration for a project.
+// Credentials are stored in plain text unless OCTRAFIC_SECRET is set (see secret.go).
 type AuthConfig struct {
-	Type     string `json:"type"`                // none, bearer, apikey, basic
+	Type     string `json:"type"`                // none, bearer, apikey, basic, digest, custom, oauth2, awssigv4
 	Token    string `json:"token,omitempty"`     // Bearer token
 	KeyName  string `json:"key_name,omitempty"`  // API key name (e.g., X-API-Key)
-	KeyValue string `json:"key_value,omitempty"` // API key value
+	KeyValue string `json:"key_value,omitempty"` // API key or custom header value
 	Location string `json:"location,omitempty"`  // header or query
-	Username string `json:"username,omitempty"`  // Basic auth username
-	Password string `json:"password,omitempty"`  // Basic auth password
+	Username string `json:"username,omitempty"`  // Basic or Digest auth username
+	Password string `json:"password,omitempty"`  // Basic or Digest auth password
+
+	TokenCommand string `json:"token_command,omitempty"` // Prints a new bearer token when the API rejects the current one
+
+	KeyValues      []string `json:"key_values,omitempty"`      // Multiple API key values rotated across requests
+	HeaderName     string   `json:"header_name,omitempty"`     // Custom auth header name
+	HeaderTemplate string   `json:"header_template,omitempty"` // Custom auth value template with {value}
+
+	TokenURL     string   `json:"token_url,omitempty"`     // OAuth2 token endpoint
+	ClientID     string   `json:"client_id,omitempty"`     // OAuth2 client ID
+	ClientSecret string   `json:"client_secret,omitempty"` // OAuth2 client secret
+	Scopes       []string `json:"scopes,omitempty"`        // OAuth2 scopes to request
+
+	AccessKey string `json:"access_key,omitempty"` // AWS access key ID (SigV4)
+	SecretKey string `json:"secret_key,omitempty"` // AWS secret access key (SigV4)
+	Region    string `json:"region,omitempty"`     // AWS region (SigV4)
+	Service   string `json:"service,omitempty"`    // AWS service name (SigV4)
 }
 
 // ClearAuth removes authentication configuration from project
@@ -146,8 +192,13 @@ func SaveProject(project *Project) error {
 
 	project.UpdatedAt = time.Now()
 
+	saved, err := sealAuth(project)
+	if err != nil {
+		return err
+	}
+
 	filePath := filepath.Join(projectPath, "project.json")
-	data, err := json.MarshalIndent(project, "", "  ")
+	data, err := json.MarshalIndent(&saved, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal project: %w", err)
 	}
@@ -187,6 +238,7 @@ func LoadProject(projectID string) (*Project, error) {
 	if err := json.Unmarshal(data, &project); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal project: %w", err)
 	}
+	unlockAuth(&project)
 
 	return &project, nil
 }
@@ -248,6 +300,9 @@ func ComputeFileHash(filePath string) (string, error) {
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
+// ErrLLM marks failures of the LLM provider while extracting endpoints from a spec
+var ErrLLM = errors.New("LLM error")
+
 // LoadOrParseSpec parses the spec file and returns endpoints with hash
 // Now uses endpoints.json storage instead of Bleve index
 func LoadOrParseSpec(specPath, projectID, baseURL, apiKey string, isTemporary bool) ([]parser.Endpoint, string, error) {
@@ -267,13 +322,10 @@ func LoadOrParseSpec(specPath, projectID, baseURL, apiKey string, isTemporary bo
 		}
 	}
 
-	// Parse spec file
-	ext := strings.ToLower(filepath.Ext(specPath))
-
 	var endpoints []parser.Endpoint
 
-	// For JSON/YAML/GraphQL/Markdown, use local parser (fast, no backend needed)
-	if ext == ".json" || ext == ".yaml" || ext == ".yml" || ext == ".graphql" || ext == ".gql" || ext == ".md" || ext == ".markdown" {
+	// JSON/YAML/GraphQL/Markdown are parsed locally (fast, no LLM needed)
+	if HasNativeParser(specPath) {
 		spec, err := parser.ParseSpecification(specPath)
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to parse spec: %w", err)
@@ -281,46 +333,85 @@ func LoadOrParseSpec(specPath, projectID, baseURL, apiKey string, isTemporary bo
 		endpoints = spec.Endpoints
 	} else {
 		// For other formats (RAML, Proto, etc), use local AI processing
-		// Read spec file content
-		specContent, err := os.ReadFile(specPath)
+		endpoints, err = parseSpecWithAgent(specPath, baseURL)
 		if err != nil {
-			return nil, "", fmt.Errorf("failed to read spec file: %w", err)
+			return nil, "", err
 		}
+	}
 
-		// Create local agent and process spec
-		localAgent, err := agent.NewAgent(baseURL)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create agent: %w", err)
-		}
+	// A project without endpoints is useless, so don't create or cache one
+	if len(endpoints) == 0 {
+		return nil, "", fmt.Errorf("%w in %s", parser.ErrNoEndpoints, filepath.Base(specPath))
+	}
 
-		apiEndpoints, err := localAgent.ProcessSpecification(string(specContent), baseURL)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to process spec with AI: %w", err)
-		}
+	if err := saveParsedEndpoints(projectID, currentHash, endpoints, isTemporary); err != nil {
+		return nil, "", err
+	}
+	return endpoints, currentHash, nil
+}
 
-		// Convert agent response to parser.Endpoint format
-		for _, ep := range apiEndpoints {
-			endpoints = append(endpoints, parser.Endpoint{
-				Method:       ep.Method,
-				Path:         ep.Path,
-				Description:  ep.Description,
-				RequiresAuth: ep.RequiresAuth,
-				AuthType:     ep.AuthType,
-			})
-		}
+// HasNativeParser reports whether a spec file is parsed locally rather than by the LLM
+func HasNativeParser(specPath string) bool {
+	return slices.Contains(parser.SupportedExtensions, strings.ToLower(filepath.Ext(specPath)))
+}
+
+// ParseSpecWithLLM extracts endpoints with the LLM whatever the file type, and caches them for the
+// project so the next LoadOrParseSpec uses them. It is the fallback when the native parser finds nothing.
+func ParseSpecWithLLM(specPath, projectID, baseURL string, isTemporary bool) ([]parser.Endpoint, error) {
+	currentHash, err := ComputeFileHash(specPath)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := parseSpecWithAgent(specPath, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := saveParsedEndpoints(projectID, currentHash, endpoints, isTemporary); err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// parseSpecWithAgent asks the LLM to extract the endpoints of a spec file
+func parseSpecWithAgent(specPath, baseURL string) ([]parser.Endpoint, error) {
+	specContent, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec file: %w", err)
 	}
 
-	// Save endpoints to JSON
-	if err := SaveEndpoints(projectID, endpoints, isTemporary); err != nil {
-		return nil, "", fmt.Errorf("failed to save endpoints: %w", err)
+	localAgent, err := agent.NewSpecAgent(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create agent: %w", ErrLLM, err)
 	}
 
-	// Store the hash
-	if err := storeHash(projectID, currentHash, isTemporary); err != nil {
-		return nil, "", fmt.Errorf("failed to store hash: %w", err)
+	apiEndpoints, err := localAgent.ProcessSpecification(string(specContent), baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to process spec with AI: %w", ErrLLM, err)
 	}
 
-	return endpoints, currentHash, nil
+	// Convert agent response to parser.Endpoint format
+	var endpoints []parser.Endpoint
+	for _, ep := range apiEndpoints {
+		endpoints = append(endpoints, parser.Endpoint{
+			Method:       ep.Method,
+			Path:         ep.Path,
+			Description:  ep.Description,
+			RequiresAuth: ep.RequiresAuth,
+			AuthType:     ep.AuthType,
+		})
+	}
+	return endpoints, nil
+}
+
+// saveParsedEndpoints caches endpoints together with the hash of the spec they came from
+func saveParsedEndpoints(projectID, specHash string, endpoints []parser.Endpoint, isTemporary bool) error {
+	if err := SaveEndpoints(projectID, parser.NormalizeEndpoints(endpoints), isTemporary); err != nil {
+		return fmt.Errorf("failed to save endpoints: %w", err)
+	}
+	if err := storeHash(projectID, specHash, isTemporary); err != nil {
+		return fmt.Errorf("failed to store hash: %w", err)
+	}
+	return nil
 }
 
 // CreateOrUpdateProject creates or updates a project with spec parsing
@@ -408,36 +499,6 @@ func FindProjectByName(name string) (*Project, error) {
 	return nil, fmt.Errorf("project not found: %s", name)
 }
 
-// ValidateSpecPath checks if spec file exists and is readable
-func ValidateSpecPath(specPath string) error {
-	if specPath == "" {
-		return fmt.Errorf("spec path is empty")
-	}
-
-	// Check if file exists
-	info, err := os.Stat(specPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("spec file does not exist: %s", specPath)
-		}
-		return fmt.Errorf("failed to access spec file: %w", err)
-	}
-
-	// Check if it's a regular file
-	if !info.Mode().IsRegular() {
-		return fmt.Errorf("spec path is not a regular file: %s", specPath)
-	}
-
-	// Check if readable
-	file, err := os.Open(specPath)
-	if err != nil {
-		return fmt.Errorf("spec file is not readable: %w", err)
-	}
-	_ = file.Close()
-
-	return nil
-}
-
 // CheckNameConflict checks if a project with the given name already exists (excluding given projectID)
 func CheckNameConflict(name string, excludeProjectID string) (*Project, error) {
 	if name == "" {
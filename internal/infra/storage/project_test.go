@@ -1,6 +1,10 @@
 package storage
 
 import (
+	"errors"
+	"github.com/Octrafic/octrafic-cli/internal/core/auth"
+	"github.com/Octrafic/octrafic-cli/internal/core/parser"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -155,3 +159,144 @@ func TestCleanupTempProjects(t *testing.T) {
 		t.Errorf("Expected no temporary projects after cleanup, found %d", len(entries))
 	}
 }
+
+func TestProjectPreferencesRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	thinking := false
+	project := &Project{
+		ID:      "prefs-test-id",
+		Name:    "Prefs Project",
+		BaseURL: "https://api.example.com",
+		Preferences: &Preferences{
+			ThinkingEnabled: &thinking,
+			ExecutionMode:   "auto",
+			Concurrency:     4,
+		},
+	}
+	if err := SaveProject(project); err != nil {
+		t.Fatalf("Failed to save project: %v", err)
+	}
+
+	loaded, err := LoadProject(project.ID)
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+	if loaded.Preferences == nil || loaded.Preferences.ThinkingEnabled == nil {
+		t.Fatal("Expected preferences to be persisted")
+	}
+	if *loaded.Preferences.ThinkingEnabled || loaded.Preferences.ExecutionMode != "auto" || loaded.Preferences.Concurrency != 4 {
+		t.Errorf("Unexpected preferences: %+v", loaded.Preferences)
+	}
+}
+
+func TestLoadedAPIKeyAuthKeepsQueryLocation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(SecretEnvVar, "")
+
+	project := &Project{
+		ID:      "apikey-query-test-id",
+		Name:    "Query Key Project",
+		BaseURL: "https://api.example.com",
+		AuthConfig: &AuthConfig{
+			Type:     "apikey",
+			KeyName:  "api_key",
+			KeyValue: "secret",
+			Location: "query",
+		},
+	}
+	if err := SaveProject(project); err != nil {
+		t.Fatalf("Failed to save project: %v", err)
+	}
+
+	loaded, err := LoadProject(project.ID)
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+	req := httptest.NewRequest("GET", "https://api.example.com/users", nil)
+	if err := loaded.AuthConfig.AuthProvider().Apply(req); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if got := req.URL.Query().Get("api_key"); got != "secret" {
+		t.Errorf("query api_key = %q, want secret (query = %q)", got, req.URL.RawQuery)
+	}
+	if got := req.Header.Get("api_key"); got != "" {
+		t.Errorf("header api_key = %q, want it only in the query", got)
+	}
+}
+
+func TestSavedBearerTokenCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(SecretEnvVar, "")
+
+	project := &Project{
+		ID:         "token-command-test-id",
+		Name:       "Token Command Project",
+		BaseURL:    "https://api.example.com",
+		AuthConfig: &AuthConfig{Type: "bearer", Token: "old", TokenCommand: "echo new"},
+	}
+	if err := SaveProject(project); err != nil {
+		t.Fatalf("Failed to save project: %v", err)
+	}
+	loaded, err := LoadProject(project.ID)
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+	bearer, ok := loaded.AuthConfig.AuthProvider().(*auth.BearerAuth)
+	if !ok || bearer.RefreshFunc == nil {
+		t.Fatalf("AuthProvider() = %#v, want a bearer provider that can refresh", loaded.AuthConfig.AuthProvider())
+	}
+	if plain, _ := (&AuthConfig{Type: "bearer", Token: "old"}).AuthProvider().(*auth.BearerAuth); plain.RefreshFunc != nil {
+		t.Error("a bearer token without a token command got a RefreshFunc")
+	}
+}
+
+func TestCreateProjectWithoutEndpoints(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	specPath := filepath.Join(t.TempDir(), "empty.json")
+	if err := os.WriteFile(specPath, []byte(`{"openapi": "3.0.0", "paths": {}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project, _, err := CreateOrUpdateProject("empty-spec-id", "Empty", "https://api.example.com", specPath, "", false)
+	if !errors.Is(err, parser.ErrNoEndpoints) {
+		t.Fatalf("expected ErrNoEndpoints, got %v", err)
+	}
+	if project != nil {
+		t.Error("expected no project for a spec without endpoints")
+	}
+	if HasEndpoints("empty-spec-id", false) {
+		t.Error("expected no endpoints to be cached")
+	}
+}
+
+func TestHistoryRolling(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	entries, err := LoadHistory("history-test-id", false, "GET", "/users/{id}")
+	if err != nil {
+		t.Fatalf("LoadHistory() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected empty history, got %d entries", len(entries))
+	}
+
+	for i := 0; i < MaxHistoryEntries+5; i++ {
+		entry := HistoryEntry{StatusCode: 200, DurationMs: int64(i), Passed: true}
+		if err := AppendHistory("history-test-id", false, "GET", "/users/{id}", entry); err != nil {
+			t.Fatalf("AppendHistory() error = %v", err)
+		}
+	}
+
+	entries, err = LoadHistory("history-test-id", false, "get", "/users/{id}")
+	if err != nil {
+		t.Fatalf("LoadHistory() error = %v", err)
+	}
+	if len(entries) != MaxHistoryEntries {
+		t.Fatalf("expected %d entries, got %d", MaxHistoryEntries, len(entries))
+	}
+	if entries[0].DurationMs != 5 || entries[len(entries)-1].DurationMs != int64(MaxHistoryEntries+4) {
+		t.Errorf("expected oldest entries to be dropped, got first=%d last=%d", entries[0].DurationMs, entries[len(entries)-1].DurationMs)
+	}
+}
@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Octrafic/octrafic-cli/internal/config"
+)
+
+// Saved credentials are encrypted with AES-256-GCM when OCTRAFIC_SECRET holds a passphrase.
+// An encrypted value is encryptedPrefix followed by base64(salt | nonce | ciphertext); values
+// without the prefix are plain text from before encryption was enabled and are read as they are.
+const (
+	encryptedPrefix  = "enc:v1:"
+	secretSaltSize   = 16
+	secretIterations = 600_000 // PBKDF2-HMAC-SHA256, as recommended by OWASP
+)
+
+// SecretEnvVar names the environment variable holding the passphrase for saved credentials
+var SecretEnvVar = config.GetEnvVarName("SECRET")
+
+// ErrWrongSecret is returned when a value can't be decrypted with the passphrase
+var ErrWrongSecret = errors.New("credentials can't be decrypted: wrong " + SecretEnvVar)
+
+// secretKeys caches derived keys, since deriving one is deliberately slow
+var secretKeys = struct {
+	sync.Mutex
+	bySalt map[string][]byte // passphrase + salt -> key
+	salt   map[string][]byte // passphrase -> salt used for new values
+}{bySalt: map[string][]byte{}, salt: map[string][]byte{}}
+
+// secretPassphrase returns the passphrase for saved credentials, or "" when encryption is off
+func secretPassphrase() string {
+	return config.GetEnv("SECRET")
+}
+
+// isEncrypted reports whether a saved value is encrypted
+func isEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// deriveKey returns the AES key for a passphrase and salt
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	secretKeys.Lock()
+	defer secretKeys.Unlock()
+
+	cacheKey := passphrase + "\x00" + string(salt)
+	if key, ok := secretKeys.bySalt[cacheKey]; ok {
+		return key, nil
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, secretIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	secretKeys.bySalt[cacheKey] = key
+	return key, nil
+}
+
+// encryptionSalt returns the salt for values encrypted with a passphrase in this process,
+// so their key is derived only once
+func encryptionSalt(passphrase string) ([]byte, error) {
+	secretKeys.Lock()
+	defer secretKeys.Unlock()
+
+	if salt, ok := secretKeys.salt[passphrase]; ok {
+		return salt, nil
+	}
+	salt := make([]byte, secretSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	secretKeys.salt[passphrase] = salt
+	return salt, nil
+}
+
+// encryptValue encrypts a value with the passphrase. Empty and already encrypted values are returned as they are.
+func encryptValue(passphrase, value string) (string, error) {
+	if value == "" || isEncrypted(value) {
+		return value, nil
+	}
+
+	salt, err := encryptionSalt(passphrase)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := append(slices.Concat(salt, nonce), gcm.Seal(nil, nonce, []byte(value), nil)...)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptValue decrypts a value encrypted with the passphrase. Plain text values are returned as they are.
+func decryptValue(passphrase, value string) (string, error) {
+	if !isEncrypted(value) {
+		return value, nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil || len(sealed) < secretSaltSize {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	gcm, err := newGCM(passphrase, sealed[:secretSaltSize])
+	if err != nil {
+		return "", err
+	}
+	sealed = sealed[secretSaltSize:]
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", ErrWrongSecret
+	}
+	return string(plain), nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// secretFields returns pointers to the sensitive fields of an auth config
+func (a *AuthConfig) secretFields() []*string {
+	fields := []*string{&a.Token, &a.TokenCommand, &a.KeyValue, &a.Password, &a.ClientSecret, &a.SecretKey}
+	for i := range a.KeyValues {
+		fields = append(fields, &a.KeyValues[i])
+	}
+	return fields
+}
+
+// Locked reports whether the config still holds encrypted credentials, because OCTRAFIC_SECRET
+// is unset or wrong. Locked credentials are kept on save but can't be used.
+func (a *AuthConfig) Locked() bool {
+	if a == nil {
+		return false
+	}
+	for _, field := range a.secretFields() {
+		if isEncrypted(*field) {
+			return true
+		}
+	}
+	return false
+}
+
+// forSaving returns the config as it should be written: a copy with the credentials encrypted
+// when OCTRAFIC_SECRET is set, or the config itself otherwise
+func (a *AuthConfig) forSaving() (*AuthConfig, error) {
+	passphrase := secretPassphrase()
+	if a == nil || passphrase == "" {
+		return a, nil
+	}
+
+	encrypted := *a
+	encrypted.KeyValues = append([]string(nil), a.KeyValues...)
+	for _, field := range encrypted.secretFields() {
+		value, err := encryptValue(passphrase, *field)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt credentials: %w", err)
+		}
+		*field = value
+	}
+	return &encrypted, nil
+}
+
+// unlock decrypts the credentials in place with OCTRAFIC_SECRET. Values it can't decrypt stay
+// encrypted, leaving the config Locked.
+func (a *AuthConfig) unlock() {
+	passphrase := secretPassphrase()
+	if a == nil || passphrase == "" {
+		return
+	}
+	for _, field := range a.secretFields() {
+		if value, err := decryptValue(passphrase, *field); err == nil {
+			*field = value
+		}
+	}
+}
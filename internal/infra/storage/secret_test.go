@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveProjectEncryptsCredentials(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(SecretEnvVar, "correct horse battery staple")
+
+	project := &Project{
+		ID:      "secret-test-id",
+		Name:    "Secret",
+		BaseURL: "https://api.example.com",
+		AuthConfig: &AuthConfig{
+			Type:      "apikey",
+			KeyName:   "X-API-Key",
+			KeyValue:  "key-plaintext-1",
+			KeyValues: []string{"key-plaintext-1", "key-plaintext-2"},
+			Location:  "header",
+		},
+	}
+	if err := SaveProject(project); err != nil {
+		t.Fatalf("SaveProject() error = %v", err)
+	}
+	if project.AuthConfig.KeyValue != "key-plaintext-1" || project.AuthConfig.KeyValues[1] != "key-plaintext-2" {
+		t.Errorf("SaveProject() changed the project in memory: %+v", project.AuthConfig)
+	}
+
+	dir, _ := GetProjectsDir()
+	data, err := os.ReadFile(filepath.Join(dir, project.ID, "project.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "plaintext") {
+		t.Errorf("project file contains a plaintext credential:\n%s", data)
+	}
+	if !strings.Contains(string(data), `"key_name": "X-API-Key"`) {
+		t.Errorf("project file should keep non-secret fields readable:\n%s", data)
+	}
+
+	loaded, err := LoadProject(project.ID)
+	if err != nil {
+		t.Fatalf("LoadProject() error = %v", err)
+	}
+	if loaded.AuthConfig.KeyValue != "key-plaintext-1" || loaded.AuthConfig.KeyValues[1] != "key-plaintext-2" {
+		t.Errorf("LoadProject() credentials = %+v, want them decrypted", loaded.AuthConfig)
+	}
+	if loaded.AuthConfig.Locked() {
+		t.Error("Locked() = true after decrypting with the right secret")
+	}
+
+	// Without the secret, or with a wrong one, credentials stay encrypted and survive another save
+	for _, secret := range []string{"", "wrong"} {
+		t.Setenv(SecretEnvVar, secret)
+		locked, err := LoadProject(project.ID)
+		if err != nil {
+			t.Fatalf("LoadProject() with secret %q: error = %v", secret, err)
+		}
+		if !locked.AuthConfig.Locked() || strings.Contains(locked.AuthConfig.KeyValue, "plaintext") {
+			t.Errorf("LoadProject() with secret %q: credentials = %+v, want them locked", secret, locked.AuthConfig)
+		}
+		if err := SaveProject(locked); err != nil {
+			t.Fatalf("SaveProject() error = %v", err)
+		}
+	}
+
+	t.Setenv(SecretEnvVar, "correct horse battery staple")
+	loaded, err = LoadProject(project.ID)
+	if err != nil {
+		t.Fatalf("LoadProject() error = %v", err)
+	}
+	if loaded.AuthConfig.KeyValues[0] != "key-plaintext-1" {
+		t.Errorf("credentials were lost after saving while locked: %+v", loaded.AuthConfig)
+	}
+}
+
+func TestLoadProjectReadsPlaintextCredentials(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(SecretEnvVar, "")
+
+	project := &Project{ID: "plain-test-id", AuthConfig: &AuthConfig{Type: "bearer", Token: "legacy-token"}}
+	if err := SaveProject(project); err != nil {
+		t.Fatalf("SaveProject() error = %v", err)
+	}
+
+	// Files written before encryption was enabled stay readable, and are encrypted on the next save
+	t.Setenv(SecretEnvVar, "s3cret")
+	loaded, err := LoadProject(project.ID)
+	if err != nil {
+		t.Fatalf("LoadProject() error = %v", err)
+	}
+	if loaded.AuthConfig.Token != "legacy-token" {
+		t.Errorf("Token = %q, want legacy-token", loaded.AuthConfig.Token)
+	}
+	if err := SaveProject(loaded); err != nil {
+		t.Fatalf("SaveProject() error = %v", err)
+	}
+	dir, _ := GetProjectsDir()
+	data, _ := os.ReadFile(filepath.Join(dir, project.ID, "project.json"))
+	if strings.Contains(string(data), "legacy-token") {
+		t.Errorf("project file still contains the plaintext token after saving with a secret:\n%s", data)
+	}
+}
+
+func TestDecryptValue(t *testing.T) {
+	encrypted, err := encryptValue("pass", "value")
+	if err != nil {
+		t.Fatalf("encryptValue() error = %v", err)
+	}
+	if got, err := decryptValue("pass", encrypted); err != nil || got != "value" {
+		t.Errorf("decryptValue() = %q, %v, want value", got, err)
+	}
+	if _, err := decryptValue("other", encrypted); err != ErrWrongSecret {
+		t.Errorf("decryptValue() with a wrong passphrase: error = %v, want ErrWrongSecret", err)
+	}
+	if _, err := decryptValue("pass", encryptedPrefix+"not base64!"); err == nil {
+		t.Error("decryptValue() of a malformed value: expected an error")
+	}
+}
+
+func TestAuthProfilesEncrypted(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(SecretEnvVar, "profiles passphrase")
+
+	project := &Project{ID: "profiles-test-id", Name: "Profiles"}
+	project.SetAuthProfile("dev", &AuthConfig{Type: "bearer", Token: "dev-plaintext"})
+	project.SetAuthProfile("prod", &AuthConfig{Type: "basic", Username: "admin", Password: "prod-plaintext"})
+	if err := SaveProject(project); err != nil {
+		t.Fatalf("SaveProject() error = %v", err)
+	}
+
+	dir, _ := GetProjectsDir()
+	data, _ := os.ReadFile(filepath.Join(dir, project.ID, "project.json"))
+	if strings.Contains(string(data), "plaintext") {
+		t.Errorf("project file contains a plaintext profile credential:\n%s", data)
+	}
+
+	loaded, err := LoadProject(project.ID)
+	if err != nil {
+		t.Fatalf("LoadProject() error = %v", err)
+	}
+	if loaded.AuthProfiles["dev"].Token != "dev-plaintext" || loaded.AuthProfiles["prod"].Password != "prod-plaintext" {
+		t.Errorf("loaded profiles = %+v, %+v; want them decrypted", loaded.AuthProfiles["dev"], loaded.AuthProfiles["prod"])
+	}
+}
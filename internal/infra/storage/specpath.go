@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// specAccessTimeout bounds the checks on a spec path; stat and open can block indefinitely on an
+// unresponsive network mount (NFS, SSHFS)
+var specAccessTimeout = 5 * time.Second
+
+// ValidateSpecPath checks if spec file exists and is readable. Symlinks are resolved first so loops
+// and dangling links are reported as such, and the checks give up after specAccessTimeout.
+func ValidateSpecPath(specPath string) error {
+	if specPath == "" {
+		return fmt.Errorf("spec path is empty")
+	}
+	return checkWithTimeout(specPath, specAccessTimeout, checkSpecPath)
+}
+
+// checkWithTimeout runs check in the background, returning an error if it doesn't finish in time.
+// A check stuck on a hung mount can't be interrupted, so its goroutine is left to finish on its own.
+func checkWithTimeout(specPath string, timeout time.Duration, check func(string) error) error {
+	done := make(chan error, 1)
+	go func() { done <- check(specPath) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %s accessing spec file %s (is it on an unresponsive network mount?)", timeout, specPath)
+	}
+}
+
+// checkSpecPath resolves specPath and checks it is a readable regular file
+func checkSpecPath(specPath string) error {
+	// Lstat tells a missing path apart from a symlink whose target is missing
+	if _, err := os.Lstat(specPath); err != nil {
+		return specAccessError(specPath, err)
+	}
+
+	resolved, err := filepath.EvalSymlinks(specPath)
+	if err != nil {
+		switch {
+		case isSymlinkLoop(err):
+			return fmt.Errorf("spec path %s is part of a symlink loop", specPath)
+		case os.IsNotExist(err):
+			return fmt.Errorf("spec path %s is a broken symlink (its target does not exist)", specPath)
+		}
+		return fmt.Errorf("failed to resolve spec path %s: %w", specPath, err)
+	}
+
+	described := specPath
+	if resolved != specPath && resolved != filepath.Clean(specPath) {
+		described = fmt.Sprintf("%s (resolves to %s)", specPath, resolved)
+	}
+
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return specAccessError(described, err)
+	}
+
+	// Opening a FIFO blocks until a writer appears, so special files are rejected before the open
+	switch mode := info.Mode(); {
+	case mode.IsDir():
+		return fmt.Errorf("spec path is a directory, not a file: %s", described)
+	case mode&os.ModeNamedPipe != 0:
+		return fmt.Errorf("spec path is a named pipe, not a regular file: %s", described)
+	case mode&os.ModeSocket != 0:
+		return fmt.Errorf("spec path is a socket, not a regular file: %s", described)
+	case mode&os.ModeDevice != 0:
+		return fmt.Errorf("spec path is a device, not a regular file: %s", described)
+	case !mode.IsRegular():
+		return fmt.Errorf("spec path is not a regular file: %s", described)
+	}
+
+	file, err := os.Open(resolved)
+	if err != nil {
+		if os.IsPermission(err) {
+			return fmt.Errorf("spec file is not readable (permission denied): %s", described)
+		}
+		return fmt.Errorf("spec file is not readable: %w", err)
+	}
+	_ = file.Close()
+
+	return nil
+}
+
+// specAccessError describes a failed stat of the spec path
+func specAccessError(described string, err error) error {
+	switch {
+	case os.IsNotExist(err):
+		return fmt.Errorf("spec file does not exist: %s", described)
+	case os.IsPermission(err):
+		return fmt.Errorf("permission denied accessing spec file %s (check the permissions of its directories)", described)
+	case isSymlinkLoop(err):
+		return fmt.Errorf("spec path %s is part of a symlink loop", described)
+	}
+	return fmt.Errorf("failed to access spec file: %w", err)
+}
+
+// isSymlinkLoop reports whether err comes from following too many symlinks
+func isSymlinkLoop(err error) bool {
+	// filepath.EvalSymlinks reports loops with its own error rather than ELOOP
+	return errors.Is(err, syscall.ELOOP) || strings.Contains(err.Error(), "too many links")
+}
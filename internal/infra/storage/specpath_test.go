@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateSpecPath(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "spec.yaml")
+	if err := os.WriteFile(spec, []byte("openapi: 3.0.0"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	symlink := func(target, name string) string {
+		t.Helper()
+		link := filepath.Join(dir, name)
+		if err := os.Symlink(target, link); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+		return link
+	}
+
+	tests := []struct {
+		name    string
+		path    func() string
+		wantErr string
+	}{
+		{"regular file", func() string { return spec }, ""},
+		{"symlink to file", func() string { return symlink(spec, "link.yaml") }, ""},
+		{"empty", func() string { return "" }, "spec path is empty"},
+		{"missing", func() string { return filepath.Join(dir, "missing.yaml") }, "does not exist"},
+		{"directory", func() string { return dir }, "is a directory"},
+		{"broken symlink", func() string { return symlink(filepath.Join(dir, "gone.yaml"), "broken.yaml") }, "broken symlink"},
+		{"symlink loop", func() string {
+			symlink(filepath.Join(dir, "b.yaml"), "a.yaml")
+			return symlink(filepath.Join(dir, "a.yaml"), "b.yaml")
+		}, "symlink loop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSpecPath(tt.path())
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateSpecPath() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateSpecPath() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckWithTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	hung := func(string) error {
+		<-release
+		return nil
+	}
+	err := checkWithTimeout("/mnt/nfs/spec.yaml", 10*time.Millisecond, hung)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("checkWithTimeout() error = %v, want a timeout", err)
+	}
+}
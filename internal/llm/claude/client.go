@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
+	"sync"
 
 	"github.com/Octrafic/octrafic-cli/internal/infra/logger"
+	"github.com/Octrafic/octrafic-cli/internal/llm/common"
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/option"
@@ -19,6 +22,7 @@ const (
 	MaxTokensNonStreaming = 4096 // Max tokens for regular Chat()
 	MaxTokensStreaming    = 8192 // Max tokens for ChatStream()
 	ThinkingBudget        = 5000 // Extended thinking budget (tokens)
+	MinThinkingBudget     = 1024 // Smallest thinking budget the API accepts
 
 	// Prompt caching notes:
 	// - Both Chat() and ChatStream() use prompt caching via CacheControl
@@ -31,6 +35,15 @@ type Client struct {
 	client anthropic.Client
 	model  string
 	ctx    context.Context
+	cancel context.CancelFunc
+
+	limitsMu   sync.Mutex
+	rateLimits *common.RateLimits
+
+	retrier *common.Retrier
+
+	maxTokens   int      // 0 keeps MaxTokensNonStreaming / MaxTokensStreaming
+	temperature *float64 // nil leaves it to the model
 }
 
 type Message struct {
@@ -81,11 +94,19 @@ func NewClientWithConfig(apiKey, model string) (*Client, error) {
 		}
 	}
 
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Retries are done by the shared retrier so they can be reported to the UI
+	retrier := common.NewRetrier()
 
 	// Build client options
 	opts := []option.RequestOption{
 		option.WithAPIKey(apiKey),
+		option.WithMaxRetries(0),
+		option.WithHTTPClient(common.NewHTTPClient()),
+		option.WithMiddleware(func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+			return retrier.Do(req, next)
+		}),
 	}
 
 	// Add custom base URL if provided (for proxies)
@@ -96,9 +117,11 @@ func NewClientWithConfig(apiKey, model string) (*Client, error) {
 	client := anthropic.NewClient(opts...)
 
 	return &Client{
-		client: client,
-		model:  model,
-		ctx:    ctx,
+		client:  client,
+		model:   model,
+		ctx:     ctx,
+		cancel:  cancel,
+		retrier: retrier,
 	}, nil
 }
 
@@ -237,9 +260,12 @@ func (c *Client) Chat(messages []Message, thinkingEnabled bool, tools []Tool) (s
 	// Build params
 	params := anthropic.MessageNewParams{
 		Model:     anthropic.Model(c.model),
-		MaxTokens: MaxTokensNonStreaming,
+		MaxTokens: c.maxTokensOr(MaxTokensNonStreaming),
 		Messages:  anthropicMessages,
 	}
+	if c.temperature != nil {
+		params.Temperature = anthropic.Float(*c.temperature)
+	}
 
 	// Add all system blocks with cache_control for prompt caching
 	// Minimum cacheable: 1024 tokens for Sonnet, 4096 for Haiku
@@ -254,7 +280,9 @@ func (c *Client) Chat(messages []Message, thinkingEnabled bool, tools []Tool) (s
 	}
 
 	// Send request
-	message, err := c.client.Messages.New(c.ctx, params)
+	var httpResp *http.Response
+	message, err := c.client.Messages.New(c.ctx, params, option.WithResponseInto(&httpResp))
+	c.recordRateLimits(httpResp)
 	if err != nil {
 		logger.Error("Anthropic error", logger.Err(err))
 		return "", "", []FunctionCallResult{}, nil, fmt.Errorf("anthropic error: %w", err)
@@ -384,7 +412,7 @@ func (c *Client) ChatStream(messages []Message, thinkingEnabled bool, tools []To
 	// Build params
 	params := anthropic.MessageNewParams{
 		Model:     anthropic.Model(c.model),
-		MaxTokens: MaxTokensStreaming,
+		MaxTokens: c.maxTokensOr(MaxTokensStreaming),
 		Messages:  anthropicMessages,
 	}
 
@@ -399,14 +427,21 @@ func (c *Client) ChatStream(messages []Message, thinkingEnabled bool, tools []To
 		params.Tools = anthropicTools
 	}
 
-	// Enable extended thinking if requested
+	// Enable extended thinking if requested. Thinking doesn't allow setting the temperature.
 	if thinkingEnabled {
-		params.Thinking = anthropic.ThinkingConfigParamOfEnabled(ThinkingBudget)
-		params.MaxTokens = MaxTokensStreaming // Must be greater than thinking budget
+		budget, err := thinkingBudget(params.MaxTokens)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		params.Thinking = anthropic.ThinkingConfigParamOfEnabled(budget)
+	} else if c.temperature != nil {
+		params.Temperature = anthropic.Float(*c.temperature)
 	}
 
 	// Create stream
-	stream := c.client.Messages.NewStreaming(c.ctx, params)
+	var httpResp *http.Response
+	stream := c.client.Messages.NewStreaming(c.ctx, params, option.WithResponseInto(&httpResp))
+	c.recordRateLimits(httpResp)
 	accumulatedMessage := anthropic.Message{}
 	var inputTokens, outputTokens int64 // Track tokens from MessageDeltaEvent
 
@@ -491,7 +526,61 @@ func (c *Client) ChatStream(messages []Message, thinkingEnabled bool, tools []To
 	return responseText, functionCalls, tokenUsage, nil
 }
 
-// Close is a no-op for the Claude client
+// Close cancels any in-flight requests
 func (c *Client) Close() {
-	// No-op
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+// SetGenerationOptions caps the tokens generated per response (0 keeps the defaults) and sets the
+// sampling temperature (nil leaves it to the model)
+func (c *Client) SetGenerationOptions(maxTokens int, temperature *float64) {
+	c.maxTokens = maxTokens
+	c.temperature = temperature
+}
+
+// thinkingBudget returns the thinking budget for a response capped at maxTokens. The budget must
+// stay below the cap, so a cap under ThinkingBudget shares its tokens between thinking and the answer.
+func thinkingBudget(maxTokens int64) (int64, error) {
+	if maxTokens > ThinkingBudget {
+		return ThinkingBudget, nil
+	}
+	budget := max(maxTokens/2, MinThinkingBudget)
+	if budget >= maxTokens {
+		return 0, fmt.Errorf("max tokens %d is too small for extended thinking, which needs more than %d; raise max tokens or turn thinking off", maxTokens, MinThinkingBudget)
+	}
+	return budget, nil
+}
+
+// maxTokensOr returns the configured max tokens, or def if none is configured
+func (c *Client) maxTokensOr(def int64) int64 {
+	if c.maxTokens > 0 {
+		return int64(c.maxTokens)
+	}
+	return def
+}
+
+// SetRetryNotifier sets the function told when a rate-limited or failed request is retried
+func (c *Client) SetRetryNotifier(notify common.RetryNotifier) {
+	c.retrier.SetNotifier(notify)
+}
+
+// RateLimits returns the rate limit state reported on the latest response
+func (c *Client) RateLimits() *common.RateLimits {
+	c.limitsMu.Lock()
+	defer c.limitsMu.Unlock()
+	return c.rateLimits
+}
+
+// recordRateLimits keeps the rate limit headers of a response, if it carried any
+func (c *Client) recordRateLimits(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	if limits := common.ParseRateLimits(resp.Header); limits != nil {
+		c.limitsMu.Lock()
+		c.rateLimits = limits
+		c.limitsMu.Unlock()
+	}
 }
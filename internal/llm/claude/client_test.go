@@ -0,0 +1,104 @@
+package claude
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// captureRequests starts a server that records the JSON body of each request and answers with a short message
+func captureRequests(t *testing.T) *[]map[string]any {
+	t.Helper()
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var body map[string]any
+		_ = json.Unmarshal(data, &body)
+		bodies = append(bodies, body)
+
+		if body["stream"] == true {
+			// The request body is all the test needs
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"msg_1","type":"message","role":"assistant","model":"claude-test",
+			"content":[{"type":"text","text":"ok"}],"stop_reason":"end_turn","usage":{"input_tokens":1,"output_tokens":1}}`))
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("ANTHROPIC_BASE_URL", server.URL)
+	return &bodies
+}
+
+func TestGenerationOptionsInRequest(t *testing.T) {
+	bodies := captureRequests(t)
+	client, err := NewClientWithConfig("key", "claude-test")
+	if err != nil {
+		t.Fatalf("NewClientWithConfig() error = %v", err)
+	}
+	messages := []Message{{Role: "user", Content: "hi"}}
+
+	if _, _, _, _, err := client.Chat(messages, false, nil); err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	temperature := 0.3
+	client.SetGenerationOptions(1000, &temperature)
+	if _, _, _, _, err := client.Chat(messages, false, nil); err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	// 1000 tokens leave no room for the smallest thinking budget
+	if _, _, _, err := client.ChatStream(messages, true, nil, func(string, bool) {}); err == nil || !strings.Contains(err.Error(), "too small for extended thinking") {
+		t.Errorf("ChatStream() with thinking under 1000 max tokens: error = %v, want too small", err)
+	}
+	client.SetGenerationOptions(4000, &temperature)
+	_, _, _, _ = client.ChatStream(messages, true, nil, func(string, bool) {})
+
+	if len(*bodies) != 3 {
+		t.Fatalf("got %d requests, want 3", len(*bodies))
+	}
+	defaults, configured, thinking := (*bodies)[0], (*bodies)[1], (*bodies)[2]
+
+	if defaults["max_tokens"] != float64(MaxTokensNonStreaming) {
+		t.Errorf("default max_tokens = %v, want %d", defaults["max_tokens"], MaxTokensNonStreaming)
+	}
+	if _, ok := defaults["temperature"]; ok {
+		t.Errorf("default request sets temperature %v, want none", defaults["temperature"])
+	}
+
+	if configured["max_tokens"] != float64(1000) || configured["temperature"] != 0.3 {
+		t.Errorf("configured request has max_tokens %v and temperature %v, want 1000 and 0.3",
+			configured["max_tokens"], configured["temperature"])
+	}
+
+	// Thinking shares the configured cap with the answer and doesn't accept a temperature
+	if budget, _ := thinking["thinking"].(map[string]any); thinking["max_tokens"] != float64(4000) || budget["budget_tokens"] != float64(2000) {
+		t.Errorf("thinking request has max_tokens %v and thinking %v, want 4000 and a 2000 budget",
+			thinking["max_tokens"], thinking["thinking"])
+	}
+	if _, ok := thinking["temperature"]; ok {
+		t.Errorf("thinking request sets temperature %v, want none", thinking["temperature"])
+	}
+}
+
+func TestThinkingBudget(t *testing.T) {
+	tests := []struct {
+		maxTokens int64
+		want      int64
+		wantErr   bool
+	}{
+		{maxTokens: MaxTokensStreaming, want: ThinkingBudget},
+		{maxTokens: ThinkingBudget, want: ThinkingBudget / 2},
+		{maxTokens: 1500, want: MinThinkingBudget},
+		{maxTokens: MinThinkingBudget, wantErr: true},
+		{maxTokens: 500, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := thinkingBudget(tt.maxTokens)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("thinkingBudget(%d) = %d, %v; want %d, error %v", tt.maxTokens, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -16,6 +16,7 @@ func NewClaudeProvider(config common.ProviderConfig) (*ClaudeProvider, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Claude client: %w", err)
 	}
+	client.SetGenerationOptions(config.MaxTokens, config.Temperature)
 
 	return &ClaudeProvider{client: client}, nil
 }
@@ -59,11 +60,22 @@ func (p *ClaudeProvider) ChatStream(messages []common.Message, tools []common.To
 	}, nil
 }
 
-// Close closes any resources
+// Close cancels any in-flight requests
 func (p *ClaudeProvider) Close() error {
+	p.client.Close()
 	return nil
 }
 
+// SetRetryNotifier sets the function told when a rate-limited or failed request is retried
+func (p *ClaudeProvider) SetRetryNotifier(notify common.RetryNotifier) {
+	p.client.SetRetryNotifier(notify)
+}
+
+// RateLimits returns the rate limit headers reported on the latest response
+func (p *ClaudeProvider) RateLimits() *common.RateLimits {
+	return p.client.RateLimits()
+}
+
 // convertMessages converts common.Messages to Claude format
 func (p *ClaudeProvider) convertMessages(messages []common.Message) []Message {
 	claudeMessages := make([]Message, 0, len(messages))
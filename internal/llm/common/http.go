@@ -0,0 +1,11 @@
+package common
+
+import "net/http"
+
+// NewHTTPClient returns the client used for LLM API calls. Requests go through the proxy set in
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY; --proxy only applies to test traffic.
+func NewHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	return &http.Client{Transport: transport}
+}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLookupPrice(t *testing.T) {
+	tests := []struct {
+		model  string
+		want   ModelPrice
+		wantOK bool
+	}{
+		{"claude-sonnet-4-20250514", ModelPrice{Input: 3, Output: 15}, true},
+		{"claude-opus-4-5-20251101", ModelPrice{Input: 5, Output: 25}, true},
+		{"claude-opus-4-1", ModelPrice{Input: 15, Output: 75}, true},
+		{"anthropic/claude-3.5-haiku", ModelPrice{}, false},
+		{"openai/gpt-4o-mini", ModelPrice{Input: 0.15, Output: 0.6}, true},
+		{"gpt-4o-2024-08-06", ModelPrice{Input: 2.5, Output: 10}, true},
+		{"llama3.1:8b", ModelPrice{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := LookupPrice(tt.model)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("LookupPrice(%q) = %v, %v; want %v, %v", tt.model, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	price := ModelPrice{Input: 3, Output: 15}
+	if got := price.Cost(1_000_000, 200_000); math.Abs(got-6) > 1e-9 {
+		t.Errorf("Cost = %v, want 6", got)
+	}
+}
+
+func TestRecordUsage(t *testing.T) {
+	RecordUsage(OperationChat, "test-model", 100, 20)
+	RecordUsage(OperationChat, "test-model", 50, 5)
+	RecordUsage(OperationSpecProcessing, "test-model", 10, 1)
+
+	var chat *Usage
+	for _, usage := range SessionUsage() {
+		if usage.Operation == OperationChat && usage.Model == "test-model" {
+			chat = &usage
+		}
+	}
+	if chat == nil {
+		t.Fatal("chat usage not recorded")
+	}
+	if chat.Calls != 2 || chat.InputTokens != 150 || chat.OutputTokens != 25 {
+		t.Errorf("chat usage = %+v, want 2 calls, 150 in, 25 out", *chat)
+	}
+}
@@ -0,0 +1,106 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LowCapacityThreshold is the remaining fraction of a limit below which capacity is considered low
+const LowCapacityThreshold = 0.1
+
+// RateLimits holds the rate limit state reported by the provider on its latest response
+type RateLimits struct {
+	RequestsLimit     int64
+	RequestsRemaining int64
+	RequestsReset     string
+	TokensLimit       int64
+	TokensRemaining   int64
+	TokensReset       string
+	Headers           map[string]string // All rate limit headers as received
+}
+
+// RateLimitReporter is implemented by providers that track rate limit headers
+type RateLimitReporter interface {
+	RateLimits() *RateLimits
+}
+
+// ParseRateLimits extracts anthropic-ratelimit-* and x-ratelimit-* headers. Returns nil when none are present.
+func ParseRateLimits(h http.Header) *RateLimits {
+	limits := &RateLimits{Headers: make(map[string]string)}
+	for name, values := range h {
+		lower := strings.ToLower(name)
+		if len(values) == 0 || (!strings.HasPrefix(lower, "anthropic-ratelimit-") && !strings.HasPrefix(lower, "x-ratelimit-")) {
+			continue
+		}
+		limits.Headers[lower] = values[0]
+	}
+	if len(limits.Headers) == 0 {
+		return nil
+	}
+
+	// Anthropic: anthropic-ratelimit-requests-limit; OpenAI-compatible: x-ratelimit-limit-requests
+	limits.RequestsLimit = parseLimitHeader(limits.Headers, "anthropic-ratelimit-requests-limit", "x-ratelimit-limit-requests")
+	limits.RequestsRemaining = parseLimitHeader(limits.Headers, "anthropic-ratelimit-requests-remaining", "x-ratelimit-remaining-requests")
+	limits.RequestsReset = firstHeader(limits.Headers, "anthropic-ratelimit-requests-reset", "x-ratelimit-reset-requests")
+	limits.TokensLimit = parseLimitHeader(limits.Headers, "anthropic-ratelimit-tokens-limit", "x-ratelimit-limit-tokens")
+	limits.TokensRemaining = parseLimitHeader(limits.Headers, "anthropic-ratelimit-tokens-remaining", "x-ratelimit-remaining-tokens")
+	limits.TokensReset = firstHeader(limits.Headers, "anthropic-ratelimit-tokens-reset", "x-ratelimit-reset-tokens")
+	return limits
+}
+
+// IsLow reports whether remaining requests or tokens have dropped below LowCapacityThreshold
+func (r *RateLimits) IsLow() bool {
+	if r == nil {
+		return false
+	}
+	return isLow(r.RequestsRemaining, r.RequestsLimit) || isLow(r.TokensRemaining, r.TokensLimit)
+}
+
+// Summary returns a short description of remaining capacity
+func (r *RateLimits) Summary() string {
+	if r == nil {
+		return "No rate limit information reported"
+	}
+	var parts []string
+	if r.RequestsLimit > 0 {
+		parts = append(parts, fmt.Sprintf("requests %d/%d", r.RequestsRemaining, r.RequestsLimit))
+	}
+	if r.TokensLimit > 0 {
+		parts = append(parts, fmt.Sprintf("tokens %d/%d", r.TokensRemaining, r.TokensLimit))
+	}
+	if len(parts) == 0 {
+		return "No rate limit information reported"
+	}
+	return strings.Join(parts, " · ")
+}
+
+// SortedHeaderNames returns the captured header names in a stable order
+func (r *RateLimits) SortedHeaderNames() []string {
+	names := make([]string, 0, len(r.Headers))
+	for name := range r.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isLow(remaining, limit int64) bool {
+	return limit > 0 && float64(remaining) < float64(limit)*LowCapacityThreshold
+}
+
+func firstHeader(headers map[string]string, names ...string) string {
+	for _, name := range names {
+		if v, ok := headers[name]; ok {
+			return v
+		}
+	}
+	return ""
+}
+
+func parseLimitHeader(headers map[string]string, names ...string) int64 {
+	n, _ := strconv.ParseInt(firstHeader(headers, names...), 10, 64)
+	return n
+}
@@ -0,0 +1,70 @@
+package common
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	tests := []struct {
+		name          string
+		headers       map[string]string
+		wantNil       bool
+		wantRequests  int64
+		wantRemaining int64
+		wantLow       bool
+	}{
+		{
+			name:    "no rate limit headers",
+			headers: map[string]string{"Content-Type": "application/json"},
+			wantNil: true,
+		},
+		{
+			name: "anthropic headers",
+			headers: map[string]string{
+				"Anthropic-Ratelimit-Requests-Limit":     "50",
+				"Anthropic-Ratelimit-Requests-Remaining": "49",
+				"Anthropic-Ratelimit-Tokens-Limit":       "40000",
+				"Anthropic-Ratelimit-Tokens-Remaining":   "1000",
+			},
+			wantRequests:  50,
+			wantRemaining: 49,
+			wantLow:       true,
+		},
+		{
+			name: "openai headers",
+			headers: map[string]string{
+				"X-Ratelimit-Limit-Requests":     "500",
+				"X-Ratelimit-Remaining-Requests": "499",
+				"X-Ratelimit-Reset-Requests":     "120ms",
+			},
+			wantRequests:  500,
+			wantRemaining: 499,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+			limits := ParseRateLimits(h)
+			if tt.wantNil {
+				if limits != nil {
+					t.Errorf("expected nil, got %+v", limits)
+				}
+				return
+			}
+			if limits == nil {
+				t.Fatal("expected rate limits, got nil")
+			}
+			if limits.RequestsLimit != tt.wantRequests || limits.RequestsRemaining != tt.wantRemaining {
+				t.Errorf("requests = %d/%d, want %d/%d", limits.RequestsRemaining, limits.RequestsLimit, tt.wantRemaining, tt.wantRequests)
+			}
+			if limits.IsLow() != tt.wantLow {
+				t.Errorf("IsLow() = %v, want %v", limits.IsLow(), tt.wantLow)
+			}
+		})
+	}
+}
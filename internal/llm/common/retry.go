@@ -0,0 +1,163 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Defaults for retrying LLM requests that were rate limited (429) or hit a transient server error (5xx)
+const (
+	DefaultMaxRetries     = 3
+	DefaultRetryBaseDelay = time.Second
+	MaxRetryDelay         = time.Minute // Longer Retry-After values are capped to this
+)
+
+// RetryEvent describes a request that is about to be resent
+type RetryEvent struct {
+	Retry      int // 1 for the first retry
+	MaxRetries int
+	StatusCode int
+	Wait       time.Duration
+}
+
+// String describes the retry for the user, e.g. "Rate limited, retrying in 2s (1/3)…"
+func (e RetryEvent) String() string {
+	reason := fmt.Sprintf("Server error %d", e.StatusCode)
+	if e.StatusCode == http.StatusTooManyRequests {
+		reason = "Rate limited"
+	}
+	return fmt.Sprintf("%s, retrying in %s (%d/%d)…", reason, e.Wait.Round(100*time.Millisecond), e.Retry, e.MaxRetries)
+}
+
+// RetryNotifier is told about each retry, e.g. to show it in the UI
+type RetryNotifier func(RetryEvent)
+
+// RetryReporter is implemented by providers that retry rate-limited and failed requests
+type RetryReporter interface {
+	SetRetryNotifier(notify RetryNotifier)
+}
+
+// Retrier resends requests that got a 429 or 5xx response, waiting as long as the response's
+// Retry-After header asks or else with exponential backoff. It is safe for concurrent use.
+type Retrier struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+
+	mu     sync.Mutex
+	notify RetryNotifier
+}
+
+// NewRetrier creates a Retrier with the default attempts and delays
+func NewRetrier() *Retrier {
+	return &Retrier{MaxRetries: DefaultMaxRetries, BaseDelay: DefaultRetryBaseDelay}
+}
+
+// SetNotifier sets the function told about each retry; nil stops notifications
+func (r *Retrier) SetNotifier(notify RetryNotifier) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notify = notify
+}
+
+// Do sends req with send, resending it while the response is retryable and attempts remain.
+// The last response is returned as is, so callers report errors as they would without retries.
+func (r *Retrier) Do(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for retry := 0; ; retry++ {
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		resp, err := send(req)
+		if err != nil || !retryableStatus(resp.StatusCode) || retry >= r.MaxRetries {
+			return resp, err
+		}
+
+		wait, ok := RetryAfter(resp.Header)
+		if !ok {
+			wait = r.backoff(retry)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		r.mu.Lock()
+		notify := r.notify
+		r.mu.Unlock()
+		if notify != nil {
+			notify(RetryEvent{Retry: retry + 1, MaxRetries: r.MaxRetries, StatusCode: resp.StatusCode, Wait: wait})
+		}
+
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns the exponential delay before the given retry, with up to 25% jitter
+func (r *Retrier) backoff(retry int) time.Duration {
+	delay := r.BaseDelay << retry
+	if delay <= 0 {
+		return 0
+	}
+	delay += time.Duration(rand.Int64N(int64(delay)/4 + 1))
+	return min(delay, MaxRetryDelay)
+}
+
+// RetryAfter reads how long a response asks clients to wait, from retry-after-ms or Retry-After
+// (seconds or an HTTP date), capped at MaxRetryDelay
+func RetryAfter(h http.Header) (time.Duration, bool) {
+	if ms, err := strconv.ParseFloat(h.Get("Retry-After-Ms"), 64); err == nil && ms >= 0 {
+		return min(time.Duration(ms*float64(time.Millisecond)), MaxRetryDelay), true
+	}
+	value := h.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds*float64(time.Second)), MaxRetryDelay), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return min(max(time.Until(date), 0), MaxRetryDelay), true
+	}
+	return 0, false
+}
+
+// retryableStatus reports whether a status is worth retrying: rate limits and transient server errors
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout, 529: // 529: Anthropic's "overloaded"
+		return true
+	}
+	return false
+}
+
+// sleep waits for d, returning early with the context's error if it is canceled
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
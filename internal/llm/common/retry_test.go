@@ -0,0 +1,126 @@
+package common
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetrierRetriesRateLimit(t *testing.T) {
+	var attempts int
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	var events []RetryEvent
+	retrier := NewRetrier()
+	retrier.SetNotifier(func(e RetryEvent) { events = append(events, e) })
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"prompt":"hi"}`))
+	resp, err := retrier.Do(req, http.DefaultClient.Do)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+	if bodies[1] != `{"prompt":"hi"}` {
+		t.Errorf("retried body = %q, want the original body", bodies[1])
+	}
+	if len(events) != 1 || events[0].StatusCode != http.StatusTooManyRequests || events[0].Retry != 1 || events[0].Wait != 0 {
+		t.Errorf("events = %+v, want one 429 retry without waiting", events)
+	}
+	if got := events[0].String(); !strings.HasPrefix(got, "Rate limited, retrying") {
+		t.Errorf("String() = %q, want a rate limit message", got)
+	}
+}
+
+func TestRetrierGivesUp(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	retrier := &Retrier{MaxRetries: 2, BaseDelay: time.Millisecond}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := retrier.Do(req, http.DefaultClient.Do)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want the last 503", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestRetrierSkipsClientErrors(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := NewRetrier().Do(req, http.DefaultClient.Do)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    time.Duration
+		wantOK  bool
+	}{
+		{name: "no header"},
+		{name: "seconds", headers: map[string]string{"Retry-After": "2"}, want: 2 * time.Second, wantOK: true},
+		{name: "milliseconds win", headers: map[string]string{"Retry-After-Ms": "150", "Retry-After": "2"}, want: 150 * time.Millisecond, wantOK: true},
+		{name: "capped", headers: map[string]string{"Retry-After": "3600"}, want: MaxRetryDelay, wantOK: true},
+		{name: "past date", headers: map[string]string{"Retry-After": "Mon, 02 Jan 2006 15:04:05 GMT"}, want: 0, wantOK: true},
+		{name: "garbage", headers: map[string]string{"Retry-After": "soon"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+			got, ok := RetryAfter(h)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("RetryAfter() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package common
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrStreamingUnsupported is wrapped by errors of providers that detect a server unable to stream
+// and can't recover the answer from its response
+var ErrStreamingUnsupported = errors.New("streaming not supported")
+
+// streamingRefusals are phrases servers use when rejecting a stream request
+var streamingRefusals = []string{"not supported", "unsupported", "not available", "does not support", "not allowed", "not enabled"}
+
+// IsStreamingUnsupported reports whether a failed streaming request should be retried without
+// streaming: the provider detected it, or the server's error mentions streaming not being supported
+func IsStreamingUnsupported(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrStreamingUnsupported) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	if !strings.Contains(msg, "stream") {
+		return false
+	}
+	for _, refusal := range streamingRefusals {
+		if strings.Contains(msg, refusal) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIsStreamingUnsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"wrapped sentinel", fmt.Errorf("%w: server answered with application/json", ErrStreamingUnsupported), true},
+		{"server refusal", errors.New("API error (400): Streaming is not supported for this model"), true},
+		{"does not support", errors.New("model o1 does not support stream=true"), true},
+		{"unrelated 400", errors.New("API error (400): invalid tools"), false},
+		{"stream cut off", errors.New("stream read error: unexpected EOF"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsStreamingUnsupported(tt.err); got != tt.want {
+				t.Errorf("IsStreamingUnsupported(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -63,9 +63,18 @@ type Provider interface {
 
 // ProviderConfig holds configuration for creating a provider
 type ProviderConfig struct {
-	Provider string // "claude", "openai", "openrouter", "ollama", "llamacpp"
+	Provider string // "claude", "openai", "openrouter", "azure", "gemini", "ollama", "llamacpp"
 	APIKey   string
 	BaseURL  string // optional override
 	Model    string // model name
 	Timeout  time.Duration
+
+	DisableStreaming bool // Always use non-streaming requests, for gateways and models that can't stream
+
+	MaxTokens   int      // Cap on tokens generated per response; 0 keeps the provider's default
+	Temperature *float64 // Sampling temperature; nil leaves it to the model
+
+	// Azure OpenAI: BaseURL is the resource endpoint and requests go to the deployment (Model if empty)
+	Deployment string
+	APIVersion string // empty uses the client's default
 }
@@ -0,0 +1,62 @@
+package common
+
+import (
+	"sort"
+	"sync"
+)
+
+// Operations LLM usage is attributed to in cost summaries
+const (
+	OperationSpecProcessing = "spec processing"
+	OperationTestGeneration = "test generation"
+	OperationChat           = "chat"
+)
+
+// Usage is the token usage accumulated for one operation and model
+type Usage struct {
+	Operation    string
+	Model        string
+	Calls        int
+	InputTokens  int64
+	OutputTokens int64
+}
+
+// sessionUsage accumulates usage for the whole process, across agents and providers
+var sessionUsage = struct {
+	mu      sync.Mutex
+	entries map[[2]string]*Usage
+}{entries: map[[2]string]*Usage{}}
+
+// RecordUsage adds the tokens of one LLM call to the session totals
+func RecordUsage(operation, model string, inputTokens, outputTokens int64) {
+	sessionUsage.mu.Lock()
+	defer sessionUsage.mu.Unlock()
+
+	key := [2]string{operation, model}
+	entry, ok := sessionUsage.entries[key]
+	if !ok {
+		entry = &Usage{Operation: operation, Model: model}
+		sessionUsage.entries[key] = entry
+	}
+	entry.Calls++
+	entry.InputTokens += inputTokens
+	entry.OutputTokens += outputTokens
+}
+
+// SessionUsage returns the usage recorded so far, sorted by operation and model
+func SessionUsage() []Usage {
+	sessionUsage.mu.Lock()
+	defer sessionUsage.mu.Unlock()
+
+	usage := make([]Usage, 0, len(sessionUsage.entries))
+	for _, entry := range sessionUsage.entries {
+		usage = append(usage, *entry)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Operation != usage[j].Operation {
+			return usage[i].Operation < usage[j].Operation
+		}
+		return usage[i].Model < usage[j].Model
+	})
+	return usage
+}
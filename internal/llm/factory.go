@@ -6,16 +6,22 @@ import (
 
 	"github.com/Octrafic/octrafic-cli/internal/llm/claude"
 	"github.com/Octrafic/octrafic-cli/internal/llm/common"
+	"github.com/Octrafic/octrafic-cli/internal/llm/gemini"
 	"github.com/Octrafic/octrafic-cli/internal/llm/openai"
 )
 
+// SupportedProviders lists the provider names accepted by CreateProvider
+var SupportedProviders = []string{"claude", "anthropic", "openai", "openrouter", "azure", "gemini", "ollama", "llamacpp"}
+
 // CreateProvider creates a provider based on the config
 func CreateProvider(config common.ProviderConfig) (common.Provider, error) {
 	switch config.Provider {
 	case "claude", "anthropic":
 		return claude.NewClaudeProvider(config)
-	case "openai", "openrouter":
+	case "openai", "openrouter", "azure":
 		return openai.NewOpenAIProvider(config)
+	case "gemini":
+		return gemini.NewGeminiProvider(config)
 	case "ollama":
 		if config.BaseURL == "" {
 			config.BaseURL = "http://localhost:11434/v1"
@@ -31,7 +37,7 @@ func CreateProvider(config common.ProviderConfig) (common.Provider, error) {
 		}
 		return openai.NewOpenAIProvider(config)
 	default:
-		return nil, fmt.Errorf("unsupported provider: %s (supported: claude, anthropic, openai, openrouter, ollama, llamacpp)", config.Provider)
+		return nil, fmt.Errorf("unsupported provider: %s (supported: %s)", config.Provider, strings.Join(SupportedProviders, ", "))
 	}
 }
 
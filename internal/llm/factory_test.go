@@ -60,3 +60,21 @@ func TestCreateProviderLlamacppDefaultURL(t *testing.T) {
 		t.Fatal("expected non-nil provider")
 	}
 }
+
+func TestCreateProviderGemini(t *testing.T) {
+	provider, err := CreateProvider(common.ProviderConfig{
+		Provider: "gemini",
+		APIKey:   "key",
+		Model:    "gemini-2.5-flash",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider == nil {
+		t.Fatal("expected non-nil provider")
+	}
+
+	if _, err := CreateProvider(common.ProviderConfig{Provider: "gemini", Model: "gemini-2.5-flash"}); err == nil {
+		t.Error("expected error for gemini without an API key")
+	}
+}
@@ -0,0 +1,417 @@
+package gemini
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Octrafic/octrafic-cli/internal/llm/common"
+	"github.com/tidwall/gjson"
+)
+
+// DefaultBaseURL is the Gemini API endpoint used when no base URL is configured
+const DefaultBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+
+// generatedIDPrefix marks function call IDs made up by the client, which Gemini doesn't know
+const generatedIDPrefix = "gemini-call-"
+
+// Message represents a chat message
+type Message struct {
+	Role             string
+	Content          string
+	FunctionResponse *FunctionResponseData
+	FunctionCalls    []FunctionCallData
+}
+
+// FunctionResponseData represents a function/tool response
+type FunctionResponseData struct {
+	ID       string
+	Name     string
+	Response map[string]interface{}
+}
+
+// FunctionCallData represents a function/tool call
+type FunctionCallData struct {
+	ID   string
+	Name string
+	Args map[string]interface{}
+}
+
+// Tool represents a tool/function definition
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema map[string]interface{}
+}
+
+// StreamCallback is called for each chunk as it's streamed
+// isThought indicates if this chunk is reasoning/thinking (true) or regular content (false)
+type StreamCallback func(chunk string, isThought bool)
+
+// ChatResponse represents the response from generateContent
+type ChatResponse struct {
+	Message   string
+	Reasoning string // Thought summaries, when thinking is enabled
+	ToolCalls []FunctionCallData
+}
+
+// TokenUsage represents token usage information
+type TokenUsage struct {
+	InputTokens  int64
+	OutputTokens int64
+}
+
+// Client talks to the Gemini generateContent API
+type Client struct {
+	httpClient *http.Client
+	apiKey     string
+	model      string
+	baseURL    string
+	ctx        context.Context
+	cancel     context.CancelFunc
+
+	// Gemini doesn't always give function calls an ID, and thinking models attach a signature
+	// that must be sent back with the call. Both are tracked here by the ID handed to the agent.
+	callsMu    sync.Mutex
+	callCount  int
+	signatures map[string]string
+
+	maxTokens   int      // 0 keeps the model's default
+	temperature *float64 // nil leaves it to the model
+
+	retrier *common.Retrier
+}
+
+// NewClientWithConfig creates a new client with explicit configuration
+func NewClientWithConfig(apiKey, model, baseURL string) (*Client, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("API key is required")
+	}
+	if model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Client{
+		httpClient: common.NewHTTPClient(),
+		apiKey:     apiKey,
+		model:      strings.TrimPrefix(model, "models/"),
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		ctx:        ctx,
+		cancel:     cancel,
+		signatures: make(map[string]string),
+		retrier:    common.NewRetrier(),
+	}, nil
+}
+
+// Close cancels any in-flight requests
+func (c *Client) Close() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+// SetGenerationOptions caps the tokens generated per response (0 keeps the model's default) and
+// sets the sampling temperature (nil leaves it to the model)
+func (c *Client) SetGenerationOptions(maxTokens int, temperature *float64) {
+	c.maxTokens = maxTokens
+	c.temperature = temperature
+}
+
+// SetRetryNotifier sets the function told when a rate-limited or failed request is retried
+func (c *Client) SetRetryNotifier(notify common.RetryNotifier) {
+	c.retrier.SetNotifier(notify)
+}
+
+// Chat sends a non-streaming generateContent request
+func (c *Client) Chat(messages []Message, tools []Tool, thinkingEnabled bool) (*ChatResponse, *TokenUsage, error) {
+	resp, err := c.post("generateContent", c.buildRequestPayload(messages, tools, thinkingEnabled))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := &ChatResponse{}
+	res := gjson.ParseBytes(body)
+	c.collectParts(res, response, nil)
+	if err := blockedError(res, response); err != nil {
+		return nil, nil, err
+	}
+	return response, parseUsage(res), nil
+}
+
+// ChatStream sends a streamGenerateContent request and reports text and thoughts as they arrive
+func (c *Client) ChatStream(messages []Message, tools []Tool, thinkingEnabled bool, callback StreamCallback) (*ChatResponse, *TokenUsage, error) {
+	resp, err := c.post("streamGenerateContent?alt=sse", c.buildRequestPayload(messages, tools, thinkingEnabled))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	response := &ChatResponse{}
+	var usage *TokenUsage
+	var last gjson.Result
+
+	reader := bufio.NewReader(resp.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, nil, err
+		}
+
+		if data, ok := strings.CutPrefix(strings.TrimSpace(line), "data: "); ok {
+			last = gjson.Parse(data)
+			if apiErr := last.Get("error"); apiErr.Exists() {
+				return nil, nil, fmt.Errorf("API error (%d): %s", apiErr.Get("code").Int(), apiErr.Get("message").String())
+			}
+			c.collectParts(last, response, callback)
+			if u := parseUsage(last); u != nil {
+				usage = u
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	if err := blockedError(last, response); err != nil {
+		return nil, nil, err
+	}
+	return response, usage, nil
+}
+
+// post sends payload to the model's method and returns the response, or an error for a non-200 status
+func (c *Client) post(method string, payload map[string]interface{}) (*http.Response, error) {
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/models/%s:%s", c.baseURL, c.model, method)
+	req, err := http.NewRequestWithContext(c.ctx, "POST", url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", c.apiKey)
+
+	resp, err := c.retrier.Do(req, c.httpClient.Do)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+	}
+	return resp, nil
+}
+
+// collectParts adds the text, thoughts and function calls of a (partial) response to response,
+// forwarding text and thoughts to callback when streaming
+func (c *Client) collectParts(res gjson.Result, response *ChatResponse, callback StreamCallback) {
+	for _, part := range res.Get("candidates.0.content.parts").Array() {
+		if call := part.Get("functionCall"); call.Exists() {
+			var args map[string]interface{}
+			_ = json.Unmarshal([]byte(call.Get("args").Raw), &args)
+			id := c.trackCall(call.Get("id").String(), part.Get("thoughtSignature").String())
+			response.ToolCalls = append(response.ToolCalls, FunctionCallData{ID: id, Name: call.Get("name").String(), Args: args})
+			continue
+		}
+
+		text := part.Get("text").String()
+		if text == "" {
+			continue
+		}
+		isThought := part.Get("thought").Bool()
+		if isThought {
+			response.Reasoning += text
+		} else {
+			response.Message += text
+		}
+		if callback != nil {
+			callback(text, isThought)
+		}
+	}
+}
+
+// trackCall returns the ID to report for a function call, generating one when Gemini sent none,
+// and remembers the call's thought signature
+func (c *Client) trackCall(id, signature string) string {
+	c.callsMu.Lock()
+	defer c.callsMu.Unlock()
+
+	if id == "" {
+		c.callCount++
+		id = fmt.Sprintf("%s%d", generatedIDPrefix, c.callCount)
+	}
+	if signature != "" {
+		c.signatures[id] = signature
+	}
+	return id
+}
+
+// signature returns the thought signature Gemini attached to the call with the given ID
+func (c *Client) signature(id string) string {
+	c.callsMu.Lock()
+	defer c.callsMu.Unlock()
+	return c.signatures[id]
+}
+
+// blockedError reports a response that ended without any output, e.g. because of a safety block
+func blockedError(res gjson.Result, response *ChatResponse) error {
+	if response.Message != "" || response.Reasoning != "" || len(response.ToolCalls) > 0 {
+		return nil
+	}
+	if reason := res.Get("promptFeedback.blockReason").String(); reason != "" {
+		return fmt.Errorf("prompt blocked by Gemini: %s", reason)
+	}
+	if reason := res.Get("candidates.0.finishReason").String(); reason != "" && reason != "STOP" {
+		return fmt.Errorf("gemini returned no content (finish reason %s)", reason)
+	}
+	return nil
+}
+
+func parseUsage(res gjson.Result) *TokenUsage {
+	usage := res.Get("usageMetadata")
+	if !usage.Exists() {
+		return nil
+	}
+	return &TokenUsage{
+		InputTokens:  usage.Get("promptTokenCount").Int(),
+		OutputTokens: usage.Get("candidatesTokenCount").Int() + usage.Get("thoughtsTokenCount").Int(),
+	}
+}
+
+func (c *Client) buildRequestPayload(messages []Message, tools []Tool, thinkingEnabled bool) map[string]interface{} {
+	payload := map[string]interface{}{}
+
+	var system []string
+	var contents []map[string]interface{}
+	for _, m := range messages {
+		if m.Role == "system" {
+			if m.Content != "" {
+				system = append(system, m.Content)
+			}
+			continue
+		}
+
+		role := "user"
+		if m.Role == "assistant" || m.Role == "model" {
+			role = "model"
+		}
+		parts := c.convertParts(m)
+		if len(parts) == 0 {
+			continue
+		}
+
+		// Gemini expects the responses to parallel function calls in a single turn
+		if n := len(contents); n > 0 && contents[n-1]["role"] == role {
+			contents[n-1]["parts"] = append(contents[n-1]["parts"].([]map[string]interface{}), parts...)
+			continue
+		}
+		contents = append(contents, map[string]interface{}{"role": role, "parts": parts})
+	}
+	payload["contents"] = contents
+
+	if len(system) > 0 {
+		payload["systemInstruction"] = map[string]interface{}{
+			"parts": []map[string]interface{}{{"text": strings.Join(system, "\n\n")}},
+		}
+	}
+
+	if len(tools) > 0 {
+		payload["tools"] = []map[string]interface{}{{"functionDeclarations": functionDeclarations(tools)}}
+	}
+
+	generationConfig := map[string]interface{}{}
+	if thinkingEnabled {
+		generationConfig["thinkingConfig"] = map[string]interface{}{"includeThoughts": true}
+	}
+	if c.maxTokens > 0 {
+		generationConfig["maxOutputTokens"] = c.maxTokens
+	}
+	if c.temperature != nil {
+		generationConfig["temperature"] = *c.temperature
+	}
+	if len(generationConfig) > 0 {
+		payload["generationConfig"] = generationConfig
+	}
+
+	return payload
+}
+
+// convertParts converts a message's text, function calls and function response to Gemini parts
+func (c *Client) convertParts(m Message) []map[string]interface{} {
+	var parts []map[string]interface{}
+	if m.Content != "" {
+		parts = append(parts, map[string]interface{}{"text": m.Content})
+	}
+
+	for _, fc := range m.FunctionCalls {
+		args := fc.Args
+		if args == nil {
+			args = map[string]interface{}{}
+		}
+		call := map[string]interface{}{"name": fc.Name, "args": args}
+		if isGeminiID(fc.ID) {
+			call["id"] = fc.ID
+		}
+		part := map[string]interface{}{"functionCall": call}
+		if signature := c.signature(fc.ID); signature != "" {
+			part["thoughtSignature"] = signature
+		}
+		parts = append(parts, part)
+	}
+
+	if m.FunctionResponse != nil {
+		response := m.FunctionResponse.Response
+		if response == nil {
+			response = map[string]interface{}{}
+		}
+		functionResponse := map[string]interface{}{"name": m.FunctionResponse.Name, "response": response}
+		if isGeminiID(m.FunctionResponse.ID) {
+			functionResponse["id"] = m.FunctionResponse.ID
+		}
+		parts = append(parts, map[string]interface{}{"functionResponse": functionResponse})
+	}
+	return parts
+}
+
+// isGeminiID reports whether a function call ID came from Gemini rather than from trackCall
+func isGeminiID(id string) bool {
+	return id != "" && !strings.HasPrefix(id, generatedIDPrefix)
+}
+
+// functionDeclarations declares the tools as Gemini functions. parametersJsonSchema takes the schemas as
+// they are, unlike parameters, which rejects JSON Schema keywords such as additionalProperties.
+func functionDeclarations(tools []Tool) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, t := range tools {
+		declaration := map[string]interface{}{
+			"name":        t.Name,
+			"description": t.Description,
+		}
+		if len(t.InputSchema) > 0 {
+			declaration["parametersJsonSchema"] = t.InputSchema
+		}
+		result = append(result, declaration)
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package openai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Octrafic/octrafic-cli/internal/llm/common"
+)
+
+func TestAzureRequestShape(t *testing.T) {
+	var got *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"pong"}}],"usage":{"prompt_tokens":3,"completion_tokens":1}}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider(common.ProviderConfig{
+		Provider:   "azure",
+		APIKey:     "azure-key",
+		BaseURL:    server.URL + "/",
+		Model:      "gpt-4o",
+		Deployment: "prod-gpt",
+		APIVersion: "2024-06-01",
+	})
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider() error = %v", err)
+	}
+
+	resp, err := provider.Chat([]common.Message{{Role: "user", Content: "ping"}}, nil, false)
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Message != "pong" {
+		t.Errorf("Message = %q, want pong", resp.Message)
+	}
+
+	if got.URL.Path != "/openai/deployments/prod-gpt/chat/completions" {
+		t.Errorf("path = %q, want the deployment's chat completions path", got.URL.Path)
+	}
+	if v := got.URL.Query().Get("api-version"); v != "2024-06-01" {
+		t.Errorf("api-version = %q, want 2024-06-01", v)
+	}
+	if key := got.Header.Get("api-key"); key != "azure-key" {
+		t.Errorf("api-key header = %q, want azure-key", key)
+	}
+	if auth := got.Header.Get("Authorization"); auth != "" {
+		t.Errorf("Authorization header = %q, want none for Azure", auth)
+	}
+}
+
+// The deployment name is only part of the URL; the payload follows the model it serves
+func TestAzureDeploymentKeepsModel(t *testing.T) {
+	var payload map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		if r.URL.Path != "/openai/deployments/reasoning-prod/chat/completions" {
+			t.Errorf("path = %q, want the deployment's chat completions path", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"pong"}}]}`))
+	}))
+	defer server.Close()
+
+	temperature := 0.2
+	provider, err := NewOpenAIProvider(common.ProviderConfig{
+		Provider:    "azure",
+		APIKey:      "azure-key",
+		BaseURL:     server.URL,
+		Model:       "o3-mini",
+		Deployment:  "reasoning-prod",
+		Temperature: &temperature,
+	})
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider() error = %v", err)
+	}
+	if _, err := provider.Chat([]common.Message{{Role: "user", Content: "ping"}}, nil, false); err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	if payload["model"] != "o3-mini" {
+		t.Errorf("model = %v, want o3-mini", payload["model"])
+	}
+	if payload["max_completion_tokens"] != float64(DefaultReasoningMaxTokens) || payload["reasoning_effort"] != "medium" {
+		t.Errorf("payload = %v, want o-series options", payload)
+	}
+	if _, ok := payload["temperature"]; ok {
+		t.Errorf("temperature sent to an o-series model: %v", payload)
+	}
+}
+
+func TestNewAzureClientDefaults(t *testing.T) {
+	client, err := NewAzureClient("key", "https://res.openai.azure.com", "gpt-4o", "", "")
+	if err != nil {
+		t.Fatalf("NewAzureClient() error = %v", err)
+	}
+	want := "https://res.openai.azure.com/openai/deployments/gpt-4o/chat/completions?api-version=" + DefaultAzureAPIVersion
+	if got := client.completionsURL(); got != want {
+		t.Errorf("completionsURL() = %q, want %q", got, want)
+	}
+
+	if _, err := NewAzureClient("key", "", "gpt-4o", "", ""); err == nil {
+		t.Error("expected error without an endpoint")
+	}
+	if _, err := NewAzureClient("key", "https://res.openai.azure.com", "", "", ""); err == nil {
+		t.Error("expected error without a deployment")
+	}
+}
@@ -8,11 +8,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 
 	"regexp"
+	"sync"
 
+	"github.com/Octrafic/octrafic-cli/internal/llm/common"
 	"github.com/tidwall/gjson"
 )
 
@@ -189,8 +192,27 @@ type Client struct {
 	model      string
 	baseURL    string
 	ctx        context.Context
+	cancel     context.CancelFunc
+
+	// Azure OpenAI sends the key in an api-key header and needs an api-version query parameter
+	azure      bool
+	apiVersion string
+
+	maxTokens   int      // 0 keeps the default
+	temperature *float64 // nil leaves it to the model
+
+	limitsMu   sync.Mutex
+	rateLimits *common.RateLimits
+
+	retrier *common.Retrier
 }
 
+// DefaultAzureAPIVersion is the Azure OpenAI API version used when none is configured
+const DefaultAzureAPIVersion = "2024-10-21"
+
+// DefaultReasoningMaxTokens caps o-series responses, reasoning included, unless configured otherwise
+const DefaultReasoningMaxTokens = 10000
+
 // NewClient creates a new client from environment variables
 func NewClient() (*Client, error) {
 	apiKey := os.Getenv("OPENAI_API_KEY")
@@ -221,15 +243,64 @@ func NewClientWithConfig(apiKey, model, baseURL string) (*Client, error) {
 		baseURL = "https://api.openai.com/v1"
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return &Client{
-		httpClient: &http.Client{},
+		httpClient: common.NewHTTPClient(),
 		apiKey:     apiKey,
 		model:      model,
 		baseURL:    strings.TrimSuffix(baseURL, "/"),
-		ctx:        context.Background(),
+		ctx:        ctx,
+		cancel:     cancel,
+		retrier:    common.NewRetrier(),
 	}, nil
 }
 
+// NewAzureClient creates a client for an Azure OpenAI deployment. endpoint is the resource URL,
+// e.g. https://my-resource.openai.azure.com; an empty apiVersion uses DefaultAzureAPIVersion.
+// The deployment only names the URL: request options such as o-series handling follow model,
+// the model the deployment serves. An empty deployment is taken to be named after the model.
+func NewAzureClient(apiKey, endpoint, model, deployment, apiVersion string) (*Client, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("azure endpoint is required")
+	}
+	if deployment == "" {
+		deployment = model
+	}
+	if deployment == "" {
+		return nil, fmt.Errorf("azure deployment is required")
+	}
+	if model == "" {
+		model = deployment
+	}
+	if apiVersion == "" {
+		apiVersion = DefaultAzureAPIVersion
+	}
+
+	baseURL := strings.TrimSuffix(endpoint, "/") + "/openai/deployments/" + url.PathEscape(deployment)
+	client, err := NewClientWithConfig(apiKey, model, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	client.azure = true
+	client.apiVersion = apiVersion
+	return client, nil
+}
+
+// SetGenerationOptions caps the tokens generated per response (0 keeps the default) and sets the
+// sampling temperature (nil leaves it to the model)
+func (c *Client) SetGenerationOptions(maxTokens int, temperature *float64) {
+	c.maxTokens = maxTokens
+	c.temperature = temperature
+}
+
+// Close cancels any in-flight requests
+func (c *Client) Close() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
 // ChatStream sends a streaming chat request
 func (c *Client) ChatStream(messages []Message, tools []Tool, thinkingEnabled bool, callback StreamCallback) (*ChatResponse, *TokenUsage, error) {
 	return c.chatStream(messages, tools, thinkingEnabled, callback)
@@ -244,22 +315,39 @@ func (c *Client) chatStream(messages []Message, tools []Tool, thinkingEnabled bo
 	}
 
 	bodyBytes, _ := json.Marshal(reqBody)
-	req, err := http.NewRequestWithContext(c.ctx, "POST", c.baseURL+"/chat/completions", bytes.NewReader(bodyBytes))
+	req, err := http.NewRequestWithContext(c.ctx, "POST", c.completionsURL(), bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, nil, err
 	}
 
 	c.setHeaders(req)
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.retrier.Do(req, c.httpClient.Do)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
+	c.recordRateLimits(resp)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
 	}
+	// Gateways that can't stream ignore "stream": true and send the whole completion as JSON: use
+	// that body as the answer rather than asking again without streaming
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, err
+		}
+		response, usage := parseCompletion(body)
+		if response.Reasoning != "" {
+			callback(response.Reasoning, true)
+		}
+		if response.Message != "" {
+			callback(response.Message, false)
+		}
+		return response, usage, nil
+	}
 
 	reader := bufio.NewReader(resp.Body)
 	var accumulatedContent, accumulatedReasoning string
@@ -369,23 +457,30 @@ func (c *Client) Chat(messages []Message, tools []Tool) (*ChatResponse, *TokenUs
 func (c *Client) chat(messages []Message, tools []Tool) (*ChatResponse, *TokenUsage, error) {
 	reqBody := c.buildRequestPayload(messages, tools, false)
 	bodyBytes, _ := json.Marshal(reqBody)
-	req, err := http.NewRequestWithContext(c.ctx, "POST", c.baseURL+"/chat/completions", bytes.NewReader(bodyBytes))
+	req, err := http.NewRequestWithContext(c.ctx, "POST", c.completionsURL(), bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, nil, err
 	}
 
 	c.setHeaders(req)
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.retrier.Do(req, c.httpClient.Do)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
+	c.recordRateLimits(resp)
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != http.StatusOK {
 		return nil, nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
 	}
 
+	response, usage := parseCompletion(body)
+	return response, usage, nil
+}
+
+// parseCompletion decodes a non-streaming chat completion body
+func parseCompletion(body []byte) (*ChatResponse, *TokenUsage) {
 	res := gjson.ParseBytes(body)
 	msg := res.Get("choices.0.message")
 
@@ -413,12 +508,22 @@ func (c *Client) chat(messages []Message, tools []Tool) (*ChatResponse, *TokenUs
 	}
 
 	usage := &TokenUsage{InputTokens: res.Get("usage.prompt_tokens").Int(), OutputTokens: res.Get("usage.completion_tokens").Int()}
-	return &ChatResponse{Message: content, Reasoning: reasoning, ToolCalls: toolCalls}, usage, nil
+	return &ChatResponse{Message: content, Reasoning: reasoning, ToolCalls: toolCalls}, usage
+}
+
+// completionsURL returns the chat completions endpoint, with the api-version parameter for Azure
+func (c *Client) completionsURL() string {
+	if c.azure {
+		return c.baseURL + "/chat/completions?api-version=" + url.QueryEscape(c.apiVersion)
+	}
+	return c.baseURL + "/chat/completions"
 }
 
 func (c *Client) setHeaders(req *http.Request) {
 	req.Header.Set("Content-Type", "application/json")
-	if c.apiKey != "" {
+	if c.azure {
+		req.Header.Set("api-key", c.apiKey)
+	} else if c.apiKey != "" {
 		req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	}
 	if strings.Contains(c.baseURL, "openrouter.ai") {
@@ -438,9 +543,24 @@ func (c *Client) buildRequestPayload(messages []Message, tools []Tool, stream bo
 		payload["tools"] = c.convertTools(tools)
 	}
 
-	if strings.HasPrefix(c.model, "o1") || strings.HasPrefix(c.model, "o3") {
+	reasoningModel := strings.HasPrefix(c.model, "o1") || strings.HasPrefix(c.model, "o3")
+	if reasoningModel {
 		payload["reasoning_effort"] = "medium"
-		payload["max_completion_tokens"] = 10000
+		payload["max_completion_tokens"] = DefaultReasoningMaxTokens
+	}
+
+	if c.maxTokens > 0 {
+		// OpenAI deprecated max_tokens, but other compatible servers only know it
+		if reasoningModel || c.azure || strings.Contains(c.baseURL, "api.openai.com") {
+			payload["max_completion_tokens"] = c.maxTokens
+		} else {
+			payload["max_tokens"] = c.maxTokens
+		}
+	}
+
+	// Reasoning models reject any temperature but the default
+	if c.temperature != nil && !reasoningModel {
+		payload["temperature"] = *c.temperature
 	}
 
 	if stream {
@@ -498,3 +618,24 @@ func (c *Client) convertTools(tools []Tool) []map[string]interface{} {
 	}
 	return result
 }
+
+// SetRetryNotifier sets the function told when a rate-limited or failed request is retried
+func (c *Client) SetRetryNotifier(notify common.RetryNotifier) {
+	c.retrier.SetNotifier(notify)
+}
+
+// RateLimits returns the rate limit state reported on the latest response
+func (c *Client) RateLimits() *common.RateLimits {
+	c.limitsMu.Lock()
+	defer c.limitsMu.Unlock()
+	return c.rateLimits
+}
+
+// recordRateLimits keeps the rate limit headers of a response, if it carried any
+func (c *Client) recordRateLimits(resp *http.Response) {
+	if limits := common.ParseRateLimits(resp.Header); limits != nil {
+		c.limitsMu.Lock()
+		c.rateLimits = limits
+		c.limitsMu.Unlock()
+	}
+}
@@ -0,0 +1,91 @@
+package openai
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBuildRequestPayloadGenerationOptions(t *testing.T) {
+	temperature := 0.2
+	tests := []struct {
+		name            string
+		model           string
+		baseURL         string
+		maxTokens       int
+		temperature     *float64
+		wantMaxTokens   any
+		wantCompletion  any
+		wantTemperature any
+	}{
+		{name: "defaults", model: "gpt-4o", baseURL: "https://api.openai.com/v1"},
+		{name: "openai uses max_completion_tokens", model: "gpt-4o", baseURL: "https://api.openai.com/v1",
+			maxTokens: 500, temperature: &temperature, wantCompletion: 500, wantTemperature: 0.2},
+		{name: "compatible servers use max_tokens", model: "llama3", baseURL: "http://localhost:11434/v1",
+			maxTokens: 500, temperature: &temperature, wantMaxTokens: 500, wantTemperature: 0.2},
+		{name: "reasoning model default", model: "o3-mini", baseURL: "https://api.openai.com/v1",
+			wantCompletion: DefaultReasoningMaxTokens},
+		{name: "reasoning model ignores temperature", model: "o3-mini", baseURL: "https://api.openai.com/v1",
+			maxTokens: 2000, temperature: &temperature, wantCompletion: 2000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClientWithConfig("key", tt.model, tt.baseURL)
+			if err != nil {
+				t.Fatalf("NewClientWithConfig() error = %v", err)
+			}
+			client.SetGenerationOptions(tt.maxTokens, tt.temperature)
+
+			payload := client.buildRequestPayload(nil, nil, false)
+			if got := payload["max_tokens"]; got != tt.wantMaxTokens {
+				t.Errorf("max_tokens = %v, want %v", got, tt.wantMaxTokens)
+			}
+			if got := payload["max_completion_tokens"]; got != tt.wantCompletion {
+				t.Errorf("max_completion_tokens = %v, want %v", got, tt.wantCompletion)
+			}
+			if got := payload["temperature"]; got != tt.wantTemperature {
+				t.Errorf("temperature = %v, want %v", got, tt.wantTemperature)
+			}
+		})
+	}
+}
+
+func TestChatStreamPlainJSONCompletion(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"<think>checking</think>pong","tool_calls":[{"id":"call-1","function":{"name":"ExecuteTest","arguments":"{\"method\":\"GET\"}"}}]}}],"usage":{"prompt_tokens":12,"completion_tokens":4}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithConfig("key", "llama3", server.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithConfig() error = %v", err)
+	}
+
+	var text, thought string
+	resp, usage, err := client.ChatStream([]Message{{Role: "user", Content: "ping"}}, nil, false, func(chunk string, isThought bool) {
+		if isThought {
+			thought += chunk
+		} else {
+			text += chunk
+		}
+	})
+	if err != nil {
+		t.Fatalf("ChatStream() error = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("server got %d requests, want the JSON answer used without asking again", requests)
+	}
+	if resp.Message != "pong" || text != "pong" || thought != "checking" {
+		t.Errorf("ChatStream() = %q, callback text %q thought %q", resp.Message, text, thought)
+	}
+	if len(resp.ToolCalls) != 1 || resp.ToolCalls[0].Name != "ExecuteTest" || resp.ToolCalls[0].Args["method"] != "GET" {
+		t.Errorf("ToolCalls = %+v, want the ExecuteTest call", resp.ToolCalls)
+	}
+	if usage == nil || usage.InputTokens != 12 || usage.OutputTokens != 4 {
+		t.Errorf("usage = %+v, want 12 input and 4 output tokens", usage)
+	}
+}
@@ -5,12 +5,12 @@ import (
 	"github.com/Octrafic/octrafic-cli/internal/llm/common"
 )
 
-// OpenAIProvider implements common.Provider for OpenAI/OpenRouter
+// OpenAIProvider implements common.Provider for OpenAI, OpenRouter and Azure OpenAI
 type OpenAIProvider struct {
 	client *Client
 }
 
-// NewOpenAIProvider creates a new OpenAI/OpenRouter provider
+// NewOpenAIProvider creates a new OpenAI, OpenRouter or Azure OpenAI provider
 func NewOpenAIProvider(config common.ProviderConfig) (*OpenAIProvider, error) {
 	// Determine base URL
 	baseURL := config.BaseURL
@@ -19,10 +19,17 @@ func NewOpenAIProvider(config common.ProviderConfig) (*OpenAIProvider, error) {
 	}
 
 	// Create client with config
-	client, err := NewClientWithConfig(config.APIKey, config.Model, baseURL)
+	var client *Client
+	var err error
+	if config.Provider == "azure" {
+		client, err = NewAzureClient(config.APIKey, config.BaseURL, config.Model, config.Deployment, config.APIVersion)
+	} else {
+		client, err = NewClientWithConfig(config.APIKey, config.Model, baseURL)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OpenAI client: %w", err)
 	}
+	client.SetGenerationOptions(config.MaxTokens, config.Temperature)
 
 	return &OpenAIProvider{client: client}, nil
 }
@@ -66,11 +73,22 @@ func (p *OpenAIProvider) ChatStream(messages []common.Message, tools []common.To
 	}, nil
 }
 
-// Close closes any resources
+// Close cancels any in-flight requests
 func (p *OpenAIProvider) Close() error {
+	p.client.Close()
 	return nil
 }
 
+// SetRetryNotifier sets the function told when a rate-limited or failed request is retried
+func (p *OpenAIProvider) SetRetryNotifier(notify common.RetryNotifier) {
+	p.client.SetRetryNotifier(notify)
+}
+
+// RateLimits returns the rate limit headers reported on the latest response
+func (p *OpenAIProvider) RateLimits() *common.RateLimits {
+	return p.client.RateLimits()
+}
+
 // convertMessages converts common.Messages to OpenAI format
 func (p *OpenAIProvider) convertMessages(messages []common.Message) []Message {
 	openaiMessages := make([]Message, 0, len(messages))
@@ -0,0 +1,275 @@
+package updater
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	binaryName    = "octrafic"
+	checksumsFile = "checksums.txt"
+)
+
+// ErrDevBuild is returned by SelfUpdate for builds without a release version
+var ErrDevBuild = errors.New("development builds can't update themselves; install a release instead")
+
+type releaseAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// SelfUpdate replaces the running binary with the latest release for this OS and architecture,
+// after checking it against the release's checksums. It returns the update info, with IsNewer
+// false when the current version is already the latest.
+func SelfUpdate(currentVersion string) (*UpdateInfo, error) {
+	if currentVersion == "" || currentVersion == "dev" {
+		return nil, ErrDevBuild
+	}
+
+	client := &http.Client{Timeout: 5 * time.Minute}
+	var release githubRelease
+	if err := getJSON(client, apiBase+"/releases/latest", &release); err != nil {
+		return nil, err
+	}
+	latest := strings.TrimPrefix(release.TagName, "v")
+	info := &UpdateInfo{
+		CurrentVersion: currentVersion,
+		LatestVersion:  latest,
+		ReleaseNotes:   release.Body,
+		HTMLURL:        release.HTMLURL,
+		IsNewer:        IsNewer(latest, currentVersion),
+	}
+	if !info.IsNewer {
+		return info, nil
+	}
+
+	asset, err := selectAsset(release.Assets, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+	checksumAsset, err := findAsset(release.Assets, checksumsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	checksums, err := download(client, checksumAsset.URL)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := download(client, asset.URL)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyChecksum(archive, asset.Name, checksums); err != nil {
+		return nil, err
+	}
+
+	binary, err := extractBinary(archive, asset.Name)
+	if err != nil {
+		return nil, err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate the running binary: %w", err)
+	}
+	if executable, err = filepath.EvalSymlinks(executable); err != nil {
+		return nil, fmt.Errorf("failed to locate the running binary: %w", err)
+	}
+	if err := replaceExecutable(executable, binary); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// assetName returns the release archive for an OS and architecture, following the
+// goreleaser name template, e.g. octrafic_Linux_x86_64.tar.gz or octrafic_Windows_x86_64.zip
+func assetName(goos, goarch string) string {
+	arch := goarch
+	switch goarch {
+	case "amd64":
+		arch = "x86_64"
+	case "386":
+		arch = "i386"
+	case "arm":
+		arch = "armv7" // the only ARM version released
+	}
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("%s_%s_%s%s", binaryName, strings.ToUpper(goos[:1])+goos[1:], arch, ext)
+}
+
+// selectAsset picks the release archive for an OS and architecture
+func selectAsset(assets []releaseAsset, goos, goarch string) (releaseAsset, error) {
+	asset, err := findAsset(assets, assetName(goos, goarch))
+	if err != nil {
+		return releaseAsset{}, fmt.Errorf("no release for %s/%s: %w", goos, goarch, err)
+	}
+	return asset, nil
+}
+
+func findAsset(assets []releaseAsset, name string) (releaseAsset, error) {
+	for _, asset := range assets {
+		if asset.Name == name {
+			return asset, nil
+		}
+	}
+	return releaseAsset{}, fmt.Errorf("release asset %s not found", name)
+}
+
+// verifyChecksum checks data against its SHA-256 in a checksums.txt file ("<hex>  <name>" per line)
+func verifyChecksum(data []byte, name string, checksums []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(hex.EncodeToString(sum[:]), fields[0]) {
+			return fmt.Errorf("checksum mismatch for %s: the download is corrupt or was tampered with", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("no checksum listed for %s", name)
+}
+
+// extractBinary returns the octrafic executable from a .tar.gz or .zip release archive
+func extractBinary(archive []byte, archiveName string) ([]byte, error) {
+	if strings.HasSuffix(archiveName, ".zip") {
+		reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", archiveName, err)
+		}
+		for _, file := range reader.File {
+			if isBinary(file.Name) {
+				rc, err := file.Open()
+				if err != nil {
+					return nil, fmt.Errorf("failed to extract %s: %w", file.Name, err)
+				}
+				defer func() { _ = rc.Close() }()
+				return io.ReadAll(rc)
+			}
+		}
+		return nil, fmt.Errorf("%s has no %s binary", archiveName, binaryName)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", archiveName, err)
+	}
+	defer func() { _ = gz.Close() }()
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s has no %s binary", archiveName, binaryName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", archiveName, err)
+		}
+		if header.Typeflag == tar.TypeReg && isBinary(header.Name) {
+			return io.ReadAll(reader)
+		}
+	}
+}
+
+func isBinary(name string) bool {
+	base := path.Base(name)
+	return base == binaryName || base == binaryName+".exe"
+}
+
+// replaceExecutable swaps the binary at path for data. The new binary is written next to it and
+// renamed over it, so an interrupted update leaves the old binary in place. Windows can't
+// overwrite a running executable, so there the old one is moved aside first and moved back if the
+// new one can't take its place.
+func replaceExecutable(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the running binary: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+binaryName+"-update-*")
+	if err != nil {
+		return fmt.Errorf("failed to write the new binary (is %s writable?): %w", filepath.Dir(path), err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write the new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write the new binary: %w", err)
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()|0111); err != nil {
+		return fmt.Errorf("failed to make the new binary executable: %w", err)
+	}
+
+	if runtime.GOOS != "windows" {
+		if err := os.Rename(tmpPath, path); err != nil {
+			return fmt.Errorf("failed to replace the binary: %w", err)
+		}
+		return nil
+	}
+
+	old := path + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(path, old); err != nil {
+		return fmt.Errorf("failed to move the old binary aside: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		// Put the old binary back so the command still exists
+		if restoreErr := os.Rename(old, path); restoreErr != nil {
+			return fmt.Errorf("failed to replace the binary: %w (the old one is at %s)", err, old)
+		}
+		return fmt.Errorf("failed to replace the binary: %w", err)
+	}
+	return nil
+}
+
+func getJSON(client *http.Client, url string, v any) error {
+	data, err := download(client, url)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse release info: %w", err)
+	}
+	return nil
+}
+
+func download(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s returned status %d", url, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,135 @@
+package updater
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeRelease returns the assets of a release with a .tar.gz and a .zip archive and their checksums
+func fakeRelease(t *testing.T) ([]releaseAsset, map[string][]byte) {
+	t.Helper()
+
+	var tgz bytes.Buffer
+	gz := gzip.NewWriter(&tgz)
+	tw := tar.NewWriter(gz)
+	for name, content := range map[string]string{"README.md": "readme", "octrafic": "linux binary"} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		_, _ = tw.Write([]byte(content))
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+
+	var zipped bytes.Buffer
+	zw := zip.NewWriter(&zipped)
+	w, _ := zw.Create("octrafic.exe")
+	_, _ = w.Write([]byte("windows binary"))
+	_ = zw.Close()
+
+	files := map[string][]byte{
+		"octrafic_Linux_x86_64.tar.gz": tgz.Bytes(),
+		"octrafic_Windows_x86_64.zip":  zipped.Bytes(),
+	}
+	var checksums strings.Builder
+	for name, data := range files {
+		sum := sha256.Sum256(data)
+		fmt.Fprintf(&checksums, "%s  %s\n", hex.EncodeToString(sum[:]), name)
+	}
+	files[checksumsFile] = []byte(checksums.String())
+
+	var assets []releaseAsset
+	for name := range files {
+		assets = append(assets, releaseAsset{Name: name, URL: "https://example.com/" + name})
+	}
+	return assets, files
+}
+
+func TestSelectAsset(t *testing.T) {
+	assets, _ := fakeRelease(t)
+
+	tests := []struct {
+		goos, goarch, want string
+		wantErr            bool
+	}{
+		{goos: "linux", goarch: "amd64", want: "octrafic_Linux_x86_64.tar.gz"},
+		{goos: "windows", goarch: "amd64", want: "octrafic_Windows_x86_64.zip"},
+		{goos: "darwin", goarch: "arm64", wantErr: true},
+	}
+	for _, tt := range tests {
+		asset, err := selectAsset(assets, tt.goos, tt.goarch)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("selectAsset(%s/%s) error = %v, wantErr %v", tt.goos, tt.goarch, err, tt.wantErr)
+			continue
+		}
+		if asset.Name != tt.want {
+			t.Errorf("selectAsset(%s/%s) = %s, want %s", tt.goos, tt.goarch, asset.Name, tt.want)
+		}
+	}
+
+	if got := assetName("linux", "arm"); got != "octrafic_Linux_armv7.tar.gz" {
+		t.Errorf("assetName(linux, arm) = %s", got)
+	}
+	if got := assetName("darwin", "arm64"); got != "octrafic_Darwin_arm64.tar.gz" {
+		t.Errorf("assetName(darwin, arm64) = %s", got)
+	}
+}
+
+func TestVerifyChecksumAndExtract(t *testing.T) {
+	_, files := fakeRelease(t)
+	checksums := files[checksumsFile]
+
+	for name, want := range map[string]string{"octrafic_Linux_x86_64.tar.gz": "linux binary", "octrafic_Windows_x86_64.zip": "windows binary"} {
+		if err := verifyChecksum(files[name], name, checksums); err != nil {
+			t.Fatalf("verifyChecksum(%s) error = %v", name, err)
+		}
+		binary, err := extractBinary(files[name], name)
+		if err != nil {
+			t.Fatalf("extractBinary(%s) error = %v", name, err)
+		}
+		if string(binary) != want {
+			t.Errorf("extractBinary(%s) = %q, want %q", name, binary, want)
+		}
+	}
+
+	tampered := append([]byte(nil), files["octrafic_Linux_x86_64.tar.gz"]...)
+	tampered[len(tampered)-1] ^= 0xff
+	if err := verifyChecksum(tampered, "octrafic_Linux_x86_64.tar.gz", checksums); err == nil || !strings.Contains(err.Error(), "mismatch") {
+		t.Errorf("verifyChecksum(tampered) error = %v, want a mismatch", err)
+	}
+	if err := verifyChecksum(files["octrafic_Linux_x86_64.tar.gz"], "octrafic_Darwin_arm64.tar.gz", checksums); err == nil {
+		t.Error("verifyChecksum() accepted an archive missing from checksums.txt")
+	}
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "octrafic")
+	if err := os.WriteFile(path, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := replaceExecutable(path, []byte("new")); err != nil {
+		t.Fatalf("replaceExecutable() error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "new" {
+		t.Errorf("binary = %q, want the new one", data)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("left %d files behind, want only the binary", len(entries))
+	}
+}
+
+func TestSelfUpdateRefusesDevBuilds(t *testing.T) {
+	if _, err := SelfUpdate("dev"); !errors.Is(err, ErrDevBuild) {
+		t.Errorf("SelfUpdate(dev) error = %v, want ErrDevBuild", err)
+	}
+}
@@ -25,9 +25,10 @@ type UpdateInfo struct {
 }
 
 type githubRelease struct {
-	TagName string `json:"tag_name"`
-	Body    string `json:"body"`
-	HTMLURL string `json:"html_url"`
+	TagName string         `json:"tag_name"`
+	Body    string         `json:"body"`
+	HTMLURL string         `json:"html_url"`
+	Assets  []releaseAsset `json:"assets"`
 }
 
 // CheckLatestVersion checks GitHub for the latest release and compares with current version